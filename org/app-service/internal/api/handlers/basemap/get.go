@@ -0,0 +1,48 @@
+package apibasemap
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/org/2112-space-lab/org/app-service/internal/services"
+)
+
+const pngContentType = "image/png"
+
+// BasemapHandler serves cached basemap tiles.
+type BasemapHandler struct {
+	Service services.BasemapService
+}
+
+// NewBasemapHandler creates a new handler with the provided BasemapService.
+func NewBasemapHandler(service services.BasemapService) *BasemapHandler {
+	return &BasemapHandler{Service: service}
+}
+
+// GetTile serves /basemap/{z}/{x}/{y}.png.
+func (h *BasemapHandler) GetTile(c echo.Context) error {
+	z, errZ := strconv.Atoi(c.Param("z"))
+	x, errX := strconv.Atoi(c.Param("x"))
+	y, errY := strconv.Atoi(strings.TrimSuffix(c.Param("y"), ".png"))
+	if errZ != nil || errX != nil || errY != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid tile coordinates")
+	}
+
+	data, err := h.Service.GetTile(c.Request().Context(), z, x, y)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidBasemapTile) {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid tile coordinates")
+		}
+		if h.Service.IsNotCached(err) {
+			return echo.NewHTTPError(http.StatusNotFound, "Tile not available offline")
+		}
+		c.Logger().Error("Failed to fetch basemap tile: ", err)
+		return echo.NewHTTPError(http.StatusBadGateway, "Unable to fetch basemap tile")
+	}
+
+	c.Response().Header().Set("Cache-Control", "public, max-age=86400")
+	return c.Blob(http.StatusOK, pngContentType, data)
+}
@@ -0,0 +1,119 @@
+package apicontext
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	"github.com/org/2112-space-lab/org/app-service/internal/services"
+	api_mappers "github.com/org/2112-space-lab/org/app-service/pkg/api"
+)
+
+const (
+	bundleJSONContentType = "application/json"
+	bundleTarContentType  = "application/gzip"
+)
+
+// ContextBundleHandler handles API requests exporting, importing and cloning GameContexts.
+type ContextBundleHandler struct {
+	Service services.ContextBundleService
+}
+
+// NewContextBundleHandler creates a new handler with the provided ContextBundleService.
+func NewContextBundleHandler(service services.ContextBundleService) *ContextBundleHandler {
+	return &ContextBundleHandler{Service: service}
+}
+
+// ExportContext downloads a GameContext as a bundle, in the format given by the `format` query parameter.
+func (h *ContextBundleHandler) ExportContext(c echo.Context) error {
+	name := c.Param("name") // Extract context name from the URL path
+	includeMappings, _ := strconv.ParseBool(c.QueryParam("mappings"))
+
+	format := services.ContextBundleFormat(c.QueryParam("format"))
+	contentType, extension := bundleJSONContentType, ".json"
+	switch format {
+	case "", services.ContextBundleFormatJSON:
+	case services.ContextBundleFormatTar:
+		contentType, extension = bundleTarContentType, ".tar.gz"
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "Unsupported format, expected json or tar")
+	}
+
+	bundle, err := h.Service.Export(c.Request().Context(), domain.GameContextName(name), includeMappings)
+	if err != nil {
+		c.Echo().Logger.Error("Failed to export GameContext: ", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Unable to export context")
+	}
+
+	var body bytes.Buffer
+	if err := services.EncodeContextBundle(&body, bundle, format); err != nil {
+		c.Echo().Logger.Error("Failed to encode context bundle: ", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Unable to encode context bundle")
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, "attachment; filename=\""+name+extension+"\"")
+	return c.Blob(http.StatusOK, contentType, body.Bytes())
+}
+
+// ImportContext creates a GameContext from a JSON or tar bundle sent as the request body.
+func (h *ContextBundleHandler) ImportContext(c echo.Context) error {
+	strategy, err := domain.ParseContextConflictStrategy(c.QueryParam("strategy"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	includeMappings, _ := strconv.ParseBool(c.QueryParam("mappings"))
+
+	bundle, err := services.DecodeContextBundle(c.Request().Body)
+	if err != nil {
+		c.Echo().Logger.Error("Failed to decode context bundle: ", err)
+		if errors.Is(err, domain.ErrContextBundleTooLarge) {
+			return echo.NewHTTPError(http.StatusRequestEntityTooLarge, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	result, err := h.Service.Import(c.Request().Context(), bundle, domain.ContextImportOptions{
+		Name:            domain.GameContextName(c.QueryParam("name")),
+		Strategy:        strategy,
+		IncludeMappings: includeMappings,
+	})
+	if err != nil {
+		return bundleError(c, "Unable to import context", err)
+	}
+
+	return c.JSON(http.StatusCreated, result)
+}
+
+// CloneContext copies a GameContext under the name given in the request body.
+func (h *ContextBundleHandler) CloneContext(c echo.Context) error {
+	name := c.Param("name") // Extract context name from the URL path
+
+	var request api_mappers.ContextCloneRequest
+	if err := c.Bind(&request); err != nil || request.Name == "" {
+		c.Echo().Logger.Error("Failed to bind ContextCloneRequest: ", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload")
+	}
+
+	result, err := h.Service.Clone(c.Request().Context(), domain.GameContextName(name), domain.GameContextName(request.Name), request.IncludeMappings)
+	if err != nil {
+		return bundleError(c, "Unable to clone context", err)
+	}
+
+	return c.JSON(http.StatusCreated, result)
+}
+
+func bundleError(c echo.Context, message string, err error) error {
+	c.Echo().Logger.Error(message+": ", err)
+	switch {
+	case errors.Is(err, domain.ErrContextExists):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	case errors.Is(err, domain.ErrUnsupportedBundle):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrTenantMismatch):
+		return echo.NewHTTPError(http.StatusForbidden, "Context belongs to another tenant")
+	}
+	return echo.NewHTTPError(http.StatusInternalServerError, message)
+}
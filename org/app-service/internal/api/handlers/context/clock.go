@@ -0,0 +1,144 @@
+package apicontext
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	"github.com/org/2112-space-lab/org/app-service/internal/services"
+	api_mappers "github.com/org/2112-space-lab/org/app-service/pkg/api"
+	"gorm.io/gorm"
+)
+
+// ClockHandler handles API requests controlling the simulation clocks of GameContexts.
+type ClockHandler struct {
+	Service services.SimulationClockService
+}
+
+// NewClockHandler creates a new handler with the provided SimulationClockService.
+func NewClockHandler(service services.SimulationClockService) *ClockHandler {
+	return &ClockHandler{Service: service}
+}
+
+// GetClock returns the simulation clock of a GameContext.
+func (h *ClockHandler) GetClock(c echo.Context) error {
+	name := c.Param("name") // Extract context name from the URL path
+
+	clock, err := h.Service.Get(c.Request().Context(), domain.GameContextName(name))
+	if err != nil {
+		return clockError(c, "Unable to retrieve clock", err)
+	}
+	return c.JSON(http.StatusOK, clockResponse(clock))
+}
+
+// StartClock starts the simulation clock of a GameContext at an epoch and speed.
+func (h *ClockHandler) StartClock(c echo.Context) error {
+	name := c.Param("name") // Extract context name from the URL path
+
+	var request api_mappers.ContextClockRequest
+	if err := c.Bind(&request); err != nil {
+		c.Echo().Logger.Error("Failed to bind ContextClockRequest: ", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload")
+	}
+	speed := 1.0
+	if request.Speed != nil {
+		speed = *request.Speed
+	}
+
+	clock, err := h.Service.Start(c.Request().Context(), domain.GameContextName(name), request.Epoch, speed)
+	if err != nil {
+		return clockError(c, "Unable to start clock", err)
+	}
+	return c.JSON(http.StatusOK, clockResponse(clock))
+}
+
+// ResetClock returns a GameContext to wall-clock time.
+func (h *ClockHandler) ResetClock(c echo.Context) error {
+	name := c.Param("name") // Extract context name from the URL path
+
+	if err := h.Service.Reset(c.Request().Context(), domain.GameContextName(name)); err != nil {
+		return clockError(c, "Unable to reset clock", err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// PauseClock freezes the simulation clock of a GameContext.
+func (h *ClockHandler) PauseClock(c echo.Context) error {
+	name := c.Param("name") // Extract context name from the URL path
+
+	clock, err := h.Service.Pause(c.Request().Context(), domain.GameContextName(name))
+	if err != nil {
+		return clockError(c, "Unable to pause clock", err)
+	}
+	return c.JSON(http.StatusOK, clockResponse(clock))
+}
+
+// ResumeClock restarts the paused simulation clock of a GameContext.
+func (h *ClockHandler) ResumeClock(c echo.Context) error {
+	name := c.Param("name") // Extract context name from the URL path
+
+	clock, err := h.Service.Resume(c.Request().Context(), domain.GameContextName(name))
+	if err != nil {
+		return clockError(c, "Unable to resume clock", err)
+	}
+	return c.JSON(http.StatusOK, clockResponse(clock))
+}
+
+// SeekClock moves the simulation clock of a GameContext to another simulated time.
+func (h *ClockHandler) SeekClock(c echo.Context) error {
+	name := c.Param("name") // Extract context name from the URL path
+
+	var request api_mappers.ContextClockSeekRequest
+	if err := c.Bind(&request); err != nil {
+		c.Echo().Logger.Error("Failed to bind ContextClockSeekRequest: ", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload")
+	}
+
+	clock, err := h.Service.Seek(c.Request().Context(), domain.GameContextName(name), request.Time)
+	if err != nil {
+		return clockError(c, "Unable to seek clock", err)
+	}
+	return c.JSON(http.StatusOK, clockResponse(clock))
+}
+
+// SetClockSpeed changes the speed multiplier of the simulation clock of a GameContext.
+func (h *ClockHandler) SetClockSpeed(c echo.Context) error {
+	name := c.Param("name") // Extract context name from the URL path
+
+	var request api_mappers.ContextClockSpeedRequest
+	if err := c.Bind(&request); err != nil {
+		c.Echo().Logger.Error("Failed to bind ContextClockSpeedRequest: ", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload")
+	}
+
+	clock, err := h.Service.SetSpeed(c.Request().Context(), domain.GameContextName(name), request.Speed)
+	if err != nil {
+		return clockError(c, "Unable to change clock speed", err)
+	}
+	return c.JSON(http.StatusOK, clockResponse(clock))
+}
+
+func clockResponse(clock domain.SimulationClock) map[string]interface{} {
+	return map[string]interface{}{
+		"contextID":  clock.ContextID,
+		"now":        clock.Now(time.Now().UTC()).Format(time.RFC3339),
+		"epoch":      clock.Epoch.Format(time.RFC3339),
+		"anchoredAt": clock.AnchoredAt.Format(time.RFC3339),
+		"speed":      clock.Speed,
+		"paused":     clock.Paused,
+	}
+}
+
+// clockError maps simulation clock failures to HTTP errors.
+func clockError(c echo.Context, message string, err error) error {
+	c.Echo().Logger.Error(message+": ", err)
+	switch {
+	case errors.Is(err, domain.ErrInvalidSimulationClock):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "Context not found")
+	}
+	return echo.NewHTTPError(http.StatusInternalServerError, message)
+}
@@ -1,6 +1,7 @@
 package apicontext
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -34,6 +35,9 @@ func (h *ContextHandler) CreateContext(c echo.Context) error {
 	createdContext, err := h.Service.Create(c.Request().Context(), gameContext)
 	if err != nil {
 		c.Echo().Logger.Error("Failed to create GameContext: ", err)
+		if errors.Is(err, domain.ErrTenantMismatch) {
+			return echo.NewHTTPError(http.StatusForbidden, "Context belongs to another tenant")
+		}
 		return echo.NewHTTPError(http.StatusInternalServerError, "Unable to create context")
 	}
 
@@ -54,6 +58,9 @@ func (h *ContextHandler) UpdateContext(c echo.Context) error {
 	updatedContext, err := h.Service.Update(c.Request().Context(), gameContext)
 	if err != nil {
 		c.Echo().Logger.Error("Failed to update GameContext: ", err)
+		if errors.Is(err, domain.ErrTenantMismatch) {
+			return echo.NewHTTPError(http.StatusForbidden, "Context belongs to another tenant")
+		}
 		return echo.NewHTTPError(http.StatusInternalServerError, "Unable to update context")
 	}
 
@@ -74,6 +81,17 @@ func (h *ContextHandler) GetContextByName(c echo.Context) error {
 	return c.JSON(http.StatusOK, context)
 }
 
+// GetActiveContexts retrieves every active GameContext.
+func (h *ContextHandler) GetActiveContexts(c echo.Context) error {
+	contexts, err := h.Service.GetActiveContexts(c.Request().Context())
+	if err != nil {
+		c.Echo().Logger.Error("Failed to retrieve active GameContexts: ", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Unable to retrieve active contexts")
+	}
+
+	return c.JSON(http.StatusOK, contexts)
+}
+
 // DeleteContextByName deletes a GameContext by its unique name.
 func (h *ContextHandler) DeleteContextByName(c echo.Context) error {
 	name := c.Param("name") // Extract context name from the URL path
@@ -94,6 +112,9 @@ func (h *ContextHandler) ActivateContext(c echo.Context) error {
 	// Call the service to activate the context
 	if err := h.Service.ActiveContext(c.Request().Context(), domain.GameContextName(name)); err != nil {
 		c.Echo().Logger.Error("Failed to activate GameContext: ", err)
+		if errors.Is(err, domain.ErrInvalidContextTransition) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
 		return echo.NewHTTPError(http.StatusInternalServerError, "Unable to activate context")
 	}
 
@@ -130,12 +151,66 @@ func (h *ContextHandler) DeactivateContext(c echo.Context) error {
 	// Call the service to deactivate the context
 	if err := h.Service.DisableContext(c.Request().Context(), domain.GameContextName(name)); err != nil {
 		c.Echo().Logger.Error("Failed to deactivate GameContext: ", err)
+		if errors.Is(err, domain.ErrInvalidContextTransition) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
 		return echo.NewHTTPError(http.StatusInternalServerError, "Unable to deactivate context")
 	}
 
 	return c.NoContent(http.StatusNoContent)
 }
 
+// ArchiveContext closes a GameContext for good by its unique name.
+func (h *ContextHandler) ArchiveContext(c echo.Context) error {
+	name := c.Param("name") // Extract context name from the URL path
+
+	if err := h.Service.ArchiveContext(c.Request().Context(), domain.GameContextName(name)); err != nil {
+		c.Echo().Logger.Error("Failed to archive GameContext: ", err)
+		if errors.Is(err, domain.ErrInvalidContextTransition) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Unable to archive context")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ScheduleContext sets the activation and deactivation times of a GameContext by its unique name.
+func (h *ContextHandler) ScheduleContext(c echo.Context) error {
+	name := c.Param("name") // Extract context name from the URL path
+
+	var request api_mappers.ContextScheduleRequest
+	if err := c.Bind(&request); err != nil {
+		c.Echo().Logger.Error("Failed to bind ContextScheduleRequest: ", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload")
+	}
+
+	if err := h.Service.Schedule(c.Request().Context(), domain.GameContextName(name), request.ActivateAt, request.DeactivateAt); err != nil {
+		c.Echo().Logger.Error("Failed to schedule GameContext: ", err)
+		switch {
+		case errors.Is(err, domain.ErrInvalidContextSchedule):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		case errors.Is(err, domain.ErrInvalidContextTransition):
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Unable to schedule context")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// UnscheduleContext cancels the schedule of a GameContext by its unique name.
+func (h *ContextHandler) UnscheduleContext(c echo.Context) error {
+	name := c.Param("name") // Extract context name from the URL path
+
+	if err := h.Service.Unschedule(c.Request().Context(), domain.GameContextName(name)); err != nil {
+		c.Echo().Logger.Error("Failed to unschedule GameContext: ", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Unable to unschedule context")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
 // GetPaginatedContexts retrieves paginated GameContexts with optional search.
 func (h *ContextHandler) GetPaginatedContexts(c echo.Context) error {
 	// Parse query parameters
@@ -0,0 +1,133 @@
+package apicontext
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	"github.com/org/2112-space-lab/org/app-service/internal/services"
+	api_mappers "github.com/org/2112-space-lab/org/app-service/pkg/api"
+	fx "github.com/org/2112-space-lab/org/app-service/pkg/option"
+	xtime "github.com/org/2112-space-lab/org/app-service/pkg/time"
+	"github.com/org/2112-space-lab/org/go-utils/pkg/fx/xspace"
+	"gorm.io/gorm"
+)
+
+// MembershipHandler handles API requests managing the membership rules of GameContexts.
+type MembershipHandler struct {
+	Service services.MembershipService
+}
+
+// NewMembershipHandler creates a new handler with the provided MembershipService.
+func NewMembershipHandler(service services.MembershipService) *MembershipHandler {
+	return &MembershipHandler{Service: service}
+}
+
+// GetRules lists the membership rules of a GameContext.
+func (h *MembershipHandler) GetRules(c echo.Context) error {
+	name := c.Param("name") // Extract context name from the URL path
+
+	rules, err := h.Service.GetRules(c.Request().Context(), domain.GameContextName(name))
+	if err != nil {
+		return membershipError(c, "Unable to retrieve membership rules", err)
+	}
+
+	response := make([]map[string]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		response = append(response, map[string]interface{}{
+			"name":    rule.Name,
+			"filters": rule.Filters,
+		})
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// PutRule creates or replaces a membership rule of a GameContext and applies the rules at once.
+func (h *MembershipHandler) PutRule(c echo.Context) error {
+	name := c.Param("name") // Extract context name from the URL path
+
+	var request api_mappers.ContextMembershipRuleRequest
+	if err := c.Bind(&request); err != nil {
+		c.Echo().Logger.Error("Failed to bind ContextMembershipRuleRequest: ", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload")
+	}
+
+	rule := domain.MembershipRule{
+		Name:    c.Param("rule"),
+		Filters: mapMembershipFilters(request),
+	}
+	change, err := h.Service.SaveRule(c.Request().Context(), domain.GameContextName(name), rule)
+	if err != nil {
+		return membershipError(c, "Unable to save membership rule", err)
+	}
+	return c.JSON(http.StatusOK, membershipChangeResponse(change))
+}
+
+// DeleteRule removes a membership rule of a GameContext and applies the remaining rules.
+func (h *MembershipHandler) DeleteRule(c echo.Context) error {
+	name := c.Param("name") // Extract context name from the URL path
+
+	change, err := h.Service.DeleteRule(c.Request().Context(), domain.GameContextName(name), c.Param("rule"))
+	if err != nil {
+		return membershipError(c, "Unable to delete membership rule", err)
+	}
+	return c.JSON(http.StatusOK, membershipChangeResponse(change))
+}
+
+// EvaluateRules applies the membership rules of a GameContext to the current catalogue.
+func (h *MembershipHandler) EvaluateRules(c echo.Context) error {
+	name := c.Param("name") // Extract context name from the URL path
+
+	change, err := h.Service.Evaluate(c.Request().Context(), domain.GameContextName(name))
+	if err != nil {
+		return membershipError(c, "Unable to evaluate membership rules", err)
+	}
+	return c.JSON(http.StatusOK, membershipChangeResponse(change))
+}
+
+// mapMembershipFilters converts the request filters to their domain form.
+func mapMembershipFilters(request api_mappers.ContextMembershipRuleRequest) domain.MembershipFilters {
+	orbitTypes := make([]xspace.OrbitType, 0, len(request.OrbitTypes))
+	for _, orbitType := range request.OrbitTypes {
+		orbitTypes = append(orbitTypes, xspace.OrbitType(orbitType))
+	}
+	return domain.MembershipFilters{
+		OrbitTypes:     orbitTypes,
+		Owners:         request.Owners,
+		ObjectTypes:    request.ObjectTypes,
+		NamePatterns:   request.NamePatterns,
+		LaunchedAfter:  xtime.ToUtcTime(request.LaunchedAfter),
+		LaunchedBefore: xtime.ToUtcTime(request.LaunchedBefore),
+		MinInclination: fx.AsOption(request.MinInclination),
+		MaxInclination: fx.AsOption(request.MaxInclination),
+		MinAltitude:    fx.AsOption(request.MinAltitude),
+		MaxAltitude:    fx.AsOption(request.MaxAltitude),
+	}
+}
+
+func membershipChangeResponse(change domain.MembershipChange) map[string]interface{} {
+	return map[string]interface{}{
+		"added":   nonNil(change.Added),
+		"removed": nonNil(change.Removed),
+	}
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// membershipError maps membership failures to HTTP errors.
+func membershipError(c echo.Context, message string, err error) error {
+	c.Echo().Logger.Error(message+": ", err)
+	switch {
+	case errors.Is(err, domain.ErrInvalidMembershipRule):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	return echo.NewHTTPError(http.StatusInternalServerError, message)
+}
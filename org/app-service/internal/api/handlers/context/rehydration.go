@@ -0,0 +1,76 @@
+package apicontext
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	"github.com/org/2112-space-lab/org/app-service/internal/services"
+	"gorm.io/gorm"
+)
+
+// RehydrationHandler handles API requests following the rehydration of GameContexts.
+type RehydrationHandler struct {
+	Service *services.RehydrationService
+}
+
+// NewRehydrationHandler creates a new handler with the provided RehydrationService.
+func NewRehydrationHandler(service *services.RehydrationService) *RehydrationHandler {
+	return &RehydrationHandler{Service: service}
+}
+
+// GetRehydration returns the progress of the last rehydration of a GameContext and the state of each of its satellites.
+func (h *RehydrationHandler) GetRehydration(c echo.Context) error {
+	name := c.Param("name") // Extract context name from the URL path
+
+	rehydration, err := h.Service.Progress(c.Request().Context(), domain.GameContextName(name))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "Rehydration not found")
+		}
+		c.Echo().Logger.Error("Failed to retrieve rehydration: ", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Unable to retrieve rehydration")
+	}
+	return c.JSON(http.StatusOK, rehydrationResponse(rehydration))
+}
+
+func rehydrationResponse(rehydration domain.Rehydration) map[string]interface{} {
+	steps := make([]map[string]interface{}, len(rehydration.Steps))
+	for i, step := range rehydration.Steps {
+		steps[i] = map[string]interface{}{
+			"spaceID":   step.SpaceID,
+			"status":    step.Status,
+			"updatedAt": step.UpdatedAt.Format(time.RFC3339),
+		}
+		if step.Error != "" {
+			steps[i]["error"] = step.Error
+		}
+	}
+
+	response := map[string]interface{}{
+		"contextName":     rehydration.ContextName,
+		"eventUid":        rehydration.EventUID,
+		"status":          rehydration.Status,
+		"percentComplete": rehydration.PercentComplete(),
+		"startTime":       rehydration.StartTime.Format(time.RFC3339),
+		"startedAt":       rehydration.StartedAt.Format(time.RFC3339),
+		"deadline":        rehydration.Deadline.Format(time.RFC3339),
+		"counts": map[string]int{
+			"total":      len(rehydration.Steps),
+			"requested":  rehydration.CountSteps(domain.RehydrationStepRequested),
+			"propagated": rehydration.CountSteps(domain.RehydrationStepPropagated),
+			"mapped":     rehydration.CountSteps(domain.RehydrationStepMapped),
+			"failed":     rehydration.CountSteps(domain.RehydrationStepFailed),
+		},
+		"steps": steps,
+	}
+	if rehydration.CompletedAt != nil {
+		response["completedAt"] = rehydration.CompletedAt.UTC().Format(time.RFC3339)
+	}
+	if rehydration.Reason != "" {
+		response["reason"] = rehydration.Reason
+	}
+	return response
+}
@@ -0,0 +1,137 @@
+package apicoverage
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	"github.com/org/2112-space-lab/org/app-service/internal/services"
+)
+
+// CoverageHandler serves coverage analytics of game contexts.
+type CoverageHandler struct {
+	Service services.CoverageService
+}
+
+// NewCoverageHandler creates a new handler with the provided CoverageService.
+func NewCoverageHandler(service services.CoverageService) *CoverageHandler {
+	return &CoverageHandler{Service: service}
+}
+
+// GetReport returns the full coverage report of a context.
+func (h *CoverageHandler) GetReport(c echo.Context) error {
+	report, err := h.report(c)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, report)
+}
+
+// GetRevisits returns the revisit statistics of a context.
+func (h *CoverageHandler) GetRevisits(c echo.Context) error {
+	report, err := h.report(c)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"contextID":          report.ContextID,
+		"from":               report.From,
+		"to":                 report.To,
+		"meanRevisitSeconds": report.MeanRevisitSeconds,
+		"maxGapSeconds":      report.MaxGapSeconds,
+		"tiles":              report.Revisits,
+	})
+}
+
+// GetTimeline returns the coverage percentage over time of a context.
+func (h *CoverageHandler) GetTimeline(c echo.Context) error {
+	report, err := h.report(c)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"contextID":    report.ContextID,
+		"from":         report.From,
+		"to":           report.To,
+		"stepSeconds":  report.StepSeconds,
+		"totalTiles":   report.TotalTiles,
+		"visitedTiles": report.VisitedTiles,
+		"samples":      report.Timeline,
+	})
+}
+
+// GetContributions returns the per-tile satellite contributions of a context.
+func (h *CoverageHandler) GetContributions(c echo.Context) error {
+	report, err := h.report(c)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"contextID": report.ContextID,
+		"from":      report.From,
+		"to":        report.To,
+		"tiles":     report.Contributions,
+	})
+}
+
+// RefreshCoverage drops the cached reports of a context and recomputes the default window.
+func (h *CoverageHandler) RefreshCoverage(c echo.Context) error {
+	contextID := c.Param("contextID")
+
+	if err := h.Service.Refresh(c.Request().Context(), contextID); err != nil {
+		c.Logger().Error("Failed to refresh coverage: ", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Unable to refresh coverage")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "Coverage refreshed successfully"})
+}
+
+// report parses the window query parameters (from, to as RFC3339, stepMinutes) and fetches the report.
+// The window defaults to the next 24 hours sampled every 10 minutes.
+func (h *CoverageHandler) report(c echo.Context) (domain.CoverageReport, error) {
+	contextID := c.Param("contextID")
+
+	step := services.DefaultCoverageStep
+	if v := c.QueryParam("stepMinutes"); v != "" {
+		minutes, err := strconv.Atoi(v)
+		if err != nil || minutes <= 0 {
+			return domain.CoverageReport{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid stepMinutes parameter")
+		}
+		step = time.Duration(minutes) * time.Minute
+	}
+
+	from, to := h.Service.DefaultWindow(time.Now(), step)
+	if v := c.QueryParam("from"); v != "" {
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return domain.CoverageReport{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid from format, expected RFC3339")
+		}
+		from = parsed
+		if c.QueryParam("to") == "" {
+			to = from.Add(services.DefaultCoverageWindow)
+		}
+	}
+	if v := c.QueryParam("to"); v != "" {
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return domain.CoverageReport{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid to format, expected RFC3339")
+		}
+		to = parsed
+	}
+
+	if !from.Before(to) {
+		return domain.CoverageReport{}, echo.NewHTTPError(http.StatusBadRequest, "from must be before to")
+	}
+	if to.Sub(from)/step > services.MaxCoverageSamples {
+		return domain.CoverageReport{}, echo.NewHTTPError(http.StatusBadRequest, "Window too large for the requested step")
+	}
+
+	report, err := h.Service.GetReport(c.Request().Context(), contextID, from, to, step)
+	if err != nil {
+		c.Logger().Error("Failed to compute coverage report: ", err)
+		return domain.CoverageReport{}, echo.NewHTTPError(http.StatusInternalServerError, "Unable to compute coverage report")
+	}
+	return report, nil
+}
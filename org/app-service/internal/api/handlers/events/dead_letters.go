@@ -0,0 +1,106 @@
+package apievents
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	"github.com/org/2112-space-lab/org/app-service/internal/services"
+	"gorm.io/gorm"
+)
+
+// DeadLetterHandler handles API requests inspecting and re-driving dead-lettered events.
+type DeadLetterHandler struct {
+	Service services.DeadLetterService
+}
+
+// NewDeadLetterHandler creates a new handler with the provided DeadLetterService.
+func NewDeadLetterHandler(service services.DeadLetterService) *DeadLetterHandler {
+	return &DeadLetterHandler{Service: service}
+}
+
+// GetDeadLetters lists dead-lettered events with pagination. Re-driven events are listed with ?all=true.
+func (h *DeadLetterHandler) GetDeadLetters(c echo.Context) error {
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(c.QueryParam("pageSize"))
+	if err != nil || pageSize <= 0 {
+		pageSize = 10
+	}
+
+	includeRedriven, _ := strconv.ParseBool(c.QueryParam("all"))
+
+	deadLetters, total, err := h.Service.GetPage(c.Request().Context(), page, pageSize, includeRedriven)
+	if err != nil {
+		c.Echo().Logger.Error("Failed to fetch dead letters: ", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Unable to fetch dead letters")
+	}
+
+	items := make([]map[string]interface{}, len(deadLetters))
+	for i, deadLetter := range deadLetters {
+		items[i] = deadLetterResponse(deadLetter)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"page":        page,
+		"pageSize":    pageSize,
+		"totalCount":  total,
+		"deadLetters": items,
+	})
+}
+
+// GetDeadLetter returns a dead-lettered event with its payload.
+func (h *DeadLetterHandler) GetDeadLetter(c echo.Context) error {
+	id := c.Param("id") // Extract dead letter ID from the URL path
+
+	deadLetter, err := h.Service.Get(c.Request().Context(), id)
+	if err != nil {
+		return deadLetterError(c, "Unable to retrieve dead letter", err)
+	}
+
+	response := deadLetterResponse(deadLetter)
+	response["event"] = deadLetter.Event
+	return c.JSON(http.StatusOK, response)
+}
+
+// RedriveDeadLetter publishes a dead-lettered event again for the handler that gave up on it.
+func (h *DeadLetterHandler) RedriveDeadLetter(c echo.Context) error {
+	id := c.Param("id") // Extract dead letter ID from the URL path
+
+	deadLetter, err := h.Service.Redrive(c.Request().Context(), id)
+	if err != nil {
+		return deadLetterError(c, "Unable to re-drive dead letter", err)
+	}
+	return c.JSON(http.StatusAccepted, deadLetterResponse(deadLetter))
+}
+
+func deadLetterResponse(deadLetter domain.DeadLetter) map[string]interface{} {
+	response := map[string]interface{}{
+		"id":             deadLetter.ID,
+		"eventUid":       deadLetter.EventUID,
+		"eventType":      deadLetter.EventType,
+		"handlerName":    deadLetter.HandlerName,
+		"attempts":       deadLetter.Attempts,
+		"lastError":      deadLetter.LastError,
+		"deadLetteredAt": deadLetter.DeadLetteredAt.Format(time.RFC3339),
+	}
+	if deadLetter.RedrivenAt != nil {
+		response["redrivenAt"] = deadLetter.RedrivenAt.Format(time.RFC3339)
+	}
+	return response
+}
+
+// deadLetterError maps a dead letter service error to an HTTP error.
+func deadLetterError(c echo.Context, message string, err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Dead letter not found")
+	}
+	c.Echo().Logger.Error(message+": ", err)
+	return echo.NewHTTPError(http.StatusInternalServerError, message)
+}
@@ -0,0 +1,185 @@
+package apievents
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	"github.com/org/2112-space-lab/org/app-service/internal/services"
+	fx "github.com/org/2112-space-lab/org/app-service/pkg/option"
+	xtime "github.com/org/2112-space-lab/org/app-service/pkg/time"
+	"gorm.io/gorm"
+)
+
+// EventHistoryHandler handles operator requests browsing the stored events of a tenant and the executions of their
+// handlers. The tenant is selected with ?tenant=.
+type EventHistoryHandler struct {
+	Service services.EventHistoryService
+}
+
+// NewEventHistoryHandler creates a new handler with the provided EventHistoryService.
+func NewEventHistoryHandler(service services.EventHistoryService) *EventHistoryHandler {
+	return &EventHistoryHandler{Service: service}
+}
+
+// GetEvents lists events with the executions of their handlers, latest first, with pagination. Events are filtered
+// with ?type=, ?uid= (both repeatable), ?context= and an RFC 3339 ?from= and ?to= publication range.
+func (h *EventHistoryHandler) GetEvents(c echo.Context) error {
+	filter, err := eventFilter(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(c.QueryParam("pageSize"))
+	if err != nil || pageSize <= 0 {
+		pageSize = 10
+	}
+
+	ctx := domain.WithTenant(c.Request().Context(), domain.TenantID(c.QueryParam("tenant")))
+	histories, total, err := h.Service.GetPage(ctx, filter, page, pageSize)
+	if err != nil {
+		c.Echo().Logger.Error("Failed to fetch events: ", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Unable to fetch events")
+	}
+
+	items := make([]map[string]interface{}, len(histories))
+	for i, history := range histories {
+		items[i] = eventHistoryResponse(history)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"page":       page,
+		"pageSize":   pageSize,
+		"totalCount": total,
+		"events":     items,
+	})
+}
+
+// GetEvent returns an event with its payload and the executions of its handlers.
+func (h *EventHistoryHandler) GetEvent(c echo.Context) error {
+	uid := c.Param("uid") // Extract event UID from the URL path
+
+	ctx := domain.WithTenant(c.Request().Context(), domain.TenantID(c.QueryParam("tenant")))
+	history, err := h.Service.Get(ctx, uid)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "Event not found")
+		}
+		c.Echo().Logger.Error("Failed to retrieve event: ", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Unable to retrieve event")
+	}
+
+	response := eventHistoryResponse(history)
+	response["payload"] = fx.GetOrDefault(history.Event.Payload, "")
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetEventStats counts the handler executions of the events selected like GetEvents, by status and by handler.
+func (h *EventHistoryHandler) GetEventStats(c echo.Context) error {
+	filter, err := eventFilter(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	ctx := domain.WithTenant(c.Request().Context(), domain.TenantID(c.QueryParam("tenant")))
+	counts, err := h.Service.CountByStatus(ctx, filter)
+	if err != nil {
+		c.Echo().Logger.Error("Failed to count handler executions: ", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Unable to count handler executions")
+	}
+
+	var total int64
+	byStatus := map[string]int64{}
+	byHandler := make([]map[string]interface{}, len(counts))
+	for i, count := range counts {
+		total += count.Count
+		byStatus[count.Status.String()] += count.Count
+		byHandler[i] = map[string]interface{}{
+			"handlerName": count.HandlerName,
+			"status":      count.Status.String(),
+			"count":       count.Count,
+		}
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"totalCount": total,
+		"byStatus":   byStatus,
+		"byHandler":  byHandler,
+	})
+}
+
+// eventFilter reads the event filter of a request.
+func eventFilter(c echo.Context) (domain.EventReplayFilter, error) {
+	params := c.QueryParams()
+	eventTypes := make([]domain.EventType, len(params["type"]))
+	for i, eventType := range params["type"] {
+		eventTypes[i] = domain.EventType(eventType)
+	}
+
+	filter := domain.EventReplayFilter{
+		EventTypes:  eventTypes,
+		EventUIDs:   params["uid"],
+		ContextName: domain.GameContextName(c.QueryParam("context")),
+	}
+	if from := c.QueryParam("from"); from != "" {
+		parsed, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return filter, errors.New("invalid from, expected an RFC 3339 time")
+		}
+		filter.From = fx.NewValueOption(xtime.NewUtcTimeIgnoreZone(parsed))
+	}
+	if to := c.QueryParam("to"); to != "" {
+		parsed, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return filter, errors.New("invalid to, expected an RFC 3339 time")
+		}
+		filter.To = fx.NewValueOption(xtime.NewUtcTimeIgnoreZone(parsed))
+	}
+	return filter, nil
+}
+
+func eventHistoryResponse(history domain.EventHistory) map[string]interface{} {
+	handlers := make([]map[string]interface{}, len(history.Handlers))
+	for i, handler := range history.Handlers {
+		handlers[i] = eventHandlerResponse(handler)
+	}
+
+	response := map[string]interface{}{
+		"eventUid":      history.Event.EventUID,
+		"eventType":     history.Event.EventType,
+		"tenant":        history.Event.TenantID,
+		"publishedAt":   history.Event.PublishedAt.Inner().Format(time.RFC3339),
+		"schemaVersion": history.Event.SchemaVersion,
+		"handlers":      handlers,
+	}
+	if history.Event.Comment.HasValue {
+		response["comment"] = history.Event.Comment.Value
+	}
+	return response
+}
+
+func eventHandlerResponse(handler domain.EventHandler) map[string]interface{} {
+	response := map[string]interface{}{
+		"handlerName": handler.HandlerName,
+		"status":      handler.Status.String(),
+		"startedAt":   handler.StartedAt.Inner().Format(time.RFC3339),
+	}
+	if handler.CompletedAt.HasValue {
+		response["completedAt"] = handler.CompletedAt.Value.Inner().Format(time.RFC3339)
+	}
+	if duration := handler.Duration(); duration.HasValue {
+		response["durationMs"] = duration.Value.Milliseconds()
+	}
+	if handler.Error.HasValue {
+		response["error"] = handler.Error.Value
+	}
+	return response
+}
@@ -0,0 +1,70 @@
+package apievents
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	"github.com/org/2112-space-lab/org/app-service/internal/services"
+	api_mappers "github.com/org/2112-space-lab/org/app-service/pkg/api"
+	fx "github.com/org/2112-space-lab/org/app-service/pkg/option"
+	xtime "github.com/org/2112-space-lab/org/app-service/pkg/time"
+)
+
+// ReplayHandler handles admin requests replaying stored events.
+type ReplayHandler struct {
+	Service services.EventReplayService
+}
+
+// NewReplayHandler creates a new handler with the provided EventReplayService.
+func NewReplayHandler(service services.EventReplayService) *ReplayHandler {
+	return &ReplayHandler{Service: service}
+}
+
+// ReplayEvents replays the stored events of a tenant selected by the request.
+func (h *ReplayHandler) ReplayEvents(c echo.Context) error {
+	var request api_mappers.EventReplayRequest
+	if err := c.Bind(&request); err != nil {
+		c.Echo().Logger.Error("Failed to bind EventReplayRequest: ", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload")
+	}
+
+	ctx := domain.WithTenant(c.Request().Context(), domain.TenantID(request.Tenant))
+	result, err := h.Service.Replay(ctx, replayOptions(request))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidEventReplay) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		c.Echo().Logger.Error("Failed to replay events: ", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Unable to replay events")
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+func replayOptions(request api_mappers.EventReplayRequest) domain.EventReplayOptions {
+	eventTypes := make([]domain.EventType, len(request.EventTypes))
+	for i, eventType := range request.EventTypes {
+		eventTypes[i] = domain.EventType(eventType)
+	}
+
+	filter := domain.EventReplayFilter{
+		EventTypes:  eventTypes,
+		EventUIDs:   request.EventUIDs,
+		ContextName: domain.GameContextName(request.ContextName),
+	}
+	if request.From != nil {
+		filter.From = fx.NewValueOption(xtime.NewUtcTimeIgnoreZone(*request.From))
+	}
+	if request.To != nil {
+		filter.To = fx.NewValueOption(xtime.NewUtcTimeIgnoreZone(*request.To))
+	}
+
+	return domain.EventReplayOptions{
+		Filter:        filter,
+		HandlerName:   request.HandlerName,
+		DryRun:        request.DryRun,
+		RatePerSecond: request.RatePerSecond,
+		Limit:         request.Limit,
+	}
+}
@@ -0,0 +1,156 @@
+package apigeo
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/org/2112-space-lab/org/app-service/internal/services"
+	"github.com/org/2112-space-lab/org/app-service/pkg/geoexport"
+	"gorm.io/gorm"
+)
+
+const (
+	formatGeoJSON = "geojson"
+	formatKML     = "kml"
+
+	geoJSONContentType = "application/geo+json"
+
+	defaultTrackDuration = 90 * time.Minute
+	defaultTrackInterval = 30 * time.Second
+)
+
+// GeoExportHandler serves GeoJSON and KML exports.
+type GeoExportHandler struct {
+	Service services.GeoExportService
+}
+
+// NewGeoExportHandler creates a new handler with the provided GeoExportService.
+func NewGeoExportHandler(service services.GeoExportService) *GeoExportHandler {
+	return &GeoExportHandler{Service: service}
+}
+
+// GetContextTiles exports the tiles of a context.
+func (h *GeoExportHandler) GetContextTiles(c echo.Context) error {
+	contextID := c.Param("contextID")
+
+	fc, err := h.Service.ExportContextTiles(c.Request().Context(), contextID)
+	if err != nil {
+		c.Logger().Error("Failed to export context tiles: ", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Unable to export context tiles")
+	}
+
+	return render(c, "tiles-"+contextID, fc)
+}
+
+// GetSatelliteMappings exports the tile mappings of a satellite within a context.
+func (h *GeoExportHandler) GetSatelliteMappings(c echo.Context) error {
+	spaceID := c.Param("spaceID")
+	contextID := c.QueryParam("contextID")
+	if contextID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Missing contextID parameter")
+	}
+
+	fc, err := h.Service.ExportSatelliteMappings(c.Request().Context(), contextID, spaceID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Context not found")
+	}
+	if err != nil {
+		c.Logger().Error("Failed to export satellite mappings: ", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Unable to export satellite mappings")
+	}
+
+	return render(c, "mappings-"+spaceID, fc)
+}
+
+// GetGroundTrack exports a satellite's ground track between startTime and endTime.
+// Defaults to one orbit-sized window from now sampled every 30 seconds.
+func (h *GeoExportHandler) GetGroundTrack(c echo.Context) error {
+	spaceID := c.Param("spaceID")
+
+	startTime := time.Now().UTC()
+	if v := c.QueryParam("startTime"); v != "" {
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid startTime format, expected RFC3339")
+		}
+		startTime = parsed
+	}
+
+	endTime := startTime.Add(defaultTrackDuration)
+	if v := c.QueryParam("endTime"); v != "" {
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid endTime format, expected RFC3339")
+		}
+		endTime = parsed
+	}
+
+	interval := defaultTrackInterval
+	if v := c.QueryParam("intervalSeconds"); v != "" {
+		seconds, err := strconv.Atoi(v)
+		if err != nil || seconds <= 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid intervalSeconds parameter")
+		}
+		interval = time.Duration(seconds) * time.Second
+	}
+
+	if !startTime.Before(endTime) {
+		return echo.NewHTTPError(http.StatusBadRequest, "startTime must be before endTime")
+	}
+
+	fc, err := h.Service.ExportGroundTrack(c.Request().Context(), spaceID, startTime, endTime, interval)
+	if err != nil {
+		c.Logger().Error("Failed to export ground track: ", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Unable to export ground track")
+	}
+
+	return render(c, "track-"+spaceID, fc)
+}
+
+// GetFootprint exports a satellite's footprint at the given time (defaults to now).
+func (h *GeoExportHandler) GetFootprint(c echo.Context) error {
+	spaceID := c.Param("spaceID")
+
+	at := time.Now().UTC()
+	if v := c.QueryParam("time"); v != "" {
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid time format, expected RFC3339")
+		}
+		at = parsed
+	}
+
+	fc, err := h.Service.ExportFootprint(c.Request().Context(), spaceID, at)
+	if err != nil {
+		c.Logger().Error("Failed to export footprint: ", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Unable to export footprint")
+	}
+
+	return render(c, "footprint-"+spaceID, fc)
+}
+
+// render writes the collection in the format requested by the `format` query parameter.
+func render(c echo.Context, name string, fc geoexport.FeatureCollection) error {
+	switch c.QueryParam("format") {
+	case "", formatGeoJSON:
+		body, err := fc.Marshal()
+		if err != nil {
+			c.Logger().Error("Failed to encode GeoJSON: ", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Unable to encode GeoJSON")
+		}
+		return c.Blob(http.StatusOK, geoJSONContentType, body)
+	case formatKML:
+		body, err := geoexport.MarshalKML(name, fc)
+		if err != nil {
+			c.Logger().Error("Failed to encode KML: ", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Unable to encode KML")
+		}
+		c.Response().Header().Set(echo.HeaderContentDisposition, "attachment; filename=\""+name+".kml\"")
+		return c.Blob(http.StatusOK, geoexport.KMLContentType, body)
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "Unsupported format, expected geojson or kml")
+	}
+}
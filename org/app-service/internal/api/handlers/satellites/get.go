@@ -123,3 +123,26 @@ func (h *SatelliteHandler) GetPaginatedSatelliteInfo(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, response)
 }
+
+// SetSensorHalfAngle sets the sensor cone half-angle of a satellite. A null value clears it.
+func (h *SatelliteHandler) SetSensorHalfAngle(c echo.Context) error {
+	spaceID := c.Param("spaceID")
+
+	var request struct {
+		HalfAngleDeg *float64 `json:"halfAngleDeg"`
+	}
+	if err := c.Bind(&request); err != nil {
+		c.Echo().Logger.Error("Failed to bind sensor request: ", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload")
+	}
+
+	if err := h.Service.SetSensorHalfAngle(c.Request().Context(), spaceID, request.HalfAngleDeg); err != nil {
+		c.Echo().Logger.Error("Failed to set sensor half-angle: ", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "Unable to set sensor half-angle")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"spaceID":      spaceID,
+		"halfAngleDeg": request.HalfAngleDeg,
+	})
+}
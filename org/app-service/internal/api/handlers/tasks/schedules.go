@@ -0,0 +1,145 @@
+package apitasks
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	"github.com/org/2112-space-lab/org/app-service/internal/services"
+	api_mappers "github.com/org/2112-space-lab/org/app-service/pkg/api"
+	"gorm.io/gorm"
+)
+
+// TaskScheduleHandler handles operator requests managing the cron schedules of tasks.
+type TaskScheduleHandler struct {
+	Service *services.TaskScheduleService
+	Tasks   domain.TaskCatalog
+}
+
+// NewTaskScheduleHandler creates a new handler with the provided TaskScheduleService, scheduling the tasks of the
+// catalog.
+func NewTaskScheduleHandler(service *services.TaskScheduleService, tasks domain.TaskCatalog) *TaskScheduleHandler {
+	return &TaskScheduleHandler{Service: service, Tasks: tasks}
+}
+
+// GetSchedules lists every schedule with the outcome of its last run.
+func (h *TaskScheduleHandler) GetSchedules(c echo.Context) error {
+	schedules, err := h.Service.GetAll(c.Request().Context())
+	if err != nil {
+		c.Echo().Logger.Error("Failed to fetch task schedules: ", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Unable to fetch task schedules")
+	}
+
+	items := make([]map[string]interface{}, len(schedules))
+	for i, schedule := range schedules {
+		items[i] = taskScheduleResponse(schedule)
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"schedules": items})
+}
+
+// GetSchedule returns a schedule with the outcome of its last run.
+func (h *TaskScheduleHandler) GetSchedule(c echo.Context) error {
+	name := c.Param("name") // Extract schedule name from the URL path
+
+	schedule, err := h.Service.Get(c.Request().Context(), name)
+	if err != nil {
+		return taskScheduleError(c, "Unable to retrieve task schedule", err)
+	}
+	return c.JSON(http.StatusOK, taskScheduleResponse(schedule))
+}
+
+// PutSchedule creates or replaces a schedule of a task finishing on its own. Its next run is computed from now on.
+func (h *TaskScheduleHandler) PutSchedule(c echo.Context) error {
+	name := c.Param("name") // Extract schedule name from the URL path
+
+	var request api_mappers.TaskScheduleRequest
+	if err := c.Bind(&request); err != nil {
+		c.Echo().Logger.Error("Failed to bind TaskScheduleRequest: ", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload")
+	}
+	if err := h.Tasks.CheckSchedulable(request.Task); err != nil {
+		return taskScheduleError(c, "Unable to save task schedule", err)
+	}
+	enabled := true
+	if request.Enabled != nil {
+		enabled = *request.Enabled
+	}
+
+	schedule, err := h.Service.Save(c.Request().Context(), domain.TaskSchedule{
+		Name:            name,
+		TaskName:        request.Task,
+		Cron:            request.Cron,
+		Args:            request.Args,
+		Enabled:         enabled,
+		MissedRunPolicy: domain.MissedRunPolicy(request.MissedRunPolicy),
+		Jitter:          time.Duration(request.JitterSeconds) * time.Second,
+	})
+	if err != nil {
+		return taskScheduleError(c, "Unable to save task schedule", err)
+	}
+	return c.JSON(http.StatusOK, taskScheduleResponse(schedule))
+}
+
+// DeleteSchedule removes a schedule. A run in progress completes.
+func (h *TaskScheduleHandler) DeleteSchedule(c echo.Context) error {
+	name := c.Param("name") // Extract schedule name from the URL path
+
+	if err := h.Service.Delete(c.Request().Context(), name); err != nil {
+		return taskScheduleError(c, "Unable to delete task schedule", err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// RunSchedule makes an enabled schedule due at once. The run starts on the next search of a scheduler for due runs.
+func (h *TaskScheduleHandler) RunSchedule(c echo.Context) error {
+	name := c.Param("name") // Extract schedule name from the URL path
+
+	schedule, err := h.Service.Trigger(c.Request().Context(), name)
+	if err != nil {
+		return taskScheduleError(c, "Unable to trigger task schedule", err)
+	}
+	return c.JSON(http.StatusAccepted, taskScheduleResponse(schedule))
+}
+
+func taskScheduleResponse(schedule domain.TaskSchedule) map[string]interface{} {
+	response := map[string]interface{}{
+		"name":            schedule.Name,
+		"task":            schedule.TaskName,
+		"cron":            schedule.Cron,
+		"args":            schedule.Args,
+		"enabled":         schedule.Enabled,
+		"missedRunPolicy": schedule.MissedRunPolicy,
+		"jitterSeconds":   int64(schedule.Jitter / time.Second),
+		"nextRunAt":       schedule.NextRunAt.Format(time.RFC3339),
+		"running":         schedule.RunningSince != nil,
+	}
+	if schedule.RunningSince != nil {
+		response["runningSince"] = schedule.RunningSince.UTC().Format(time.RFC3339)
+		response["runningOwner"] = schedule.RunningOwner
+	}
+	if schedule.LastRunAt != nil {
+		response["lastRunAt"] = schedule.LastRunAt.UTC().Format(time.RFC3339)
+		response["lastStatus"] = schedule.LastStatus
+		response["lastDurationMs"] = schedule.LastDuration.Milliseconds()
+	}
+	if schedule.LastError != "" {
+		response["lastError"] = schedule.LastError
+	}
+	return response
+}
+
+// taskScheduleError maps a task schedule service error to an HTTP error.
+func taskScheduleError(c echo.Context, message string, err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidTaskSchedule):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "Task schedule not found")
+	case errors.Is(err, domain.ErrTaskScheduleDisabled):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	}
+	c.Echo().Logger.Error(message+": ", err)
+	return echo.NewHTTPError(http.StatusInternalServerError, message)
+}
@@ -1,6 +1,7 @@
 package tiles
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -9,6 +10,8 @@ import (
 	"github.com/org/2112-space-lab/org/app-service/internal/config/constants"
 	"github.com/org/2112-space-lab/org/app-service/internal/domain"
 	"github.com/org/2112-space-lab/org/app-service/internal/services"
+	fx "github.com/org/2112-space-lab/org/app-service/pkg/option"
+	"gorm.io/gorm"
 )
 
 type TileHandler struct {
@@ -22,7 +25,12 @@ func NewTileHandler(service services.TileService) *TileHandler {
 
 // GetAllTiles fetches all available tiles.
 func (h *TileHandler) GetAllTiles(c echo.Context) error {
-	tiles, err := h.Service.FindAllTiles(c.Request().Context(), "todoTileHandler")
+	contextID := c.QueryParam("contextID")
+	if contextID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Missing contextID parameter")
+	}
+
+	tiles, err := h.Service.FindAllTiles(c.Request().Context(), contextID)
 	if err != nil {
 		c.Echo().Logger.Error("Failed to fetch tiles: ", err)
 		return echo.NewHTTPError(http.StatusInternalServerError, "Unable to fetch tiles")
@@ -40,6 +48,11 @@ func (h *TileHandler) GetAllTiles(c echo.Context) error {
 
 // GetTilesInRegionHandler handles requests to fetch tiles in a region.
 func (h *TileHandler) GetTilesInRegionHandler(c echo.Context) error {
+	contextID := c.QueryParam("contextID")
+	if contextID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Missing contextID parameter")
+	}
+
 	// Parse query parameters for bounding box
 	minLatStr := c.QueryParam("minLat")
 	minLonStr := c.QueryParam("minLon")
@@ -65,7 +78,7 @@ func (h *TileHandler) GetTilesInRegionHandler(c echo.Context) error {
 	}
 
 	// Call the service to fetch tiles
-	tiles, err := h.Service.GetTilesInRegion(c.Request().Context(), "todoTileHandler", minLat, minLon, maxLat, maxLon)
+	tiles, err := h.Service.GetTilesInRegion(c.Request().Context(), contextID, minLat, minLon, maxLat, maxLon)
 	if err != nil {
 		c.Logger().Error("Failed to fetch tiles in region:", err)
 		return echo.NewHTTPError(http.StatusInternalServerError, "unable to fetch tiles in region")
@@ -77,6 +90,11 @@ func (h *TileHandler) GetTilesInRegionHandler(c echo.Context) error {
 
 // GetPaginatedSatelliteMappings fetches a paginated list of satellite mappings with optional search filters.
 func (h *TileHandler) GetPaginatedSatelliteMappings(c echo.Context) error {
+	contextID := c.QueryParam("contextID")
+	if contextID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Missing contextID parameter")
+	}
+
 	// Parse query parameters for pagination
 	pageStr := c.QueryParam("page")
 	pageSizeStr := c.QueryParam("pageSize")
@@ -99,10 +117,9 @@ func (h *TileHandler) GetPaginatedSatelliteMappings(c echo.Context) error {
 	}
 
 	// Call the service method for pagination with search filters
-	mappings, totalRecords, err := h.Service.ListSatellitesMappingWithPagination(c.Request().Context(), "todoTileHandler", page, pageSize, searchRequest)
+	mappings, totalRecords, err := h.Service.ListSatellitesMappingWithPagination(c.Request().Context(), contextID, page, pageSize, searchRequest)
 	if err != nil {
-		c.Echo().Logger.Error("Failed to fetch paginated satellites mappings: ", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "Unable to fetch satellites mappings")
+		return mappingError(c, "Unable to fetch satellites mappings", err)
 	}
 
 	// Prepare the response
@@ -118,22 +135,70 @@ func (h *TileHandler) GetPaginatedSatelliteMappings(c echo.Context) error {
 
 // GetSatelliteMappingsBySpaceID handles requests to fetch tiles in a region.
 func (h *TileHandler) GetSatelliteMappingsBySpaceID(c echo.Context) error {
+	contextID := c.QueryParam("contextID")
+	if contextID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Missing contextID parameter")
+	}
+
 	// Parse query parameters for bounding box
 	spaceID := c.QueryParam("spaceID")
 
 	// Call the service to fetch mappings
-	mappings, err := h.Service.GetSatelliteMappingsBySpaceID(c.Request().Context(), "todoTileHandler", spaceID)
+	mappings, err := h.Service.GetSatelliteMappingsBySpaceID(c.Request().Context(), contextID, spaceID)
 	if err != nil {
-		c.Logger().Error("Failed to fetch mappings:", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "unable to fetch mappings by space ID [%s]", spaceID)
+		return mappingError(c, "Unable to fetch mappings by space ID", err)
 	}
 
 	// Return tiles in JSON response
 	return c.JSON(http.StatusOK, mappings)
 }
 
+// GetMappingsInWindow handles requests to fetch mappings whose pass overlaps [from, to].
+// Optional spaceID and tileID narrow the result, e.g. "which satellites fly over this tile tomorrow morning".
+func (h *TileHandler) GetMappingsInWindow(c echo.Context) error {
+	contextID := c.QueryParam("contextID")
+	if contextID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Missing contextID parameter")
+	}
+
+	filter := domain.MappingWindowFilter{
+		SpaceID: c.QueryParam("spaceID"),
+		TileID:  c.QueryParam("tileID"),
+	}
+
+	if fromStr := c.QueryParam("from"); fromStr != "" {
+		from, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid from format, expected RFC3339")
+		}
+		filter.From = fx.NewValueOption(from)
+	}
+	if toStr := c.QueryParam("to"); toStr != "" {
+		to, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid to format, expected RFC3339")
+		}
+		filter.To = fx.NewValueOption(to)
+	}
+	if err := filter.Validate(); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	mappings, err := h.Service.FindMappingsInWindow(c.Request().Context(), contextID, filter)
+	if err != nil {
+		return mappingError(c, "Unable to fetch mappings in window", err)
+	}
+
+	return c.JSON(http.StatusOK, mappings)
+}
+
 // RecomputeMappingsBySpaceID handles requests to recompute satellite mappings for a given SPACE ID.
 func (h *TileHandler) RecomputeMappingsBySpaceID(c echo.Context) error {
+	contextID := c.QueryParam("contextID")
+	if contextID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Missing contextID parameter")
+	}
+
 	// Extract the SPACE ID from the query parameter
 	spaceID := c.QueryParam("spaceID")
 	if spaceID == "" {
@@ -165,10 +230,9 @@ func (h *TileHandler) RecomputeMappingsBySpaceID(c echo.Context) error {
 	}
 
 	// Call the service method to recompute mappings
-	err = h.Service.RecomputeMappings(c.Request().Context(), "todoTileHandler", spaceID, startTime, endTime)
+	err = h.Service.RecomputeMappings(c.Request().Context(), contextID, spaceID, startTime, endTime)
 	if err != nil {
-		c.Logger().Error("Failed to recompute mappings for SPACE ID:", spaceID, "Error:", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "Unable to recompute mappings for SPACE ID")
+		return mappingError(c, "Unable to recompute mappings for SPACE ID", err)
 	}
 
 	// Return a success response
@@ -179,3 +243,12 @@ func (h *TileHandler) RecomputeMappingsBySpaceID(c echo.Context) error {
 		"endTime":   endTime.Format(time.RFC3339),
 	})
 }
+
+// mappingError maps mapping failures to HTTP errors. Contexts of other tenants are not found.
+func mappingError(c echo.Context, message string, err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Context not found")
+	}
+	c.Echo().Logger.Error(message+": ", err)
+	return echo.NewHTTPError(http.StatusInternalServerError, message)
+}
@@ -1 +1,76 @@
 package tle
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	"github.com/org/2112-space-lab/org/app-service/internal/services"
+	api_mappers "github.com/org/2112-space-lab/org/app-service/pkg/api"
+)
+
+// TleHandler handles API requests related to TLEs.
+type TleHandler struct {
+	Service services.TleService
+}
+
+// NewTleHandler creates a new handler with the provided TleService.
+func NewTleHandler(service services.TleService) *TleHandler {
+	return &TleHandler{Service: service}
+}
+
+// GetPinnedTLEs lists the pinned TLEs of a GameContext by its unique name.
+func (h *TleHandler) GetPinnedTLEs(c echo.Context) error {
+	name := c.Param("name") // Extract context name from the URL path
+
+	tles, pinned, err := h.Service.GetPinnedTLEs(c.Request().Context(), domain.GameContextName(name))
+	if err != nil {
+		c.Echo().Logger.Error("Failed to retrieve pinned TLEs: ", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Unable to retrieve pinned TLEs")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"name":   name,
+		"pinned": pinned,
+		"tles":   tles,
+	})
+}
+
+// PinContextTLEs pins the TLEs of a GameContext at the epoch given in the request body.
+func (h *TleHandler) PinContextTLEs(c echo.Context) error {
+	name := c.Param("name") // Extract context name from the URL path
+
+	var request api_mappers.ContextTlePinRequest
+	if err := c.Bind(&request); err != nil {
+		c.Echo().Logger.Error("Failed to bind ContextTlePinRequest: ", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload")
+	}
+
+	count, err := h.Service.PinContextTLEs(c.Request().Context(), domain.GameContextName(name), request.Epoch)
+	if err != nil {
+		c.Echo().Logger.Error("Failed to pin TLEs: ", err)
+		if errors.Is(err, domain.ErrNoTLEsAtEpoch) {
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Unable to pin TLEs")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"name":   name,
+		"epoch":  request.Epoch.UTC(),
+		"pinned": count,
+	})
+}
+
+// UnpinContextTLEs makes a GameContext follow the latest TLEs again.
+func (h *TleHandler) UnpinContextTLEs(c echo.Context) error {
+	name := c.Param("name") // Extract context name from the URL path
+
+	if err := h.Service.UnpinContextTLEs(c.Request().Context(), domain.GameContextName(name)); err != nil {
+		c.Echo().Logger.Error("Failed to unpin TLEs: ", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Unable to unpin TLEs")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
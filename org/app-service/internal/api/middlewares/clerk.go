@@ -2,30 +2,38 @@ package middlewares
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/clerk/clerk-sdk-go/v2"
 	clerkhttp "github.com/clerk/clerk-sdk-go/v2/http"
 	"github.com/labstack/echo/v4"
+	"github.com/org/2112-space-lab/org/app-service/internal/config/constants"
 )
 
-// ClerkMiddleware integrates Clerk authentication into Echo
+// ClerkMiddleware integrates Clerk authentication into Echo.
+// A valid session token stores its claims under CONTEXT_KEY_CLAIMS and an invalid one is rejected with 401.
+// Requests without a token pass through; TenantMiddleware decides whether a principal is required.
 func ClerkMiddleware(opts ...clerkhttp.AuthorizationOption) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			handler := clerkhttp.RequireHeaderAuthorization(opts...)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(c.Path(), "/health") {
+				return next(c)
+			}
+
+			var nextErr error
+			handler := clerkhttp.WithHeaderAuthorization(opts...)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				c.SetRequest(r)
-				c.Response().Writer = w
 
 				claims, ok := clerk.SessionClaimsFromContext(r.Context())
 				if ok {
-					c.Set("claims", claims)
+					c.Set(constants.CONTEXT_KEY_CLAIMS, claims)
 				}
 
-				_ = next(c)
+				nextErr = next(c)
 			}))
 
-			handler.ServeHTTP(c.Response().Writer, c.Request())
-			return nil
+			handler.ServeHTTP(c.Response(), c.Request())
+			return nextErr
 		}
 	}
 }
@@ -0,0 +1,52 @@
+package middlewares
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/labstack/echo/v4"
+	"github.com/org/2112-space-lab/org/app-service/internal/config/constants"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+)
+
+// TenantMiddleware resolves the tenant of the authenticated principal and scopes the request context to it.
+// Without a tenant-bearing principal, the request is rejected when requirePrincipal is set and runs for the
+// default tenant otherwise.
+func TenantMiddleware(requirePrincipal bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if strings.HasPrefix(c.Path(), "/health") {
+				return next(c)
+			}
+
+			claims, _ := c.Get(constants.CONTEXT_KEY_CLAIMS).(*clerk.SessionClaims)
+			tenantID, ok := TenantFromClaims(claims)
+			if !ok {
+				if requirePrincipal {
+					return echo.NewHTTPError(http.StatusUnauthorized, constants.MSG_NOT_AUTHORIZED)
+				}
+				tenantID = domain.DefaultTenantID
+			}
+
+			c.Set(constants.CONTEXT_KEY_TENANT, tenantID)
+			c.SetRequest(c.Request().WithContext(domain.WithTenant(c.Request().Context(), tenantID)))
+			return next(c)
+		}
+	}
+}
+
+// TenantFromClaims returns the tenant of a session: its active organization, or the user itself
+// for sessions outside any organization.
+func TenantFromClaims(claims *clerk.SessionClaims) (domain.TenantID, bool) {
+	if claims == nil {
+		return "", false
+	}
+	if claims.ActiveOrganizationID != "" {
+		return domain.TenantID(claims.ActiveOrganizationID), true
+	}
+	if claims.Subject != "" {
+		return domain.TenantID(claims.Subject), true
+	}
+	return "", false
+}
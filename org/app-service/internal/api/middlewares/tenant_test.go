@@ -0,0 +1,116 @@
+package middlewares
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	clerkhttp "github.com/clerk/clerk-sdk-go/v2/http"
+	"github.com/go-jose/go-jose/v3"
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/labstack/echo/v4"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+)
+
+type testSessionClaims struct {
+	jwt.Claims
+	ActiveOrganizationID string `json:"org_id,omitempty"`
+}
+
+// newTenantTestServer chains the Clerk and tenant middlewares the way the public router does, in front of
+// a handler echoing the tenant it sees on the request context.
+func newTenantTestServer(t *testing.T, requirePrincipal bool) (*echo.Echo, *rsa.PrivateKey) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	publicKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	e := echo.New()
+	e.Use(ClerkMiddleware(clerkhttp.JSONWebKey(publicKey)), TenantMiddleware(requirePrincipal))
+	e.GET("/contexts", func(c echo.Context) error {
+		return c.String(http.StatusOK, string(domain.TenantFromContext(c.Request().Context())))
+	})
+	e.GET("/health/alive", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+	return e, key
+}
+
+func signSessionToken(t *testing.T, key *rsa.PrivateKey, subject string, organizationID string) string {
+	t.Helper()
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key}, (&jose.SignerOptions{}).WithType("JWT"))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	now := time.Now()
+	claims := testSessionClaims{
+		Claims: jwt.Claims{
+			Issuer:   "https://clerk.example.com",
+			Subject:  subject,
+			IssuedAt: jwt.NewNumericDate(now),
+			Expiry:   jwt.NewNumericDate(now.Add(time.Minute)),
+		},
+		ActiveOrganizationID: organizationID,
+	}
+	token, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	return token
+}
+
+func TestClerkAndTenantMiddlewares(t *testing.T) {
+	tests := []struct {
+		name             string
+		requirePrincipal bool
+		path             string
+		subject          string
+		organizationID   string
+		foreignKey       bool
+		expectedStatus   int
+		expectedTenant   string
+	}{
+		{name: "Organization session", path: "/contexts", subject: "user_1", organizationID: "org_1", expectedStatus: http.StatusOK, expectedTenant: "org_1"},
+		{name: "Personal session", path: "/contexts", subject: "user_1", expectedStatus: http.StatusOK, expectedTenant: "user_1"},
+		{name: "Anonymous with optional principal", path: "/contexts", expectedStatus: http.StatusOK, expectedTenant: string(domain.DefaultTenantID)},
+		{name: "Anonymous with required principal", requirePrincipal: true, path: "/contexts", expectedStatus: http.StatusUnauthorized},
+		{name: "Token signed by another key", path: "/contexts", subject: "user_1", foreignKey: true, expectedStatus: http.StatusUnauthorized},
+		{name: "Health check", requirePrincipal: true, path: "/health/alive", expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, key := newTenantTestServer(t, tt.requirePrincipal)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.foreignKey {
+				_, key = newTenantTestServer(t, tt.requirePrincipal)
+			}
+			if tt.subject != "" {
+				req.Header.Set(echo.HeaderAuthorization, "Bearer "+signSessionToken(t, key, tt.subject, tt.organizationID))
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("Expected %v, but got %v", tt.expectedStatus, rec.Code)
+			}
+			if tt.expectedTenant != "" && rec.Body.String() != tt.expectedTenant {
+				t.Errorf("Expected %v, but got %v", tt.expectedTenant, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package routers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	metricsHandlers "github.com/org/2112-space-lab/org/app-service/internal/api/handlers/metrics"
+	logger "github.com/org/2112-space-lab/org/app-service/pkg/log"
+)
+
+// MetricsRouter serves the Prometheus metrics of a process running no API, such as an event detector.
+type MetricsRouter struct {
+	Echo *echo.Echo
+	Name string
+}
+
+// InitMetricsRouter initializes a router serving /metrics only.
+func InitMetricsRouter(name string) *MetricsRouter {
+	r := &MetricsRouter{Echo: echo.New(), Name: name}
+	r.Echo.HideBanner = true
+	r.Echo.HidePort = true
+	r.Echo.GET("/metrics", metricsHandlers.GetMetrics)
+	return r
+}
+
+// Serve runs the server until ctx is done. A server that cannot start is logged without stopping the process,
+// whose work does not depend on its metrics.
+func (r *MetricsRouter) Serve(ctx context.Context, host, port string) {
+	serverAddress := fmt.Sprintf("%s:%s", host, port)
+	go func() {
+		logger.Infof("Starting %s metrics server on %s", r.Name, serverAddress)
+		if err := r.Echo.Start(serverAddress); err != nil && err != http.ErrServerClosed {
+			logger.Errorf("❌ %s metrics server error: %v", r.Name, err)
+		}
+	}()
+
+	<-ctx.Done()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := r.Echo.Shutdown(shutdownCtx); err != nil {
+		logger.Errorf("❌ Failed to shutdown %s metrics server: %v", r.Name, err)
+	}
+}
@@ -10,10 +10,13 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/org/2112-space-lab/org/app-service/internal/api/handlers/errors"
+	apievents "github.com/org/2112-space-lab/org/app-service/internal/api/handlers/events"
 	healthHandlers "github.com/org/2112-space-lab/org/app-service/internal/api/handlers/healthz"
 	metricsHandlers "github.com/org/2112-space-lab/org/app-service/internal/api/handlers/metrics"
+	apitasks "github.com/org/2112-space-lab/org/app-service/internal/api/handlers/tasks"
 	"github.com/org/2112-space-lab/org/app-service/internal/config"
 	"github.com/org/2112-space-lab/org/app-service/internal/dependencies"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
 	logger "github.com/org/2112-space-lab/org/app-service/pkg/log"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -23,6 +26,7 @@ type ProtectedRouter struct {
 	Echo         *echo.Echo
 	Name         string
 	Dependencies *dependencies.Dependencies
+	Tasks        domain.TaskCatalog
 }
 
 // Init initializes the Echo instance for the router.
@@ -32,11 +36,12 @@ func (r *ProtectedRouter) Init() {
 
 }
 
-func InitProtectedAPIRouter(env *config.SEnv, deps *dependencies.Dependencies) *ProtectedRouter {
+func InitProtectedAPIRouter(env *config.SEnv, deps *dependencies.Dependencies, tasks domain.TaskCatalog) *ProtectedRouter {
 	logger.Debug("Initializing protected api router ...")
 	protectedApiRouter := &ProtectedRouter{
 		Name:         "public API",
 		Dependencies: deps,
+		Tasks:        tasks,
 	}
 	protectedApiRouter.Init()
 	protectedApiRouter.registerPrometheusMetrics()
@@ -48,6 +53,9 @@ func InitProtectedAPIRouter(env *config.SEnv, deps *dependencies.Dependencies) *
 	logger.Debug("Registering metrics api protected routes ...")
 	protectedApiRouter.registerMetricsAPIRoutes()
 
+	logger.Debug("Registering admin api protected routes ...")
+	protectedApiRouter.registerAdminAPIRoutes()
+
 	// finally register default fallback error handlers
 	// 404 is handled here as the last route
 	logger.Debug("Registering protected api error handlers ...")
@@ -99,6 +107,28 @@ func (r *ProtectedRouter) registerMetricsAPIRoutes() {
 	metrics.GET("", metricsHandlers.GetMetrics)
 }
 
+// registerAdminAPIRoutes registers operator routes, reachable on the protected port only.
+func (r *ProtectedRouter) registerAdminAPIRoutes() {
+	replayHandler := apievents.NewReplayHandler(r.Dependencies.Services.EventReplayService)
+	historyHandler := apievents.NewEventHistoryHandler(r.Dependencies.Services.EventHistoryService)
+	deadLetterHandler := apievents.NewDeadLetterHandler(r.Dependencies.Services.DeadLetterService)
+	scheduleHandler := apitasks.NewTaskScheduleHandler(&r.Dependencies.Services.TaskScheduleService, r.Tasks)
+
+	admin := r.Echo.Group("/admin")
+	admin.POST("/events/replay", replayHandler.ReplayEvents)
+	admin.GET("/events", historyHandler.GetEvents)
+	admin.GET("/events/stats", historyHandler.GetEventStats)
+	admin.GET("/events/dead-letters", deadLetterHandler.GetDeadLetters)
+	admin.GET("/events/dead-letters/:id", deadLetterHandler.GetDeadLetter)
+	admin.POST("/events/dead-letters/:id/redrive", deadLetterHandler.RedriveDeadLetter)
+	admin.GET("/events/:uid", historyHandler.GetEvent)
+	admin.GET("/schedules", scheduleHandler.GetSchedules)
+	admin.GET("/schedules/:name", scheduleHandler.GetSchedule)
+	admin.PUT("/schedules/:name", scheduleHandler.PutSchedule)
+	admin.DELETE("/schedules/:name", scheduleHandler.DeleteSchedule)
+	admin.POST("/schedules/:name/run", scheduleHandler.RunSchedule)
+}
+
 // Start the Echo server
 func (r *ProtectedRouter) Start(host string, port string) {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
@@ -6,16 +6,21 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"time"
 
 	"github.com/clerk/clerk-sdk-go/v2"
 	"github.com/labstack/echo/v4"
 	apiaudittrail "github.com/org/2112-space-lab/org/app-service/internal/api/handlers/audits"
+	apibasemap "github.com/org/2112-space-lab/org/app-service/internal/api/handlers/basemap"
 	apicontext "github.com/org/2112-space-lab/org/app-service/internal/api/handlers/context"
+	apicoverage "github.com/org/2112-space-lab/org/app-service/internal/api/handlers/coverage"
 	"github.com/org/2112-space-lab/org/app-service/internal/api/handlers/errors"
+	apigeo "github.com/org/2112-space-lab/org/app-service/internal/api/handlers/geo"
 	healthHandlers "github.com/org/2112-space-lab/org/app-service/internal/api/handlers/healthz"
 	"github.com/org/2112-space-lab/org/app-service/internal/api/handlers/satellites"
 	"github.com/org/2112-space-lab/org/app-service/internal/api/handlers/tiles"
+	apitle "github.com/org/2112-space-lab/org/app-service/internal/api/handlers/tle"
 	apiuser "github.com/org/2112-space-lab/org/app-service/internal/api/handlers/users"
 	"github.com/org/2112-space-lab/org/app-service/internal/api/middlewares"
 	"github.com/org/2112-space-lab/org/app-service/internal/config"
@@ -43,7 +48,7 @@ func NewPublicRouter(env *config.SEnv, deps *dependencies.Dependencies) *PublicR
 	}
 	router.setupEcho()
 	router.registerRoutes()
-	router.registerMiddlewares()
+	router.registerMiddlewares(env)
 
 	logger.Debug("Public API router initialization complete.")
 	return router
@@ -56,14 +61,16 @@ func (r *PublicRouter) setupEcho() {
 }
 
 // registerMiddlewares configures middleware for the Echo instance.
-func (r *PublicRouter) registerMiddlewares() {
+func (r *PublicRouter) registerMiddlewares(env *config.SEnv) {
 	logger.Debug("Registering middlewares...")
+	requirePrincipal, _ := strconv.ParseBool(env.EnvVars.Tenancy.RequirePrincipal)
 	middlewareList := []echo.MiddlewareFunc{
 		middlewares.SlashesMiddleware(),
 		middlewares.LoggerMiddleware(),
 		middlewares.TimeoutMiddleware(),
 		middlewares.ResponseHeadersMiddleware(),
-		// middlewares.ClerkMiddleware(),
+		middlewares.ClerkMiddleware(),
+		middlewares.TenantMiddleware(requirePrincipal),
 		middlewares.LogNonGETRequestsMiddleware(r.RouteTableMapping, r.Dependencies.Services.AuditTrailService),
 	}
 
@@ -106,15 +113,24 @@ func (r *PublicRouter) registerPublicAPIRoutes() {
 	// Handlers
 	satelliteHandler := satellites.NewSatelliteHandler(r.Dependencies.Services.SatelliteService)
 	contextHandler := apicontext.NewContextHandler(r.Dependencies.Services.ContextService)
+	contextBundleHandler := apicontext.NewContextBundleHandler(r.Dependencies.Services.ContextBundleService)
+	membershipHandler := apicontext.NewMembershipHandler(r.Dependencies.Services.MembershipService)
+	clockHandler := apicontext.NewClockHandler(r.Dependencies.Services.ClockService)
+	rehydrationHandler := apicontext.NewRehydrationHandler(&r.Dependencies.Services.RehydrationService)
 	tileHandler := tiles.NewTileHandler(r.Dependencies.Services.TileService)
 	auditTrailHandler := apiaudittrail.NewAuditTrailHandler(r.Dependencies.Services.AuditTrailService)
 	userHandler := apiuser.NewUserHandler()
+	basemapHandler := apibasemap.NewBasemapHandler(r.Dependencies.Services.BasemapService)
+	geoExportHandler := apigeo.NewGeoExportHandler(r.Dependencies.Services.GeoExportService)
+	coverageHandler := apicoverage.NewCoverageHandler(r.Dependencies.Services.CoverageService)
+	tleHandler := apitle.NewTleHandler(r.Dependencies.Services.TleService)
 
 	// Satellite routes
 	satellite := r.Echo.Group("/satellites")
 	satellite.GET("/orbit", satelliteHandler.GetSatellitePositionsBySpaceID)
 	satellite.GET("/paginated", satelliteHandler.GetPaginatedSatellites)
 	satellite.GET("/paginated/tles", satelliteHandler.GetPaginatedSatelliteInfo)
+	satellite.PUT("/:spaceID/sensor", satelliteHandler.SetSensorHalfAngle)
 
 	// Tile routes
 	tile := r.Echo.Group("/tiles")
@@ -123,22 +139,64 @@ func (r *PublicRouter) registerPublicAPIRoutes() {
 	tile.GET("/mappings", tileHandler.GetPaginatedSatelliteMappings)
 	tile.PUT("/mappings/recompute/byspaceID", tileHandler.RecomputeMappingsBySpaceID)
 	tile.GET("/mappings/byspaceID", tileHandler.GetSatelliteMappingsBySpaceID)
+	tile.GET("/mappings/window", tileHandler.GetMappingsInWindow)
 
 	// Context routes
 	context := r.Echo.Group("/contexts")
 	context.GET("/all", contextHandler.GetPaginatedContexts)
+	context.GET("/active", contextHandler.GetActiveContexts)
 	context.POST("/", contextHandler.CreateContext)
+	context.POST("/import", contextBundleHandler.ImportContext)
 	context.PUT("/:name", contextHandler.UpdateContext)
 	context.GET("/:name", contextHandler.GetContextByName)
 	context.DELETE("/:name", contextHandler.DeleteContextByName)
 	context.PUT("/:name/activate", contextHandler.ActivateContext)
 	context.PUT("/:name/deactivate", contextHandler.DeactivateContext)
+	context.PUT("/:name/archive", contextHandler.ArchiveContext)
+	context.PUT("/:name/schedule", contextHandler.ScheduleContext)
+	context.DELETE("/:name/schedule", contextHandler.UnscheduleContext)
 	context.POST("/:name/assign/satellites", contextHandler.AssignSatellites)
+	context.GET("/:name/rules", membershipHandler.GetRules)
+	context.POST("/:name/rules/evaluate", membershipHandler.EvaluateRules)
+	context.PUT("/:name/rules/:rule", membershipHandler.PutRule)
+	context.DELETE("/:name/rules/:rule", membershipHandler.DeleteRule)
+	context.GET("/:name/export", contextBundleHandler.ExportContext)
+	context.POST("/:name/clone", contextBundleHandler.CloneContext)
+	context.GET("/:name/tles/pin", tleHandler.GetPinnedTLEs)
+	context.PUT("/:name/tles/pin", tleHandler.PinContextTLEs)
+	context.DELETE("/:name/tles/pin", tleHandler.UnpinContextTLEs)
+	context.GET("/:name/clock", clockHandler.GetClock)
+	context.PUT("/:name/clock", clockHandler.StartClock)
+	context.DELETE("/:name/clock", clockHandler.ResetClock)
+	context.PUT("/:name/clock/pause", clockHandler.PauseClock)
+	context.PUT("/:name/clock/resume", clockHandler.ResumeClock)
+	context.PUT("/:name/clock/seek", clockHandler.SeekClock)
+	context.PUT("/:name/clock/speed", clockHandler.SetClockSpeed)
+	context.GET("/:name/rehydration", rehydrationHandler.GetRehydration)
 
 	// Audit trail routes
 	audit := r.Echo.Group("/audit-trails")
 	audit.GET("/", auditTrailHandler.GetAuditTrails)
 
+	// Basemap routes ({y} carries the .png extension)
+	basemap := r.Echo.Group("/basemap")
+	basemap.GET("/:z/:x/:y", basemapHandler.GetTile)
+
+	// Geo export routes (GeoJSON by default, KML with ?format=kml)
+	geo := r.Echo.Group("/geo")
+	geo.GET("/contexts/:contextID/tiles", geoExportHandler.GetContextTiles)
+	geo.GET("/satellites/:spaceID/mappings", geoExportHandler.GetSatelliteMappings)
+	geo.GET("/satellites/:spaceID/track", geoExportHandler.GetGroundTrack)
+	geo.GET("/satellites/:spaceID/footprint", geoExportHandler.GetFootprint)
+
+	// Coverage analytics routes (?from=&to= RFC3339, ?stepMinutes=)
+	coverage := r.Echo.Group("/coverage")
+	coverage.GET("/contexts/:contextID", coverageHandler.GetReport)
+	coverage.GET("/contexts/:contextID/revisits", coverageHandler.GetRevisits)
+	coverage.GET("/contexts/:contextID/timeline", coverageHandler.GetTimeline)
+	coverage.GET("/contexts/:contextID/contributions", coverageHandler.GetContributions)
+	coverage.PUT("/contexts/:contextID/refresh", coverageHandler.RefreshCoverage)
+
 	// User routes
 	user := r.Echo.Group("/users")
 	user.GET("/", userHandler.GetUsers)
@@ -0,0 +1,214 @@
+package basemap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/config"
+	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
+)
+
+// ErrTileNotCached is returned when a tile is missing from the cache and upstream fetching is disabled.
+var ErrTileNotCached = errors.New("basemap tile not cached")
+
+const (
+	defaultFetchTimeout = 15 * time.Second
+	defaultMaxSeedTiles = 100000
+)
+
+// BasemapClient serves raster basemap tiles from a disk cache, filling it from upstream templates on miss.
+type BasemapClient struct {
+	cacheDir     string
+	templates    []string
+	subdomains   []string
+	offline      bool
+	maxSeedTiles int
+	httpClient   *http.Client
+	next         uint64
+}
+
+// NewBasemapClient constructor
+func NewBasemapClient(env *config.SEnv) *BasemapClient {
+	cfg := env.EnvVars.Basemap
+	offline, _ := strconv.ParseBool(cfg.Offline)
+	maxSeedTiles, err := strconv.Atoi(cfg.MaxSeedTiles)
+	if err != nil || maxSeedTiles <= 0 {
+		maxSeedTiles = defaultMaxSeedTiles
+	}
+	return &BasemapClient{
+		cacheDir:     cfg.CacheDir,
+		templates:    splitList(cfg.UpstreamUrls),
+		subdomains:   splitList(cfg.Subdomains),
+		offline:      offline,
+		maxSeedTiles: maxSeedTiles,
+		httpClient:   &http.Client{Timeout: defaultFetchTimeout},
+	}
+}
+
+// IsOffline reports whether the client is restricted to the local cache.
+func (c *BasemapClient) IsOffline() bool {
+	return c.offline
+}
+
+// MaxSeedTiles returns the largest number of tiles a single seeding run may cover.
+func (c *BasemapClient) MaxSeedTiles() int {
+	return c.maxSeedTiles
+}
+
+// GetTile returns the PNG for z/x/y, fetching and caching it when allowed.
+func (c *BasemapClient) GetTile(ctx context.Context, z, x, y int) ([]byte, error) {
+	data, err := c.ReadCached(z, x, y)
+	if err == nil {
+		return data, nil
+	}
+	if !errors.Is(err, ErrTileNotCached) {
+		return nil, err
+	}
+	if c.offline {
+		return nil, ErrTileNotCached
+	}
+
+	data, err = c.FetchUpstream(ctx, z, x, y)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.WriteCached(z, x, y, data); err != nil {
+		log.Warnf("⚠️ Failed to cache basemap tile %d/%d/%d: %v", z, x, y, err)
+	}
+	return data, nil
+}
+
+// HasTile reports whether z/x/y is present in the cache.
+func (c *BasemapClient) HasTile(z, x, y int) bool {
+	_, err := os.Stat(c.tilePath(z, x, y))
+	return err == nil
+}
+
+// ReadCached reads a tile from the disk cache.
+func (c *BasemapClient) ReadCached(z, x, y int) ([]byte, error) {
+	data, err := os.ReadFile(c.tilePath(z, x, y))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrTileNotCached
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cached tile %d/%d/%d: %w", z, x, y, err)
+	}
+	return data, nil
+}
+
+// WriteCached stores a tile in the disk cache. The write goes through a temp file so readers never see partial tiles.
+func (c *BasemapClient) WriteCached(z, x, y int, data []byte) error {
+	path := c.tilePath(z, x, y)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tile-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp tile: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write temp tile: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to close temp tile: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to move tile into cache: %w", err)
+	}
+	return nil
+}
+
+// FetchUpstream downloads a tile, rotating across templates and subdomains and falling back on failure.
+func (c *BasemapClient) FetchUpstream(ctx context.Context, z, x, y int) ([]byte, error) {
+	urls := c.candidateURLs(z, x, y)
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("no basemap upstream configured")
+	}
+
+	var lastErr error
+	for _, url := range urls {
+		data, err := c.fetch(ctx, url)
+		if err == nil {
+			return data, nil
+		}
+		lastErr = err
+		log.Debugf("Basemap upstream %s failed: %v", url, err)
+	}
+	return nil, fmt.Errorf("failed to fetch tile %d/%d/%d from upstream: %w", z, x, y, lastErr)
+}
+
+func (c *BasemapClient) fetch(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "2112-app-service/basemap")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP status %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// candidateURLs expands every template, starting at a rotating offset to spread load across subdomains.
+func (c *BasemapClient) candidateURLs(z, x, y int) []string {
+	subdomains := c.subdomains
+	if len(subdomains) == 0 {
+		subdomains = []string{""}
+	}
+
+	var urls []string
+	for _, tpl := range c.templates {
+		if !strings.Contains(tpl, "{s}") {
+			urls = append(urls, expandTemplate(tpl, "", z, x, y))
+			continue
+		}
+		start := int(atomic.AddUint64(&c.next, 1) % uint64(len(subdomains)))
+		for i := range subdomains {
+			urls = append(urls, expandTemplate(tpl, subdomains[(start+i)%len(subdomains)], z, x, y))
+		}
+	}
+	return urls
+}
+
+func (c *BasemapClient) tilePath(z, x, y int) string {
+	return filepath.Join(c.cacheDir, strconv.Itoa(z), strconv.Itoa(x), strconv.Itoa(y)+".png")
+}
+
+func expandTemplate(tpl, subdomain string, z, x, y int) string {
+	return strings.NewReplacer(
+		"{s}", subdomain,
+		"{z}", strconv.Itoa(z),
+		"{x}", strconv.Itoa(x),
+		"{y}", strconv.Itoa(y),
+	).Replace(tpl)
+}
+
+func splitList(v string) []string {
+	var out []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
@@ -0,0 +1,60 @@
+// Package broker abstracts the message broker carrying events between services, so the event pipeline runs on
+// RabbitMQ, Redis Streams or an in-memory bus alike.
+package broker
+
+import "context"
+
+// DeadLetterSuffix names the queue or stream receiving the rejected messages of a queue or stream.
+const DeadLetterSuffix = ".dead-letter"
+
+// Broker publishes messages and delivers them to subscribers whose header filter they match. Deliveries must be
+// acknowledged: rejected deliveries are dead-lettered.
+type Broker interface {
+	Publish(ctx context.Context, body []byte, headers *Header) error
+	// Subscribe delivers the messages matching filter until ctx is done or the broker is closed; the channel is
+	// then closed.
+	Subscribe(ctx context.Context, filter *Header) (<-chan Message, error)
+	Close() error
+}
+
+// Message is a message delivered to a subscriber.
+type Message struct {
+	Body        []byte
+	Headers     map[string]interface{}
+	Redelivered bool // Delivered before without being acknowledged
+	ack         func() error
+	nack        func(requeue bool) error
+}
+
+// NewMessage creates a message acknowledged with ack and rejected with nack.
+func NewMessage(body []byte, headers map[string]interface{}, redelivered bool, ack func() error, nack func(requeue bool) error) Message {
+	return Message{Body: body, Headers: headers, Redelivered: redelivered, ack: ack, nack: nack}
+}
+
+// Ack acknowledges a processed message.
+func (m Message) Ack() error {
+	return m.ack()
+}
+
+// Nack rejects a message, delivering it again when requeue is set and dead-lettering it otherwise.
+func (m Message) Nack(requeue bool) error {
+	return m.nack(requeue)
+}
+
+// Header returns the string value of a header, empty when missing.
+func (m Message) Header(key string) string {
+	value, _ := m.Headers[key].(string)
+	return value
+}
+
+// Prefetcher is implemented by brokers bounding the messages delivered to a subscriber and not yet acknowledged.
+// The bound applies to the subscriptions made after it is set.
+type Prefetcher interface {
+	SetPrefetch(count int)
+}
+
+// DeadLetterSubscriber is implemented by brokers whose rejected messages can be consumed from their dead-letter
+// queue or stream. Dead letters rejected with requeue are delivered again.
+type DeadLetterSubscriber interface {
+	SubscribeDeadLetters(ctx context.Context) (<-chan Message, error)
+}
@@ -0,0 +1,85 @@
+package broker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+)
+
+// HeaderTenantID carries the tenant owning a message, so consumers can bind to a single tenant.
+const HeaderTenantID = "tenant_id"
+
+// HeaderTargetHandler restricts a re-driven message to a single handler of its consumers.
+const HeaderTargetHandler = "target_handler"
+
+// HeaderReplay marks a message replayed from the event store.
+const HeaderReplay = "replay"
+
+// HeaderMatch selects how a subscription filter matches: HeaderMatchAll (the default) or HeaderMatchAny, like
+// the x-match argument of RabbitMQ headers exchanges.
+const HeaderMatch = "x-match"
+
+const (
+	HeaderMatchAll = "all"
+	HeaderMatchAny = "any"
+)
+
+// Header struct to store filtering headers dynamically
+type Header struct {
+	Fields map[string]interface{}
+}
+
+// NewHeader creates a new Header instance
+func NewHeader() *Header {
+	return &Header{Fields: make(map[string]interface{})}
+}
+
+// AddField adds a new key-value pair to the Header
+func (h *Header) AddField(key string, value interface{}) {
+	h.Fields[key] = value
+}
+
+// Matches reports whether the headers of a message pass the filter h: all of its fields must be equal in headers,
+// or any of them when h matches any. A nil or empty filter matches every message.
+func (h *Header) Matches(headers map[string]interface{}) bool {
+	if h == nil {
+		return true
+	}
+	matchAny := h.Fields[HeaderMatch] == HeaderMatchAny
+	fields := 0
+	for key, expected := range h.Fields {
+		if key == HeaderMatch {
+			continue
+		}
+		fields++
+		value, ok := headers[key]
+		matched := ok && value == expected
+		if matchAny && matched {
+			return true
+		}
+		if !matchAny && !matched {
+			return false
+		}
+	}
+	return !matchAny || fields == 0
+}
+
+// Key identifies the filter h, so the subscriptions with the same filter share a queue or consumer group. Filters
+// with the same fields have the same key whatever their order; a nil or empty filter has an empty key.
+func (h *Header) Key() string {
+	if h == nil || len(h.Fields) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(h.Fields))
+	for key := range h.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	hash := sha256.New()
+	for _, key := range keys {
+		fmt.Fprintf(hash, "%s=%v\n", key, h.Fields[key])
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
@@ -0,0 +1,46 @@
+package broker
+
+import "testing"
+
+func TestHeaderKey(t *testing.T) {
+	header := func(fields map[string]interface{}) *Header {
+		h := NewHeader()
+		for key, value := range fields {
+			h.AddField(key, value)
+		}
+		return h
+	}
+
+	tests := []struct {
+		name          string
+		first, second *Header
+		expectedEqual bool
+	}{
+		{name: "Nil and empty", first: nil, second: NewHeader(), expectedEqual: true},
+		{
+			name:          "Same fields",
+			first:         header(map[string]interface{}{"satellite_id": "25544", HeaderTenantID: "tenant-a"}),
+			second:        header(map[string]interface{}{HeaderTenantID: "tenant-a", "satellite_id": "25544"}),
+			expectedEqual: true,
+		},
+		{
+			name:   "Different values",
+			first:  header(map[string]interface{}{"satellite_id": "25544"}),
+			second: header(map[string]interface{}{"satellite_id": "43013"}),
+		},
+		{
+			name:   "Filter and no filter",
+			first:  header(map[string]interface{}{"satellite_id": "25544"}),
+			second: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, second := tt.first.Key(), tt.second.Key()
+			if (first == second) != tt.expectedEqual {
+				t.Errorf("Expected equal keys %v, but got %q and %q", tt.expectedEqual, first, second)
+			}
+		})
+	}
+}
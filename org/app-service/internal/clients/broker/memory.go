@@ -0,0 +1,196 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// DefaultMemoryBufferSize bounds the messages waiting for a subscriber of the in-memory bus.
+const DefaultMemoryBufferSize = 1000
+
+// ErrClosed is returned when using a closed broker.
+var ErrClosed = errors.New("broker closed")
+
+// MemoryBroker is an in-process bus for tests and single-process mode. Each message is delivered to every
+// subscription whose filter it matches, and is lost when the process stops.
+type MemoryBroker struct {
+	mu            sync.Mutex
+	subscriptions map[*memorySubscription]struct{}
+	deadLetters   []Message
+	deadLetterBox chan Message // Rejected messages waiting for a dead-letter subscriber
+	closed        bool
+}
+
+type memorySubscription struct {
+	filter   *Header
+	messages chan Message
+}
+
+// NewMemoryBroker creates an in-memory bus.
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{
+		subscriptions: map[*memorySubscription]struct{}{},
+		deadLetterBox: make(chan Message, DefaultMemoryBufferSize),
+	}
+}
+
+// Publish delivers a message to the matching subscriptions. A full subscription blocks the publisher, like a
+// broker applying back-pressure.
+func (b *MemoryBroker) Publish(ctx context.Context, body []byte, headers *Header) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return ErrClosed
+	}
+	fields := map[string]interface{}{}
+	if headers != nil {
+		for key, value := range headers.Fields {
+			fields[key] = value
+		}
+	}
+	var targets []*memorySubscription
+	for subscription := range b.subscriptions {
+		if subscription.filter.Matches(fields) {
+			targets = append(targets, subscription)
+		}
+	}
+	b.mu.Unlock()
+
+	for _, subscription := range targets {
+		if err := b.deliver(ctx, subscription, body, fields, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deliver queues a message for a subscription; rejecting it with requeue delivers it again.
+func (b *MemoryBroker) deliver(ctx context.Context, subscription *memorySubscription, body []byte, headers map[string]interface{}, redelivered bool) (err error) {
+	var message Message
+	message = NewMessage(body, headers, redelivered,
+		func() error { return nil },
+		func(requeue bool) error {
+			if requeue {
+				go b.deliver(context.Background(), subscription, body, headers, true)
+				return nil
+			}
+			b.mu.Lock()
+			b.deadLetters = append(b.deadLetters, message)
+			b.mu.Unlock()
+			b.deadLetter(body, headers)
+			return nil
+		},
+	)
+
+	defer func() {
+		// The subscription channel is closed when the bus closes or the subscriber leaves.
+		if recover() != nil {
+			err = ErrClosed
+		}
+	}()
+	select {
+	case subscription.messages <- message:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// deadLetter queues a rejected message for the dead-letter subscribers; rejecting it with requeue queues it again.
+func (b *MemoryBroker) deadLetter(body []byte, headers map[string]interface{}) {
+	var message Message
+	message = NewMessage(body, headers, false,
+		func() error { return nil },
+		func(requeue bool) error {
+			if requeue {
+				go b.deadLetter(body, headers)
+			}
+			return nil
+		},
+	)
+	select {
+	case b.deadLetterBox <- message:
+	default:
+		// Like a bounded dead-letter queue, the bus drops the message when nobody drains it.
+	}
+}
+
+// SubscribeDeadLetters delivers the rejected messages until ctx is done.
+func (b *MemoryBroker) SubscribeDeadLetters(ctx context.Context) (<-chan Message, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil, ErrClosed
+	}
+
+	messages := make(chan Message)
+	go func() {
+		defer close(messages)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message := <-b.deadLetterBox:
+				select {
+				case messages <- message:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return messages, nil
+}
+
+// Subscribe registers a subscription until ctx is done.
+func (b *MemoryBroker) Subscribe(ctx context.Context, filter *Header) (<-chan Message, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil, ErrClosed
+	}
+
+	subscription := &memorySubscription{filter: filter, messages: make(chan Message, DefaultMemoryBufferSize)}
+	b.subscriptions[subscription] = struct{}{}
+	go func() {
+		<-ctx.Done()
+		b.unsubscribe(subscription)
+	}()
+	return subscription.messages, nil
+}
+
+func (b *MemoryBroker) unsubscribe(subscription *memorySubscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.subscriptions[subscription]; ok {
+		delete(b.subscriptions, subscription)
+		close(subscription.messages)
+	}
+}
+
+// Subscriptions returns the number of active subscriptions.
+func (b *MemoryBroker) Subscriptions() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subscriptions)
+}
+
+// DeadLetters returns the messages rejected without requeue.
+func (b *MemoryBroker) DeadLetters() []Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]Message(nil), b.deadLetters...)
+}
+
+// Close ends every subscription.
+func (b *MemoryBroker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for subscription := range b.subscriptions {
+		delete(b.subscriptions, subscription)
+		close(subscription.messages)
+	}
+	return nil
+}
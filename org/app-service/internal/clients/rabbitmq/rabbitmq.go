@@ -2,8 +2,10 @@ package rabbitmq
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/org/2112-space-lab/org/app-service/internal/clients/broker"
 	"github.com/org/2112-space-lab/org/app-service/internal/config"
 	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
 	"github.com/org/2112-space-lab/org/app-service/pkg/tracing"
@@ -13,8 +15,10 @@ import (
 const (
 	ExhangeDefaultName     = "headers.exchange"
 	ExchangeTypeHeaders    = "headers"
+	ExchangeTypeFanout     = "fanout"
+	DefaultPrefetchCount   = 50
 	RabbitMQFormat         = "application/json"
-	DefaultAutoAcknowledge = true
+	DefaultAutoAcknowledge = false // Consumers acknowledge once their handlers succeeded
 	DefaultAutoDelete      = false
 	DefaultNotExclusive    = false
 	DefaultNoLocal         = false
@@ -23,17 +27,67 @@ const (
 	DefaultImmediate       = false
 	DefaultMandatory       = false
 	DefaultKey             = ""
+
+	// inputQueueVersion suffixes the input queue declared with dead-lettering. Queue arguments cannot change once a
+	// queue exists, so the dead-lettering queue takes a new name and the legacy queue is drained into it.
+	inputQueueVersion = ".v2"
+	// legacyDrainAttempts bounds the drain and delete rounds of the legacy input queue while publishers still fill it.
+	legacyDrainAttempts = 3
+	// DefaultFilteredQueueExpiry deletes the input queue of a filter unused for that long, in milliseconds, so the
+	// queues of filters no longer subscribed do not pile up messages.
+	DefaultFilteredQueueExpiry = 24 * 60 * 60 * 1000
 )
 
-// RabbitMQClient wraps the RabbitMQ connection and channel.
+// RabbitMQClient wraps the RabbitMQ connection and channel. It implements broker.Broker on a headers exchange.
 type RabbitMQClient struct {
-	conn        *amqp.Connection
-	channel     *amqp.Channel
-	env         *config.SEnv
-	outputQueue string
-	inputQueue  string
-	exchange    string
-	defaultArgs amqp.Table
+	conn          *amqp.Connection
+	channel       *amqp.Channel
+	env           *config.SEnv
+	outputQueue   string
+	inputQueue    string
+	exchange      string
+	defaultArgs   amqp.Table
+	prefetchCount int
+}
+
+// SetPrefetch bounds the unacknowledged deliveries of the next subscriptions.
+func (r *RabbitMQClient) SetPrefetch(count int) {
+	r.prefetchCount = count
+}
+
+// InputQueue returns the queue consumed by the subscriptions without filter, which dead-letters the messages they
+// reject.
+func (r *RabbitMQClient) InputQueue() string {
+	return r.inputQueue + inputQueueVersion
+}
+
+// inputQueueFor returns the queue of the subscriptions with filter. Subscriptions with different filters consume
+// their own queue, so a message routed to one of them is not consumed by the others.
+func (r *RabbitMQClient) inputQueueFor(filter *broker.Header) string {
+	if key := filter.Key(); key != "" {
+		return r.InputQueue() + "." + key
+	}
+	return r.InputQueue()
+}
+
+// inputQueueArgs returns the arguments of an input queue: it dead-letters the messages its consumers reject, and
+// expires when it is the unused queue of a filter.
+func (r *RabbitMQClient) inputQueueArgs(name string) amqp.Table {
+	args := amqp.Table{"x-dead-letter-exchange": r.deadLetterExchange()}
+	if name != r.InputQueue() {
+		args["x-expires"] = int32(DefaultFilteredQueueExpiry)
+	}
+	return args
+}
+
+// DeadLetterQueue returns the queue receiving the rejected messages of the input queue.
+func (r *RabbitMQClient) DeadLetterQueue() string {
+	return r.inputQueue + broker.DeadLetterSuffix
+}
+
+// deadLetterExchange returns the exchange routing the rejected messages of the input queue to its dead-letter queue.
+func (r *RabbitMQClient) deadLetterExchange() string {
+	return r.exchange + broker.DeadLetterSuffix
 }
 
 // NewRabbitMQClient initializes a new RabbitMQ client.
@@ -50,13 +104,14 @@ func NewRabbitMQClient(env *config.SEnv) (*RabbitMQClient, error) {
 	}
 
 	client := &RabbitMQClient{
-		conn:        conn,
-		channel:     ch,
-		env:         env,
-		outputQueue: env.EnvVars.RabbitMQ.OutputQueue,
-		inputQueue:  env.EnvVars.RabbitMQ.InputQueue,
-		exchange:    ExhangeDefaultName,
-		defaultArgs: amqp.Table{},
+		conn:          conn,
+		channel:       ch,
+		env:           env,
+		outputQueue:   env.EnvVars.RabbitMQ.OutputQueue,
+		inputQueue:    env.EnvVars.RabbitMQ.InputQueue,
+		exchange:      ExhangeDefaultName,
+		defaultArgs:   amqp.Table{},
+		prefetchCount: DefaultPrefetchCount,
 	}
 
 	if err := client.SetupQueues(); err != nil {
@@ -68,13 +123,14 @@ func NewRabbitMQClient(env *config.SEnv) (*RabbitMQClient, error) {
 }
 
 // Close closes the RabbitMQ connection and channel.
-func (r *RabbitMQClient) Close() {
+func (r *RabbitMQClient) Close() error {
 	if r.channel != nil {
 		r.channel.Close()
 	}
 	if r.conn != nil {
-		r.conn.Close()
+		return r.conn.Close()
 	}
+	return nil
 }
 
 // SetupQueues ensures queues and the exchange exist and are bound correctly.
@@ -83,7 +139,19 @@ func (r *RabbitMQClient) SetupQueues() error {
 		return fmt.Errorf("failed to declare headers exchange: %w", err)
 	}
 
-	if _, err := r.DeclareQueue(r.inputQueue); err != nil {
+	if err := r.DeclareExchange(r.deadLetterExchange(), ExchangeTypeFanout); err != nil {
+		return fmt.Errorf("failed to declare dead-letter exchange: %w", err)
+	}
+
+	if _, err := r.DeclareQueue(r.DeadLetterQueue()); err != nil {
+		return fmt.Errorf("failed to declare dead-letter queue: %w", err)
+	}
+
+	if err := r.channel.QueueBind(r.DeadLetterQueue(), DefaultKey, r.deadLetterExchange(), DefaultNoWait, r.defaultArgs); err != nil {
+		return fmt.Errorf("failed to bind dead-letter queue: %w", err)
+	}
+
+	if _, err := r.declareInputQueue(r.InputQueue()); err != nil {
 		return fmt.Errorf("failed to declare input queue: %w", err)
 	}
 
@@ -91,7 +159,7 @@ func (r *RabbitMQClient) SetupQueues() error {
 		return fmt.Errorf("failed to declare output queue: %w", err)
 	}
 
-	log.Debugf("✅ Queues and exchange setup complete: Exchange=%s, Input=%s, Output=%s", r.exchange, r.inputQueue, r.outputQueue)
+	log.Debugf("✅ Queues and exchange setup complete: Exchange=%s, Input=%s, Output=%s, DeadLetter=%s", r.exchange, r.InputQueue(), r.outputQueue, r.DeadLetterQueue())
 	return nil
 }
 
@@ -120,8 +188,84 @@ func (r *RabbitMQClient) DeclareQueue(queueName string) (amqp.Queue, error) {
 	)
 }
 
-// PublishMessage sends a message with dynamic headers.
-func (r *RabbitMQClient) PublishMessage(ctx context.Context, body []byte, headers *Header) (err error) {
+// declareInputQueue declares an input queue, dead-lettering the messages its consumers reject.
+func (r *RabbitMQClient) declareInputQueue(name string) (amqp.Queue, error) {
+	return r.channel.QueueDeclare(
+		name,
+		DefaultDurable,
+		DefaultAutoDelete,
+		DefaultNoLocal,
+		DefaultNoWait,
+		r.inputQueueArgs(name),
+	)
+}
+
+// migrateLegacyInputQueue moves the messages of the input queue declared without dead-lettering to the input queue
+// target, then deletes it along with its bindings. Messages routed to it while it is drained are moved on the next
+// round.
+func (r *RabbitMQClient) migrateLegacyInputQueue(target string) error {
+	for attempt := 1; attempt <= legacyDrainAttempts; attempt++ {
+		done, err := r.drainLegacyInputQueue(target)
+		if err != nil || done {
+			return err
+		}
+	}
+	log.Warnf("⚠️ Legacy input queue %s still receives messages, left in place", r.inputQueue)
+	return nil
+}
+
+// drainLegacyInputQueue runs one drain and delete round on its own channel: a missing queue, or a queue not empty
+// on deletion, closes the channel it was inspected on. It reports whether the legacy queue is gone.
+func (r *RabbitMQClient) drainLegacyInputQueue(target string) (bool, error) {
+	ch, err := r.conn.Channel()
+	if err != nil {
+		return false, err
+	}
+	defer ch.Close()
+
+	legacy, err := ch.QueueDeclarePassive(r.inputQueue, DefaultDurable, DefaultAutoDelete, DefaultNotExclusive, DefaultNoWait, r.defaultArgs)
+	var amqpErr *amqp.Error
+	if errors.As(err, &amqpErr) && amqpErr.Code == amqp.NotFound {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	moved := 0
+	for {
+		delivery, ok, err := ch.Get(legacy.Name, DefaultAutoAcknowledge)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			break
+		}
+		if err := ch.Publish("", target, DefaultMandatory, DefaultImmediate, amqp.Publishing{
+			ContentType: delivery.ContentType,
+			Headers:     delivery.Headers,
+			Body:        delivery.Body,
+		}); err != nil {
+			return false, err
+		}
+		if err := delivery.Ack(false); err != nil {
+			return false, err
+		}
+		moved++
+	}
+
+	if _, err := ch.QueueDelete(legacy.Name, false, true, DefaultNoWait); err != nil {
+		if errors.As(err, &amqpErr) && amqpErr.Code == amqp.PreconditionFailed {
+			return false, nil
+		}
+		return false, err
+	}
+	log.Infof("📦 Moved %d messages from legacy input queue %s to %s", moved, legacy.Name, target)
+	return true, nil
+}
+
+// Publish sends a message with dynamic headers.
+func (r *RabbitMQClient) Publish(ctx context.Context, body []byte, headers *broker.Header) (err error) {
 	_, span := tracing.NewSpan(ctx, "PublishMessage")
 	defer span.EndWithError(err)
 	err = r.channel.Publish(
@@ -143,26 +287,102 @@ func (r *RabbitMQClient) PublishMessage(ctx context.Context, body []byte, header
 	return nil
 }
 
-// ConsumeMessages listens for messages that match specific headers.
-func (r *RabbitMQClient) ConsumeMessages(filterHeaders *Header) (<-chan amqp.Delivery, error) {
-	_, err := r.DeclareQueue(r.inputQueue)
+// Subscribe listens for messages that match specific headers. Deliveries must be acknowledged: rejected
+// deliveries are routed to the dead-letter queue.
+func (r *RabbitMQClient) Subscribe(ctx context.Context, filterHeaders *broker.Header) (<-chan broker.Message, error) {
+	deliveries, err := r.consumeMessages(filterHeaders)
+	if err != nil {
+		return nil, err
+	}
+	return forward(ctx, deliveries), nil
+}
+
+// SubscribeDeadLetters consumes the dead-letter queue. Dead letters rejected with requeue stay in the queue.
+func (r *RabbitMQClient) SubscribeDeadLetters(ctx context.Context) (<-chan broker.Message, error) {
+	deliveries, err := r.channel.Consume(
+		r.DeadLetterQueue(),
+		DefaultKey,
+		DefaultAutoAcknowledge,
+		DefaultNotExclusive,
+		DefaultNoLocal,
+		DefaultNoWait,
+		r.defaultArgs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume dead-letter queue: %w", err)
+	}
+	return forward(ctx, deliveries), nil
+}
+
+// forward delivers the deliveries as broker messages until ctx is done or the deliveries end.
+func forward(ctx context.Context, deliveries <-chan amqp.Delivery) <-chan broker.Message {
+	messages := make(chan broker.Message)
+	go func() {
+		defer close(messages)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case delivery, ok := <-deliveries:
+				if !ok {
+					return
+				}
+				select {
+				case messages <- toMessage(delivery):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return messages
+}
+
+// toMessage wraps a delivery, acknowledging it on its channel.
+func toMessage(delivery amqp.Delivery) broker.Message {
+	return broker.NewMessage(
+		delivery.Body,
+		delivery.Headers,
+		delivery.Redelivered,
+		func() error { return delivery.Ack(false) },
+		func(requeue bool) error { return delivery.Nack(false, requeue) },
+	)
+}
+
+// consumeMessages binds the input queue of the headers to them and consumes it.
+func (r *RabbitMQClient) consumeMessages(filterHeaders *broker.Header) (<-chan amqp.Delivery, error) {
+	queue := r.inputQueueFor(filterHeaders)
+	_, err := r.declareInputQueue(queue)
 	if err != nil {
 		return nil, fmt.Errorf("failed to declare queue: %w", err)
 	}
 
+	if err = r.channel.Qos(r.prefetchCount, 0, false); err != nil {
+		return nil, fmt.Errorf("failed to set prefetch count: %w", err)
+	}
+
+	var bindArgs amqp.Table
+	if filterHeaders != nil {
+		bindArgs = amqp.Table(filterHeaders.Fields)
+	}
 	err = r.channel.QueueBind(
-		r.inputQueue,
+		queue,
 		DefaultKey,
 		r.exchange,
 		DefaultNoWait,
-		amqp.Table(filterHeaders.Fields),
+		bindArgs,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to bind queue with headers: %w", err)
 	}
 
+	// Once the input queue is bound, the legacy queue can go without messages being left unrouted.
+	if err := r.migrateLegacyInputQueue(queue); err != nil {
+		return nil, fmt.Errorf("failed to migrate legacy input queue: %w", err)
+	}
+
 	msgs, err := r.channel.Consume(
-		r.inputQueue,
+		queue,
 		DefaultKey,
 		DefaultAutoAcknowledge,
 		DefaultNotExclusive,
@@ -174,6 +394,6 @@ func (r *RabbitMQClient) ConsumeMessages(filterHeaders *Header) (<-chan amqp.Del
 		return nil, fmt.Errorf("failed to register consumer: %w", err)
 	}
 
-	log.Debugf("📥 Listening for messages with filters: %+v", filterHeaders.Fields)
+	log.Debugf("📥 Listening for messages on %s with filters: %+v", queue, bindArgs)
 	return msgs, nil
 }
@@ -0,0 +1,125 @@
+package rabbitmq
+
+import (
+	"testing"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/clients/broker"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// recordingAcknowledger records how deliveries are settled.
+type recordingAcknowledger struct {
+	acked   []uint64
+	nacked  []uint64
+	requeue []bool
+}
+
+func (a *recordingAcknowledger) Ack(tag uint64, multiple bool) error {
+	a.acked = append(a.acked, tag)
+	return nil
+}
+
+func (a *recordingAcknowledger) Nack(tag uint64, multiple bool, requeue bool) error {
+	a.nacked = append(a.nacked, tag)
+	a.requeue = append(a.requeue, requeue)
+	return nil
+}
+
+func (a *recordingAcknowledger) Reject(tag uint64, requeue bool) error {
+	return a.Nack(tag, false, requeue)
+}
+
+func TestToMessage(t *testing.T) {
+	tests := []struct {
+		name            string
+		settle          func(message broker.Message) error
+		expectedAcked   int
+		expectedRequeue []bool
+	}{
+		{name: "Acknowledged", settle: broker.Message.Ack, expectedAcked: 1},
+		{
+			name:            "Requeued",
+			settle:          func(message broker.Message) error { return message.Nack(true) },
+			expectedRequeue: []bool{true},
+		},
+		{
+			name:            "Dead-lettered",
+			settle:          func(message broker.Message) error { return message.Nack(false) },
+			expectedRequeue: []bool{false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			acknowledger := &recordingAcknowledger{}
+			message := toMessage(amqp.Delivery{
+				Acknowledger: acknowledger,
+				DeliveryTag:  7,
+				Body:         []byte("event"),
+				Headers:      amqp.Table{broker.HeaderTenantID: "tenant-a"},
+				Redelivered:  true,
+			})
+
+			if got := message.Header(broker.HeaderTenantID); got != "tenant-a" {
+				t.Errorf("Expected tenant header tenant-a, but got %s", got)
+			}
+			if !message.Redelivered {
+				t.Errorf("Expected a redelivered message, but got a first delivery")
+			}
+			if err := tt.settle(message); err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+			if got := len(acknowledger.acked); got != tt.expectedAcked {
+				t.Errorf("Expected %d acknowledgements, but got %d", tt.expectedAcked, got)
+			}
+			if len(acknowledger.requeue) != len(tt.expectedRequeue) {
+				t.Fatalf("Expected rejections %v, but got %v", tt.expectedRequeue, acknowledger.requeue)
+			}
+			for i, requeue := range tt.expectedRequeue {
+				if acknowledger.requeue[i] != requeue {
+					t.Errorf("Expected rejections %v, but got %v", tt.expectedRequeue, acknowledger.requeue)
+				}
+			}
+		})
+	}
+}
+
+func TestInputQueueFor(t *testing.T) {
+	client := &RabbitMQClient{inputQueue: "events", exchange: ExhangeDefaultName}
+	filter := func(spaceID string) *broker.Header {
+		header := broker.NewHeader()
+		header.AddField("satellite_id", spaceID)
+		return header
+	}
+
+	if got := client.inputQueueFor(nil); got != "events.v2" {
+		t.Errorf("Expected queue events.v2 without filter, but got %s", got)
+	}
+	if first, second := client.inputQueueFor(filter("25544")), client.inputQueueFor(filter("43013")); first == second {
+		t.Errorf("Expected distinct queues for distinct filters, but got %s twice", first)
+	}
+	if first, second := client.inputQueueFor(filter("25544")), client.inputQueueFor(filter("25544")); first != second {
+		t.Errorf("Expected the same queue for the same filter, but got %s and %s", first, second)
+	}
+
+	tests := []struct {
+		name            string
+		queue           string
+		expectedExpires bool
+	}{
+		{name: "Unfiltered", queue: client.inputQueueFor(nil)},
+		{name: "Filtered", queue: client.inputQueueFor(filter("25544")), expectedExpires: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := client.inputQueueArgs(tt.queue)
+			if got := args["x-dead-letter-exchange"]; got != ExhangeDefaultName+broker.DeadLetterSuffix {
+				t.Errorf("Expected dead-letter exchange %s, but got %v", ExhangeDefaultName+broker.DeadLetterSuffix, got)
+			}
+			if _, ok := args["x-expires"]; ok != tt.expectedExpires {
+				t.Errorf("Expected expiry %v, but got %v", tt.expectedExpires, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/google/uuid"
+	"github.com/org/2112-space-lab/org/app-service/internal/clients/broker"
+	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
+)
+
+const (
+	DefaultStreamMaxLen     = 100000           // Entries kept per stream, trimmed approximately
+	DefaultStreamReadCount  = 50               // Entries read at once, like the RabbitMQ prefetch count
+	DefaultStreamBlock      = 5 * time.Second  // Wait for new entries before checking for stale ones again
+	DefaultStreamClaimIdle  = 60 * time.Second // Idle time after which entries of a crashed consumer are claimed
+	streamFieldBody         = "body"
+	streamFieldHeaders      = "headers"
+	streamFieldRedeliveries = "redeliveries"
+)
+
+// StreamBroker implements broker.Broker on a Redis stream read by consumer groups: each entry is processed by a
+// single consumer of each group. Subscriptions with different filters read in their own group, so each of them
+// sees every entry. Acknowledged entries are removed from the pending list, rejected ones are moved to
+// the dead-letter stream or appended again, and entries left pending by a crashed consumer are claimed again.
+type StreamBroker struct {
+	client    *redis.Client
+	stream    string
+	group     string
+	consumer  string
+	readCount int64
+}
+
+// NewStreamBroker creates a broker on stream, consumed by group.
+func NewStreamBroker(r *RedisClient, stream, group string) *StreamBroker {
+	hostname, _ := os.Hostname()
+	return &StreamBroker{
+		client:    r.client,
+		stream:    stream,
+		group:     group,
+		consumer:  hostname + "-" + uuid.NewString(),
+		readCount: DefaultStreamReadCount,
+	}
+}
+
+// SetPrefetch bounds the entries read at once by the next subscriptions.
+func (b *StreamBroker) SetPrefetch(count int) {
+	b.readCount = int64(count)
+}
+
+// DeadLetterStream returns the stream receiving the rejected entries of the stream.
+func (b *StreamBroker) DeadLetterStream() string {
+	return b.stream + broker.DeadLetterSuffix
+}
+
+// Publish appends a message to the stream.
+func (b *StreamBroker) Publish(ctx context.Context, body []byte, headers *broker.Header) error {
+	fields := map[string]interface{}{}
+	if headers != nil {
+		fields = headers.Fields
+	}
+	return b.add(b.stream, body, fields, 0)
+}
+
+func (b *StreamBroker) add(stream string, body []byte, headers map[string]interface{}, redeliveries int) error {
+	encoded, err := json.Marshal(headers)
+	if err != nil {
+		return fmt.Errorf("failed to encode headers: %w", err)
+	}
+	err = b.client.XAdd(&redis.XAddArgs{
+		Stream:       stream,
+		MaxLenApprox: DefaultStreamMaxLen,
+		Values: map[string]interface{}{
+			streamFieldBody:         body,
+			streamFieldHeaders:      encoded,
+			streamFieldRedeliveries: redeliveries,
+		},
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("failed to append message to stream %s: %w", stream, err)
+	}
+	return nil
+}
+
+// Subscribe creates the consumer group of filter when missing and delivers the entries matching filter. Entries
+// not matching are acknowledged in that group only, like messages a headers exchange does not route to the queue of
+// the filter: subscriptions with other filters still receive them.
+func (b *StreamBroker) Subscribe(ctx context.Context, filter *broker.Header) (<-chan broker.Message, error) {
+	subscription := *b
+	subscription.group = b.groupFor(filter)
+	return subscription.subscribe(ctx, filter)
+}
+
+// groupFor returns the consumer group of the subscriptions with filter.
+func (b *StreamBroker) groupFor(filter *broker.Header) string {
+	if key := filter.Key(); key != "" {
+		return b.group + "." + key
+	}
+	return b.group
+}
+
+// subscribe delivers the entries matching filter read in the group of b.
+func (b *StreamBroker) subscribe(ctx context.Context, filter *broker.Header) (<-chan broker.Message, error) {
+	err := b.client.XGroupCreateMkStream(b.stream, b.group, "$").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return nil, fmt.Errorf("failed to create consumer group %s: %w", b.group, err)
+	}
+
+	messages := make(chan broker.Message)
+	go func() {
+		defer close(messages)
+		for ctx.Err() == nil {
+			entries, err := b.read()
+			if err != nil {
+				log.Errorf("❌ Failed to read stream %s: %v", b.stream, err)
+				return
+			}
+			for _, entry := range entries {
+				message, ok := b.toMessage(entry, filter)
+				if !ok {
+					continue
+				}
+				select {
+				case messages <- message:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return messages, nil
+}
+
+// SubscribeDeadLetters delivers the entries of the dead-letter stream to the consumer group.
+func (b *StreamBroker) SubscribeDeadLetters(ctx context.Context) (<-chan broker.Message, error) {
+	deadLetters := *b
+	deadLetters.stream = b.DeadLetterStream()
+	return deadLetters.subscribe(ctx, nil)
+}
+
+// read claims the stale entries of crashed consumers, then waits for new entries.
+func (b *StreamBroker) read() ([]redis.XMessage, error) {
+	stale, err := b.claimStale()
+	if err != nil || len(stale) > 0 {
+		return stale, err
+	}
+
+	streams, err := b.client.XReadGroup(&redis.XReadGroupArgs{
+		Group:    b.group,
+		Consumer: b.consumer,
+		Streams:  []string{b.stream, ">"},
+		Count:    b.readCount,
+		Block:    DefaultStreamBlock,
+	}).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entries []redis.XMessage
+	for _, stream := range streams {
+		entries = append(entries, stream.Messages...)
+	}
+	return entries, nil
+}
+
+// claimStale takes over the entries other consumers left pending for longer than DefaultStreamClaimIdle.
+func (b *StreamBroker) claimStale() ([]redis.XMessage, error) {
+	pending, err := b.client.XPendingExt(&redis.XPendingExtArgs{
+		Stream: b.stream,
+		Group:  b.group,
+		Start:  "-",
+		End:    "+",
+		Count:  b.readCount,
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, entry := range pending {
+		if entry.Consumer != b.consumer && entry.Idle >= DefaultStreamClaimIdle {
+			ids = append(ids, entry.Id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	claimed, err := b.client.XClaim(&redis.XClaimArgs{
+		Stream:   b.stream,
+		Group:    b.group,
+		Consumer: b.consumer,
+		MinIdle:  DefaultStreamClaimIdle,
+		Messages: ids,
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	for i := range claimed {
+		claimed[i].Values[streamFieldRedeliveries] = "1"
+	}
+	return claimed, nil
+}
+
+// toMessage decodes an entry, acknowledging it right away when it does not match filter.
+func (b *StreamBroker) toMessage(entry redis.XMessage, filter *broker.Header) (broker.Message, bool) {
+	body, _ := entry.Values[streamFieldBody].(string)
+	headers := map[string]interface{}{}
+	if encoded, ok := entry.Values[streamFieldHeaders].(string); ok {
+		if err := json.Unmarshal([]byte(encoded), &headers); err != nil {
+			log.Warnf("⚠️ Ignoring invalid headers of stream entry %s: %v", entry.ID, err)
+		}
+	}
+	redeliveries, _ := entry.Values[streamFieldRedeliveries].(string)
+
+	ack := func() error { return b.client.XAck(b.stream, b.group, entry.ID).Err() }
+	if !filter.Matches(headers) {
+		if err := ack(); err != nil {
+			log.Errorf("❌ Failed to acknowledge stream entry %s: %v", entry.ID, err)
+		}
+		return broker.Message{}, false
+	}
+
+	nack := func(requeue bool) error {
+		target, count := b.DeadLetterStream(), 0
+		if requeue {
+			target, count = b.stream, 1
+		}
+		if err := b.add(target, []byte(body), headers, count); err != nil {
+			return err
+		}
+		return ack()
+	}
+	return broker.NewMessage([]byte(body), headers, redeliveries != "" && redeliveries != "0", ack, nack), true
+}
+
+// Close does nothing: the Redis client is shared and stays open, and the consumer is kept in the group so the
+// entries it left pending are claimed by the other consumers.
+func (b *StreamBroker) Close() error {
+	return nil
+}
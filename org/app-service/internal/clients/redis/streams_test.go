@@ -0,0 +1,270 @@
+package redis
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/org/2112-space-lab/org/app-service/internal/clients/broker"
+)
+
+// fakeStreams serves the stream commands of the StreamBroker over the Redis protocol, keeping the entries and the
+// consumer groups in memory.
+type fakeStreams struct {
+	mu      sync.Mutex
+	entries map[string][][]string            // Fields of the entries of each stream, identified by their position
+	groups  map[string]map[string]*fakeGroup // Consumer groups of each stream
+}
+
+type fakeGroup struct {
+	delivered int             // Entries delivered to the group
+	pending   map[string]bool // Entries delivered and not acknowledged
+}
+
+func newFakeStreams() *fakeStreams {
+	return &fakeStreams{entries: map[string][][]string{}, groups: map[string]map[string]*fakeGroup{}}
+}
+
+// dial connects a client to the fake.
+func (f *fakeStreams) dial() (net.Conn, error) {
+	client, server := net.Pipe()
+	go f.serve(server)
+	return client, nil
+}
+
+func (f *fakeStreams) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+		if _, err := io.WriteString(conn, f.execute(args)); err != nil {
+			return
+		}
+	}
+}
+
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	count, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+	args := make([]string, count)
+	for i := range args {
+		header, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		length, _ := strconv.Atoi(strings.TrimSpace(header[1:]))
+		value := make([]byte, length+2) // Followed by CRLF
+		if _, err := io.ReadFull(reader, value); err != nil {
+			return nil, err
+		}
+		args[i] = string(value[:length])
+	}
+	return args, nil
+}
+
+func (f *fakeStreams) execute(args []string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch strings.ToUpper(args[0]) {
+	case "XGROUP": // XGROUP CREATE stream group $ MKSTREAM
+		stream, group := args[2], args[3]
+		if f.groups[stream] == nil {
+			f.groups[stream] = map[string]*fakeGroup{}
+		}
+		if _, ok := f.groups[stream][group]; ok {
+			return "-BUSYGROUP Consumer Group name already exists\r\n"
+		}
+		f.groups[stream][group] = &fakeGroup{delivered: len(f.entries[stream]), pending: map[string]bool{}}
+		return "+OK\r\n"
+	case "XADD": // XADD stream MAXLEN ~ count * field value...
+		stream := args[1]
+		f.entries[stream] = append(f.entries[stream], args[6:])
+		return bulk(fmt.Sprintf("%d-0", len(f.entries[stream])))
+	case "XREADGROUP": // XREADGROUP GROUP group consumer COUNT n BLOCK ms STREAMS stream >
+		group, stream := f.groups[args[len(args)-2]][args[2]], args[len(args)-2]
+		if group == nil || group.delivered == len(f.entries[stream]) {
+			f.mu.Unlock()
+			time.Sleep(10 * time.Millisecond) // Stands for the blocking wait of new entries
+			f.mu.Lock()
+			return "*-1\r\n"
+		}
+		var reply strings.Builder
+		entries := f.entries[stream][group.delivered:]
+		fmt.Fprintf(&reply, "*1\r\n*2\r\n%s*%d\r\n", bulk(stream), len(entries))
+		for _, fields := range entries {
+			group.delivered++
+			id := fmt.Sprintf("%d-0", group.delivered)
+			group.pending[id] = true
+			fmt.Fprintf(&reply, "*2\r\n%s*%d\r\n", bulk(id), len(fields))
+			for _, field := range fields {
+				reply.WriteString(bulk(field))
+			}
+		}
+		return reply.String()
+	case "XPENDING":
+		return "*0\r\n"
+	case "XACK": // XACK stream group id
+		group := f.groups[args[1]][args[2]]
+		if group == nil || !group.pending[args[3]] {
+			return ":0\r\n"
+		}
+		delete(group.pending, args[3])
+		return ":1\r\n"
+	}
+	return fmt.Sprintf("-ERR unknown command %s\r\n", args[0])
+}
+
+func bulk(value string) string {
+	return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+}
+
+// acknowledged returns the entries of a stream acknowledged by a group.
+func (f *fakeStreams) acknowledged(stream, group string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	g := f.groups[stream][group]
+	if g == nil {
+		return 0
+	}
+	return g.delivered - len(g.pending)
+}
+
+func newTestStreamBroker(f *fakeStreams) *StreamBroker {
+	client := redis.NewClient(&redis.Options{Dialer: f.dial})
+	return NewStreamBroker(&RedisClient{client: client}, "events", "app-service")
+}
+
+func satelliteFilter(spaceID string) *broker.Header {
+	filter := broker.NewHeader()
+	filter.AddField("satellite_id", spaceID)
+	return filter
+}
+
+func receive(t *testing.T, messages <-chan broker.Message) broker.Message {
+	t.Helper()
+	select {
+	case message := <-messages:
+		return message
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Expected a message, but got none")
+		return broker.Message{}
+	}
+}
+
+func TestStreamBrokerFilters(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fake := newFakeStreams()
+	streams := newTestStreamBroker(fake)
+
+	// Shards with different filters read in their own group: each skips the entries of the other without
+	// acknowledging them for it.
+	first, err := streams.Subscribe(ctx, satelliteFilter("25544"))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	second, err := streams.Subscribe(ctx, satelliteFilter("43013"))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	for _, spaceID := range []string{"25544", "43013"} {
+		headers := satelliteFilter(spaceID)
+		if err := streams.Publish(ctx, []byte(spaceID), headers); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		messages <-chan broker.Message
+		filter   *broker.Header
+		expected string
+	}{
+		{name: "First shard", messages: first, filter: satelliteFilter("25544"), expected: "25544"},
+		{name: "Second shard", messages: second, filter: satelliteFilter("43013"), expected: "43013"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := receive(t, tt.messages)
+			if got := string(message.Body); got != tt.expected {
+				t.Errorf("Expected message %s, but got %s", tt.expected, got)
+			}
+			if err := message.Ack(); err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+			group := streams.groupFor(tt.filter)
+			deadline := time.Now().Add(2 * time.Second)
+			for fake.acknowledged("events", group) < 2 && time.Now().Before(deadline) {
+				time.Sleep(time.Millisecond)
+			}
+			if got := fake.acknowledged("events", group); got != 2 {
+				t.Errorf("Expected 2 entries acknowledged by group %s, but got %d", group, got)
+			}
+		})
+	}
+}
+
+func TestStreamBrokerRejection(t *testing.T) {
+	tests := []struct {
+		name                string
+		requeue             bool
+		expectedRedelivered bool
+		expectedDeadLetter  bool
+	}{
+		{name: "Requeued", requeue: true, expectedRedelivered: true},
+		{name: "Dead-lettered", requeue: false, expectedDeadLetter: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			streams := newTestStreamBroker(newFakeStreams())
+
+			messages, err := streams.Subscribe(ctx, nil)
+			if err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+			deadLetters, err := streams.SubscribeDeadLetters(ctx)
+			if err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+			if err := streams.Publish(ctx, []byte("event"), nil); err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+
+			if err := receive(t, messages).Nack(tt.requeue); err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+
+			target := messages
+			if tt.expectedDeadLetter {
+				target = deadLetters
+			}
+			message := receive(t, target)
+			if got := string(message.Body); got != "event" {
+				t.Errorf("Expected message event, but got %s", got)
+			}
+			if message.Redelivered != tt.expectedRedelivered {
+				t.Errorf("Expected redelivered %v, but got %v", tt.expectedRedelivered, message.Redelivered)
+			}
+		})
+	}
+}
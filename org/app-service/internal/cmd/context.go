@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"github.com/org/2112-space-lab/org/app-service/internal/app"
+	"github.com/org/2112-space-lab/org/app-service/internal/cmd/gamecontext"
+	"github.com/org/2112-space-lab/org/app-service/internal/proc"
+
+	logger "github.com/org/2112-space-lab/org/app-service/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+// ContextCmd creates the `context` command with its subcommands
+func ContextCmd(app *app.App) *cobra.Command {
+	contextCmd := &cobra.Command{
+		Use:   "context <option>",
+		Short: "Manage game contexts",
+		Long: `Export, import and clone game contexts as portable bundles.
+Type 'context -h' for more information.
+
+Available options are:
+- context export <name>
+- context import <file>
+- context clone <name> <new name>`,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			execContextPersistentPreRun(app)
+		},
+	}
+
+	// Register subcommands dynamically
+	contextCmd.AddCommand(gamecontext.ExportCmd(app))
+	contextCmd.AddCommand(gamecontext.ImportCmd(app))
+	contextCmd.AddCommand(gamecontext.CloneCmd(app))
+
+	return contextCmd
+}
+
+// execContextPersistentPreRun handles shared setup logic for all context subcommands
+func execContextPersistentPreRun(app *app.App) {
+	logger.Debug("Executing context persistent pre run ...")
+
+	proc.InitClients()
+	proc.ConfigureClients()
+	proc.InitDbConnection()
+	proc.InitModels()
+}
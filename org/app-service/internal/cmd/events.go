@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"github.com/org/2112-space-lab/org/app-service/internal/app"
+	"github.com/org/2112-space-lab/org/app-service/internal/cmd/eventstore"
+	"github.com/org/2112-space-lab/org/app-service/internal/proc"
+
+	logger "github.com/org/2112-space-lab/org/app-service/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+// EventsCmd creates the `events` command with its subcommands
+func EventsCmd(app *app.App) *cobra.Command {
+	eventsCmd := &cobra.Command{
+		Use:   "events <option>",
+		Short: "Manage stored events",
+		Long: `Work with the events persisted by the event monitors.
+Type 'events -h' for more information.
+
+Available options are:
+- events replay --type <type> --from <time> --to <time>`,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			execEventsPersistentPreRun(app)
+		},
+	}
+
+	// Register subcommands dynamically
+	eventsCmd.AddCommand(eventstore.ReplayCmd(app))
+
+	return eventsCmd
+}
+
+// execEventsPersistentPreRun handles shared setup logic for all events subcommands
+func execEventsPersistentPreRun(app *app.App) {
+	logger.Debug("Executing events persistent pre run ...")
+
+	proc.InitClients()
+	proc.ConfigureClients()
+	proc.InitDbConnection()
+	proc.InitModels()
+}
@@ -0,0 +1,33 @@
+package eventstore
+
+import (
+	"github.com/org/2112-space-lab/org/app-service/internal/proc"
+	"github.com/spf13/cobra"
+)
+
+// ReplayCmd creates the `replay` subcommand
+func ReplayCmd(serviceComponent interface{}) *cobra.Command {
+	var opts proc.EventReplayOptions
+
+	cmd := &cobra.Command{
+		Use:   "replay",
+		Short: "Replay stored events",
+		Long: `Publish stored events again, oldest first, to every registered handler or to a single handler.
+Select events by type, UID, context and time range (RFC3339). Use --dry-run to list them first.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			proc.EventReplay(cmd.Context(), opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.Tenant, "tenant", "", "Tenant owning the events")
+	cmd.Flags().StringSliceVar(&opts.EventTypes, "type", nil, "Event types to replay")
+	cmd.Flags().StringSliceVar(&opts.EventUIDs, "uid", nil, "Event UIDs to replay")
+	cmd.Flags().StringVar(&opts.ContextName, "context", "", "Replay events of this context only")
+	cmd.Flags().StringVar(&opts.From, "from", "", "Replay events published at or after this time")
+	cmd.Flags().StringVar(&opts.To, "to", "", "Replay events published at or before this time")
+	cmd.Flags().StringVar(&opts.HandlerName, "handler", "", "Replay to this handler only")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "List the selected events without replaying them")
+	cmd.Flags().Float64Var(&opts.RatePerSecond, "rate", 10, "Maximum events replayed per second, unlimited when 0")
+	cmd.Flags().IntVar(&opts.Limit, "limit", 0, "Maximum events replayed")
+	return cmd
+}
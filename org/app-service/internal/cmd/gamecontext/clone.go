@@ -0,0 +1,24 @@
+package gamecontext
+
+import (
+	"github.com/org/2112-space-lab/org/app-service/internal/proc"
+	"github.com/spf13/cobra"
+)
+
+// CloneCmd creates the `clone` subcommand
+func CloneCmd(serviceComponent interface{}) *cobra.Command {
+	var opts proc.ContextCloneOptions
+
+	cmd := &cobra.Command{
+		Use:   "clone <name> <new name>",
+		Short: "Clone a context",
+		Long:  `Copy a context under a new name. The copy starts as a draft.`,
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			proc.ContextClone(cmd.Context(), args[0], args[1], opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.Tenant, "tenant", "", "Tenant owning the context")
+	cmd.Flags().BoolVar(&opts.IncludeMappings, "mappings", false, "Copy tile/satellite mappings")
+	return cmd
+}
@@ -0,0 +1,26 @@
+package gamecontext
+
+import (
+	"github.com/org/2112-space-lab/org/app-service/internal/proc"
+	"github.com/spf13/cobra"
+)
+
+// ExportCmd creates the `export` subcommand
+func ExportCmd(serviceComponent interface{}) *cobra.Command {
+	var opts proc.ContextExportOptions
+
+	cmd := &cobra.Command{
+		Use:   "export <name>",
+		Short: "Export a context",
+		Long:  `Export a context with its satellites, pinned TLEs, tiles and optionally mappings to a JSON or tar bundle.`,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			proc.ContextExport(cmd.Context(), args[0], opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.Tenant, "tenant", "", "Tenant owning the context")
+	cmd.Flags().StringVar(&opts.Format, "format", "json", "Bundle format: json or tar")
+	cmd.Flags().StringVarP(&opts.Out, "out", "o", "", "Output file, stdout when empty")
+	cmd.Flags().BoolVar(&opts.IncludeMappings, "mappings", false, "Include tile/satellite mappings")
+	return cmd
+}
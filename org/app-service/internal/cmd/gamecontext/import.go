@@ -0,0 +1,26 @@
+package gamecontext
+
+import (
+	"github.com/org/2112-space-lab/org/app-service/internal/proc"
+	"github.com/spf13/cobra"
+)
+
+// ImportCmd creates the `import` subcommand
+func ImportCmd(serviceComponent interface{}) *cobra.Command {
+	var opts proc.ContextImportOptions
+
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import a context",
+		Long:  `Import a context from a JSON or tar bundle. Use - to read the bundle from stdin.`,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			proc.ContextImport(cmd.Context(), args[0], opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.Tenant, "tenant", "", "Tenant owning the imported context")
+	cmd.Flags().StringVar(&opts.Name, "name", "", "Name of the imported context, the bundled name when empty")
+	cmd.Flags().StringVar(&opts.Strategy, "strategy", "fail", "What to do when the context exists: fail, skip, replace or rename")
+	cmd.Flags().BoolVar(&opts.IncludeMappings, "mappings", false, "Import bundled tile/satellite mappings")
+	return cmd
+}
@@ -45,4 +45,6 @@ func registerSubcommands(app *app.App) {
 	rootCmd.AddCommand(DbCmd(app))
 	rootCmd.AddCommand(InfoCmd(app))
 	rootCmd.AddCommand(TaskCmd(app))
+	rootCmd.AddCommand(ContextCmd(app))
+	rootCmd.AddCommand(EventsCmd(app))
 }
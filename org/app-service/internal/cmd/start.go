@@ -13,8 +13,16 @@ func StartCmd(app *app.App) *cobra.Command {
 	startCmd := &cobra.Command{
 		Use:   "start",
 		Short: "Start public and protected API services",
-		Long:  "Start both public and protected API services, as well as optional daemons.",
+		Long:  "Start both public and protected API services, the task scheduler and the daemon tasks, as well as optional daemons.",
 		Run: func(cmd *cobra.Command, args []string) {
+			if !config.NoSchedulerFlag {
+				logger.Debug("Starting task scheduler...")
+				go proc.StartTaskScheduler(app.Dependencies)
+			}
+			if !config.NoDaemonsFlag {
+				logger.Debug("Starting daemon tasks...")
+				go proc.StartDaemons(app.Dependencies)
+			}
 			logger.Debug("Starting public and protected API services...")
 			proc.StartPublicApi(app.Dependencies)
 			proc.StartProtectedApi(app.Dependencies)
@@ -28,6 +36,8 @@ func StartCmd(app *app.App) *cobra.Command {
 
 	// Set global flags
 	startCmd.PersistentFlags().BoolVar(&config.StartWatcherFlag, "watcher", false, "Start watcher daemon in background")
+	startCmd.PersistentFlags().BoolVar(&config.NoSchedulerFlag, "no-scheduler", false, "Do not run the task scheduler in background")
+	startCmd.PersistentFlags().BoolVar(&config.NoDaemonsFlag, "no-daemons", false, "Do not run the daemon tasks in background")
 	startCmd.PersistentFlags().StringVarP(&config.HostFlag, "host", "H", "", "Service host")
 	startCmd.PersistentFlags().StringVar(&config.ProtectedPortFlag, "protected-api-port", "", "Protected API Service port")
 	startCmd.PersistentFlags().StringVar(&config.PublicPortFlag, "public-api-port", "", "Public API Service port")
@@ -8,11 +8,25 @@ const (
 	DEFAULT_PUBLIC_CESLESTRACK_URL        string = "https://celestrak.com/NORAD/elements/gp.php"
 	DEFAULT_PRIVATE_PROPAGATOR_URL        string = "http://propagator-service:5000/satellite/propagate"
 	DEFAULT_PUBLIC_CESLESTRACK_SATCAT_URL string = "https://celestrak.org/pub/satcat.csv"
+	DEFAULT_BASEMAP_UPSTREAM_URLS         string = "https://{s}.basemaps.cartocdn.com/light_all/{z}/{x}/{y}.png" // CartoDB Positron
+	DEFAULT_BASEMAP_SUBDOMAINS            string = "a,b,c"
+	DEFAULT_BASEMAP_CACHE_DIR             string = "./data/basemap"
+	DEFAULT_BASEMAP_OFFLINE               string = "false"
+	DEFAULT_BASEMAP_MAX_SEED_TILES        string = "100000"
+	DEFAULT_SPATIAL_ENGINE                string = SPATIAL_ENGINE_POSTGIS
+	DEFAULT_SPATIAL_MEMORY_TTL            string = "5m"
+	DEFAULT_TENANCY_REQUIRE_PRINCIPAL     string = "false"
+	DEFAULT_BROKER_TYPE                   string = BROKER_TYPE_RABBITMQ
+	DEFAULT_BROKER_REDIS_STREAM           string = "events"
+	DEFAULT_BROKER_REDIS_GROUP            string = "app-service"
+	DEFAULT_BROKER_EVENT_ENVELOPE         string = EVENT_ENVELOPE_EVENT_ROOT
+	DEFAULT_BROKER_EVENT_SOURCE           string = "/app-service"
 
 	// defaults
 	DEFAULT_PROTECTED_API_PORT       string = "8080"
 	DEFAULT_PUBLIC_API_PORT          string = "8081"
 	DEFAULT_HIDDEN_API_PORT          string = "8079"
+	DEFAULT_METRICS_PORT             string = "9464"
 	DEFAULT_HOST                     string = "0.0.0.0"
 	DEFAULT_DEV_HOST                 string = "127.0.0.1"
 	DEFAULT_LOG_LEVEL                string = "warn"
@@ -35,6 +49,10 @@ const (
 	FEATURE_PROPAGATOR string = "propagator"
 	FEATURE_REDIS      string = "redis"
 	FEATURE_RABBITMQ   string = "rabbitmq"
+	FEATURE_BASEMAP    string = "basemap"
+	FEATURE_SPATIAL    string = "spatial"
+	FEATURE_TENANCY    string = "tenancy"
+	FEATURE_BROKER     string = "broker"
 
 	// generic words
 	WORD_DATABASE        string = "database"
@@ -58,6 +76,17 @@ const (
 	DB_TIMEZONE_UTC       string = "Etc/GMT"
 	DB_TIMEZONE_MELBOURNE string = "Australia/Melbourne"
 
+	SPATIAL_ENGINE_POSTGIS string = "postgis"
+	SPATIAL_ENGINE_MEMORY  string = "memory"
+
+	BROKER_TYPE_RABBITMQ string = "rabbitmq"
+	BROKER_TYPE_REDIS    string = "redis"
+	BROKER_TYPE_MEMORY   string = "memory"
+
+	EVENT_ENVELOPE_EVENT_ROOT             string = "event-root"
+	EVENT_ENVELOPE_CLOUDEVENTS_STRUCTURED string = "cloudevents-structured"
+	EVENT_ENVELOPE_CLOUDEVENTS_BINARY     string = "cloudevents-binary"
+
 	DEFAULT_REDIS_PASSWORD string = "2112"
 	DEFAULT_REDIS_PORT     int32  = 6379
 
@@ -67,6 +96,9 @@ const (
 	HEADER_AUTH_BEARER_WORD  string = "Bearer"
 	HEADER_KRATOS_COOKIE     string = "ory_kratos_session"
 
+	CONTEXT_KEY_CLAIMS string = "claims"
+	CONTEXT_KEY_TENANT string = "tenant"
+
 	// output messages
 	MSG_SERVER_SHUTTING_DOWN        string = "server is shutting down"
 	MSG_NOT_ACCEPTABLE              string = "not acceptable"
@@ -24,6 +24,10 @@ type EnvVars struct {
 	Propagator      features.PropagatorConfig `mapstructure:",squash"`
 	Clerk           features.ClerkConfig      `mapstructure:",squash"`
 	RabbitMQ        features.RabbitMQConfig   `mapstructure:",squash"`
+	Basemap         features.BasemapConfig    `mapstructure:",squash"`
+	Spatial         features.SpatialConfig    `mapstructure:",squash"`
+	Tenancy         features.TenancyConfig    `mapstructure:",squash"`
+	Broker          features.BrokerConfig     `mapstructure:",squash"`
 }
 
 func (c *EnvVars) Init() {
@@ -73,6 +77,7 @@ func (c *EnvVars) setDefaults() {
 	viper.SetDefault("HOST", constants.DEFAULT_HOST)
 	viper.SetDefault("PROTECTED_API_PORT", constants.DEFAULT_PROTECTED_API_PORT)
 	viper.SetDefault("PUBLIC_API_PORT", constants.DEFAULT_PUBLIC_API_PORT)
+	viper.SetDefault("METRICS_PORT", constants.DEFAULT_METRICS_PORT)
 	viper.SetDefault("LOG_LEVEL", constants.DEFAULT_LOG_LEVEL)
 	viper.SetDefault("REQUEST_TIMEOUT_DURATION", strconv.Itoa(constants.DEFAULT_REQUEST_TIMEOUT_DURATION))
 	viper.SetDefault("WATCHER_SLEEP_INTERVAL", strconv.Itoa(constants.DEFAULT_WATCHER_SLEEP_INTERVAL))
@@ -95,6 +100,23 @@ func (c *EnvVars) setDefaults() {
 	viper.SetDefault("CELESTRACK_URL", constants.DEFAULT_PUBLIC_CESLESTRACK_URL)
 	viper.SetDefault("PROPAGATOR_URL", constants.DEFAULT_PRIVATE_PROPAGATOR_URL)
 	viper.SetDefault("CELESTRACK_SATCAT_URL", constants.DEFAULT_PUBLIC_CESLESTRACK_SATCAT_URL)
+
+	viper.SetDefault("BASEMAP_CACHE_DIR", constants.DEFAULT_BASEMAP_CACHE_DIR)
+	viper.SetDefault("BASEMAP_UPSTREAM_URLS", constants.DEFAULT_BASEMAP_UPSTREAM_URLS)
+	viper.SetDefault("BASEMAP_SUBDOMAINS", constants.DEFAULT_BASEMAP_SUBDOMAINS)
+	viper.SetDefault("BASEMAP_OFFLINE", constants.DEFAULT_BASEMAP_OFFLINE)
+	viper.SetDefault("BASEMAP_MAX_SEED_TILES", constants.DEFAULT_BASEMAP_MAX_SEED_TILES)
+
+	viper.SetDefault("SPATIAL_ENGINE", constants.DEFAULT_SPATIAL_ENGINE)
+	viper.SetDefault("SPATIAL_MEMORY_TTL", constants.DEFAULT_SPATIAL_MEMORY_TTL)
+
+	viper.SetDefault("TENANCY_REQUIRE_PRINCIPAL", constants.DEFAULT_TENANCY_REQUIRE_PRINCIPAL)
+
+	viper.SetDefault("BROKER_TYPE", constants.DEFAULT_BROKER_TYPE)
+	viper.SetDefault("BROKER_REDIS_STREAM", constants.DEFAULT_BROKER_REDIS_STREAM)
+	viper.SetDefault("BROKER_REDIS_GROUP", constants.DEFAULT_BROKER_REDIS_GROUP)
+	viper.SetDefault("BROKER_EVENT_ENVELOPE", constants.DEFAULT_BROKER_EVENT_ENVELOPE)
+	viper.SetDefault("BROKER_EVENT_SOURCE", constants.DEFAULT_BROKER_EVENT_SOURCE)
 }
 
 func (c *EnvVars) OverrideUsingFlags() {
@@ -0,0 +1,26 @@
+package features
+
+import "github.com/org/2112-space-lab/org/app-service/internal/config/constants"
+
+type BasemapConfig struct {
+	CacheDir     string `mapstructure:"BASEMAP_CACHE_DIR"`
+	UpstreamUrls string `mapstructure:"BASEMAP_UPSTREAM_URLS"`  // comma-separated templates using {s}, {z}, {x}, {y}
+	Subdomains   string `mapstructure:"BASEMAP_SUBDOMAINS"`     // comma-separated values substituted for {s}
+	Offline      string `mapstructure:"BASEMAP_OFFLINE"`        // when "true", tiles are served from cache only
+	MaxSeedTiles string `mapstructure:"BASEMAP_MAX_SEED_TILES"` // upper bound on the tiles a single seeding run may cover
+}
+
+var basemap = &Feature{
+	Name:       constants.FEATURE_BASEMAP,
+	Config:     &BasemapConfig{},
+	enabled:    true,
+	configured: false,
+	ready:      false,
+	requirements: []string{
+		"CacheDir",
+	},
+}
+
+func init() {
+	Features.Add(basemap)
+}
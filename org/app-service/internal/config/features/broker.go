@@ -0,0 +1,28 @@
+package features
+
+import "github.com/org/2112-space-lab/org/app-service/internal/config/constants"
+
+// BrokerConfig selects the message broker carrying events.
+type BrokerConfig struct {
+	Type        string `mapstructure:"BROKER_TYPE"`         // "rabbitmq" (default), "redis" for Redis Streams or "memory" for a single process
+	RedisStream string `mapstructure:"BROKER_REDIS_STREAM"` // Stream of the events with the redis broker
+	RedisGroup  string `mapstructure:"BROKER_REDIS_GROUP"`  // Consumer group of the service with the redis broker
+	// "event-root" (default), "cloudevents-structured" or "cloudevents-binary"; consumers read every envelope
+	EventEnvelope string `mapstructure:"BROKER_EVENT_ENVELOPE"`
+	EventSource   string `mapstructure:"BROKER_EVENT_SOURCE"` // CloudEvents source of the published events
+}
+
+var broker = &Feature{
+	Name:       constants.FEATURE_BROKER,
+	Config:     &BrokerConfig{},
+	enabled:    true,
+	configured: false,
+	ready:      false,
+	requirements: []string{
+		"Type",
+	},
+}
+
+func init() {
+	Features.Add(broker)
+}
@@ -7,6 +7,7 @@ type ServiceConfig struct {
 	ProtectedApiPort       string `mapstructure:"PROTECTED_API_PORT"`
 	PublicApiPort          string `mapstructure:"PUBLIC_API_PORT"`
 	HiddenApiPort          string `mapstructure:"HIDDEN_API_PORT"`
+	MetricsPort            string `mapstructure:"METRICS_PORT"` // Metrics of the processes running no API
 	LogLevel               string `mapstructure:"LOG_LEVEL"`
 	RequestTimeoutDuration string `mapstructure:"REQUEST_TIMEOUT_DURATION"`
 	WatcherSleepInterval   string `mapstructure:"WATCHER_SLEEP_INTERVAL"`
@@ -0,0 +1,23 @@
+package features
+
+import "github.com/org/2112-space-lab/org/app-service/internal/config/constants"
+
+type SpatialConfig struct {
+	Engine    string `mapstructure:"SPATIAL_ENGINE"`     // "postgis" (default) or "memory" for the in-memory R-tree
+	MemoryTTL string `mapstructure:"SPATIAL_MEMORY_TTL"` // duration after which the in-memory index is reloaded from the database
+}
+
+var spatial = &Feature{
+	Name:       constants.FEATURE_SPATIAL,
+	Config:     &SpatialConfig{},
+	enabled:    true,
+	configured: false,
+	ready:      false,
+	requirements: []string{
+		"Engine",
+	},
+}
+
+func init() {
+	Features.Add(spatial)
+}
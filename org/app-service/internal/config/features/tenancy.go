@@ -0,0 +1,22 @@
+package features
+
+import "github.com/org/2112-space-lab/org/app-service/internal/config/constants"
+
+type TenancyConfig struct {
+	RequirePrincipal string `mapstructure:"TENANCY_REQUIRE_PRINCIPAL"` // when "true", requests without a tenant-bearing principal are rejected
+}
+
+var tenancy = &Feature{
+	Name:       constants.FEATURE_TENANCY,
+	Config:     &TenancyConfig{},
+	enabled:    true,
+	configured: false,
+	ready:      false,
+	requirements: []string{
+		"RequirePrincipal",
+	},
+}
+
+func init() {
+	Features.Add(tenancy)
+}
@@ -45,6 +45,16 @@ var NoBorderFlag bool
 // description: Start watcher daemon
 var StartWatcherFlag bool
 
+// Flag: 				NoScheduler (bool)
+// default: 		false
+// description: Do not run the task scheduler in the start process
+var NoSchedulerFlag bool
+
+// Flag: 				NoDaemons (bool)
+// default: 		false
+// description: Do not run the daemon tasks in the start process
+var NoDaemonsFlag bool
+
 // Flag: 				pushEndpoint (string)
 // default:
 // description:
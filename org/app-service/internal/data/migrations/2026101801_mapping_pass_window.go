@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func init() {
+	type TileSatelliteMapping struct {
+		EnteredAt       *time.Time `gorm:"null;index"`
+		ExitedAt        *time.Time `gorm:"null;index"`
+		DurationSeconds *float64   `gorm:"type:double precision;null"`
+	}
+
+	m := &gormigrate.Migration{
+		ID: "2026101801_mapping_pass_window",
+		Migrate: func(db *gorm.DB) error {
+			return db.Set("gorm:table_options", "SCHEMA=config_schema").
+				AutoMigrate(&TileSatelliteMapping{})
+		},
+		Rollback: func(db *gorm.DB) error {
+			for _, column := range []string{"entered_at", "exited_at", "duration_seconds"} {
+				if err := db.Migrator().DropColumn(&TileSatelliteMapping{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	AddMigration(m)
+}
@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func init() {
+	type Satellite struct {
+		SensorHalfAngle *float64 `gorm:"type:float"`
+	}
+
+	m := &gormigrate.Migration{
+		ID: "2026101802_satellite_sensor_half_angle",
+		Migrate: func(db *gorm.DB) error {
+			return db.Set("gorm:table_options", "SCHEMA=config_schema").
+				AutoMigrate(&Satellite{})
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Migrator().DropColumn(&Satellite{}, "sensor_half_angle")
+		},
+	}
+
+	AddMigration(m)
+}
@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func init() {
+	type Event struct {
+		TenantID string `gorm:"size:255;not null;default:'default';index"`
+	}
+
+	m := &gormigrate.Migration{
+		ID: "2026101803_tenant_isolation",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.Set("gorm:table_options", "SCHEMA=config_schema").
+				AutoMigrate(&Event{}); err != nil {
+				return err
+			}
+
+			// Contexts created before tenancy belong to the default tenant.
+			if err := db.Exec(`
+				UPDATE config_schema.contexts
+				SET tenant_id = 'default'
+				WHERE tenant_id = '';
+			`).Error; err != nil {
+				return err
+			}
+
+			// Names are unique per tenant (unique_tenant_context_name), no longer globally.
+			return db.Exec(`
+				ALTER TABLE config_schema.contexts
+				DROP CONSTRAINT IF EXISTS uni_contexts_name;
+			`).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			if err := db.Exec(`
+				ALTER TABLE config_schema.contexts
+				ADD CONSTRAINT uni_contexts_name
+				UNIQUE (name);
+			`).Error; err != nil {
+				return err
+			}
+			return db.Migrator().DropColumn(&Event{}, "tenant_id")
+		},
+	}
+
+	AddMigration(m)
+}
@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func init() {
+	type Context struct {
+		State                   string `gorm:"size:32;not null;default:'draft';index"`
+		ScheduledActivationAt   *time.Time
+		ScheduledDeactivationAt *time.Time
+	}
+
+	m := &gormigrate.Migration{
+		ID: "2026101804_context_lifecycle",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.Set("gorm:table_options", "SCHEMA=config_schema").
+				AutoMigrate(&Context{}); err != nil {
+				return err
+			}
+
+			// Contexts running before the state machine start out active, the others as drafts.
+			return db.Exec(`
+				UPDATE config_schema.contexts
+				SET state = 'active'
+				WHERE is_active = TRUE;
+			`).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			for _, column := range []string{"state", "scheduled_activation_at", "scheduled_deactivation_at"} {
+				if err := db.Migrator().DropColumn(&Context{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	AddMigration(m)
+}
@@ -0,0 +1,59 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func init() {
+	type Context struct {
+		ID             string `gorm:"type:char(36);primary_key;"`
+		TlePinnedEpoch *time.Time
+	}
+
+	type TLEVersion struct {
+		ID        string    `gorm:"type:char(36);primary_key;"`
+		SpaceID   string    `gorm:"size:255;not null;uniqueIndex:idx_tle_versions_space_epoch"`
+		Epoch     time.Time `gorm:"not null;uniqueIndex:idx_tle_versions_space_epoch"`
+		Line1     string    `gorm:"size:255;not null"`
+		Line2     string    `gorm:"size:255;not null"`
+		CreatedAt time.Time
+	}
+
+	type ContextTLEPin struct {
+		ContextID    string     `gorm:"size:255;primaryKey"`
+		SpaceID      string     `gorm:"size:255;primaryKey"`
+		TLEVersionID string     `gorm:"not null;index"`
+		Context      Context    `gorm:"constraint:OnDelete:CASCADE;foreignKey:ContextID;references:ID"`
+		TLEVersion   TLEVersion `gorm:"constraint:OnDelete:RESTRICT;foreignKey:TLEVersionID;references:ID"`
+	}
+
+	m := &gormigrate.Migration{
+		ID: "2026101805_tle_pinning",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.Set("gorm:table_options", "SCHEMA=config_schema").
+				AutoMigrate(&Context{}, &TLEVersion{}, &ContextTLEPin{}); err != nil {
+				return err
+			}
+
+			// Seed the history with the TLEs stored so far, so contexts can pin epochs before the migration.
+			return db.Exec(`
+				INSERT INTO config_schema.tle_versions (id, space_id, epoch, line1, line2, created_at)
+				SELECT DISTINCT ON (space_id, epoch) id, space_id, epoch, line1, line2, created_at
+				FROM config_schema.tles
+				ORDER BY space_id, epoch, created_at DESC
+				ON CONFLICT DO NOTHING;
+			`).Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			if err := db.Migrator().DropTable("config_schema.context_tle_pins", "config_schema.tle_versions"); err != nil {
+				return err
+			}
+			return db.Migrator().DropColumn(&Context{}, "tle_pinned_epoch")
+		},
+	}
+
+	AddMigration(m)
+}
@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func init() {
+	type Context struct {
+		ID string `gorm:"type:char(36);primary_key;"`
+	}
+
+	type ContextSatellite struct {
+		RuleAssigned bool `gorm:"not null;default:false"`
+	}
+
+	type ContextMembershipRule struct {
+		ID        string  `gorm:"type:char(36);primary_key;"`
+		ContextID string  `gorm:"type:char(36);not null;uniqueIndex:idx_membership_rules_context_name"`
+		Name      string  `gorm:"size:255;not null;uniqueIndex:idx_membership_rules_context_name"`
+		Filters   string  `gorm:"type:jsonb;not null"`
+		Context   Context `gorm:"constraint:OnDelete:CASCADE;foreignKey:ContextID;references:ID"`
+		CreatedAt time.Time
+		UpdatedAt time.Time
+	}
+
+	m := &gormigrate.Migration{
+		ID: "2026101806_context_membership_rules",
+		Migrate: func(db *gorm.DB) error {
+			return db.Set("gorm:table_options", "SCHEMA=config_schema").
+				AutoMigrate(&ContextSatellite{}, &ContextMembershipRule{})
+		},
+		Rollback: func(db *gorm.DB) error {
+			if err := db.Migrator().DropTable("config_schema.context_membership_rules"); err != nil {
+				return err
+			}
+			return db.Migrator().DropColumn(&ContextSatellite{}, "rule_assigned")
+		},
+	}
+
+	AddMigration(m)
+}
@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func init() {
+	type Context struct {
+		ID string `gorm:"type:char(36);primary_key;"`
+	}
+
+	type ContextSimulationClock struct {
+		ContextID  string    `gorm:"type:char(36);primaryKey"`
+		Epoch      time.Time `gorm:"not null"`
+		AnchoredAt time.Time `gorm:"not null"`
+		Speed      float64   `gorm:"not null;default:1"`
+		Paused     bool      `gorm:"not null;default:false"`
+		Context    Context   `gorm:"constraint:OnDelete:CASCADE;foreignKey:ContextID;references:ID"`
+		UpdatedAt  time.Time
+	}
+
+	m := &gormigrate.Migration{
+		ID: "2026101807_context_simulation_clock",
+		Migrate: func(db *gorm.DB) error {
+			return db.Set("gorm:table_options", "SCHEMA=config_schema").
+				AutoMigrate(&ContextSimulationClock{})
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Migrator().DropTable("config_schema.context_simulation_clocks")
+		},
+	}
+
+	AddMigration(m)
+}
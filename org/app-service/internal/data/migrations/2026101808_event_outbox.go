@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func init() {
+	type OutboxMessage struct {
+		Sequence      int64      `gorm:"primaryKey;autoIncrement"`
+		EventUID      string     `gorm:"size:255;not null;unique"`
+		AggregateID   string     `gorm:"size:255;not null;index:idx_outbox_aggregate"`
+		EventType     string     `gorm:"size:255;not null"`
+		TenantID      string     `gorm:"size:255;not null;default:'default'"`
+		Event         string     `gorm:"type:json;not null"`
+		Attempts      int        `gorm:"not null;default:0"`
+		NextAttemptAt time.Time  `gorm:"not null;default:CURRENT_TIMESTAMP"`
+		LastError     string     `gorm:"type:text"`
+		CreatedAt     time.Time  `gorm:"not null;default:CURRENT_TIMESTAMP"`
+		SentAt        *time.Time `gorm:"null;index:idx_outbox_pending"`
+	}
+
+	m := &gormigrate.Migration{
+		ID: "2026101808_event_outbox",
+		Migrate: func(db *gorm.DB) error {
+			return db.Set("gorm:table_options", "SCHEMA=config_schema").
+				AutoMigrate(&OutboxMessage{})
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Migrator().DropTable("config_schema.outbox_messages")
+		},
+	}
+
+	AddMigration(m)
+}
@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func init() {
+	type DeadLetter struct {
+		ID             string     `gorm:"type:char(36);primaryKey"`
+		EventUID       string     `gorm:"size:255;not null;index"`
+		EventType      string     `gorm:"size:255;not null"`
+		TenantID       string     `gorm:"size:255;not null;default:'default';index"`
+		HandlerName    string     `gorm:"size:255;not null"`
+		Attempts       int        `gorm:"not null"`
+		LastError      string     `gorm:"type:text"`
+		Event          string     `gorm:"type:json;not null"`
+		DeadLetteredAt time.Time  `gorm:"not null;default:CURRENT_TIMESTAMP;index"`
+		RedrivenAt     *time.Time `gorm:"null"`
+	}
+
+	m := &gormigrate.Migration{
+		ID: "2026101809_dead_letters",
+		Migrate: func(db *gorm.DB) error {
+			return db.Set("gorm:table_options", "SCHEMA=config_schema").
+				AutoMigrate(&DeadLetter{})
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Migrator().DropTable("config_schema.dead_letters")
+		},
+	}
+
+	AddMigration(m)
+}
@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func init() {
+	type ProcessedEvent struct {
+		EventUID    string    `gorm:"size:255;primaryKey"`
+		HandlerName string    `gorm:"size:255;primaryKey"`
+		ProcessedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP;index"`
+	}
+
+	m := &gormigrate.Migration{
+		ID: "2026101810_processed_events",
+		Migrate: func(db *gorm.DB) error {
+			return db.Set("gorm:table_options", "SCHEMA=config_schema").
+				AutoMigrate(&ProcessedEvent{})
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Migrator().DropTable("config_schema.processed_events")
+		},
+	}
+
+	AddMigration(m)
+}
@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func init() {
+	type Event struct {
+		SchemaVersion int `gorm:"not null;default:1"`
+	}
+
+	m := &gormigrate.Migration{
+		ID: "2026101811_event_schema_version",
+		Migrate: func(db *gorm.DB) error {
+			return db.Set("gorm:table_options", "SCHEMA=config_schema").
+				AutoMigrate(&Event{})
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Migrator().DropColumn(&Event{}, "schema_version")
+		},
+	}
+
+	AddMigration(m)
+}
@@ -0,0 +1,67 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func init() {
+	type Event struct {
+		DisplayName string `gorm:"type:varchar(255);not null;default:''"`
+		CreatedAt   time.Time
+		UpdatedAt   time.Time
+		DeleteAt    *time.Time
+		ProcessedAt *time.Time
+		IsActive    bool `gorm:"not null;default:true"`
+		IsFavourite bool `gorm:"not null;default:false"`
+	}
+
+	type EventHandler struct {
+		HandlerName string    `gorm:"size:255;not null;default:''"`
+		StartedAt   time.Time `gorm:"not null;default:CURRENT_TIMESTAMP;index"`
+		DisplayName string    `gorm:"type:varchar(255);not null;default:''"`
+		CreatedAt   time.Time
+		UpdatedAt   time.Time
+		DeleteAt    *time.Time
+		ProcessedAt *time.Time
+		IsActive    bool `gorm:"not null;default:true"`
+		IsFavourite bool `gorm:"not null;default:false"`
+	}
+
+	modelBaseColumns := []string{"display_name", "created_at", "updated_at", "delete_at", "processed_at", "is_active", "is_favourite"}
+
+	m := &gormigrate.Migration{
+		ID: "2026101812_event_history",
+		Migrate: func(db *gorm.DB) error {
+			// Handler executions reference events by UID, not by ID.
+			if err := db.Exec(`
+				ALTER TABLE config_schema.event_handlers
+				DROP CONSTRAINT IF EXISTS fk_event_handlers_event;
+			`).Error; err != nil {
+				return err
+			}
+			if db.Migrator().HasColumn(&EventHandler{}, "handler") {
+				if err := db.Migrator().RenameColumn(&EventHandler{}, "handler", "handler_name"); err != nil {
+					return err
+				}
+			}
+			return db.Set("gorm:table_options", "SCHEMA=config_schema").
+				AutoMigrate(&Event{}, &EventHandler{})
+		},
+		Rollback: func(db *gorm.DB) error {
+			for _, column := range modelBaseColumns {
+				if err := db.Migrator().DropColumn(&Event{}, column); err != nil {
+					return err
+				}
+				if err := db.Migrator().DropColumn(&EventHandler{}, column); err != nil {
+					return err
+				}
+			}
+			return db.Migrator().RenameColumn(&EventHandler{}, "handler_name", "handler")
+		},
+	}
+
+	AddMigration(m)
+}
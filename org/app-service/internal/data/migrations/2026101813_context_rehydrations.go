@@ -0,0 +1,54 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func init() {
+	type Context struct {
+		ID string `gorm:"type:char(36);primary_key;"`
+	}
+
+	type ContextRehydration struct {
+		ContextID   string     `gorm:"type:char(36);primaryKey"`
+		ContextName string     `gorm:"size:255;not null"`
+		TenantID    string     `gorm:"size:255;not null;default:'default'"`
+		EventUID    string     `gorm:"size:255;not null"`
+		Status      string     `gorm:"size:50;not null;index"`
+		StartTime   time.Time  `gorm:"not null"`
+		StartedAt   time.Time  `gorm:"not null"`
+		Deadline    time.Time  `gorm:"not null;index"`
+		CompletedAt *time.Time `gorm:"null"`
+		Reason      string     `gorm:"type:text"`
+		Context     Context    `gorm:"constraint:OnDelete:CASCADE;foreignKey:ContextID;references:ID"`
+		UpdatedAt   time.Time
+	}
+
+	type ContextRehydrationStep struct {
+		ContextID string    `gorm:"type:char(36);primaryKey"`
+		SpaceID   string    `gorm:"size:255;primaryKey"`
+		Status    string    `gorm:"size:50;not null"`
+		Error     string    `gorm:"type:text"`
+		Context   Context   `gorm:"constraint:OnDelete:CASCADE;foreignKey:ContextID;references:ID"`
+		UpdatedAt time.Time `gorm:"not null"`
+	}
+
+	m := &gormigrate.Migration{
+		ID: "2026101813_context_rehydrations",
+		Migrate: func(db *gorm.DB) error {
+			return db.Set("gorm:table_options", "SCHEMA=config_schema").
+				AutoMigrate(&ContextRehydration{}, &ContextRehydrationStep{})
+		},
+		Rollback: func(db *gorm.DB) error {
+			if err := db.Migrator().DropTable("config_schema.context_rehydration_steps"); err != nil {
+				return err
+			}
+			return db.Migrator().DropTable("config_schema.context_rehydrations")
+		},
+	}
+
+	AddMigration(m)
+}
@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func init() {
+	type TaskSchedule struct {
+		Name            string     `gorm:"size:255;primaryKey"`
+		TaskName        string     `gorm:"size:255;not null"`
+		Cron            string     `gorm:"size:255;not null"`
+		Args            string     `gorm:"type:json;not null;default:'{}'"`
+		Enabled         bool       `gorm:"not null;default:true"`
+		MissedRunPolicy string     `gorm:"size:50;not null;default:'skip'"`
+		JitterSeconds   int64      `gorm:"not null;default:0"`
+		NextRunAt       time.Time  `gorm:"not null;index:idx_task_schedules_due"`
+		RunningSince    *time.Time `gorm:"null"`
+		RunningOwner    string     `gorm:"size:255;not null;default:''"`
+		LeaseUntil      *time.Time `gorm:"null"`
+		LastRunAt       *time.Time `gorm:"null"`
+		LastStatus      string     `gorm:"size:50;not null;default:''"`
+		LastError       string     `gorm:"type:text"`
+		LastDurationMs  int64      `gorm:"not null;default:0"`
+		CreatedAt       time.Time  `gorm:"not null;default:CURRENT_TIMESTAMP"`
+		UpdatedAt       time.Time  `gorm:"not null;default:CURRENT_TIMESTAMP"`
+	}
+
+	m := &gormigrate.Migration{
+		ID: "2026101814_task_schedules",
+		Migrate: func(db *gorm.DB) error {
+			return db.Set("gorm:table_options", "SCHEMA=config_schema").
+				AutoMigrate(&TaskSchedule{})
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Migrator().DropTable("config_schema.task_schedules")
+		},
+	}
+
+	AddMigration(m)
+}
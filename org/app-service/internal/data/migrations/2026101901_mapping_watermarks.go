@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func init() {
+	type Context struct {
+		ID string `gorm:"type:char(36);primary_key;"`
+	}
+
+	type MappingWatermark struct {
+		ContextID string    `gorm:"type:char(36);primaryKey"`
+		SpaceID   string    `gorm:"size:255;primaryKey"`
+		Context   Context   `gorm:"constraint:OnDelete:CASCADE;foreignKey:ContextID;references:ID"`
+		Watermark time.Time `gorm:"not null"`
+		UpdatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP"`
+	}
+
+	m := &gormigrate.Migration{
+		ID: "2026101901_mapping_watermarks",
+		Migrate: func(db *gorm.DB) error {
+			return db.Set("gorm:table_options", "SCHEMA=config_schema").
+				AutoMigrate(&MappingWatermark{})
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Migrator().DropTable("config_schema.mapping_watermarks")
+		},
+	}
+
+	AddMigration(m)
+}
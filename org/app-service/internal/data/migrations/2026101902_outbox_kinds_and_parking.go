@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func init() {
+	type OutboxMessage struct {
+		Kind     string     `gorm:"size:32;not null;default:'event'"`
+		ParkedAt *time.Time `gorm:"null"`
+	}
+
+	m := &gormigrate.Migration{
+		ID: "2026101902_outbox_kinds_and_parking",
+		Migrate: func(db *gorm.DB) error {
+			return db.Set("gorm:table_options", "SCHEMA=config_schema").
+				AutoMigrate(&OutboxMessage{})
+		},
+		Rollback: func(db *gorm.DB) error {
+			if err := db.Migrator().DropColumn(&OutboxMessage{}, "parked_at"); err != nil {
+				return err
+			}
+			return db.Migrator().DropColumn(&OutboxMessage{}, "kind")
+		},
+	}
+
+	AddMigration(m)
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+)
+
+// ContextMembershipRule is a saved satellite filter of a context, its filters stored as JSON.
+type ContextMembershipRule struct {
+	ID        string  `gorm:"type:char(36);primary_key;"`
+	ContextID string  `gorm:"type:char(36);not null;uniqueIndex:idx_membership_rules_context_name"`
+	Name      string  `gorm:"size:255;not null;uniqueIndex:idx_membership_rules_context_name"`
+	Filters   string  `gorm:"type:jsonb;not null"`
+	Context   Context `gorm:"constraint:OnDelete:CASCADE;foreignKey:ContextID;references:ID"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// MapToMembershipRuleDomain converts a ContextMembershipRule database model to a MembershipRule domain model.
+func MapToMembershipRuleDomain(r ContextMembershipRule) (domain.MembershipRule, error) {
+	rule := domain.MembershipRule{
+		ID:        r.ID,
+		ContextID: r.ContextID,
+		Name:      r.Name,
+	}
+	err := json.Unmarshal([]byte(r.Filters), &rule.Filters)
+	return rule, err
+}
+
+// MapToMembershipRuleModel converts a MembershipRule domain model to a ContextMembershipRule database model.
+func MapToMembershipRuleModel(r domain.MembershipRule) (ContextMembershipRule, error) {
+	filters, err := json.Marshal(r.Filters)
+	if err != nil {
+		return ContextMembershipRule{}, err
+	}
+	return ContextMembershipRule{
+		ID:        r.ID,
+		ContextID: r.ContextID,
+		Name:      r.Name,
+		Filters:   string(filters),
+	}, nil
+}
@@ -11,16 +11,20 @@ import (
 // Context represents the database model for logical groupings.
 type Context struct {
 	ModelBase
-	Name                       string     `gorm:"size:255;unique;not null"` // Unique name of the context
-	TenantID                   string     `gorm:"size:255;not null;index"`  // Tenant identifier
-	Description                string     `gorm:"size:1024"`                // Optional description of the context
-	MaxSatellite               int        `gorm:"not null"`                 // Maximum number of satellites allowed
-	MaxTiles                   int        `gorm:"not null"`                 // Maximum number of tiles allowed
+	Name                       string     `gorm:"size:255;not null"`                      // Name of the context, unique per tenant
+	TenantID                   string     `gorm:"size:255;not null;index"`                // Tenant identifier
+	Description                string     `gorm:"size:1024"`                              // Optional description of the context
+	MaxSatellite               int        `gorm:"not null"`                               // Maximum number of satellites allowed
+	MaxTiles                   int        `gorm:"not null"`                               // Maximum number of tiles allowed
+	State                      string     `gorm:"size:32;not null;default:'draft';index"` // Lifecycle state
+	ScheduledActivationAt      *time.Time // Time the scheduler activates the context
+	ScheduledDeactivationAt    *time.Time // Time the scheduler pauses the context
 	ActivatedAt                *time.Time // Time the context was activated
 	DesactivatedAt             *time.Time // Time the context was deactivated
 	TriggerGeneratedMappingAt  *time.Time // Time the mapping was generated
 	TriggerImportedTLEAt       *time.Time // Time the TLE data was imported
 	TriggerImportedSatelliteAt *time.Time // Time the satellite data was imported
+	TlePinnedEpoch             *time.Time // Epoch of the pinned TLE snapshot
 }
 
 // MapToContextDomain converts a Context database model to a GameContext domain model.
@@ -41,11 +45,15 @@ func MapToContextDomain(c Context) domain.GameContext {
 		Description: fx.ConvertOption(fx.AsOption(&c.Description), func(d string) domain.GameContextDescription {
 			return domain.GameContextDescription(d)
 		}),
+		State:                      domain.GameContextState(c.State),
+		ScheduledActivationAt:      xtime.ToUtcTime(c.ScheduledActivationAt),
+		ScheduledDeactivationAt:    xtime.ToUtcTime(c.ScheduledDeactivationAt),
 		ActivatedAt:                xtime.ToUtcTime(c.ActivatedAt),
 		DesactivatedAt:             xtime.ToUtcTime(c.DesactivatedAt),
 		TriggerGeneratedMappingAt:  xtime.ToUtcTime(c.TriggerGeneratedMappingAt),
 		TriggerImportedTLEAt:       xtime.ToUtcTime(c.TriggerImportedTLEAt),
 		TriggerImportedSatelliteAt: xtime.ToUtcTime(c.TriggerImportedSatelliteAt),
+		TlePinnedEpoch:             xtime.ToUtcTime(c.TlePinnedEpoch),
 	}
 }
 
@@ -65,11 +73,15 @@ func MapToContextModel(c domain.GameContext) Context {
 		TenantID:                   string(c.TenantID),
 		Name:                       string(c.Name),
 		Description:                string(fx.GetOrDefault(c.Description, domain.GameContextDescription(""))),
+		State:                      string(c.State),
+		ScheduledActivationAt:      xtime.ToTimePointer(c.ScheduledActivationAt),
+		ScheduledDeactivationAt:    xtime.ToTimePointer(c.ScheduledDeactivationAt),
 		ActivatedAt:                xtime.ToTimePointer(c.ActivatedAt),
 		DesactivatedAt:             xtime.ToTimePointer(c.DesactivatedAt),
 		TriggerGeneratedMappingAt:  xtime.ToTimePointer(c.TriggerGeneratedMappingAt),
 		TriggerImportedTLEAt:       xtime.ToTimePointer(c.TriggerImportedTLEAt),
 		TriggerImportedSatelliteAt: xtime.ToTimePointer(c.TriggerImportedSatelliteAt),
+		TlePinnedEpoch:             xtime.ToTimePointer(c.TlePinnedEpoch),
 	}
 }
 
@@ -129,30 +141,33 @@ func MapToContextTLEModel(ct domain.GameContextTLE) ContextTLE {
 
 // ContextSatellite defines the many-to-many relationship between Context and Satellite.
 type ContextSatellite struct {
-	ContextID   string    `gorm:"not null;index"` // Foreign key to Context
-	SatelliteID string    `gorm:"not null;index"` // Foreign key to Satellite
-	Context     Context   `gorm:"constraint:OnDelete:CASCADE;foreignKey:ContextID;references:ID"`
-	Satellite   Satellite `gorm:"constraint:OnDelete:CASCADE;foreignKey:SatelliteID;references:ID"`
-	LockedSince time.Time
-	LockedBy    string
+	ContextID    string    `gorm:"not null;index"` // Foreign key to Context
+	SatelliteID  string    `gorm:"not null;index"` // Foreign key to Satellite
+	Context      Context   `gorm:"constraint:OnDelete:CASCADE;foreignKey:ContextID;references:ID"`
+	Satellite    Satellite `gorm:"constraint:OnDelete:CASCADE;foreignKey:SatelliteID;references:ID"`
+	LockedSince  time.Time
+	LockedBy     string
+	RuleAssigned bool `gorm:"not null;default:false"` // Assigned by a membership rule rather than by hand
 }
 
 // MapToContextSatelliteDomain converts a ContextSatellite database model to a GameContextSatellite domain model.
 func MapToContextSatelliteDomain(cs ContextSatellite) domain.GameContextSatellite {
 	return domain.GameContextSatellite{
-		ContextID:   cs.ContextID,
-		SatelliteID: domain.SatelliteID(cs.SatelliteID),
-		Satellite:   MapToSatelliteDomain(cs.Satellite),
-		LockedSince: xtime.NewUtcTimeIgnoreZone(cs.LockedSince),
-		LockedBy: cs.LockedBy,
+		ContextID:    cs.ContextID,
+		SatelliteID:  domain.SatelliteID(cs.SatelliteID),
+		Satellite:    MapToSatelliteDomain(cs.Satellite),
+		LockedSince:  xtime.NewUtcTimeIgnoreZone(cs.LockedSince),
+		LockedBy:     cs.LockedBy,
+		RuleAssigned: cs.RuleAssigned,
 	}
 }
 
 // MapToContextSatelliteModel converts a GameContextSatellite domain model to a ContextSatellite database model.
 func MapToContextSatelliteModel(cs domain.GameContextSatellite) ContextSatellite {
 	return ContextSatellite{
-		ContextID:   cs.ContextID,
-		SatelliteID: string(cs.SatelliteID),
-		Satellite:   MapToSatelliteModel(cs.Satellite),
+		ContextID:    cs.ContextID,
+		SatelliteID:  string(cs.SatelliteID),
+		Satellite:    MapToSatelliteModel(cs.Satellite),
+		RuleAssigned: cs.RuleAssigned,
 	}
 }
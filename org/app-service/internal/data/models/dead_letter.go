@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+)
+
+// DeadLetter is the database model of an event a handler gave up on.
+type DeadLetter struct {
+	ID             string     `gorm:"type:char(36);primaryKey"`
+	EventUID       string     `gorm:"size:255;not null;index"`                   // UID of the dead-lettered event
+	EventType      string     `gorm:"size:255;not null"`                         // Event type (e.g., "SATELLITE_TLE_PROPAGATED")
+	TenantID       string     `gorm:"size:255;not null;default:'default';index"` // Tenant owning the event
+	HandlerName    string     `gorm:"size:255;not null"`                         // Handler that failed, empty when rejected before dispatch
+	Attempts       int        `gorm:"not null"`                                  // Attempts made before giving up
+	LastError      string     `gorm:"type:text"`                                 // Error of the last attempt
+	Event          string     `gorm:"type:json;not null"`                        // Event root in JSON format
+	DeadLetteredAt time.Time  `gorm:"not null;default:CURRENT_TIMESTAMP;index"`
+	RedrivenAt     *time.Time `gorm:"null"` // Last re-drive, null until re-driven
+}
+
+// MapToDeadLetterDomain converts a dead letter database model to its domain model.
+func MapToDeadLetterDomain(d DeadLetter) domain.DeadLetter {
+	return domain.DeadLetter{
+		ID:             d.ID,
+		EventUID:       d.EventUID,
+		EventType:      domain.EventType(d.EventType),
+		TenantID:       domain.TenantID(d.TenantID),
+		HandlerName:    d.HandlerName,
+		Attempts:       d.Attempts,
+		LastError:      d.LastError,
+		Event:          d.Event,
+		DeadLetteredAt: d.DeadLetteredAt,
+		RedrivenAt:     d.RedrivenAt,
+	}
+}
+
+// MapToDeadLetterModel converts a dead letter domain model to its database model.
+func MapToDeadLetterModel(d domain.DeadLetter) DeadLetter {
+	return DeadLetter{
+		ID:             d.ID,
+		EventUID:       d.EventUID,
+		EventType:      string(d.EventType),
+		TenantID:       string(d.TenantID),
+		HandlerName:    d.HandlerName,
+		Attempts:       d.Attempts,
+		LastError:      d.LastError,
+		Event:          d.Event,
+		DeadLetteredAt: d.DeadLetteredAt,
+		RedrivenAt:     d.RedrivenAt,
+	}
+}
@@ -12,11 +12,13 @@ import (
 // Event represents the database model for events.
 type Event struct {
 	ModelBase
-	EventType   string    `gorm:"size:255;not null;index"` // Event type (e.g., "SATELLITE_TLE_PROPAGATED")
-	EventUID    string    `gorm:"size:255;not null;unique"`
-	Payload     string    `gorm:"type:json;not null"` // Event payload in JSON format
-	PublishedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP"`
-	Comment     *string   `gorm:"type:text"` // Optional event comment
+	EventType     string    `gorm:"size:255;not null;index"` // Event type (e.g., "SATELLITE_TLE_PROPAGATED")
+	EventUID      string    `gorm:"size:255;not null;unique"`
+	Payload       string    `gorm:"type:json;not null"` // Event payload in JSON format
+	PublishedAt   time.Time `gorm:"not null;default:CURRENT_TIMESTAMP"`
+	Comment       *string   `gorm:"type:text"`                                 // Optional event comment
+	TenantID      string    `gorm:"size:255;not null;default:'default';index"` // Tenant owning the event
+	SchemaVersion int       `gorm:"not null;default:1"`                        // Version of the payload schema
 }
 
 // EventHandlerLog tracks when an event handler starts, ends, and the event that triggered it.
@@ -45,11 +47,13 @@ func MapToEventDomain(e Event) domain.Event {
 			IsFavourite: e.IsFavourite,
 			DisplayName: e.DisplayName,
 		},
-		EventType:   domain.EventType(e.EventType),
-		EventUID:    e.EventUID,
-		Payload:     fx.AsOption(&e.Payload),
-		PublishedAt: xtime.NewUtcTimeIgnoreZone(e.PublishedAt),
-		Comment:     fx.AsOption(e.Comment),
+		EventType:     domain.EventType(e.EventType),
+		EventUID:      e.EventUID,
+		Payload:       fx.AsOption(&e.Payload),
+		PublishedAt:   xtime.NewUtcTimeIgnoreZone(e.PublishedAt),
+		Comment:       fx.AsOption(e.Comment),
+		TenantID:      domain.TenantID(e.TenantID),
+		SchemaVersion: e.SchemaVersion,
 	}
 }
 
@@ -66,11 +70,13 @@ func MapToEventModel(e domain.Event) Event {
 			IsFavourite: e.ModelBase.IsFavourite,
 			DisplayName: e.ModelBase.DisplayName,
 		},
-		EventType:   string(e.EventType),
-		EventUID:    e.EventUID,
-		Payload:     fx.GetOrDefault(e.Payload, ""),
-		PublishedAt: e.PublishedAt.Inner(),
-		Comment:     fx.ConvertToStrPtr(e.Comment),
+		EventType:     string(e.EventType),
+		EventUID:      e.EventUID,
+		Payload:       fx.GetOrDefault(e.Payload, ""),
+		PublishedAt:   e.PublishedAt.Inner(),
+		Comment:       fx.ConvertToStrPtr(e.Comment),
+		TenantID:      string(e.TenantID),
+		SchemaVersion: e.SchemaVersion,
 	}
 }
 
@@ -9,11 +9,15 @@ import (
 // TileSatelliteMapping defines the relationship between a satellite and a tile.
 type TileSatelliteMapping struct {
 	ModelBase
+	ContextID             string    `gorm:"type:char(36);not null;index"`                  // Foreign key to Context table
 	SpaceID               string    `gorm:"size:255;not null;index"`                       // Foreign key to Satellite table via SPACE ID
 	TileID                string    `gorm:"type:char(36);not null;uniqueIndex:norad_tile"` // Foreign key to Tile table
 	IntersectionLatitude  float64   `gorm:"type:double precision;not null;"`               // Latitude of the intersection point
 	IntersectionLongitude float64   `gorm:"type:double precision;not null;"`               // Longitude of the intersection point
 	IntersectedAt         time.Time `gorm:"not null"`                                      // Time of intersection
+	EnteredAt             time.Time `gorm:"null;index"`                                    // Time the track enters the tile
+	ExitedAt              time.Time `gorm:"null;index"`                                    // Time the track leaves the tile
+	DurationSeconds       float64   `gorm:"type:double precision;null"`                    // Time spent over the tile
 	ComputationID         string    `gorm:"size:36;not null;index"`                        // Foreign key to Computation table
 }
 
@@ -30,11 +34,15 @@ func MapToTileSatelliteMappingDomain(t TileSatelliteMapping) (domain.TileSatelli
 			IsFavourite: t.IsFavourite,
 			DisplayName: t.DisplayName,
 		},
+		ContextID:             t.ContextID,
 		SpaceID:               t.SpaceID,
 		TileID:                t.TileID,
 		IntersectionLatitude:  t.IntersectionLatitude,
 		IntersectionLongitude: t.IntersectionLongitude,
 		IntersectedAt:         t.IntersectedAt,
+		EnteredAt:             t.EnteredAt,
+		ExitedAt:              t.ExitedAt,
+		DurationSeconds:       t.DurationSeconds,
 		ComputationID:         t.ComputationID,
 	}, nil
 }
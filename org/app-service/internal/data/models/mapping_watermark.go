@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// MappingWatermark is the time up to which the mappings of a satellite in a context were computed.
+// It is written in the transaction storing the mappings, so both always agree.
+type MappingWatermark struct {
+	ContextID string    `gorm:"type:char(36);primaryKey"`
+	SpaceID   string    `gorm:"size:255;primaryKey"`
+	Watermark time.Time `gorm:"not null"`
+	UpdatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP"`
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+)
+
+// OutboxMessage is the database model of an event waiting in the transactional outbox.
+type OutboxMessage struct {
+	Sequence      int64      `gorm:"primaryKey;autoIncrement"`                     // Publication order
+	EventUID      string     `gorm:"size:255;not null;unique"`                     // UID of the stored event
+	AggregateID   string     `gorm:"size:255;not null;index:idx_outbox_aggregate"` // Events of an aggregate are published in order
+	Kind          string     `gorm:"size:32;not null;default:'event'"`             // How the relay publishes the message
+	EventType     string     `gorm:"size:255;not null"`                            // Event type (e.g., "SATELLITE_TLE_PROPAGATED")
+	TenantID      string     `gorm:"size:255;not null;default:'default'"`          // Tenant owning the event
+	Event         string     `gorm:"type:json;not null"`                           // Event root in JSON format
+	Attempts      int        `gorm:"not null;default:0"`                           // Failed publication attempts
+	NextAttemptAt time.Time  `gorm:"not null;default:CURRENT_TIMESTAMP"`           // Earliest next publication attempt
+	LastError     string     `gorm:"type:text"`                                    // Error of the last failed attempt
+	CreatedAt     time.Time  `gorm:"not null;default:CURRENT_TIMESTAMP"`           // Time the event was stored
+	SentAt        *time.Time `gorm:"null;index:idx_outbox_pending"`                // Publication time, null while pending
+	ParkedAt      *time.Time `gorm:"null"`                                         // Time the relay gave up on the message
+}
+
+// MapToOutboxMessageDomain converts an outbox message database model to its domain model.
+func MapToOutboxMessageDomain(m OutboxMessage) domain.OutboxMessage {
+	return domain.OutboxMessage{
+		Sequence:      m.Sequence,
+		EventUID:      m.EventUID,
+		AggregateID:   m.AggregateID,
+		Kind:          domain.OutboxMessageKind(m.Kind),
+		EventType:     domain.EventType(m.EventType),
+		TenantID:      domain.TenantID(m.TenantID),
+		Event:         m.Event,
+		Attempts:      m.Attempts,
+		NextAttemptAt: m.NextAttemptAt,
+		LastError:     m.LastError,
+		CreatedAt:     m.CreatedAt,
+		SentAt:        m.SentAt,
+		ParkedAt:      m.ParkedAt,
+	}
+}
+
+// MapToOutboxMessageModel converts an outbox message domain model to its database model.
+func MapToOutboxMessageModel(m domain.OutboxMessage) OutboxMessage {
+	return OutboxMessage{
+		Sequence:      m.Sequence,
+		EventUID:      m.EventUID,
+		AggregateID:   m.AggregateID,
+		Kind:          string(m.Kind),
+		EventType:     string(m.EventType),
+		TenantID:      string(m.TenantID),
+		Event:         m.Event,
+		Attempts:      m.Attempts,
+		NextAttemptAt: m.NextAttemptAt,
+		LastError:     m.LastError,
+		CreatedAt:     m.CreatedAt,
+		SentAt:        m.SentAt,
+		ParkedAt:      m.ParkedAt,
+	}
+}
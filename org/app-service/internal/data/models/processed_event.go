@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// ProcessedEvent is the database model of an event claimed by an exactly-once-effect handler.
+type ProcessedEvent struct {
+	EventUID    string    `gorm:"size:255;primaryKey"` // UID of the processed event
+	HandlerName string    `gorm:"size:255;primaryKey"` // Handler that processed it
+	ProcessedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP;index"`
+}
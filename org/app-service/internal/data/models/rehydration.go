@@ -0,0 +1,86 @@
+package models
+
+import (
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+)
+
+// ContextRehydration is the database model of the rehydration of a context.
+type ContextRehydration struct {
+	ContextID   string     `gorm:"type:char(36);primaryKey"`
+	ContextName string     `gorm:"size:255;not null"`                   // Name of the context when the rehydration started
+	TenantID    string     `gorm:"size:255;not null;default:'default'"` // Tenant owning the context
+	EventUID    string     `gorm:"size:255;not null"`                   // UID of the requesting event
+	Status      string     `gorm:"size:50;not null;index"`              // RUNNING, SUCCEEDED, FAILED or TIMED_OUT
+	StartTime   time.Time  `gorm:"not null"`                            // Simulated time positions and mappings start from
+	StartedAt   time.Time  `gorm:"not null"`
+	Deadline    time.Time  `gorm:"not null;index"` // Steps not done by then fail
+	CompletedAt *time.Time `gorm:"null"`
+	Reason      string     `gorm:"type:text"`
+	Context     Context    `gorm:"constraint:OnDelete:CASCADE;foreignKey:ContextID;references:ID"`
+	UpdatedAt   time.Time
+}
+
+// ContextRehydrationStep is the database model of the rehydration of a satellite of a context.
+type ContextRehydrationStep struct {
+	ContextID string    `gorm:"type:char(36);primaryKey"`
+	SpaceID   string    `gorm:"size:255;primaryKey"`
+	Status    string    `gorm:"size:50;not null"` // REQUESTED, PROPAGATED, MAPPED or FAILED
+	Error     string    `gorm:"type:text"`
+	Context   Context   `gorm:"constraint:OnDelete:CASCADE;foreignKey:ContextID;references:ID"`
+	UpdatedAt time.Time `gorm:"not null"`
+}
+
+// MapToRehydrationDomain converts a rehydration and its steps to a Rehydration domain model.
+func MapToRehydrationDomain(r ContextRehydration, steps []ContextRehydrationStep) domain.Rehydration {
+	rehydration := domain.Rehydration{
+		ContextID:   r.ContextID,
+		ContextName: domain.GameContextName(r.ContextName),
+		TenantID:    domain.TenantID(r.TenantID),
+		EventUID:    r.EventUID,
+		Status:      domain.RehydrationStatus(r.Status),
+		StartTime:   r.StartTime.UTC(),
+		StartedAt:   r.StartedAt.UTC(),
+		Deadline:    r.Deadline.UTC(),
+		CompletedAt: r.CompletedAt,
+		Reason:      r.Reason,
+		Steps:       make([]domain.RehydrationStep, len(steps)),
+	}
+	for i, step := range steps {
+		rehydration.Steps[i] = domain.RehydrationStep{
+			SpaceID:   step.SpaceID,
+			Status:    domain.RehydrationStepStatus(step.Status),
+			Error:     step.Error,
+			UpdatedAt: step.UpdatedAt.UTC(),
+		}
+	}
+	return rehydration
+}
+
+// MapToRehydrationModel converts a Rehydration domain model to its database models.
+func MapToRehydrationModel(r domain.Rehydration) (ContextRehydration, []ContextRehydrationStep) {
+	rehydration := ContextRehydration{
+		ContextID:   r.ContextID,
+		ContextName: string(r.ContextName),
+		TenantID:    string(r.TenantID),
+		EventUID:    r.EventUID,
+		Status:      string(r.Status),
+		StartTime:   r.StartTime,
+		StartedAt:   r.StartedAt,
+		Deadline:    r.Deadline,
+		CompletedAt: r.CompletedAt,
+		Reason:      r.Reason,
+	}
+	steps := make([]ContextRehydrationStep, len(r.Steps))
+	for i, step := range r.Steps {
+		steps[i] = ContextRehydrationStep{
+			ContextID: r.ContextID,
+			SpaceID:   step.SpaceID,
+			Status:    string(step.Status),
+			Error:     step.Error,
+			UpdatedAt: step.UpdatedAt,
+		}
+	}
+	return rehydration, steps
+}
@@ -11,21 +11,22 @@ import (
 // Satellite represents a satellite database model.
 type Satellite struct {
 	ModelBase
-	Name           string     `gorm:"size:255;not null"`        // Satellite name
-	SpaceID        string     `gorm:"size:255;unique;not null"` // SPACE ID
-	Type           string     `gorm:"size:255"`                 // Satellite type (e.g., telescope, communication)
-	LaunchDate     *time.Time `gorm:"type:date"`                // Launch date
-	DecayDate      *time.Time `gorm:"type:date"`                // Decay date (optional)
-	IntlDesignator string     `gorm:"size:255"`                 // International designator
-	Owner          string     `gorm:"size:255"`                 // Ownership information
-	ObjectType     string     `gorm:"size:255"`                 // Object type (e.g., "PAYLOAD")
-	Period         *float64   `gorm:"type:float"`               // Orbital period in minutes (optional)
-	Inclination    *float64   `gorm:"type:float"`               // Orbital inclination in degrees (optional)
-	Apogee         *float64   `gorm:"type:float"`               // Apogee altitude in kilometers (optional)
-	Perigee        *float64   `gorm:"type:float"`               // Perigee altitude in kilometers (optional)
-	RCS            *float64   `gorm:"type:float"`               // Radar cross-section in square meters (optional)
-	Altitude       *float64   `gorm:"type:float"`               // Altitude in kilometers (optional)
-	OrbitType      string     `gorm:"size:255;not null"`
+	Name            string     `gorm:"size:255;not null"`        // Satellite name
+	SpaceID         string     `gorm:"size:255;unique;not null"` // SPACE ID
+	Type            string     `gorm:"size:255"`                 // Satellite type (e.g., telescope, communication)
+	LaunchDate      *time.Time `gorm:"type:date"`                // Launch date
+	DecayDate       *time.Time `gorm:"type:date"`                // Decay date (optional)
+	IntlDesignator  string     `gorm:"size:255"`                 // International designator
+	Owner           string     `gorm:"size:255"`                 // Ownership information
+	ObjectType      string     `gorm:"size:255"`                 // Object type (e.g., "PAYLOAD")
+	Period          *float64   `gorm:"type:float"`               // Orbital period in minutes (optional)
+	Inclination     *float64   `gorm:"type:float"`               // Orbital inclination in degrees (optional)
+	Apogee          *float64   `gorm:"type:float"`               // Apogee altitude in kilometers (optional)
+	Perigee         *float64   `gorm:"type:float"`               // Perigee altitude in kilometers (optional)
+	RCS             *float64   `gorm:"type:float"`               // Radar cross-section in square meters (optional)
+	Altitude        *float64   `gorm:"type:float"`               // Altitude in kilometers (optional)
+	OrbitType       string     `gorm:"size:255;not null"`
+	SensorHalfAngle *float64   `gorm:"type:float"` // Sensor cone half-angle in degrees (optional)
 }
 
 // MapToSatelliteDomain converts a Satellite database model to a Satellite domain model.
@@ -50,6 +51,20 @@ func MapToSatelliteDomain(s Satellite) domain.Satellite {
 	if err != nil {
 		return domain.Satellite{}
 	}
+	domainSatellite.SensorHalfAngle = fx.ConvertToFloatOption(s.SensorHalfAngle)
+	// Keep the stored identity, context associations reference it.
+	if s.ID != "" {
+		domainSatellite.ModelBase = domain.ModelBase{
+			ID:          s.ID,
+			CreatedAt:   s.CreatedAt,
+			UpdatedAt:   &s.UpdatedAt,
+			DeleteAt:    s.DeleteAt,
+			ProcessedAt: s.ProcessedAt,
+			IsActive:    s.IsActive,
+			IsFavourite: s.IsFavourite,
+			DisplayName: s.DisplayName,
+		}
+	}
 
 	return domainSatellite
 }
@@ -67,20 +82,21 @@ func MapToSatelliteModel(d domain.Satellite) Satellite {
 			IsFavourite: d.ModelBase.IsFavourite,
 			DisplayName: d.ModelBase.DisplayName,
 		},
-		Name:           d.Name,
-		SpaceID:        d.SpaceID,
-		Type:           string(d.Type),
-		LaunchDate:     xtime.ConvertToTimePtr(d.LaunchDate),
-		DecayDate:      xtime.ConvertToTimePtr(d.DecayDate),
-		IntlDesignator: d.IntlDesignator,
-		Owner:          d.Owner,
-		ObjectType:     d.ObjectType,
-		Period:         fx.ConvertToFloatPtr(d.PeriodInMinutes),
-		Inclination:    fx.ConvertToFloatPtr(d.InclinationInDegrees),
-		Apogee:         fx.ConvertToFloatPtr(d.ApogeeInKm),
-		Perigee:        fx.ConvertToFloatPtr(d.PerigeeInKm),
-		RCS:            fx.ConvertToFloatPtr(d.RCS),
-		Altitude:       fx.ConvertToFloatPtr(d.Altitude),
-		OrbitType:      string(d.OrbitType),
+		Name:            d.Name,
+		SpaceID:         d.SpaceID,
+		Type:            string(d.Type),
+		LaunchDate:      xtime.ConvertToTimePtr(d.LaunchDate),
+		DecayDate:       xtime.ConvertToTimePtr(d.DecayDate),
+		IntlDesignator:  d.IntlDesignator,
+		Owner:           d.Owner,
+		ObjectType:      d.ObjectType,
+		Period:          fx.ConvertToFloatPtr(d.PeriodInMinutes),
+		Inclination:     fx.ConvertToFloatPtr(d.InclinationInDegrees),
+		Apogee:          fx.ConvertToFloatPtr(d.ApogeeInKm),
+		Perigee:         fx.ConvertToFloatPtr(d.PerigeeInKm),
+		RCS:             fx.ConvertToFloatPtr(d.RCS),
+		Altitude:        fx.ConvertToFloatPtr(d.Altitude),
+		OrbitType:       string(d.OrbitType),
+		SensorHalfAngle: fx.ConvertToFloatPtr(d.SensorHalfAngle),
 	}
 }
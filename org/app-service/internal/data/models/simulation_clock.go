@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+)
+
+// ContextSimulationClock stores the simulation clock of a context.
+type ContextSimulationClock struct {
+	ContextID  string    `gorm:"type:char(36);primaryKey"`
+	Epoch      time.Time `gorm:"not null"`           // Simulated time at AnchoredAt
+	AnchoredAt time.Time `gorm:"not null"`           // Wall-clock time Epoch was reached
+	Speed      float64   `gorm:"not null;default:1"` // Simulated seconds per wall-clock second
+	Paused     bool      `gorm:"not null;default:false"`
+	Context    Context   `gorm:"constraint:OnDelete:CASCADE;foreignKey:ContextID;references:ID"`
+	UpdatedAt  time.Time
+}
+
+// MapToSimulationClockDomain converts a ContextSimulationClock database model to a SimulationClock domain model.
+func MapToSimulationClockDomain(c ContextSimulationClock) domain.SimulationClock {
+	return domain.SimulationClock{
+		ContextID:  c.ContextID,
+		Epoch:      c.Epoch.UTC(),
+		AnchoredAt: c.AnchoredAt.UTC(),
+		Speed:      c.Speed,
+		Paused:     c.Paused,
+	}
+}
+
+// MapToSimulationClockModel converts a SimulationClock domain model to a ContextSimulationClock database model.
+func MapToSimulationClockModel(c domain.SimulationClock) ContextSimulationClock {
+	return ContextSimulationClock{
+		ContextID:  c.ContextID,
+		Epoch:      c.Epoch,
+		AnchoredAt: c.AnchoredAt,
+		Speed:      c.Speed,
+		Paused:     c.Paused,
+	}
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+)
+
+// TaskSchedule is the database model of a cron schedule of a task.
+type TaskSchedule struct {
+	Name            string     `gorm:"size:255;primaryKey"`
+	TaskName        string     `gorm:"size:255;not null"`                     // Task run, as for app task exec
+	Cron            string     `gorm:"size:255;not null"`                     // Cron expression, in UTC
+	Args            string     `gorm:"type:json;not null;default:'{}'"`       // Task arguments in JSON format
+	Enabled         bool       `gorm:"not null;default:true"`                 // Disabled schedules never run
+	MissedRunPolicy string     `gorm:"size:50;not null;default:'skip'"`       // skip or run_once
+	JitterSeconds   int64      `gorm:"not null;default:0"`                    // Longest random delay of a run
+	NextRunAt       time.Time  `gorm:"not null;index:idx_task_schedules_due"` // Next run, jitter included
+	RunningSince    *time.Time `gorm:"null"`                                  // Start of the current run, null when idle
+	RunningOwner    string     `gorm:"size:255;not null;default:''"`          // Scheduler running the task
+	LeaseUntil      *time.Time `gorm:"null"`                                  // Expiry of the claim of the current run
+	LastRunAt       *time.Time `gorm:"null"`
+	LastStatus      string     `gorm:"size:50;not null;default:''"`
+	LastError       string     `gorm:"type:text"`
+	LastDurationMs  int64      `gorm:"not null;default:0"`
+	CreatedAt       time.Time  `gorm:"not null;default:CURRENT_TIMESTAMP"`
+	UpdatedAt       time.Time  `gorm:"not null;default:CURRENT_TIMESTAMP"`
+}
+
+// MapToTaskScheduleDomain converts a task schedule database model to its domain model.
+func MapToTaskScheduleDomain(m TaskSchedule) domain.TaskSchedule {
+	args := map[string]string{}
+	_ = json.Unmarshal([]byte(m.Args), &args)
+	return domain.TaskSchedule{
+		Name:            m.Name,
+		TaskName:        m.TaskName,
+		Cron:            m.Cron,
+		Args:            args,
+		Enabled:         m.Enabled,
+		MissedRunPolicy: domain.MissedRunPolicy(m.MissedRunPolicy),
+		Jitter:          time.Duration(m.JitterSeconds) * time.Second,
+		NextRunAt:       m.NextRunAt.UTC(),
+		RunningSince:    m.RunningSince,
+		RunningOwner:    m.RunningOwner,
+		LeaseUntil:      m.LeaseUntil,
+		LastRunAt:       m.LastRunAt,
+		LastStatus:      domain.TaskRunStatus(m.LastStatus),
+		LastError:       m.LastError,
+		LastDuration:    time.Duration(m.LastDurationMs) * time.Millisecond,
+		CreatedAt:       m.CreatedAt.UTC(),
+		UpdatedAt:       m.UpdatedAt.UTC(),
+	}
+}
+
+// MapToTaskScheduleModel converts a task schedule domain model to its database model.
+func MapToTaskScheduleModel(m domain.TaskSchedule) TaskSchedule {
+	args := "{}"
+	if len(m.Args) > 0 {
+		if encoded, err := json.Marshal(m.Args); err == nil {
+			args = string(encoded)
+		}
+	}
+	return TaskSchedule{
+		Name:            m.Name,
+		TaskName:        m.TaskName,
+		Cron:            m.Cron,
+		Args:            args,
+		Enabled:         m.Enabled,
+		MissedRunPolicy: string(m.MissedRunPolicy),
+		JitterSeconds:   int64(m.Jitter / time.Second),
+		NextRunAt:       m.NextRunAt,
+		RunningSince:    m.RunningSince,
+		RunningOwner:    m.RunningOwner,
+		LeaseUntil:      m.LeaseUntil,
+		LastRunAt:       m.LastRunAt,
+		LastStatus:      string(m.LastStatus),
+		LastError:       m.LastError,
+		LastDurationMs:  m.LastDuration.Milliseconds(),
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+	}
+}
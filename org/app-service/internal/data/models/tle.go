@@ -55,3 +55,34 @@ func MapToTLEModel(t domain.TLE) TLE {
 		Epoch:   t.Epoch,
 	}
 }
+
+// TLEVersion is an append-only record of every TLE ever ingested, one row per satellite and epoch.
+type TLEVersion struct {
+	ID        string    `gorm:"type:char(36);primary_key;"`
+	SpaceID   string    `gorm:"size:255;not null;uniqueIndex:idx_tle_versions_space_epoch"`
+	Epoch     time.Time `gorm:"not null;uniqueIndex:idx_tle_versions_space_epoch"`
+	Line1     string    `gorm:"size:255;not null"`
+	Line2     string    `gorm:"size:255;not null"`
+	CreatedAt time.Time
+}
+
+// ContextTLEPin records the TLE version a context uses for a satellite while its TLEs are pinned.
+type ContextTLEPin struct {
+	ContextID    string     `gorm:"size:255;primaryKey"` // Foreign key to Context
+	SpaceID      string     `gorm:"size:255;primaryKey"` // SPACE ID of the pinned satellite
+	TLEVersionID string     `gorm:"not null;index"`      // Foreign key to TLEVersion
+	Context      Context    `gorm:"constraint:OnDelete:CASCADE;foreignKey:ContextID;references:ID"`
+	TLEVersion   TLEVersion `gorm:"constraint:OnDelete:RESTRICT;foreignKey:TLEVersionID;references:ID"`
+}
+
+// MapTLEVersionToDomain converts a TLEVersion to a domain.TLE.
+func MapTLEVersionToDomain(v TLEVersion) domain.TLE {
+	return domain.TLE{
+		ModelBase: domain.ModelBase{ID: v.ID, CreatedAt: v.CreatedAt},
+		ID:        v.ID,
+		SpaceID:   v.SpaceID,
+		Line1:     v.Line1,
+		Line2:     v.Line2,
+		Epoch:     v.Epoch,
+	}
+}
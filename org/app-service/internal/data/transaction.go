@@ -0,0 +1,28 @@
+package data
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// Transaction runs fn in a database transaction. Repositories called with the ctx given to fn join the transaction
+// through Conn, so their writes commit or roll back together. Nested calls join the outer transaction.
+func (d *Database) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+	return d.DbHandler.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// Conn returns the transaction of ctx, the database handle outside a transaction.
+func (d *Database) Conn(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return d.DbHandler.WithContext(ctx)
+}
@@ -1,11 +1,15 @@
 package dependencies
 
 import (
+	"github.com/org/2112-space-lab/org/app-service/internal/clients/basemap"
+	"github.com/org/2112-space-lab/org/app-service/internal/clients/broker"
 	"github.com/org/2112-space-lab/org/app-service/internal/clients/celestrack"
 	propagator "github.com/org/2112-space-lab/org/app-service/internal/clients/propagate"
 	"github.com/org/2112-space-lab/org/app-service/internal/clients/rabbitmq"
 	"github.com/org/2112-space-lab/org/app-service/internal/clients/redis"
 	"github.com/org/2112-space-lab/org/app-service/internal/config"
+	"github.com/org/2112-space-lab/org/app-service/internal/config/constants"
+	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
 )
 
 // Clients holds all client instances
@@ -13,7 +17,8 @@ type Clients struct {
 	RedisClient      *redis.RedisClient
 	PropagatorClient *propagator.PropagatorClient
 	CelestrackClient *celestrack.CelestrackClient
-	RabbitMQClient   *rabbitmq.RabbitMQClient
+	Broker           broker.Broker
+	BasemapClient    *basemap.BasemapClient
 }
 
 // NewClients initializes and returns a Clients struct
@@ -22,15 +27,12 @@ func NewClients(env *config.SEnv) *Clients {
 	if err != nil {
 		panic("Failed to initialize Redis client")
 	}
-	rabbitMqClient, err := rabbitmq.NewRabbitMQClient(env)
-	if err != nil {
-		panic("Failed to initialize RabbitMq client")
-	}
 	return &Clients{
 		RedisClient:      redisClient,
 		PropagatorClient: propagator.NewPropagatorClient(env),
 		CelestrackClient: celestrack.NewCelestrackClient(env),
-		RabbitMQClient:   rabbitMqClient,
+		Broker:           newBroker(env, redisClient),
+		BasemapClient:    basemap.NewBasemapClient(env),
 	}
 }
 
@@ -41,3 +43,20 @@ func (c *Clients) Get(client interface{}) interface{} {
 	}
 	return client
 }
+
+// newBroker selects the message broker carrying events.
+func newBroker(env *config.SEnv, redisClient *redis.RedisClient) broker.Broker {
+	switch env.EnvVars.Broker.Type {
+	case constants.BROKER_TYPE_MEMORY:
+		log.Info("Using in-memory event broker, events stay within this process")
+		return broker.NewMemoryBroker()
+	case constants.BROKER_TYPE_REDIS:
+		log.Info("Using Redis Streams event broker")
+		return redis.NewStreamBroker(redisClient, env.EnvVars.Broker.RedisStream, env.EnvVars.Broker.RedisGroup)
+	}
+	rabbitMqClient, err := rabbitmq.NewRabbitMQClient(env)
+	if err != nil {
+		panic("Failed to initialize RabbitMq client")
+	}
+	return rabbitMqClient
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 
 	"github.com/org/2112-space-lab/org/app-service/internal/config"
+	"github.com/org/2112-space-lab/org/app-service/internal/config/constants"
 	"github.com/org/2112-space-lab/org/app-service/internal/data"
 	"github.com/org/2112-space-lab/org/app-service/internal/events"
 	event_handlers "github.com/org/2112-space-lab/org/app-service/internal/events/handlers"
 	model "github.com/org/2112-space-lab/org/app-service/internal/graphql/models/generated"
+	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
 )
 
 // Dependencies holds all dependencies in one place
@@ -24,14 +26,14 @@ func NewDependencies(ctx context.Context, env *config.SEnv) (*Dependencies, erro
 	database := data.NewDatabase()
 	clients := NewClients(env)
 
-	repositories := NewRepositories(&database, clients)
-	eventLoop := events.NewEventProcessor(repositories.EventRepo, repositories.EventHandlerRepo)
-	eventEmitter, err := events.NewEventEmitter(ctx, clients.RabbitMQClient, eventLoop)
+	repositories := NewRepositories(&database, clients, env)
+	eventLoop := events.NewEventProcessor(&repositories.EventRepo, &repositories.EventHandlerRepo)
+	eventEmitter, err := events.NewEventEmitter(ctx, clients.Broker, eventLoop, &repositories.OutboxRepo, newEnvelope(env))
 	if err != nil {
 		return &Dependencies{}, err
 	}
 	services := NewServices(repositories, clients, eventEmitter)
-	rehydrateGameContextHandler := event_handlers.NewRehydrateGameContextHandler(services.ContextService, eventEmitter, repositories.GlobalPropRepo, repositories.TleRepo)
+	rehydrateGameContextHandler := event_handlers.NewRehydrateGameContextHandler(services.ContextService, services.ClockService, eventEmitter, repositories.GlobalPropRepo, repositories.TleRepo, &services.RehydrationService)
 	eventLoop.RegisterHandler(model.EventTypeRehydrateGameContextRequested, rehydrateGameContextHandler)
 
 	return &Dependencies{
@@ -42,3 +44,19 @@ func NewDependencies(ctx context.Context, env *config.SEnv) (*Dependencies, erro
 		EventEmitter: eventEmitter,
 	}, nil
 }
+
+// newEnvelope selects the envelope of the published events.
+func newEnvelope(env *config.SEnv) events.Envelope {
+	envelope := events.Envelope{Source: env.EnvVars.Broker.EventSource}
+	switch env.EnvVars.Broker.EventEnvelope {
+	case constants.EVENT_ENVELOPE_CLOUDEVENTS_STRUCTURED:
+		log.Info("Publishing events as structured CloudEvents")
+		envelope.Mode = events.EnvelopeCloudEventsStructured
+	case constants.EVENT_ENVELOPE_CLOUDEVENTS_BINARY:
+		log.Info("Publishing events as binary CloudEvents")
+		envelope.Mode = events.EnvelopeCloudEventsBinary
+	default:
+		envelope.Mode = events.EnvelopeEventRoot
+	}
+	return envelope
+}
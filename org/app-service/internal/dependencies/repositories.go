@@ -3,38 +3,78 @@ package dependencies
 import (
 	"time"
 
+	"github.com/org/2112-space-lab/org/app-service/internal/config"
+	"github.com/org/2112-space-lab/org/app-service/internal/config/constants"
 	"github.com/org/2112-space-lab/org/app-service/internal/data"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
 	repository "github.com/org/2112-space-lab/org/app-service/internal/repositories"
+	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
 )
 
 // Repositories holds all repository instances
 type Repositories struct {
-	TleRepo          repository.TleRepository
-	SatelliteRepo    repository.SatelliteRepository
-	TileRepo         repository.TileRepository
-	MappingRepo      repository.TileSatelliteMappingRepository
-	ContextRepo      repository.ContextRepository
-	AuditRepo        repository.AuditTrailRepository
-	GlobalPropRepo   repository.GlobalPropertyRepository
-	EventRepo        repository.EventRepository
-	EventHandlerRepo repository.EventHandlerRepository
+	TleRepo              repository.TleRepository
+	SatelliteRepo        repository.SatelliteRepository
+	TileRepo             domain.TileRepository
+	MappingRepo          repository.TileSatelliteMappingRepository
+	MappingWatermarkRepo repository.MappingWatermarkRepository
+	ContextRepo          repository.ContextRepository
+	AuditRepo            repository.AuditTrailRepository
+	GlobalPropRepo       repository.GlobalPropertyRepository
+	EventRepo            repository.EventRepository
+	EventHandlerRepo     repository.EventHandlerRepository
+	CoverageCacheRepo    repository.CoverageCacheRepository
+	MembershipRuleRepo   repository.MembershipRuleRepository
+	SimulationClockRepo  repository.SimulationClockRepository
+	OutboxRepo           repository.OutboxRepository
+	DeadLetterRepo       repository.DeadLetterRepository
+	ProcessedEventRepo   repository.ProcessedEventRepository
+	RehydrationRepo      repository.RehydrationRepository
+	TaskScheduleRepo     repository.TaskScheduleRepository
+	Transactor           repository.Transactor
 }
 
 // NewRepositories initializes and returns a Repositories struct
-func NewRepositories(db *data.Database, clients *Clients) *Repositories {
+func NewRepositories(db *data.Database, clients *Clients, env *config.SEnv) *Repositories {
 	return &Repositories{
-		TleRepo:          repository.NewTLERepository(db, clients.RedisClient),
-		SatelliteRepo:    repository.NewSatelliteRepository(db, clients.RedisClient, time.Hour*24),
-		TileRepo:         repository.NewTileRepository(db),
-		MappingRepo:      repository.NewTileSatelliteMappingRepository(db),
-		ContextRepo:      repository.NewContextRepository(db),
-		AuditRepo:        repository.NewAuditTrailRepository(db),
-		GlobalPropRepo:   repository.NewGlobalPropertyRepository(db),
-		EventRepo:        repository.NewEventRepository(db),
-		EventHandlerRepo: repository.NewEventHandlerRepository(db),
+		TleRepo:              repository.NewTLERepository(db, clients.RedisClient),
+		SatelliteRepo:        repository.NewSatelliteRepository(db, clients.RedisClient, time.Hour*24),
+		TileRepo:             newTileRepository(db, env),
+		MappingRepo:          repository.NewTileSatelliteMappingRepository(db),
+		MappingWatermarkRepo: repository.NewMappingWatermarkRepository(db),
+		ContextRepo:          repository.NewContextRepository(db),
+		AuditRepo:            repository.NewAuditTrailRepository(db),
+		GlobalPropRepo:       repository.NewGlobalPropertyRepository(db),
+		EventRepo:            repository.NewEventRepository(db),
+		EventHandlerRepo:     repository.NewEventHandlerRepository(db),
+		CoverageCacheRepo:    repository.NewCoverageCacheRepository(clients.RedisClient, time.Hour*6),
+		MembershipRuleRepo:   repository.NewMembershipRuleRepository(db),
+		SimulationClockRepo:  repository.NewSimulationClockRepository(db),
+		OutboxRepo:           repository.NewOutboxRepository(db),
+		DeadLetterRepo:       repository.NewDeadLetterRepository(db),
+		ProcessedEventRepo:   repository.NewProcessedEventRepository(db),
+		RehydrationRepo:      repository.NewRehydrationRepository(db),
+		TaskScheduleRepo:     repository.NewTaskScheduleRepository(db),
+		Transactor:           repository.NewTransactor(db),
 	}
 }
 
+// newTileRepository selects the spatial engine backing tile queries.
+func newTileRepository(db *data.Database, env *config.SEnv) domain.TileRepository {
+	postgis := repository.NewTileRepository(db)
+	if env.EnvVars.Spatial.Engine == constants.SPATIAL_ENGINE_MEMORY {
+		ttl, err := time.ParseDuration(env.EnvVars.Spatial.MemoryTTL)
+		if err != nil {
+			log.Warnf("Invalid SPATIAL_MEMORY_TTL [%s], using %s: %v", env.EnvVars.Spatial.MemoryTTL, constants.DEFAULT_SPATIAL_MEMORY_TTL, err)
+			ttl, _ = time.ParseDuration(constants.DEFAULT_SPATIAL_MEMORY_TTL)
+		}
+		log.Infof("Using in-memory spatial engine for tiles, reloaded every %s", ttl)
+		memory := repository.NewMemoryTileRepository(&postgis, ttl)
+		return &memory
+	}
+	return &postgis
+}
+
 // Get retrieves a specific repository and panics if it's not set
 func (r *Repositories) Get(repo interface{}) interface{} {
 	if repo == nil {
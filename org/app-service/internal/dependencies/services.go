@@ -7,22 +7,49 @@ import (
 
 // Services holds all service instances
 type Services struct {
-	SatelliteService  services.SatelliteService
-	TileService       services.TileService
-	ContextService    services.ContextService
-	AuditTrailService services.AuditTrailService
-	TleService        services.TleService
+	SatelliteService     services.SatelliteService
+	TileService          services.TileService
+	ContextService       services.ContextService
+	AuditTrailService    services.AuditTrailService
+	TleService           services.TleService
+	GeoExportService     services.GeoExportService
+	BasemapService       services.BasemapService
+	CoverageService      services.CoverageService
+	LifecycleService     services.ContextLifecycleService
+	ContextBundleService services.ContextBundleService
+	MembershipService    services.MembershipService
+	ClockService         services.SimulationClockService
+	OutboxRelayService   services.OutboxRelayService
+	DeadLetterService    services.DeadLetterService
+	EventReplayService   services.EventReplayService
+	EventHistoryService  services.EventHistoryService
+	RehydrationService   services.RehydrationService
+	TaskScheduleService  services.TaskScheduleService
 }
 
 // NewServices initializes and returns a Services struct
 func NewServices(repos *Repositories, clients *Clients, emitter *events.EventEmitter) *Services {
-	return &Services{
-		SatelliteService:  services.NewSatelliteService(repos.TleRepo, clients.PropagatorClient, clients.CelestrackClient, repos.SatelliteRepo),
-		TileService:       services.NewTileService(repos.TileRepo, repos.TleRepo, repos.SatelliteRepo, repos.MappingRepo),
-		ContextService:    services.NewContextService(repos.ContextRepo, emitter),
-		AuditTrailService: services.NewAuditTrailService(repos.AuditRepo),
-		TleService:        services.NewTleService(clients.CelestrackClient, repos.TleRepo, &repos.ContextRepo),
+	s := &Services{
+		SatelliteService:     services.NewSatelliteService(repos.TleRepo, clients.PropagatorClient, clients.CelestrackClient, repos.SatelliteRepo),
+		ContextService:       services.NewContextService(&repos.ContextRepo, emitter),
+		AuditTrailService:    services.NewAuditTrailService(repos.AuditRepo),
+		TleService:           services.NewTleService(clients.CelestrackClient, repos.TleRepo, &repos.ContextRepo),
+		GeoExportService:     services.NewGeoExportService(repos.TileRepo, repos.MappingRepo, repos.TleRepo, repos.SatelliteRepo),
+		BasemapService:       services.NewBasemapService(clients.BasemapClient),
+		CoverageService:      services.NewCoverageService(repos.TileRepo, &repos.MappingRepo, repos.SatelliteRepo, &repos.CoverageCacheRepo),
+		ContextBundleService: services.NewContextBundleService(&repos.ContextRepo, &repos.SatelliteRepo, &repos.TleRepo, repos.TileRepo, &repos.MappingRepo, &repos.Transactor),
+		MembershipService:    services.NewMembershipService(repos.MembershipRuleRepo, repos.ContextRepo, repos.SatelliteRepo, emitter),
+		ClockService:         services.NewSimulationClockService(repos.SimulationClockRepo, repos.ContextRepo, repos.GlobalPropRepo),
+		OutboxRelayService:   services.NewOutboxRelayService(&repos.OutboxRepo, emitter, &repos.TleRepo, repos.GlobalPropRepo),
+		DeadLetterService:    services.NewDeadLetterService(&repos.DeadLetterRepo, emitter),
+		EventReplayService:   services.NewEventReplayService(repos.EventRepo, emitter),
+		EventHistoryService:  services.NewEventHistoryService(repos.EventRepo, repos.EventHandlerRepo),
+		TaskScheduleService:  services.NewTaskScheduleService(&repos.TaskScheduleRepo, repos.GlobalPropRepo),
 	}
+	s.TileService = services.NewTileService(repos.TileRepo, repos.TleRepo, repos.SatelliteRepo, repos.MappingRepo, repos.MappingWatermarkRepo, repos.GlobalPropRepo, &repos.Transactor, &s.CoverageService)
+	s.LifecycleService = services.NewContextLifecycleService(&s.ContextService, &repos.ContextRepo, &s.MembershipService, &s.TleService, &repos.TleRepo, &s.SatelliteService, &repos.GlobalPropRepo)
+	s.RehydrationService = services.NewRehydrationService(&repos.RehydrationRepo, repos.ContextRepo, &s.TileService, emitter, repos.GlobalPropRepo)
+	return s
 }
 
 // Get retrieves a specific service and panics if it's not set
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/org/2112-space-lab/org/go-utils/pkg/fx/xpolygon"
+)
+
+// ContextBundleVersion is the bundle format written by exports. Imports accept this version and older ones.
+const ContextBundleVersion = 1
+
+// MaxContextBundleSize bounds the bytes read from a bundle, and the bytes it decompresses to.
+const MaxContextBundleSize int64 = 256 << 20
+
+var (
+	// ErrContextExists is returned when an import or clone targets a name already used in the tenant.
+	ErrContextExists = errors.New("context already exists")
+	// ErrUnsupportedBundle is returned for bundles of an unknown version or format.
+	ErrUnsupportedBundle = errors.New("unsupported context bundle")
+	// ErrContextBundleTooLarge is returned for bundles larger than MaxContextBundleSize.
+	ErrContextBundleTooLarge = errors.New("context bundle too large")
+)
+
+// ContextBundle is a portable copy of a game context. Tiles, satellites and mappings are identified by quadkey
+// and SPACE ID rather than database IDs, so a bundle can be imported in another environment.
+type ContextBundle struct {
+	Version    int                    `json:"version"`
+	ExportedAt time.Time              `json:"exportedAt"`
+	Context    ContextBundleContext   `json:"context"`
+	Satellites []string               `json:"satellites"` // SPACE IDs of the assigned satellites
+	TLEs       []ContextBundleTLE     `json:"tles"`
+	Tiles      []ContextBundleTile    `json:"tiles"`
+	Mappings   []ContextBundleMapping `json:"mappings,omitempty"`
+}
+
+// ContextBundleContext holds the metadata of a bundled context. Lifecycle state and schedule are environment
+// specific and not bundled: imported contexts start as drafts.
+type ContextBundleContext struct {
+	Name        GameContextName `json:"name"`
+	DisplayName string          `json:"displayName,omitempty"`
+	Description string          `json:"description,omitempty"`
+	IsFavourite bool            `json:"isFavourite,omitempty"`
+	TlePinnedAt *time.Time      `json:"tlePinnedAt,omitempty"` // Epoch of the pinned TLE snapshot, TLEs then holds the pinned versions
+}
+
+// ContextBundleTLE is a TLE pinned to a bundled context.
+type ContextBundleTLE struct {
+	ID      string    `json:"id"`
+	SpaceID string    `json:"spaceId"`
+	Line1   string    `json:"line1"`
+	Line2   string    `json:"line2"`
+	Epoch   time.Time `json:"epoch"`
+}
+
+// ContextBundleTile is a tile of a bundled context, with its geometry so it can be created where it is missing.
+type ContextBundleTile struct {
+	Quadkey   string           `json:"quadkey"`
+	ZoomLevel int              `json:"zoomLevel"`
+	CenterLat float64          `json:"centerLat"`
+	CenterLon float64          `json:"centerLon"`
+	NbFaces   int              `json:"nbFaces"`
+	Radius    float64          `json:"radius"`
+	Vertices  []xpolygon.Point `json:"vertices"`
+}
+
+// ContextBundleMapping is a satellite pass over a tile of a bundled context.
+type ContextBundleMapping struct {
+	SpaceID               string    `json:"spaceId"`
+	Quadkey               string    `json:"quadkey"`
+	IntersectionLatitude  float64   `json:"intersectionLatitude"`
+	IntersectionLongitude float64   `json:"intersectionLongitude"`
+	IntersectedAt         time.Time `json:"intersectedAt"`
+	EnteredAt             time.Time `json:"enteredAt"`
+	ExitedAt              time.Time `json:"exitedAt"`
+	DurationSeconds       float64   `json:"durationSeconds"`
+}
+
+// Validate checks that a bundle can be imported.
+func (b ContextBundle) Validate() error {
+	if b.Version < 1 || b.Version > ContextBundleVersion {
+		return fmt.Errorf("%w: version %d, expected at most %d", ErrUnsupportedBundle, b.Version, ContextBundleVersion)
+	}
+	if b.Context.Name == "" {
+		return fmt.Errorf("%w: missing context name", ErrUnsupportedBundle)
+	}
+	return nil
+}
+
+// ContextConflictStrategy selects what an import does when the tenant already has a context with the bundled name.
+type ContextConflictStrategy string
+
+const (
+	ContextConflictFail    ContextConflictStrategy = "fail"    // Refuse the import
+	ContextConflictSkip    ContextConflictStrategy = "skip"    // Keep the existing context untouched
+	ContextConflictReplace ContextConflictStrategy = "replace" // Overwrite the existing context and its associations
+	ContextConflictRename  ContextConflictStrategy = "rename"  // Import under the first free "<name>-copy-<n>" name
+)
+
+// ParseContextConflictStrategy validates a strategy name. An empty name selects ContextConflictFail.
+func ParseContextConflictStrategy(s string) (ContextConflictStrategy, error) {
+	switch strategy := ContextConflictStrategy(s); strategy {
+	case "":
+		return ContextConflictFail, nil
+	case ContextConflictFail, ContextConflictSkip, ContextConflictReplace, ContextConflictRename:
+		return strategy, nil
+	}
+	return "", fmt.Errorf("unknown conflict strategy [%s]", s)
+}
+
+// ContextImportOptions tune an import.
+type ContextImportOptions struct {
+	Name            GameContextName         // Overrides the bundled name when set
+	Strategy        ContextConflictStrategy // Applied when the name is taken
+	IncludeMappings bool                    // Imports the bundled mappings
+}
+
+// ContextImportResult reports what an import did.
+type ContextImportResult struct {
+	Context           GameContext `json:"context"`
+	Skipped           bool        `json:"skipped"`
+	Satellites        int         `json:"satellites"`
+	TLEs              int         `json:"tles"`
+	Tiles             int         `json:"tiles"`
+	Mappings          int         `json:"mappings"`
+	MissingSatellites []string    `json:"missingSatellites,omitempty"` // SPACE IDs unknown to this environment
+}
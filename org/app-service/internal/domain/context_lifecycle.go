@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// GameContextState is the lifecycle state of a context.
+type GameContextState string
+
+const (
+	GameContextStateDraft     GameContextState = "draft"     // Being set up, never run
+	GameContextStateScheduled GameContextState = "scheduled" // Waiting for its activation time
+	GameContextStateActive    GameContextState = "active"    // Running
+	GameContextStatePaused    GameContextState = "paused"    // Stopped, may be resumed
+	GameContextStateArchived  GameContextState = "archived"  // Closed for good
+)
+
+// ErrInvalidContextTransition is returned when a context cannot move from its current state to the requested one.
+var ErrInvalidContextTransition = errors.New("invalid context state transition")
+
+// ErrInvalidContextSchedule is returned when activation and deactivation times cannot be applied to a context.
+var ErrInvalidContextSchedule = errors.New("invalid context schedule")
+
+// gameContextTransitions lists the states each state may move to.
+var gameContextTransitions = map[GameContextState][]GameContextState{
+	GameContextStateDraft:     {GameContextStateScheduled, GameContextStateActive, GameContextStateArchived},
+	GameContextStateScheduled: {GameContextStateDraft, GameContextStateActive, GameContextStateArchived},
+	GameContextStateActive:    {GameContextStatePaused, GameContextStateArchived},
+	GameContextStatePaused:    {GameContextStateScheduled, GameContextStateActive, GameContextStateArchived},
+	GameContextStateArchived:  {},
+}
+
+// ParseGameContextState validates a state name.
+func ParseGameContextState(s string) (GameContextState, error) {
+	state := GameContextState(s)
+	if _, ok := gameContextTransitions[state]; !ok {
+		return "", fmt.Errorf("unknown context state [%s]", s)
+	}
+	return state, nil
+}
+
+// CanTransitionTo reports whether a context in state s may move to state to.
+func (s GameContextState) CanTransitionTo(to GameContextState) bool {
+	for _, allowed := range gameContextTransitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateTransition returns ErrInvalidContextTransition when s may not move to state to.
+func (s GameContextState) ValidateTransition(to GameContextState) error {
+	if !s.CanTransitionTo(to) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidContextTransition, s, to)
+	}
+	return nil
+}
+
+// IsRunning reports whether contexts in this state are live.
+func (s GameContextState) IsRunning() bool {
+	return s == GameContextStateActive
+}
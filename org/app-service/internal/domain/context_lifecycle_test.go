@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGameContextStateValidateTransition(t *testing.T) {
+	tests := []struct {
+		from    GameContextState
+		to      GameContextState
+		allowed bool
+	}{
+		{from: GameContextStateDraft, to: GameContextStateScheduled, allowed: true},
+		{from: GameContextStateDraft, to: GameContextStateActive, allowed: true},
+		{from: GameContextStateDraft, to: GameContextStatePaused, allowed: false},
+		{from: GameContextStateScheduled, to: GameContextStateDraft, allowed: true},
+		{from: GameContextStateScheduled, to: GameContextStateActive, allowed: true},
+		{from: GameContextStateScheduled, to: GameContextStatePaused, allowed: false},
+		{from: GameContextStateActive, to: GameContextStatePaused, allowed: true},
+		{from: GameContextStateActive, to: GameContextStateScheduled, allowed: false},
+		{from: GameContextStateActive, to: GameContextStateActive, allowed: false},
+		{from: GameContextStatePaused, to: GameContextStateScheduled, allowed: true},
+		{from: GameContextStatePaused, to: GameContextStateActive, allowed: true},
+		{from: GameContextStatePaused, to: GameContextStateDraft, allowed: false},
+		{from: GameContextStateArchived, to: GameContextStateActive, allowed: false},
+		{from: GameContextStateArchived, to: GameContextStateDraft, allowed: false},
+		{from: GameContextState("unknown"), to: GameContextStateActive, allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+" to "+string(tt.to), func(t *testing.T) {
+			err := tt.from.ValidateTransition(tt.to)
+			if tt.allowed && err != nil {
+				t.Errorf("Expected no error, but got %v", err)
+			}
+			if !tt.allowed && !errors.Is(err, ErrInvalidContextTransition) {
+				t.Errorf("Expected ErrInvalidContextTransition, but got %v", err)
+			}
+		})
+	}
+}
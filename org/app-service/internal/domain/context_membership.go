@@ -0,0 +1,170 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	fx "github.com/org/2112-space-lab/org/app-service/pkg/option"
+	xtime "github.com/org/2112-space-lab/org/app-service/pkg/time"
+	"github.com/org/2112-space-lab/org/go-utils/pkg/fx/xspace"
+)
+
+// ErrInvalidMembershipRule is returned when a membership rule cannot be saved.
+var ErrInvalidMembershipRule = errors.New("invalid membership rule")
+
+// MembershipFilters select satellites from the catalogue. Every set filter must hold for a satellite to match;
+// list filters hold when any of their values does. Satellites missing a filtered value do not match.
+type MembershipFilters struct {
+	OrbitTypes     []xspace.OrbitType       `json:"orbitTypes,omitempty"`
+	Owners         []string                 `json:"owners,omitempty"`
+	ObjectTypes    []string                 `json:"objectTypes,omitempty"`
+	NamePatterns   []string                 `json:"namePatterns,omitempty"` // Case-insensitive globs, e.g. "STARLINK-*"
+	LaunchedAfter  fx.Option[xtime.UtcTime] `json:"launchedAfter"`
+	LaunchedBefore fx.Option[xtime.UtcTime] `json:"launchedBefore"`
+	MinInclination fx.Option[float64]       `json:"minInclination"` // Degrees
+	MaxInclination fx.Option[float64]       `json:"maxInclination"`
+	MinAltitude    fx.Option[float64]       `json:"minAltitude"` // Kilometers
+	MaxAltitude    fx.Option[float64]       `json:"maxAltitude"`
+}
+
+// MembershipRule is a saved filter assigning the matching satellites to a context.
+type MembershipRule struct {
+	ID        string
+	ContextID string
+	Name      string
+	Filters   MembershipFilters
+}
+
+// Validate checks that a rule is named, filters something and has consistent ranges and patterns.
+func (r MembershipRule) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("%w: missing name", ErrInvalidMembershipRule)
+	}
+	f := r.Filters
+	if len(f.OrbitTypes) == 0 && len(f.Owners) == 0 && len(f.ObjectTypes) == 0 && len(f.NamePatterns) == 0 &&
+		!f.LaunchedAfter.HasValue && !f.LaunchedBefore.HasValue &&
+		!f.MinInclination.HasValue && !f.MaxInclination.HasValue &&
+		!f.MinAltitude.HasValue && !f.MaxAltitude.HasValue {
+		return fmt.Errorf("%w: rule %s has no filter and would match the whole catalogue", ErrInvalidMembershipRule, r.Name)
+	}
+	if f.LaunchedAfter.HasValue && f.LaunchedBefore.HasValue && f.LaunchedBefore.Value.Before(f.LaunchedAfter.Value) {
+		return fmt.Errorf("%w: launchedBefore precedes launchedAfter", ErrInvalidMembershipRule)
+	}
+	if f.MinInclination.HasValue && f.MaxInclination.HasValue && f.MaxInclination.Value < f.MinInclination.Value {
+		return fmt.Errorf("%w: maxInclination is below minInclination", ErrInvalidMembershipRule)
+	}
+	if f.MinAltitude.HasValue && f.MaxAltitude.HasValue && f.MaxAltitude.Value < f.MinAltitude.Value {
+		return fmt.Errorf("%w: maxAltitude is below minAltitude", ErrInvalidMembershipRule)
+	}
+	for _, pattern := range f.NamePatterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("%w: bad name pattern %q", ErrInvalidMembershipRule, pattern)
+		}
+	}
+	return nil
+}
+
+// Matches reports whether a satellite passes every filter of the rule.
+func (r MembershipRule) Matches(s Satellite) bool {
+	f := r.Filters
+	if len(f.OrbitTypes) > 0 && !containsFold(orbitTypeNames(f.OrbitTypes), string(s.OrbitType)) {
+		return false
+	}
+	if len(f.Owners) > 0 && !containsFold(f.Owners, s.Owner) {
+		return false
+	}
+	if len(f.ObjectTypes) > 0 && !containsFold(f.ObjectTypes, s.ObjectType) {
+		return false
+	}
+	if len(f.NamePatterns) > 0 && !matchesAnyPattern(f.NamePatterns, s.Name) {
+		return false
+	}
+	if f.LaunchedAfter.HasValue || f.LaunchedBefore.HasValue {
+		if !s.LaunchDate.HasValue {
+			return false
+		}
+		if f.LaunchedAfter.HasValue && s.LaunchDate.Value.Before(f.LaunchedAfter.Value) {
+			return false
+		}
+		if f.LaunchedBefore.HasValue && s.LaunchDate.Value.After(f.LaunchedBefore.Value) {
+			return false
+		}
+	}
+	if !inRange(s.InclinationInDegrees, f.MinInclination, f.MaxInclination) {
+		return false
+	}
+	return inRange(s.Altitude, f.MinAltitude, f.MaxAltitude)
+}
+
+// MatchesAny reports whether a satellite matches at least one of the rules.
+func MatchesAny(rules []MembershipRule, s Satellite) bool {
+	for _, rule := range rules {
+		if rule.Matches(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// MembershipChange lists the SPACE IDs of the satellites a rule evaluation added to and removed from a context.
+type MembershipChange struct {
+	Added   []string
+	Removed []string
+}
+
+// IsEmpty reports whether the evaluation left the membership unchanged.
+func (c MembershipChange) IsEmpty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0
+}
+
+// MembershipRuleRepository stores membership rules and the rule-assigned satellites of contexts.
+type MembershipRuleRepository interface {
+	SaveRule(ctx context.Context, rule MembershipRule) error
+	DeleteRule(ctx context.Context, contextID, name string) error
+	FindRulesByContext(ctx context.Context, contextID string) ([]MembershipRule, error)
+	FindContextsWithRules(ctx context.Context) ([]GameContext, error)
+	FindMembers(ctx context.Context, contextID string) ([]GameContextSatellite, error)
+	ApplyMembership(ctx context.Context, contextID string, add, remove []SatelliteID) error
+}
+
+func inRange(value fx.Option[float64], min, max fx.Option[float64]) bool {
+	if !min.HasValue && !max.HasValue {
+		return true
+	}
+	if !value.HasValue {
+		return false
+	}
+	if min.HasValue && value.Value < min.Value {
+		return false
+	}
+	return !max.HasValue || value.Value <= max.Value
+}
+
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAnyPattern(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(strings.ToUpper(pattern), strings.ToUpper(name)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func orbitTypeNames(orbitTypes []xspace.OrbitType) []string {
+	names := make([]string, len(orbitTypes))
+	for i, orbitType := range orbitTypes {
+		names[i] = string(orbitType)
+	}
+	return names
+}
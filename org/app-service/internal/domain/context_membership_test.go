@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	fx "github.com/org/2112-space-lab/org/app-service/pkg/option"
+	xtime "github.com/org/2112-space-lab/org/app-service/pkg/time"
+	"github.com/org/2112-space-lab/org/go-utils/pkg/fx/xspace"
+)
+
+func TestMembershipRuleMatches(t *testing.T) {
+	starlink := Satellite{
+		Name:                 "STARLINK-1007",
+		Owner:                "US",
+		ObjectType:           "PAYLOAD",
+		OrbitType:            xspace.OrbitTypeLEO,
+		LaunchDate:           fx.NewValueOption(xtime.NewUtcTimeIgnoreZone(time.Date(2019, 11, 11, 0, 0, 0, 0, time.UTC))),
+		InclinationInDegrees: fx.NewValueOption(53.05),
+		Altitude:             fx.NewValueOption(550.0),
+	}
+	launched := func(year int) fx.Option[xtime.UtcTime] {
+		return fx.NewValueOption(xtime.NewUtcTimeIgnoreZone(time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)))
+	}
+
+	tests := []struct {
+		name      string
+		filters   MembershipFilters
+		satellite Satellite
+		expected  bool
+	}{
+		{name: "Name pattern", filters: MembershipFilters{NamePatterns: []string{"starlink-*"}}, satellite: starlink, expected: true},
+		{name: "Other name pattern", filters: MembershipFilters{NamePatterns: []string{"ONEWEB-*"}}, satellite: starlink, expected: false},
+		{name: "Any orbit type", filters: MembershipFilters{OrbitTypes: []xspace.OrbitType{xspace.OrbitTypeMEO, xspace.OrbitTypeLEO}}, satellite: starlink, expected: true},
+		{name: "Owner and object type", filters: MembershipFilters{Owners: []string{"us"}, ObjectTypes: []string{"PAYLOAD"}}, satellite: starlink, expected: true},
+		{name: "Wrong object type", filters: MembershipFilters{Owners: []string{"US"}, ObjectTypes: []string{"DEBRIS"}}, satellite: starlink, expected: false},
+		{name: "Launch window", filters: MembershipFilters{LaunchedAfter: launched(2019), LaunchedBefore: launched(2020)}, satellite: starlink, expected: true},
+		{name: "Launched too early", filters: MembershipFilters{LaunchedAfter: launched(2020)}, satellite: starlink, expected: false},
+		{name: "Shell 1", filters: MembershipFilters{
+			NamePatterns:   []string{"STARLINK-*"},
+			MinInclination: fx.NewValueOption(52.5), MaxInclination: fx.NewValueOption(53.5),
+			MinAltitude: fx.NewValueOption(540.0), MaxAltitude: fx.NewValueOption(570.0),
+		}, satellite: starlink, expected: true},
+		{name: "Outside altitude range", filters: MembershipFilters{MaxAltitude: fx.NewValueOption(500.0)}, satellite: starlink, expected: false},
+		{name: "Missing altitude", filters: MembershipFilters{MinAltitude: fx.NewValueOption(500.0)}, satellite: Satellite{Name: "UNKNOWN"}, expected: false},
+		{name: "Missing launch date", filters: MembershipFilters{LaunchedAfter: launched(2019)}, satellite: Satellite{Name: "UNKNOWN"}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := MembershipRule{Name: "rule", Filters: tt.filters}
+			if got := rule.Matches(tt.satellite); got != tt.expected {
+				t.Errorf("Expected %v, but got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestMembershipRuleValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		rule        MembershipRule
+		expectedErr error
+	}{
+		{name: "Valid", rule: MembershipRule{Name: "starlink", Filters: MembershipFilters{NamePatterns: []string{"STARLINK-*"}}}},
+		{name: "Missing name", rule: MembershipRule{Filters: MembershipFilters{Owners: []string{"US"}}}, expectedErr: ErrInvalidMembershipRule},
+		{name: "No filter", rule: MembershipRule{Name: "all"}, expectedErr: ErrInvalidMembershipRule},
+		{name: "Inverted altitude range", rule: MembershipRule{Name: "leo", Filters: MembershipFilters{
+			MinAltitude: fx.NewValueOption(600.0), MaxAltitude: fx.NewValueOption(500.0),
+		}}, expectedErr: ErrInvalidMembershipRule},
+		{name: "Bad pattern", rule: MembershipRule{Name: "bad", Filters: MembershipFilters{NamePatterns: []string{"STARLINK-["}}}, expectedErr: ErrInvalidMembershipRule},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.rule.Validate(); !errors.Is(err, tt.expectedErr) {
+				t.Errorf("Expected %v, but got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
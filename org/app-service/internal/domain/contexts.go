@@ -18,20 +18,25 @@ type GameContext struct {
 	Name                       GameContextName
 	TenantID                   TenantID
 	Description                fx.Option[GameContextDescription]
+	State                      GameContextState
+	ScheduledActivationAt      fx.Option[xtime.UtcTime]
+	ScheduledDeactivationAt    fx.Option[xtime.UtcTime]
 	ActivatedAt                fx.Option[xtime.UtcTime]
 	DesactivatedAt             fx.Option[xtime.UtcTime]
 	TriggerGeneratedMappingAt  fx.Option[xtime.UtcTime]
 	TriggerImportedTLEAt       fx.Option[xtime.UtcTime]
 	TriggerImportedSatelliteAt fx.Option[xtime.UtcTime]
+	TlePinnedEpoch             fx.Option[xtime.UtcTime] // Epoch of the pinned TLE snapshot, latest TLEs when unset
 }
 
 // GameContextSatellite represents the relationship between Context and Satellite in the domain layer.
 type GameContextSatellite struct {
-	ContextID   string      // ID of the Context
-	SatelliteID SatelliteID // ID of the Satellite
-	Satellite   Satellite
-	LockedSince xtime.UtcTime
-	LockedBy    string
+	ContextID    string      // ID of the Context
+	SatelliteID  SatelliteID // ID of the Satellite
+	Satellite    Satellite
+	LockedSince  xtime.UtcTime
+	LockedBy     string
+	RuleAssigned bool // Assigned by a membership rule, removed when the satellite no longer matches
 }
 
 // GameContextTLE represents the relationship between Context and TLE in the domain layer.
@@ -67,7 +72,13 @@ type GameContextRepository interface {
 	ActivateContext(ctx context.Context, gameContextName GameContextName) error
 
 	// Retrieve active contexts
-	GetActiveContext(ctx context.Context) (GameContext, error)
+	FindActiveContexts(ctx context.Context) ([]GameContext, error)
+
+	// Lifecycle state machine and scheduling
+	Transition(ctx context.Context, gameContextName GameContextName, from, to GameContextState, at time.Time) error
+	SetSchedule(ctx context.Context, gameContextName GameContextName, activateAt, deactivateAt *time.Time) error
+	FindDueForActivation(ctx context.Context, before time.Time) ([]GameContext, error)
+	FindDueForDeactivation(ctx context.Context, before time.Time) ([]GameContext, error)
 
 	// Pagination with filtering
 	FindAllWithPagination(ctx context.Context, page int, pageSize int, wildcard string) ([]GameContext, error)
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// CoverageReport aggregates revisit, coverage and contribution statistics for a game context over a time window.
+type CoverageReport struct {
+	ContextID          string             `json:"contextID"`
+	From               time.Time          `json:"from"`
+	To                 time.Time          `json:"to"`
+	StepSeconds        float64            `json:"stepSeconds"`
+	TotalTiles         int                `json:"totalTiles"`
+	VisitedTiles       int                `json:"visitedTiles"`
+	MeanRevisitSeconds float64            `json:"meanRevisitSeconds"` // Mean of the per-tile mean revisit times
+	MaxGapSeconds      float64            `json:"maxGapSeconds"`      // Largest gap observed on any tile
+	Timeline           []CoverageSample   `json:"timeline"`
+	Revisits           []TileRevisitStats `json:"revisits"`
+	Contributions      []TileContribution `json:"contributions"`
+	ComputedAt         time.Time          `json:"computedAt"`
+}
+
+// CoverageSnapshot holds the tiles and satellite passes a coverage report is computed from,
+// so the report can be rebuilt when the passes of a single satellite change.
+type CoverageSnapshot struct {
+	ContextID string         `json:"contextID"`
+	From      time.Time      `json:"from"`
+	To        time.Time      `json:"to"`
+	Tiles     []CoverageTile `json:"tiles"`
+	Passes    []CoveragePass `json:"passes"`
+}
+
+// CoverageTile identifies a tile of a coverage snapshot.
+type CoverageTile struct {
+	ID      string `json:"id"`
+	Quadkey string `json:"quadkey"`
+}
+
+// CoveragePass is a single satellite access to a tile, clipped to the snapshot window.
+type CoveragePass struct {
+	TileID  string    `json:"tileID"`
+	SpaceID string    `json:"spaceID"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+}
+
+// CoverageSample is the coverage state of a context at a given instant.
+type CoverageSample struct {
+	At                time.Time `json:"at"`
+	CoveredTiles      int       `json:"coveredTiles"`      // Tiles currently seen by at least one satellite
+	Percent           float64   `json:"percent"`           // CoveredTiles / TotalTiles
+	CumulativeTiles   int       `json:"cumulativeTiles"`   // Tiles seen at least once since the window start
+	CumulativePercent float64   `json:"cumulativePercent"` // CumulativeTiles / TotalTiles
+}
+
+// TileRevisitStats describes how often a tile is revisited within the window.
+// Gaps are measured between merged passes of any satellite; MaxGapSeconds also counts
+// the leading and trailing gaps to the window bounds, so an unvisited tile reports the whole window.
+type TileRevisitStats struct {
+	TileID             string     `json:"tileID"`
+	Quadkey            string     `json:"quadkey"`
+	PassCount          int        `json:"passCount"`
+	AccessSeconds      float64    `json:"accessSeconds"`
+	MeanRevisitSeconds float64    `json:"meanRevisitSeconds"`
+	MaxGapSeconds      float64    `json:"maxGapSeconds"`
+	FirstPassAt        *time.Time `json:"firstPassAt,omitempty"`
+	LastPassAt         *time.Time `json:"lastPassAt,omitempty"`
+}
+
+// TileContribution breaks down a tile's access time per satellite.
+type TileContribution struct {
+	TileID     string                  `json:"tileID"`
+	Quadkey    string                  `json:"quadkey"`
+	Satellites []SatelliteContribution `json:"satellites"`
+}
+
+// SatelliteContribution is one satellite's share of a tile's access time.
+type SatelliteContribution struct {
+	SpaceID      string  `json:"spaceID"`
+	PassCount    int     `json:"passCount"`
+	TotalSeconds float64 `json:"totalSeconds"`
+	Share        float64 `json:"share"` // Fraction of the tile's summed per-satellite access time
+}
+
+// CoverageCacheRepository caches the coverage reports and snapshots of contexts.
+type CoverageCacheRepository interface {
+	Get(ctx context.Context, contextID string, from, to time.Time, step time.Duration) (CoverageReport, bool, error)
+	Save(ctx context.Context, report CoverageReport) error
+	Invalidate(ctx context.Context, contextID string) error
+	GetSnapshot(ctx context.Context, contextID string, from, to time.Time) (CoverageSnapshot, bool, error)
+	SaveSnapshot(ctx context.Context, snapshot CoverageSnapshot) error
+}
@@ -0,0 +1,189 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCronExpression is returned for a cron expression that cannot be parsed.
+var ErrInvalidCronExpression = errors.New("invalid cron expression")
+
+// cronMaxYears bounds the search for the next activation of an expression that never matches, like 0 0 30 2 *.
+const cronMaxYears = 5
+
+// cronMacros are the predefined schedules accepted instead of the five fields.
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronField describes the values of one of the five fields of a cron expression.
+type cronField struct {
+	name     string
+	min, max int
+	names    []string // Names of the values from min on, if any
+}
+
+var (
+	cronMinute     = cronField{name: "minute", min: 0, max: 59}
+	cronHour       = cronField{name: "hour", min: 0, max: 23}
+	cronDayOfMonth = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth      = cronField{name: "month", min: 1, max: 12,
+		names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}}
+	// Sunday is both 0 and 7.
+	cronDayOfWeek = cronField{name: "day of week", min: 0, max: 7,
+		names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}}
+)
+
+// CronSchedule is a parsed cron expression: minute, hour, day of month, month and day of week, evaluated in UTC.
+// Fields accept *, values, ranges, lists and steps, and months and days of week their English abbreviations.
+// As in cron, a time matches when its day matches the day of month or the day of week if both are restricted.
+type CronSchedule struct {
+	Expression  string
+	minutes     uint64
+	hours       uint64
+	daysOfMonth uint64
+	months      uint64
+	daysOfWeek  uint64
+	anyDay      bool // Day of month is *
+	anyWeekday  bool // Day of week is *
+}
+
+// ParseCronExpression parses a five-field cron expression or one of @yearly, @annually, @monthly, @weekly,
+// @daily, @midnight and @hourly.
+func ParseCronExpression(expression string) (CronSchedule, error) {
+	expression = strings.TrimSpace(expression)
+	fieldsExpression := expression
+	if macro, ok := cronMacros[strings.ToLower(expression)]; ok {
+		fieldsExpression = macro
+	}
+
+	fields := strings.Fields(fieldsExpression)
+	if len(fields) != 5 {
+		return CronSchedule{}, fmt.Errorf("%w: expected 5 fields, got %d in %q", ErrInvalidCronExpression, len(fields), expression)
+	}
+
+	schedule := CronSchedule{
+		Expression: expression,
+		anyDay:     fields[2] == "*" || fields[2] == "?",
+		anyWeekday: fields[4] == "*" || fields[4] == "?",
+	}
+	var err error
+	for i, target := range []struct {
+		field cronField
+		bits  *uint64
+	}{
+		{cronMinute, &schedule.minutes},
+		{cronHour, &schedule.hours},
+		{cronDayOfMonth, &schedule.daysOfMonth},
+		{cronMonth, &schedule.months},
+		{cronDayOfWeek, &schedule.daysOfWeek},
+	} {
+		if *target.bits, err = target.field.parse(fields[i]); err != nil {
+			return CronSchedule{}, err
+		}
+	}
+	if schedule.daysOfWeek&(1<<7) != 0 {
+		schedule.daysOfWeek |= 1
+	}
+	return schedule, nil
+}
+
+// Next returns the first time after t the schedule activates, at a whole minute in UTC. It returns the zero time
+// when the schedule never activates.
+func (s CronSchedule) Next(t time.Time) time.Time {
+	next := t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := next.AddDate(cronMaxYears, 0, 0)
+
+	for next.Before(limit) {
+		switch {
+		case s.months&(1<<uint(next.Month())) == 0:
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !s.matchesDay(next):
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, time.UTC)
+		case s.hours&(1<<uint(next.Hour())) == 0:
+			next = next.Truncate(time.Hour).Add(time.Hour)
+		case s.minutes&(1<<uint(next.Minute())) == 0:
+			next = next.Add(time.Minute)
+		default:
+			return next
+		}
+	}
+	return time.Time{}
+}
+
+func (s CronSchedule) matchesDay(t time.Time) bool {
+	dayOfMonth := s.daysOfMonth&(1<<uint(t.Day())) != 0
+	dayOfWeek := s.daysOfWeek&(1<<uint(t.Weekday())) != 0
+	if s.anyDay || s.anyWeekday {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
+
+// parse returns the values selected by a field as a bit set.
+func (f cronField) parse(expression string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(expression, ",") {
+		rangeExpression, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			rangeExpression = part[:i]
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("%w: invalid step in %s %q", ErrInvalidCronExpression, f.name, part)
+			}
+		}
+
+		low, high := f.min, f.max
+		switch {
+		case rangeExpression == "*" || rangeExpression == "?":
+		case strings.Contains(rangeExpression, "-"):
+			bounds := strings.SplitN(rangeExpression, "-", 2)
+			var err error
+			if low, err = f.value(bounds[0]); err != nil {
+				return 0, err
+			}
+			if high, err = f.value(bounds[1]); err != nil {
+				return 0, err
+			}
+			if high < low {
+				return 0, fmt.Errorf("%w: descending range in %s %q", ErrInvalidCronExpression, f.name, part)
+			}
+		default:
+			var err error
+			if low, err = f.value(rangeExpression); err != nil {
+				return 0, err
+			}
+			if !strings.Contains(part, "/") {
+				high = low
+			}
+		}
+
+		for value := low; value <= high; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+	return bits, nil
+}
+
+// value parses a single value of a field, a number or a name.
+func (f cronField) value(expression string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(expression, name) {
+			return f.min + i, nil
+		}
+	}
+	value, err := strconv.Atoi(expression)
+	if err != nil || value < f.min || value > f.max {
+		return 0, fmt.Errorf("%w: %s %q out of [%d, %d]", ErrInvalidCronExpression, f.name, expression, f.min, f.max)
+	}
+	return value, nil
+}
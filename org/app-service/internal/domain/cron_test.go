@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	// Thursday 1 October 2026
+	from := time.Date(2026, 10, 1, 12, 34, 56, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		expression string
+		from       time.Time
+		expected   time.Time
+	}{
+		{name: "Every minute", expression: "* * * * *", from: from, expected: time.Date(2026, 10, 1, 12, 35, 0, 0, time.UTC)},
+		{name: "Strictly after", expression: "35 12 * * *", from: time.Date(2026, 10, 1, 12, 35, 0, 0, time.UTC), expected: time.Date(2026, 10, 2, 12, 35, 0, 0, time.UTC)},
+		{name: "Step", expression: "*/15 * * * *", from: from, expected: time.Date(2026, 10, 1, 12, 45, 0, 0, time.UTC)},
+		{name: "Range with step", expression: "0 8-18/4 * * *", from: from, expected: time.Date(2026, 10, 1, 16, 0, 0, 0, time.UTC)},
+		{name: "List", expression: "0 3,15 * * *", from: from, expected: time.Date(2026, 10, 1, 15, 0, 0, 0, time.UTC)},
+		{name: "Next day", expression: "0 6 * * *", from: from, expected: time.Date(2026, 10, 2, 6, 0, 0, 0, time.UTC)},
+		{name: "Day of week name", expression: "0 0 * * MON", from: from, expected: time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)},
+		{name: "Sunday as 7", expression: "0 0 * * 7", from: from, expected: time.Date(2026, 10, 4, 0, 0, 0, 0, time.UTC)},
+		{name: "Month name", expression: "0 0 1 JAN *", from: from, expected: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "Day of month or day of week", expression: "0 0 15 * MON", from: from, expected: time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)},
+		{name: "Leap day", expression: "0 0 29 2 *", from: from, expected: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{name: "Macro", expression: "@daily", from: from, expected: time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC)},
+		{name: "Never", expression: "0 0 30 2 *", from: from, expected: time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseCronExpression(tt.expression)
+			if err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+			if next := schedule.Next(tt.from); !next.Equal(tt.expected) {
+				t.Errorf("Expected %s, but got %s", tt.expected, next)
+			}
+		})
+	}
+}
+
+func TestParseCronExpressionErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+	}{
+		{name: "Too few fields", expression: "* * * *"},
+		{name: "Too many fields", expression: "* * * * * *"},
+		{name: "Out of range", expression: "60 * * * *"},
+		{name: "Unknown name", expression: "0 0 * * FUN"},
+		{name: "Descending range", expression: "0 10-2 * * *"},
+		{name: "Zero step", expression: "*/0 * * * *"},
+		{name: "Unknown macro", expression: "@sometimes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseCronExpression(tt.expression); !errors.Is(err, ErrInvalidCronExpression) {
+				t.Errorf("Expected ErrInvalidCronExpression, but got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// DeadLetter records an event a handler could not process within its retry policy. Re-driving it publishes the
+// event again for that handler only.
+type DeadLetter struct {
+	ID             string
+	EventUID       string
+	EventType      EventType
+	TenantID       TenantID
+	HandlerName    string
+	Attempts       int
+	LastError      string
+	Event          string // EventRoot in JSON
+	DeadLetteredAt time.Time
+	RedrivenAt     *time.Time
+}
+
+// DeadLetterRepository stores dead-lettered events.
+type DeadLetterRepository interface {
+	Save(ctx context.Context, deadLetter DeadLetter) error
+	FindByID(ctx context.Context, id string) (DeadLetter, error)
+	FindPage(ctx context.Context, page, pageSize int, includeRedriven bool) ([]DeadLetter, int64, error)
+	MarkRedriven(ctx context.Context, id string, at time.Time) error
+}
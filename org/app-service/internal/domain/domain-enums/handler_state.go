@@ -48,6 +48,12 @@ func (dm handlerStates) Completed() HandlerState { return HandlerState{"Complete
 // Failed helper for value
 func (dm handlerStates) Failed() HandlerState { return HandlerState{"Failed"} }
 
+// DeadLettered helper for value
+func (dm handlerStates) DeadLettered() HandlerState { return HandlerState{"DeadLettered"} }
+
+// Skipped helper for value
+func (dm handlerStates) Skipped() HandlerState { return HandlerState{"Skipped"} }
+
 // FromString checks potential enum value
 func (dm handlerStates) FromString(s PotentialHandlerState) (HandlerState, error) {
 	switch strings.ToUpper(string(s)) {
@@ -57,6 +63,10 @@ func (dm handlerStates) FromString(s PotentialHandlerState) (HandlerState, error
 		return dm.Completed(), nil
 	case dm.Failed().upperString():
 		return dm.Failed(), nil
+	case dm.DeadLettered().upperString():
+		return dm.DeadLettered(), nil
+	case dm.Skipped().upperString():
+		return dm.Skipped(), nil
 	}
 	return dm.Unknown(), errors.New("unknown handler state [" + string(s) + "]")
 }
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"time"
+
 	domainenum "github.com/org/2112-space-lab/org/app-service/internal/domain/domain-enums"
 	fx "github.com/org/2112-space-lab/org/app-service/pkg/option"
 	xtime "github.com/org/2112-space-lab/org/app-service/pkg/time"
@@ -17,6 +19,9 @@ type Event struct {
 	Payload     fx.Option[string]
 	PublishedAt xtime.UtcTime
 	Comment     fx.Option[string]
+	TenantID    TenantID
+	// SchemaVersion is the version of the payload schema, so replays upcast payloads stored by older versions.
+	SchemaVersion int
 }
 
 // EventHandlerLog represents the execution log of an event handler.
@@ -29,3 +34,24 @@ type EventHandler struct {
 	Status      domainenum.HandlerState
 	Error       fx.Option[string]
 }
+
+// Duration returns how long the handler ran, empty while it runs.
+func (h EventHandler) Duration() fx.Option[time.Duration] {
+	if !h.CompletedAt.HasValue {
+		return fx.NewEmptyOption[time.Duration]()
+	}
+	return fx.NewValueOption(h.StartedAt.DurationUntil(h.CompletedAt.Value))
+}
+
+// EventHandlerStatusCount counts the executions of a handler in a status.
+type EventHandlerStatusCount struct {
+	HandlerName string
+	Status      domainenum.HandlerState
+	Count       int64
+}
+
+// EventHistory is a stored event with the executions of its handlers, in start order.
+type EventHistory struct {
+	Event    Event
+	Handlers []EventHandler
+}
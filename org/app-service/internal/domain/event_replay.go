@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+
+	fx "github.com/org/2112-space-lab/org/app-service/pkg/option"
+	xtime "github.com/org/2112-space-lab/org/app-service/pkg/time"
+)
+
+// ErrInvalidEventReplay is returned when a replay request cannot be run.
+var ErrInvalidEventReplay = errors.New("invalid event replay")
+
+// EventReplayFilter selects stored events of a tenant to replay. Every set criterion must hold.
+type EventReplayFilter struct {
+	EventTypes  []EventType
+	EventUIDs   []string
+	ContextName GameContextName // Matches the contextName or name field of the payload
+	From        fx.Option[xtime.UtcTime]
+	To          fx.Option[xtime.UtcTime]
+}
+
+// Validate refuses a filter selecting the whole event store and inverted time ranges.
+func (f EventReplayFilter) Validate() error {
+	if len(f.EventTypes) == 0 && len(f.EventUIDs) == 0 && f.ContextName == "" && !f.From.HasValue && !f.To.HasValue {
+		return fmt.Errorf("%w: select events by type, UID, context or time range", ErrInvalidEventReplay)
+	}
+	if f.From.HasValue && f.To.HasValue && f.To.Value.Before(f.From.Value) {
+		return fmt.Errorf("%w: to precedes from", ErrInvalidEventReplay)
+	}
+	return nil
+}
+
+// EventReplayOptions describes a replay: which events, to which handler, and how fast.
+type EventReplayOptions struct {
+	Filter        EventReplayFilter
+	HandlerName   string  // Replays to this handler only, to every registered handler when empty
+	DryRun        bool    // Lists the selected events without publishing them
+	RatePerSecond float64 // Maximum events published per second, unlimited when not positive
+	Limit         int     // Maximum events replayed
+}
+
+// EventReplayResult reports the events a replay selected and published, in publication order.
+type EventReplayResult struct {
+	DryRun      bool     `json:"dryRun"`
+	HandlerName string   `json:"handlerName,omitempty"`
+	Matched     int      `json:"matched"`
+	Replayed    int      `json:"replayed"`
+	EventUIDs   []string `json:"eventUids"`
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	fx "github.com/org/2112-space-lab/org/app-service/pkg/option"
+	xtime "github.com/org/2112-space-lab/org/app-service/pkg/time"
+)
+
+func TestEventReplayFilterValidate(t *testing.T) {
+	at := func(hour int) fx.Option[xtime.UtcTime] {
+		return fx.NewValueOption(xtime.NewUtcTimeIgnoreZone(time.Date(2026, 10, 18, hour, 0, 0, 0, time.UTC)))
+	}
+
+	tests := []struct {
+		name   string
+		filter EventReplayFilter
+		valid  bool
+	}{
+		{name: "Whole event store", filter: EventReplayFilter{}, valid: false},
+		{name: "By type", filter: EventReplayFilter{EventTypes: []EventType{"SATELLITE_TLE_PROPAGATED"}}, valid: true},
+		{name: "By UID", filter: EventReplayFilter{EventUIDs: []string{"event-uid"}}, valid: true},
+		{name: "By context", filter: EventReplayFilter{ContextName: "demo"}, valid: true},
+		{name: "Since a time", filter: EventReplayFilter{From: at(0)}, valid: true},
+		{name: "Time range", filter: EventReplayFilter{From: at(0), To: at(23)}, valid: true},
+		{name: "Inverted time range", filter: EventReplayFilter{From: at(23), To: at(0)}, valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.filter.Validate()
+			if tt.valid && err != nil {
+				t.Errorf("Expected no error, but got %v", err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidEventReplay) {
+				t.Errorf("Expected ErrInvalidEventReplay, but got %v", err)
+			}
+		})
+	}
+}
@@ -2,9 +2,13 @@ package domain
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
 	"time"
 
 	"github.com/google/uuid"
+	fx "github.com/org/2112-space-lab/org/app-service/pkg/option"
 )
 
 type MappingRepository interface {
@@ -19,22 +23,70 @@ type MappingRepository interface {
 	ListSatellitesMappingWithPagination(ctx context.Context, contextID string, page int, pageSize int, search *SearchRequest) ([]TileSatelliteInfo, int64, error)
 	GetSatelliteMappingsBySpaceID(ctx context.Context, contextID, spaceID string) ([]TileSatelliteInfo, error)
 	DeleteMappingsBySpaceID(ctx context.Context, contextID, spaceID string) error
+	FindMappingsInWindow(ctx context.Context, contextID string, filter MappingWindowFilter) ([]TileSatelliteInfo, error)
+	FindOpenMappings(ctx context.Context, contextID, spaceID string, at time.Time) ([]TileSatelliteMapping, error)
+	ApplyMappingIncrement(ctx context.Context, contextID, spaceID string, extended, added []TileSatelliteMapping) error
+	ReplaceMappings(ctx context.Context, contextID, spaceID string, from time.Time, mappings []TileSatelliteMapping) error
+	PurgeMappingsBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+// MappingMode selects how tiles are matched against a satellite track.
+type MappingMode string
+
+const (
+	// MappingModeNadir records tiles crossed by the sub-satellite point.
+	MappingModeNadir MappingMode = "nadir"
+	// MappingModeSwath records every tile inside the footprint swept along the track.
+	MappingModeSwath MappingMode = "swath"
+)
+
+// MaxMappingWindow is the longest closed window accepted by FindMappingsInWindow.
+const MaxMappingWindow = 31 * 24 * time.Hour
+
+// ErrInvalidMappingWindow is returned when a mapping window cannot be queried.
+var ErrInvalidMappingWindow = errors.New("invalid mapping window")
+
+// MappingWindowFilter selects mappings whose pass overlaps [From, To]. Unset bounds are open;
+// empty SpaceID or TileID match any satellite or tile.
+type MappingWindowFilter struct {
+	SpaceID string
+	TileID  string
+	From    fx.Option[time.Time]
+	To      fx.Option[time.Time]
+}
+
+// Validate refuses inverted windows and closed windows longer than MaxMappingWindow.
+func (f MappingWindowFilter) Validate() error {
+	if !f.From.HasValue || !f.To.HasValue {
+		return nil
+	}
+	if f.To.Value.Before(f.From.Value) {
+		return fmt.Errorf("%w: to precedes from", ErrInvalidMappingWindow)
+	}
+	if f.To.Value.Sub(f.From.Value) > MaxMappingWindow {
+		return fmt.Errorf("%w: window exceeds %s", ErrInvalidMappingWindow, MaxMappingWindow)
+	}
+	return nil
 }
 
 // TileSatelliteMapping represents the domain entity TileSatelliteMapping
 type TileSatelliteMapping struct {
 	ModelBase
+	ContextID             string // Context the mapping was computed for
 	SpaceID               string
 	TileID                string
 	IntersectionLongitude float64
 	IntersectionLatitude  float64
-	IntersectedAt         time.Time
+	IntersectedAt         time.Time // Time the track reaches the intersection point
+	EnteredAt             time.Time // Time the track enters the tile
+	ExitedAt              time.Time // Time the track leaves the tile
+	DurationSeconds       float64   // ExitedAt - EnteredAt, in seconds
 	ComputationID         string
 }
 
 // NewMapping constructor
 func NewMapping(spaceID string,
-	tileID string, intersection Point, interestedTime time.Time, createdAt time.Time, displayName string, isActive bool, isFavourite bool) TileSatelliteMapping {
+	tileID string, intersection Point, interestedTime time.Time, enteredAt time.Time, exitedAt time.Time, createdAt time.Time, displayName string, isActive bool, isFavourite bool) TileSatelliteMapping {
 
 	return TileSatelliteMapping{
 		ModelBase: ModelBase{
@@ -51,23 +103,108 @@ func NewMapping(spaceID string,
 		IntersectionLongitude: intersection.Longitude,
 		IntersectionLatitude:  intersection.Latitude,
 		IntersectedAt:         interestedTime,
+		EnteredAt:             enteredAt,
+		ExitedAt:              exitedAt,
+		DurationSeconds:       exitedAt.Sub(enteredAt).Seconds(),
 	}
 
 }
 
 // TileSatelliteInfo represents the aggregated data of a tile and satellite, sorted by AOS time.
 type TileSatelliteInfo struct {
-	MappingID     string
-	TileID        string  // The ID of the tile
-	TileQuadkey   string  // The Quadkey of the tile
-	TileCenterLat float64 // Latitude of the tile center
-	TileCenterLon float64 // Longitude of the tile center
-	TileZoomLevel int     // Zoom level of the tile
-	SpaceID       string  // The SPACE ID of the satellite
-	Intersection  Point
+	MappingID       string
+	TileID          string  // The ID of the tile
+	TileQuadkey     string  // The Quadkey of the tile
+	TileCenterLat   float64 // Latitude of the tile center
+	TileCenterLon   float64 // Longitude of the tile center
+	TileZoomLevel   int     // Zoom level of the tile
+	SpaceID         string  // The SPACE ID of the satellite
+	Intersection    Point
+	IntersectedAt   time.Time
+	EnteredAt       time.Time
+	ExitedAt        time.Time
+	DurationSeconds float64
+}
+
+// StitchMappings joins mappings computed from a track starting at watermark to the open mappings
+// of the previous computation, which were cut where its track ended. A fresh mapping entering a tile
+// at the watermark continues the open mapping of that tile: the open one is extended and returned in
+// extended. All other fresh mappings are returned in added.
+func StitchMappings(open, fresh []TileSatelliteMapping, watermark time.Time, tolerance time.Duration) (extended, added []TileSatelliteMapping) {
+	openByTile := make(map[string]TileSatelliteMapping, len(open))
+	for _, m := range open {
+		if m.ExitedAt.Before(watermark.Add(-tolerance)) {
+			continue
+		}
+		if current, ok := openByTile[m.TileID]; !ok || m.ExitedAt.After(current.ExitedAt) {
+			openByTile[m.TileID] = m
+		}
+	}
+
+	for _, m := range fresh {
+		previous, ok := openByTile[m.TileID]
+		if !ok || m.EnteredAt.After(watermark.Add(tolerance)) {
+			added = append(added, m)
+			continue
+		}
+
+		// Keep the intersection of the longer piece, which is closer to the middle of the pass.
+		if m.DurationSeconds > previous.DurationSeconds {
+			previous.IntersectionLatitude = m.IntersectionLatitude
+			previous.IntersectionLongitude = m.IntersectionLongitude
+			previous.IntersectedAt = m.IntersectedAt
+		}
+		previous.ExitedAt = m.ExitedAt
+		previous.DurationSeconds = previous.ExitedAt.Sub(previous.EnteredAt).Seconds()
+		if m.UpdatedAt != nil {
+			previous.UpdatedAt = m.UpdatedAt
+		}
+
+		extended = append(extended, previous)
+		delete(openByTile, m.TileID)
+	}
+	return extended, added
 }
 
 type Point struct {
 	Longitude float64
 	Latitude  float64
 }
+
+// TrackTimeAt interpolates the time at a fraction (0..1) of a track's planar length,
+// as returned by PostGIS ST_LineLocatePoint on the same EPSG:4326 line. Points must be sorted by Timestamp.
+func TrackTimeAt(points []SatellitePosition, fraction float64) time.Time {
+	if len(points) == 0 {
+		return time.Time{}
+	}
+	if fraction <= 0 || len(points) == 1 {
+		return points[0].Timestamp
+	}
+	if fraction >= 1 {
+		return points[len(points)-1].Timestamp
+	}
+
+	lengths := make([]float64, len(points)-1)
+	var total float64
+	for i := 0; i < len(points)-1; i++ {
+		lengths[i] = math.Hypot(points[i+1].Longitude-points[i].Longitude, points[i+1].Latitude-points[i].Latitude)
+		total += lengths[i]
+	}
+	if total == 0 {
+		return points[0].Timestamp
+	}
+
+	target := fraction * total
+	for i, l := range lengths {
+		if target <= l {
+			ratio := 0.0
+			if l > 0 {
+				ratio = target / l
+			}
+			span := points[i+1].Timestamp.Sub(points[i].Timestamp)
+			return points[i].Timestamp.Add(time.Duration(ratio * float64(span)))
+		}
+		target -= l
+	}
+	return points[len(points)-1].Timestamp
+}
@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	fx "github.com/org/2112-space-lab/org/app-service/pkg/option"
+)
+
+func TestTrackTimeAt(t *testing.T) {
+	start := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	// Two legs of equal length but unequal duration: 0..10° in 60s, then 10..20° in 120s.
+	points := []SatellitePosition{
+		{Longitude: 0, Latitude: 0, Timestamp: start},
+		{Longitude: 10, Latitude: 0, Timestamp: start.Add(60 * time.Second)},
+		{Longitude: 20, Latitude: 0, Timestamp: start.Add(180 * time.Second)},
+	}
+
+	tests := []struct {
+		name     string
+		points   []SatellitePosition
+		fraction float64
+		expected time.Time
+	}{
+		{name: "No points", points: nil, fraction: 0.5, expected: time.Time{}},
+		{name: "Single point", points: points[:1], fraction: 0.5, expected: start},
+		{name: "Start", points: points, fraction: 0, expected: start},
+		{name: "Before start", points: points, fraction: -0.1, expected: start},
+		{name: "End", points: points, fraction: 1, expected: start.Add(180 * time.Second)},
+		{name: "Past end", points: points, fraction: 1.5, expected: start.Add(180 * time.Second)},
+		{name: "Middle of first leg", points: points, fraction: 0.25, expected: start.Add(30 * time.Second)},
+		{name: "Leg boundary", points: points, fraction: 0.5, expected: start.Add(60 * time.Second)},
+		{name: "Middle of second leg", points: points, fraction: 0.75, expected: start.Add(120 * time.Second)},
+		{
+			name: "Stationary track",
+			points: []SatellitePosition{
+				{Longitude: 5, Latitude: 5, Timestamp: start},
+				{Longitude: 5, Latitude: 5, Timestamp: start.Add(time.Minute)},
+			},
+			fraction: 0.5,
+			expected: start,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TrackTimeAt(tt.points, tt.fraction); !got.Equal(tt.expected) {
+				t.Errorf("Expected %s, but got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestMappingWindowFilterValidate(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		filter  MappingWindowFilter
+		invalid bool
+	}{
+		{name: "Open window", filter: MappingWindowFilter{}},
+		{name: "Open end", filter: MappingWindowFilter{From: fx.NewValueOption(from)}},
+		{name: "Closed window", filter: MappingWindowFilter{From: fx.NewValueOption(from), To: fx.NewValueOption(from.Add(6 * time.Hour))}},
+		{name: "Instant", filter: MappingWindowFilter{From: fx.NewValueOption(from), To: fx.NewValueOption(from)}},
+		{name: "Inverted", filter: MappingWindowFilter{From: fx.NewValueOption(from), To: fx.NewValueOption(from.Add(-time.Second))}, invalid: true},
+		{name: "Too large", filter: MappingWindowFilter{From: fx.NewValueOption(from), To: fx.NewValueOption(from.Add(MaxMappingWindow + time.Second))}, invalid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.filter.Validate()
+			if tt.invalid && !errors.Is(err, ErrInvalidMappingWindow) {
+				t.Errorf("Expected ErrInvalidMappingWindow, but got %v", err)
+			}
+			if !tt.invalid && err != nil {
+				t.Errorf("Expected no error, but got %v", err)
+			}
+		})
+	}
+}
+
+func TestStitchMappings(t *testing.T) {
+	watermark := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	mapping := func(id, tileID string, enter, exit time.Duration) TileSatelliteMapping {
+		return TileSatelliteMapping{
+			ModelBase:       ModelBase{ID: id},
+			TileID:          tileID,
+			EnteredAt:       watermark.Add(enter),
+			ExitedAt:        watermark.Add(exit),
+			IntersectedAt:   watermark.Add((enter + exit) / 2),
+			DurationSeconds: (exit - enter).Seconds(),
+		}
+	}
+
+	open := []TileSatelliteMapping{
+		mapping("cut", "t1", -2*time.Minute, 0),                  // Cut at the watermark
+		mapping("closed", "t2", -10*time.Minute, -5*time.Minute), // Ended well before the watermark
+	}
+	fresh := []TileSatelliteMapping{
+		mapping("continued", "t1", 0, 3*time.Minute),           // Continues the cut pass
+		mapping("later", "t1", 30*time.Minute, 31*time.Minute), // Second pass over the same tile
+		mapping("revisit", "t2", 0, time.Minute),               // No open pass to continue
+		mapping("new", "t3", 5*time.Minute, 6*time.Minute),
+	}
+
+	extended, added := StitchMappings(open, fresh, watermark, time.Second)
+
+	if len(extended) != 1 {
+		t.Fatalf("Expected 1 extended mapping, but got %d", len(extended))
+	}
+	e := extended[0]
+	if e.ID != "cut" {
+		t.Errorf("Expected the cut mapping to be extended, but got %s", e.ID)
+	}
+	if !e.EnteredAt.Equal(watermark.Add(-2*time.Minute)) || !e.ExitedAt.Equal(watermark.Add(3*time.Minute)) {
+		t.Errorf("Expected the pass to span [-2m, 3m], but got [%s, %s]", e.EnteredAt, e.ExitedAt)
+	}
+	if e.DurationSeconds != 300 {
+		t.Errorf("Expected a 300s duration, but got %v", e.DurationSeconds)
+	}
+	// The fresh piece is longer, so its intersection is kept.
+	if !e.IntersectedAt.Equal(watermark.Add(90 * time.Second)) {
+		t.Errorf("Expected the intersection of the longer piece, but got %s", e.IntersectedAt)
+	}
+
+	var addedIDs []string
+	for _, m := range added {
+		addedIDs = append(addedIDs, m.ID)
+	}
+	expected := []string{"later", "revisit", "new"}
+	if len(addedIDs) != len(expected) {
+		t.Fatalf("Expected added %v, but got %v", expected, addedIDs)
+	}
+	for i := range expected {
+		if addedIDs[i] != expected[i] {
+			t.Errorf("Expected added %v, but got %v", expected, addedIDs)
+			break
+		}
+	}
+}
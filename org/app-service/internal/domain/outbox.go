@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// OutboxMessageKind tells the outbox relay how to publish a message.
+type OutboxMessageKind string
+
+const (
+	// OutboxKindEvent is an EventRoot published through the event emitter.
+	OutboxKindEvent OutboxMessageKind = "event"
+	// OutboxKindTLEUpdate is a stored TLE whose cache entry is refreshed and whose update is broadcast to the propagators.
+	OutboxKindTLEUpdate OutboxMessageKind = "tle_update"
+)
+
+// EventTypeTLEUpdated labels the outbox messages of kind OutboxKindTLEUpdate.
+const EventTypeTLEUpdated EventType = "SATELLITE_TLE_UPDATED"
+
+// ErrOutboxMessageMalformed is returned for a message the relay can never publish; it is parked without retry.
+var ErrOutboxMessageMalformed = errors.New("malformed outbox message")
+
+// OutboxMessage is an event stored with the state change it announces and published later by the outbox relay.
+// Messages of the same aggregate are published in the order they were stored.
+type OutboxMessage struct {
+	Sequence      int64
+	EventUID      string
+	AggregateID   string
+	Kind          OutboxMessageKind // OutboxKindEvent when empty
+	EventType     EventType
+	TenantID      TenantID
+	Event         string // EventRoot in JSON, or the payload of other kinds
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+	SentAt        *time.Time
+	ParkedAt      *time.Time // Set once the relay gave up on the message
+}
+
+// OutboxRetryDelay returns how long to wait before retrying a message after its attempts-th failure: the base delay
+// doubled on each failure, capped at maxDelay.
+func OutboxRetryDelay(attempts int, base, maxDelay time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= maxDelay {
+			return maxDelay
+		}
+	}
+	if delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
+
+// OutboxRepository stores outbox messages until they are published.
+type OutboxRepository interface {
+	Enqueue(ctx context.Context, message OutboxMessage) error
+	FindPending(ctx context.Context, limit int) ([]OutboxMessage, error)
+	MarkSent(ctx context.Context, sequence int64, sentAt time.Time) error
+	MarkFailed(ctx context.Context, sequence int64, attempts int, nextAttemptAt time.Time, lastError string) error
+	MarkParked(ctx context.Context, sequence int64, attempts int, parkedAt time.Time, lastError string) error
+	WithRelayLock(ctx context.Context, fn func(ctx context.Context) error) (bool, error)
+	PurgeSentBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOutboxRetryDelay(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts int
+		expected time.Duration
+	}{
+		{name: "First failure", attempts: 1, expected: time.Second},
+		{name: "Doubles", attempts: 2, expected: 2 * time.Second},
+		{name: "Keeps doubling", attempts: 5, expected: 16 * time.Second},
+		{name: "Capped", attempts: 10, expected: time.Minute},
+		{name: "Stays capped", attempts: 1000, expected: time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := OutboxRetryDelay(tt.attempts, time.Second, time.Minute); got != tt.expected {
+				t.Errorf("Expected %s, but got %s", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package domain
+
+import "context"
+
+// ProcessedEventRepository records which handlers processed which events, so that exactly-once-effect handlers
+// skip duplicate deliveries of an event.
+type ProcessedEventRepository interface {
+	// Claim records that handlerName processes eventUID. It returns false when the event was already claimed.
+	// Called within the transaction of the handler's effects, the claim is kept only if they are.
+	Claim(ctx context.Context, eventUID, handlerName string) (bool, error)
+}
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// RehydrationStatus is the state of the rehydration of a context.
+type RehydrationStatus string
+
+const (
+	RehydrationStatusRunning   RehydrationStatus = "RUNNING"
+	RehydrationStatusSucceeded RehydrationStatus = "SUCCEEDED"
+	RehydrationStatusFailed    RehydrationStatus = "FAILED"
+	RehydrationStatusTimedOut  RehydrationStatus = "TIMED_OUT"
+)
+
+// RehydrationStepStatus is the state of the rehydration of a satellite: its propagation is requested, then its
+// propagation completes, then its mappings are computed.
+type RehydrationStepStatus string
+
+const (
+	RehydrationStepRequested  RehydrationStepStatus = "REQUESTED"
+	RehydrationStepPropagated RehydrationStepStatus = "PROPAGATED"
+	RehydrationStepMapped     RehydrationStepStatus = "MAPPED"
+	RehydrationStepFailed     RehydrationStepStatus = "FAILED"
+)
+
+// Done reports whether a step has nothing left to do.
+func (s RehydrationStepStatus) Done() bool {
+	return s == RehydrationStepMapped || s == RehydrationStepFailed
+}
+
+// RehydrationStep tracks the rehydration of a satellite of a context.
+type RehydrationStep struct {
+	SpaceID   string
+	Status    RehydrationStepStatus
+	Error     string
+	UpdatedAt time.Time
+}
+
+// Rehydration tracks the rehydration of a context from the request to the mappings of each of its satellites.
+// A context has a single rehydration; a new request replaces the previous one.
+type Rehydration struct {
+	ContextID   string
+	ContextName GameContextName
+	TenantID    TenantID
+	EventUID    string // UID of the requesting event
+	Status      RehydrationStatus
+	StartTime   time.Time // Simulated time positions and mappings start from
+	StartedAt   time.Time
+	Deadline    time.Time // Steps not done by then fail
+	CompletedAt *time.Time
+	Reason      string
+	Steps       []RehydrationStep
+}
+
+// CountSteps returns the number of steps in status.
+func (r Rehydration) CountSteps(status RehydrationStepStatus) int {
+	count := 0
+	for _, step := range r.Steps {
+		if step.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// Done reports whether every step of the rehydration is done.
+func (r Rehydration) Done() bool {
+	for _, step := range r.Steps {
+		if !step.Status.Done() {
+			return false
+		}
+	}
+	return true
+}
+
+// PercentComplete returns the share of the work done, in percent. A step counts half once its satellite is
+// propagated and fully once it is done.
+func (r Rehydration) PercentComplete() float64 {
+	if len(r.Steps) == 0 {
+		if r.Status == RehydrationStatusRunning {
+			return 0
+		}
+		return 100
+	}
+	var done float64
+	for _, step := range r.Steps {
+		switch {
+		case step.Status.Done():
+			done += 1
+		case step.Status == RehydrationStepPropagated:
+			done += 0.5
+		}
+	}
+	return done * 100 / float64(len(r.Steps))
+}
+
+// Outcome returns the status a rehydration whose steps are all done completes with.
+func (r Rehydration) Outcome() RehydrationStatus {
+	if r.CountSteps(RehydrationStepFailed) > 0 {
+		return RehydrationStatusFailed
+	}
+	return RehydrationStatusSucceeded
+}
+
+// RehydrationRepository stores the rehydrations of contexts and their steps.
+type RehydrationRepository interface {
+	Start(ctx context.Context, rehydration Rehydration) error
+	FindByContext(ctx context.Context, contextID string) (Rehydration, error)
+	AdvanceStep(ctx context.Context, contextID, spaceID string, from []RehydrationStepStatus, to RehydrationStepStatus, stepErr string) (bool, error)
+	FindAwaitingPropagation(ctx context.Context, spaceID string) ([]string, error)
+	FailPendingSteps(ctx context.Context, contextID, stepErr string) error
+	Complete(ctx context.Context, contextID string, status RehydrationStatus, reason string, completedAt time.Time) (bool, error)
+	FindOverdue(ctx context.Context, now time.Time) ([]Rehydration, error)
+	FindStalled(ctx context.Context, before time.Time) ([]Rehydration, error)
+	FailStalledSteps(ctx context.Context, contextID string, before time.Time, stepErr string) error
+}
@@ -0,0 +1,75 @@
+package domain
+
+import "testing"
+
+func TestRehydrationProgress(t *testing.T) {
+	steps := func(statuses ...RehydrationStepStatus) []RehydrationStep {
+		result := make([]RehydrationStep, len(statuses))
+		for i, status := range statuses {
+			result[i] = RehydrationStep{SpaceID: string(rune('a' + i)), Status: status}
+		}
+		return result
+	}
+
+	tests := []struct {
+		name            string
+		rehydration     Rehydration
+		expectedPercent float64
+		expectedDone    bool
+		expectedOutcome RehydrationStatus
+	}{
+		{
+			name:            "Just requested",
+			rehydration:     Rehydration{Status: RehydrationStatusRunning, Steps: steps(RehydrationStepRequested, RehydrationStepRequested)},
+			expectedPercent: 0,
+			expectedOutcome: RehydrationStatusSucceeded,
+		},
+		{
+			name:            "Propagated counts half",
+			rehydration:     Rehydration{Status: RehydrationStatusRunning, Steps: steps(RehydrationStepPropagated, RehydrationStepRequested)},
+			expectedPercent: 25,
+			expectedOutcome: RehydrationStatusSucceeded,
+		},
+		{
+			name:            "Partly mapped",
+			rehydration:     Rehydration{Status: RehydrationStatusRunning, Steps: steps(RehydrationStepMapped, RehydrationStepPropagated, RehydrationStepRequested, RehydrationStepRequested)},
+			expectedPercent: 37.5,
+			expectedOutcome: RehydrationStatusSucceeded,
+		},
+		{
+			name:            "All mapped",
+			rehydration:     Rehydration{Status: RehydrationStatusRunning, Steps: steps(RehydrationStepMapped, RehydrationStepMapped)},
+			expectedPercent: 100,
+			expectedDone:    true,
+			expectedOutcome: RehydrationStatusSucceeded,
+		},
+		{
+			name:            "Failed steps are done",
+			rehydration:     Rehydration{Status: RehydrationStatusRunning, Steps: steps(RehydrationStepMapped, RehydrationStepFailed)},
+			expectedPercent: 100,
+			expectedDone:    true,
+			expectedOutcome: RehydrationStatusFailed,
+		},
+		{
+			name:            "No satellites",
+			rehydration:     Rehydration{Status: RehydrationStatusSucceeded},
+			expectedPercent: 100,
+			expectedDone:    true,
+			expectedOutcome: RehydrationStatusSucceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if percent := tt.rehydration.PercentComplete(); percent != tt.expectedPercent {
+				t.Errorf("Expected %v percent complete, but got %v", tt.expectedPercent, percent)
+			}
+			if done := tt.rehydration.Done(); done != tt.expectedDone {
+				t.Errorf("Expected done %v, but got %v", tt.expectedDone, done)
+			}
+			if outcome := tt.rehydration.Outcome(); outcome != tt.expectedOutcome {
+				t.Errorf("Expected outcome %s, but got %s", tt.expectedOutcome, outcome)
+			}
+		})
+	}
+}
@@ -51,6 +51,7 @@ type Satellite struct {
 	TleUpdatedAt         fx.Option[xtime.UtcTime] `gorm:"-"`
 	Altitude             fx.Option[float64]
 	OrbitType            xspace.OrbitType
+	SensorHalfAngle      fx.Option[float64] // Nadir sensor cone half-angle in degrees, used by swath mapping
 }
 
 // NewSatelliteFromParameters creates a new Satellite instance with optional SATCAT data.
@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxSimulationSpeed bounds the speed multiplier of a simulation clock.
+const MaxSimulationSpeed = 10000.0
+
+// ErrInvalidSimulationClock is returned when a simulation clock cannot be set as requested.
+var ErrInvalidSimulationClock = errors.New("invalid simulation clock")
+
+// SimulationClock maps wall-clock time to the simulated time of a context. The simulated time was Epoch at the
+// wall-clock instant AnchoredAt and advances Speed times faster than wall-clock time unless the clock is paused.
+type SimulationClock struct {
+	ContextID  string
+	Epoch      time.Time
+	AnchoredAt time.Time
+	Speed      float64
+	Paused     bool
+}
+
+// NewSimulationClock creates a running clock showing epoch at the wall-clock instant now.
+func NewSimulationClock(contextID string, epoch time.Time, speed float64, now time.Time) (SimulationClock, error) {
+	if err := validateSimulationSpeed(speed); err != nil {
+		return SimulationClock{}, err
+	}
+	return SimulationClock{
+		ContextID:  contextID,
+		Epoch:      epoch.UTC(),
+		AnchoredAt: now.UTC(),
+		Speed:      speed,
+	}, nil
+}
+
+// RealTimeClock is the clock of contexts without a simulation clock: it follows wall-clock time.
+func RealTimeClock(contextID string, now time.Time) SimulationClock {
+	return SimulationClock{ContextID: contextID, Epoch: now.UTC(), AnchoredAt: now.UTC(), Speed: 1}
+}
+
+// Now returns the simulated time at the wall-clock instant now.
+func (c SimulationClock) Now(now time.Time) time.Time {
+	if c.Paused {
+		return c.Epoch
+	}
+	elapsed := float64(now.Sub(c.AnchoredAt)) * c.Speed
+	return c.Epoch.Add(time.Duration(elapsed))
+}
+
+// WallDuration returns the wall-clock time the clock takes to advance by the simulated duration d.
+func (c SimulationClock) WallDuration(d time.Duration) time.Duration {
+	if c.Speed <= 0 {
+		return d
+	}
+	return time.Duration(float64(d) / c.Speed)
+}
+
+// Pause freezes the clock at its simulated time at now.
+func (c SimulationClock) Pause(now time.Time) SimulationClock {
+	if c.Paused {
+		return c
+	}
+	c = c.reanchor(now)
+	c.Paused = true
+	return c
+}
+
+// Resume restarts a paused clock from the simulated time it was paused at.
+func (c SimulationClock) Resume(now time.Time) SimulationClock {
+	if !c.Paused {
+		return c
+	}
+	c.AnchoredAt = now.UTC()
+	c.Paused = false
+	return c
+}
+
+// Seek moves the clock to the simulated time to, keeping its speed and paused state.
+func (c SimulationClock) Seek(to time.Time, now time.Time) SimulationClock {
+	c.Epoch = to.UTC()
+	c.AnchoredAt = now.UTC()
+	return c
+}
+
+// WithSpeed changes the speed multiplier from now on, without jumping in simulated time.
+func (c SimulationClock) WithSpeed(speed float64, now time.Time) (SimulationClock, error) {
+	if err := validateSimulationSpeed(speed); err != nil {
+		return c, err
+	}
+	c = c.reanchor(now)
+	c.Speed = speed
+	return c, nil
+}
+
+// reanchor rebases the clock on now so that later changes apply from the current simulated time.
+func (c SimulationClock) reanchor(now time.Time) SimulationClock {
+	c.Epoch = c.Now(now).UTC()
+	c.AnchoredAt = now.UTC()
+	return c
+}
+
+func validateSimulationSpeed(speed float64) error {
+	if speed <= 0 || speed > MaxSimulationSpeed {
+		return fmt.Errorf("%w: speed must be in (0, %g], got %g", ErrInvalidSimulationClock, MaxSimulationSpeed, speed)
+	}
+	return nil
+}
+
+// SimulationClockRepository stores the simulation clocks of contexts.
+type SimulationClockRepository interface {
+	FindByContext(ctx context.Context, contextID string) (clock SimulationClock, found bool, err error)
+	Save(ctx context.Context, clock SimulationClock) error
+	Delete(ctx context.Context, contextID string) error
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSimulationClockNow(t *testing.T) {
+	wall := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	epoch := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock, err := NewSimulationClock("ctx", epoch, 60, wall)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	paused := clock.Pause(wall.Add(time.Minute))
+	resumed := paused.Resume(wall.Add(time.Hour))
+	faster, err := clock.WithSpeed(120, wall.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	seeked := clock.Seek(epoch.Add(-24*time.Hour), wall.Add(time.Minute))
+
+	tests := []struct {
+		name     string
+		clock    SimulationClock
+		at       time.Time
+		expected time.Time
+	}{
+		{name: "At anchor", clock: clock, at: wall, expected: epoch},
+		{name: "Accelerated", clock: clock, at: wall.Add(time.Minute), expected: epoch.Add(time.Hour)},
+		{name: "Paused", clock: paused, at: wall.Add(time.Hour), expected: epoch.Add(time.Hour)},
+		{name: "Resumed where paused", clock: resumed, at: wall.Add(time.Hour + time.Minute), expected: epoch.Add(2 * time.Hour)},
+		{name: "Speed change keeps time", clock: faster, at: wall.Add(2 * time.Minute), expected: epoch.Add(3 * time.Hour)},
+		{name: "Seek into the past", clock: seeked, at: wall.Add(2 * time.Minute), expected: epoch.Add(-23 * time.Hour)},
+		{name: "Real time", clock: RealTimeClock("ctx", wall), at: wall.Add(time.Minute), expected: wall.Add(time.Minute)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.clock.Now(tt.at); !got.Equal(tt.expected) {
+				t.Errorf("Expected %s, but got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSimulationClockSpeed(t *testing.T) {
+	wall := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		speed float64
+		valid bool
+	}{
+		{name: "Real time", speed: 1, valid: true},
+		{name: "Slow motion", speed: 0.5, valid: true},
+		{name: "Maximum", speed: MaxSimulationSpeed, valid: true},
+		{name: "Zero", speed: 0, valid: false},
+		{name: "Reverse", speed: -1, valid: false},
+		{name: "Too fast", speed: MaxSimulationSpeed + 1, valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSimulationClock("ctx", wall, tt.speed, wall)
+			if tt.valid && err != nil {
+				t.Errorf("Expected no error, but got %v", err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidSimulationClock) {
+				t.Errorf("Expected ErrInvalidSimulationClock, but got %v", err)
+			}
+		})
+	}
+}
+
+func TestSimulationClockWallDuration(t *testing.T) {
+	wall := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	clock, _ := NewSimulationClock("ctx", wall, 60, wall)
+	if got := clock.WallDuration(time.Minute); got != time.Second {
+		t.Errorf("Expected %s, but got %s", time.Second, got)
+	}
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidTaskSchedule is returned for a task schedule that cannot be saved.
+var ErrInvalidTaskSchedule = errors.New("invalid task schedule")
+
+// ErrTaskScheduleDisabled is returned when running a disabled task schedule.
+var ErrTaskScheduleDisabled = errors.New("task schedule disabled")
+
+// MissedRunPolicy decides what happens to the runs of a schedule missed while no scheduler was running or while
+// the previous run was still running.
+type MissedRunPolicy string
+
+const (
+	// MissedRunSkip drops missed runs: the task runs next at its next activation.
+	MissedRunSkip MissedRunPolicy = "skip"
+	// MissedRunOnce catches up with missed runs by running the task once, at once.
+	MissedRunOnce MissedRunPolicy = "run_once"
+)
+
+// TaskRunStatus is the outcome of a scheduled run of a task.
+type TaskRunStatus string
+
+const (
+	TaskRunSucceeded TaskRunStatus = "SUCCEEDED"
+	TaskRunFailed    TaskRunStatus = "FAILED"
+)
+
+// TaskSchedule runs a task with its arguments on a cron schedule. A run is delayed by up to Jitter, so that
+// schedules sharing an expression do not all start at once, and never overlaps the previous run of the schedule.
+type TaskSchedule struct {
+	Name            string
+	TaskName        string
+	Cron            string
+	Args            map[string]string
+	Enabled         bool
+	MissedRunPolicy MissedRunPolicy
+	Jitter          time.Duration
+	NextRunAt       time.Time
+	RunningSince    *time.Time
+	RunningOwner    string     // Scheduler running the task
+	LeaseUntil      *time.Time // Renewed while the task runs; another scheduler may run the task from then on
+	LastRunAt       *time.Time
+	LastStatus      TaskRunStatus
+	LastError       string
+	LastDuration    time.Duration
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// TaskRun is the outcome of a scheduled run of a task.
+type TaskRun struct {
+	StartedAt time.Time
+	Duration  time.Duration
+	Status    TaskRunStatus
+	Error     string
+}
+
+// Validate checks a schedule can be saved and returns its parsed cron expression.
+func (s TaskSchedule) Validate() (CronSchedule, error) {
+	if s.Name == "" {
+		return CronSchedule{}, fmt.Errorf("%w: name is required", ErrInvalidTaskSchedule)
+	}
+	if s.TaskName == "" {
+		return CronSchedule{}, fmt.Errorf("%w: task is required", ErrInvalidTaskSchedule)
+	}
+	switch s.MissedRunPolicy {
+	case MissedRunSkip, MissedRunOnce:
+	default:
+		return CronSchedule{}, fmt.Errorf("%w: unknown missed run policy %q", ErrInvalidTaskSchedule, s.MissedRunPolicy)
+	}
+	if s.Jitter < 0 {
+		return CronSchedule{}, fmt.Errorf("%w: jitter must not be negative", ErrInvalidTaskSchedule)
+	}
+
+	cron, err := ParseCronExpression(s.Cron)
+	if err != nil {
+		return CronSchedule{}, fmt.Errorf("%w: %w", ErrInvalidTaskSchedule, err)
+	}
+	if cron.Next(time.Now()).IsZero() {
+		return CronSchedule{}, fmt.Errorf("%w: %q never activates", ErrInvalidTaskSchedule, s.Cron)
+	}
+	return cron, nil
+}
+
+// NextRun returns when a schedule with cron and jitter runs next after t: its next activation delayed by fraction of
+// the jitter, fraction being in [0, 1). It returns the zero time when the schedule never activates.
+func NextRun(cron CronSchedule, jitter time.Duration, t time.Time, fraction float64) time.Time {
+	next := cron.Next(t)
+	if next.IsZero() {
+		return next
+	}
+	return next.Add(time.Duration(float64(jitter) * fraction))
+}
+
+// Missed reports whether the due run of a schedule is late by more than grace at now.
+func (s TaskSchedule) Missed(now time.Time, grace time.Duration) bool {
+	return now.Sub(s.NextRunAt) > grace
+}
+
+// TaskCatalog knows the tasks schedules may run.
+type TaskCatalog interface {
+	// CheckSchedulable returns an error wrapping ErrInvalidTaskSchedule unless the task exists and finishes on its
+	// own: a task running until cancelled would hold its schedule forever.
+	CheckSchedulable(taskName string) error
+}
+
+// TaskScheduleRepository stores task schedules and coordinates the schedulers running them.
+type TaskScheduleRepository interface {
+	FindAll(ctx context.Context) ([]TaskSchedule, error)
+	FindByName(ctx context.Context, name string) (TaskSchedule, error)
+	Save(ctx context.Context, schedule TaskSchedule) error
+	DeleteByName(ctx context.Context, name string) error
+	FindDue(ctx context.Context, now time.Time) ([]TaskSchedule, error)
+	Claim(ctx context.Context, name string, dueAt, nextRunAt, now, leaseUntil time.Time, owner string) (bool, error)
+	Reschedule(ctx context.Context, name string, dueAt, nextRunAt time.Time) (bool, error)
+	Renew(ctx context.Context, name, owner string, leaseUntil time.Time) (bool, error)
+	Finish(ctx context.Context, name, owner string, run TaskRun) error
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTaskScheduleValidate(t *testing.T) {
+	valid := TaskSchedule{Name: "tle-refresh", TaskName: "celestrack_tle_upload", Cron: "0 */6 * * *", MissedRunPolicy: MissedRunSkip}
+
+	tests := []struct {
+		name        string
+		schedule    func(s TaskSchedule) TaskSchedule
+		expectedErr bool
+	}{
+		{name: "Valid", schedule: func(s TaskSchedule) TaskSchedule { return s }},
+		{name: "Catching up", schedule: func(s TaskSchedule) TaskSchedule { s.MissedRunPolicy = MissedRunOnce; return s }},
+		{name: "Missing name", schedule: func(s TaskSchedule) TaskSchedule { s.Name = ""; return s }, expectedErr: true},
+		{name: "Missing task", schedule: func(s TaskSchedule) TaskSchedule { s.TaskName = ""; return s }, expectedErr: true},
+		{name: "Invalid cron", schedule: func(s TaskSchedule) TaskSchedule { s.Cron = "every day"; return s }, expectedErr: true},
+		{name: "Never activates", schedule: func(s TaskSchedule) TaskSchedule { s.Cron = "0 0 31 4 *"; return s }, expectedErr: true},
+		{name: "Unknown policy", schedule: func(s TaskSchedule) TaskSchedule { s.MissedRunPolicy = "retry"; return s }, expectedErr: true},
+		{name: "Negative jitter", schedule: func(s TaskSchedule) TaskSchedule { s.Jitter = -time.Second; return s }, expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.schedule(valid).Validate()
+			if tt.expectedErr && !errors.Is(err, ErrInvalidTaskSchedule) {
+				t.Errorf("Expected ErrInvalidTaskSchedule, but got %v", err)
+			}
+			if !tt.expectedErr && err != nil {
+				t.Errorf("Expected no error, but got %v", err)
+			}
+		})
+	}
+}
+
+func TestNextRun(t *testing.T) {
+	cron, err := ParseCronExpression("0 * * * *")
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	from := time.Date(2026, 10, 1, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		jitter   time.Duration
+		fraction float64
+		expected time.Time
+	}{
+		{name: "No jitter", jitter: 0, fraction: 0.5, expected: time.Date(2026, 10, 1, 13, 0, 0, 0, time.UTC)},
+		{name: "Start of the jitter", jitter: 10 * time.Minute, fraction: 0, expected: time.Date(2026, 10, 1, 13, 0, 0, 0, time.UTC)},
+		{name: "Within the jitter", jitter: 10 * time.Minute, fraction: 0.5, expected: time.Date(2026, 10, 1, 13, 5, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if next := NextRun(cron, tt.jitter, from, tt.fraction); !next.Equal(tt.expected) {
+				t.Errorf("Expected %s, but got %s", tt.expected, next)
+			}
+		})
+	}
+}
+
+func TestTaskScheduleMissed(t *testing.T) {
+	due := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	schedule := TaskSchedule{NextRunAt: due}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected bool
+	}{
+		{name: "On time", now: due, expected: false},
+		{name: "Within grace", now: due.Add(time.Minute), expected: false},
+		{name: "Late", now: due.Add(time.Hour), expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if missed := schedule.Missed(tt.now, 2*time.Minute); missed != tt.expected {
+				t.Errorf("Expected missed %v, but got %v", tt.expected, missed)
+			}
+		})
+	}
+}
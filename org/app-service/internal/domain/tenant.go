@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// DefaultTenantID owns the data created before tenancy and the work done without an authenticated principal,
+// such as background tasks and requests when principal resolution is not required.
+const DefaultTenantID TenantID = "default"
+
+// ErrTenantMismatch is returned when a caller writes a resource on behalf of another tenant.
+var ErrTenantMismatch = errors.New("resource belongs to another tenant")
+
+type tenantContextKey struct{}
+
+// WithTenant returns a copy of ctx scoped to the given tenant. An empty tenant scopes to DefaultTenantID.
+func WithTenant(ctx context.Context, tenantID TenantID) context.Context {
+	if tenantID == "" {
+		tenantID = DefaultTenantID
+	}
+	return context.WithValue(ctx, tenantContextKey{}, tenantID)
+}
+
+// TenantFromContext returns the tenant ctx is scoped to, DefaultTenantID when none was set.
+func TenantFromContext(ctx context.Context) TenantID {
+	if tenantID, ok := ctx.Value(tenantContextKey{}).(TenantID); ok && tenantID != "" {
+		return tenantID
+	}
+	return DefaultTenantID
+}
+
+// TenantKey prefixes a cache or storage key with the tenant of ctx. Keys of the default tenant are left
+// unprefixed so data written before tenancy stays readable.
+func TenantKey(ctx context.Context, key string) string {
+	tenantID := TenantFromContext(ctx)
+	if tenantID == DefaultTenantID {
+		return key
+	}
+	return fmt.Sprintf("tenant:%s:%s", tenantID, key)
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTenantFromContext(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected TenantID
+	}{
+		{name: "Unscoped", ctx: context.Background(), expected: DefaultTenantID},
+		{name: "Empty tenant", ctx: WithTenant(context.Background(), ""), expected: DefaultTenantID},
+		{name: "Scoped", ctx: WithTenant(context.Background(), "org_alpha"), expected: "org_alpha"},
+		{name: "Rescoped", ctx: WithTenant(WithTenant(context.Background(), "org_alpha"), "org_beta"), expected: "org_beta"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TenantFromContext(tt.ctx); got != tt.expected {
+				t.Errorf("Expected %s, but got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestTenantKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		tenantID TenantID
+		expected string
+	}{
+		{name: "Default tenant", tenantID: DefaultTenantID, expected: "coverage:ctx"},
+		{name: "Alpha", tenantID: "org_alpha", expected: "tenant:org_alpha:coverage:ctx"},
+		{name: "Beta", tenantID: "org_beta", expected: "tenant:org_beta:coverage:ctx"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TenantKey(WithTenant(context.Background(), tt.tenantID), "coverage:ctx"); got != tt.expected {
+				t.Errorf("Expected %s, but got %s", tt.expected, got)
+			}
+		})
+	}
+}
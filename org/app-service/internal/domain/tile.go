@@ -10,20 +10,21 @@ import (
 
 // TileRepository defines the interface for Tile repository operations.
 type TileRepository interface {
-	FindByQuadkey(ctx context.Context, key string) (*Tile, error)                                                            // Find a tile by Quadkey
-	FindBySpatialLocation(ctx context.Context, lat, lon float64) (*Tile, error)                                              // Find a tile by spatial location
-	FindTilesInRegion(ctx context.Context, contextID string, minLat, minLon, maxLat, maxLon float64) ([]Tile, error)         // Find tiles intersecting a region
-	FindAll(ctx context.Context) ([]Tile, error)                                                                             // Retrieve all tiles
-	Save(ctx context.Context, tile Tile) error                                                                               // Save a new tile
-	Update(ctx context.Context, tile Tile) error                                                                             // Update an existing tile
-	Upsert(ctx context.Context, tile Tile) error                                                                             // Upsert (insert or update) a tile
-	DeleteByQuadkey(ctx context.Context, key string) error                                                                   // Delete a tile by Quadkey
-	DeleteBySpatialLocation(ctx context.Context, lat float64, lon float64) error                                             // Delete a tile by spatial location
-	FindTilesVisibleFromLine(ctx context.Context, sat Satellite, points []SatellitePosition) ([]TileSatelliteMapping, error) // Find tiles visible from a satellite trajectory
-	FindTilesIntersectingLocation(ctx context.Context, contextID string, lat, lon, radius float64) ([]Tile, error)           // Find tiles intersecting a location with a radius
-	AssociateTileWithContext(ctx context.Context, contextID string, tileID string) error // Associate a tile with a context
-	GetTilesByContext(ctx context.Context, contextID string) ([]Tile, error)             // Retrieve all tiles associated with a context
-	RemoveTileFromContext(ctx context.Context, contextID string, tileID string) error    // Remove a tile from a context
+	FindByQuadkey(ctx context.Context, key string) (*Tile, error)                                                                                      // Find a tile by Quadkey
+	FindBySpatialLocation(ctx context.Context, lat, lon float64) (*Tile, error)                                                                        // Find a tile by spatial location
+	FindTilesInRegion(ctx context.Context, contextID string, minLat, minLon, maxLat, maxLon float64) ([]Tile, error)                                   // Find tiles intersecting a region
+	FindAll(ctx context.Context) ([]Tile, error)                                                                                                       // Retrieve all tiles
+	Save(ctx context.Context, tile Tile) error                                                                                                         // Save a new tile
+	Update(ctx context.Context, tile Tile) error                                                                                                       // Update an existing tile
+	Upsert(ctx context.Context, tile Tile) error                                                                                                       // Upsert (insert or update) a tile
+	DeleteByQuadkey(ctx context.Context, key string) error                                                                                             // Delete a tile by Quadkey
+	DeleteBySpatialLocation(ctx context.Context, lat float64, lon float64) error                                                                       // Delete a tile by spatial location
+	FindTilesVisibleFromLine(ctx context.Context, sat Satellite, points []SatellitePosition) ([]TileSatelliteMapping, error)                           // Find tiles visible from a satellite trajectory
+	FindTilesVisibleFromSwath(ctx context.Context, sat Satellite, points []SatellitePosition, minElevationDeg float64) ([]TileSatelliteMapping, error) // Find tiles inside the footprint swept along a trajectory
+	FindTilesIntersectingLocation(ctx context.Context, contextID string, lat, lon, radius float64) ([]Tile, error)                                     // Find tiles intersecting a location with a radius
+	AssociateTileWithContext(ctx context.Context, contextID string, tileID string) error                                                               // Associate a tile with a context
+	GetTilesByContext(ctx context.Context, contextID string) ([]Tile, error)                                                                           // Retrieve all tiles associated with a context
+	RemoveTileFromContext(ctx context.Context, contextID string, tileID string) error                                                                  // Remove a tile from a context
 }
 
 // Tile represents the domain entity Tile
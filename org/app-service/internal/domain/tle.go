@@ -9,6 +9,40 @@ import (
 	"github.com/org/2112-space-lab/org/go-utils/pkg/fx/xtime"
 )
 
+// ErrNoTLEsAtEpoch is returned when pinning a context at an epoch older than every recorded TLE of its satellites.
+var ErrNoTLEsAtEpoch = errors.New("no TLE recorded at or before the epoch")
+
+// ErrTLENotPinned is returned when a pinned context has no TLE pinned for a satellite.
+var ErrTLENotPinned = errors.New("no TLE pinned for the satellite")
+
+// TLEPins holds the TLEs a context is pinned to, by SPACE ID. The zero value is a context following the latest TLEs.
+type TLEPins struct {
+	Pinned bool
+	tles   map[string]TLE
+}
+
+// NewTLEPins returns the pins of a context pinned to tles.
+func NewTLEPins(tles []TLE) TLEPins {
+	pins := TLEPins{Pinned: true, tles: make(map[string]TLE, len(tles))}
+	for _, tle := range tles {
+		pins.tles[tle.SpaceID] = tle
+	}
+	return pins
+}
+
+// Lookup returns the pinned TLE of a satellite. pinned is false when the context follows the latest TLEs; a pinned
+// context without a pin for the satellite returns ErrTLENotPinned.
+func (p TLEPins) Lookup(spaceID string) (tle TLE, pinned bool, err error) {
+	if !p.Pinned {
+		return tle, false, nil
+	}
+	tle, ok := p.tles[spaceID]
+	if !ok {
+		return tle, true, fmt.Errorf("SPACE ID %s: %w", spaceID, ErrTLENotPinned)
+	}
+	return tle, true, nil
+}
+
 // TLE represents the domain entity for Two-Line Element sets.
 type TLE struct {
 	ModelBase
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTLEPinsLookup(t *testing.T) {
+	iss := TLE{SpaceID: "25544", Line1: "1 25544U", Line2: "2 25544"}
+
+	tests := []struct {
+		name           string
+		pins           TLEPins
+		spaceID        string
+		expectedPinned bool
+		expectedLine1  string
+		expectedErr    error
+	}{
+		{name: "Context following the latest TLEs", pins: TLEPins{}, spaceID: "25544"},
+		{name: "Pinned satellite", pins: NewTLEPins([]TLE{iss}), spaceID: "25544", expectedPinned: true, expectedLine1: iss.Line1},
+		{name: "Pinned context without a pin for the satellite", pins: NewTLEPins([]TLE{iss}), spaceID: "48274", expectedPinned: true, expectedErr: ErrTLENotPinned},
+		{name: "Pinned context without any pin", pins: NewTLEPins(nil), spaceID: "25544", expectedPinned: true, expectedErr: ErrTLENotPinned},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tle, pinned, err := tt.pins.Lookup(tt.spaceID)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("Expected error %v, but got %v", tt.expectedErr, err)
+			}
+			if pinned != tt.expectedPinned {
+				t.Errorf("Expected pinned %v, but got %v", tt.expectedPinned, pinned)
+			}
+			if tle.Line1 != tt.expectedLine1 {
+				t.Errorf("Expected %q, but got %q", tt.expectedLine1, tle.Line1)
+			}
+		})
+	}
+}
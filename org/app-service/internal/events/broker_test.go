@@ -0,0 +1,231 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/org/2112-space-lab/org/app-service/internal/clients/broker"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	model "github.com/org/2112-space-lab/org/app-service/internal/graphql/models/generated"
+)
+
+type recordingHandler struct {
+	err    error
+	events chan model.EventRoot
+}
+
+func (h *recordingHandler) Run(ctx context.Context, event model.EventRoot) error {
+	h.events <- event
+	return h.err
+}
+
+func (h *recordingHandler) HandlerName() string { return "RecordingHandler" }
+
+func (h *recordingHandler) RetryPolicy() RetryPolicy { return RetryPolicy{MaxAttempts: 1} }
+
+type memoryDeadLetters struct {
+	mu          sync.Mutex
+	failures    int // Saves failing before the store recovers
+	deadLetters []domain.DeadLetter
+}
+
+func (r *memoryDeadLetters) Save(ctx context.Context, deadLetter domain.DeadLetter) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.failures > 0 {
+		r.failures--
+		return errors.New("store unavailable")
+	}
+	r.deadLetters = append(r.deadLetters, deadLetter)
+	return nil
+}
+
+func (r *memoryDeadLetters) FindByID(ctx context.Context, id string) (domain.DeadLetter, error) {
+	return domain.DeadLetter{}, errors.New("not found")
+}
+
+func (r *memoryDeadLetters) FindPage(ctx context.Context, page, pageSize int, includeRedriven bool) ([]domain.DeadLetter, int64, error) {
+	return nil, 0, nil
+}
+
+func (r *memoryDeadLetters) MarkRedriven(ctx context.Context, id string, at time.Time) error {
+	return nil
+}
+
+func (r *memoryDeadLetters) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.deadLetters)
+}
+
+func (r *memoryDeadLetters) handlerNames() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var names []string
+	for _, deadLetter := range r.deadLetters {
+		names = append(names, deadLetter.HandlerName)
+	}
+	return names
+}
+
+type stagedClaimsKey struct{}
+
+// memoryClaims keeps the claims of the transactions it runs only when they commit, like the processed events table.
+type memoryClaims struct {
+	mu        sync.Mutex
+	committed map[string]bool
+	crashes   int // Transactions dying before their commit
+}
+
+func newMemoryClaims() *memoryClaims {
+	return &memoryClaims{committed: map[string]bool{}}
+}
+
+func (c *memoryClaims) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	staged := map[string]bool{}
+	err := fn(context.WithValue(ctx, stagedClaimsKey{}, staged))
+
+	c.mu.Lock()
+	crash := c.crashes > 0
+	if crash {
+		c.crashes--
+	}
+	c.mu.Unlock()
+	if crash {
+		<-ctx.Done() // The process dies, the transaction is never committed.
+		return ctx.Err()
+	}
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range staged {
+		c.committed[key] = true
+	}
+	return nil
+}
+
+func (c *memoryClaims) Claim(ctx context.Context, eventUID, handlerName string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := eventUID + "/" + handlerName
+	staged, _ := ctx.Value(stagedClaimsKey{}).(map[string]bool)
+	if c.committed[key] || staged[key] {
+		return false, nil
+	}
+	if staged != nil {
+		staged[key] = true
+	}
+	return true, nil
+}
+
+type discardEvents struct{}
+
+func (discardEvents) Save(ctx context.Context, event domain.Event) error { return nil }
+
+type discardHandlerLogs struct{}
+
+func (discardHandlerLogs) Save(ctx context.Context, handler domain.EventHandler) error { return nil }
+
+// waitFor polls condition until it holds or the deadline passes.
+func waitFor(deadline time.Time, condition func() bool) {
+	for !condition() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestEventPipelineOnMemoryBroker(t *testing.T) {
+	tests := []struct {
+		name                string
+		body                string // Published as is instead of an emitted event when set
+		handlerErr          error
+		failedSaves         int
+		expectedHandled     bool
+		expectedDeadLetters []string // Handler names of the recorded dead letters
+		expectedRejected    int
+	}{
+		{name: "Processed", expectedHandled: true},
+		{
+			name:                "Handler failure recorded",
+			handlerErr:          backoff.Permanent(errors.New("boom")),
+			expectedHandled:     true,
+			expectedDeadLetters: []string{"RecordingHandler"},
+		},
+		{
+			name:                "Handler failure not recorded is rejected and recorded from the dead-letter queue",
+			handlerErr:          backoff.Permanent(errors.New("boom")),
+			failedSaves:         1,
+			expectedHandled:     true,
+			expectedDeadLetters: []string{""},
+			expectedRejected:    1,
+		},
+		{
+			name:                "Unparseable message is rejected and recorded from the dead-letter queue",
+			body:                "not an event",
+			expectedDeadLetters: []string{""},
+			expectedRejected:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			eventRepo, handlerRepo := discardEvents{}, discardHandlerLogs{}
+			deadLetters := &memoryDeadLetters{failures: tt.failedSaves}
+			bus := broker.NewMemoryBroker()
+
+			claims := newMemoryClaims()
+			monitor, _ := NewEventMonitor(ctx, bus, eventRepo, handlerRepo, deadLetters, claims, claims)
+			handler := &recordingHandler{err: tt.handlerErr, events: make(chan model.EventRoot, 1)}
+			monitor.RegisterHandler(ctx, model.EventTypeSystemHealthChecked, handler)
+			go monitor.StartMonitoring(ctx, nil)
+			for bus.Subscriptions() == 0 {
+				time.Sleep(time.Millisecond)
+			}
+
+			if tt.body != "" {
+				if err := bus.Publish(ctx, []byte(tt.body), nil); err != nil {
+					t.Fatalf("Expected no error, but got %v", err)
+				}
+			} else {
+				emitter, _ := NewEventEmitter(ctx, bus, NewEventProcessor(eventRepo, handlerRepo), nil, DefaultEnvelope())
+				if err := emitter.PublishEvent(ctx, model.EventRoot{EventType: model.EventTypeSystemHealthChecked.String(), Payload: "{}"}); err != nil {
+					t.Fatalf("Expected no error, but got %v", err)
+				}
+			}
+
+			if tt.expectedHandled {
+				select {
+				case event := <-handler.events:
+					if event.EventUID == "" {
+						t.Errorf("Expected a stamped UID, but got none")
+					}
+				case <-time.After(2 * time.Second):
+					t.Fatalf("Expected the handler to receive the event, but it did not")
+				}
+			}
+
+			deadline := time.Now().Add(2 * time.Second)
+			waitFor(deadline, func() bool { return deadLetters.count() >= len(tt.expectedDeadLetters) })
+			if got := deadLetters.handlerNames(); !reflect.DeepEqual(got, tt.expectedDeadLetters) {
+				t.Errorf("Expected dead letters of handlers %q, but got %q", tt.expectedDeadLetters, got)
+			}
+			waitFor(deadline, func() bool { return len(bus.DeadLetters()) >= tt.expectedRejected })
+			if got := len(bus.DeadLetters()); got != tt.expectedRejected {
+				t.Errorf("Expected %d rejected messages, but got %d", tt.expectedRejected, got)
+			}
+			if !tt.expectedHandled && len(handler.events) > 0 {
+				t.Errorf("Expected the handler not to receive the message, but it did")
+			}
+		})
+	}
+}
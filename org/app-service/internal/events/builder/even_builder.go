@@ -97,3 +97,49 @@ func NewSatelliteTlePropagationRequestedEvent(request model.SatelliteTlePropagat
 		Payload:      string(payloadBytes),
 	}, nil
 }
+
+// NewGameContextStateChangedEvent creates an EventRoot for a GameContextStateChanged event
+func NewGameContextStateChangedEvent(name, from, to, reason string) (*model.EventRoot, error) {
+	payload := model.GameContextStateChanged{
+		Name:      name,
+		From:      from,
+		To:        to,
+		Reason:    reason,
+		ChangedAt: generateEventTimestamp(),
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize context state changed event payload: %w", err)
+	}
+
+	return &model.EventRoot{
+		EventTimeUtc: generateEventTimestamp(),
+		EventUID:     generateEventUID(),
+		EventType:    model.EventTypeGameContextStateChanged.String(),
+		Payload:      string(payloadBytes),
+	}, nil
+}
+
+// NewGameContextMembershipChangedEvent creates an EventRoot for a GAME_CONTEXT_SATELLITES_ADDED or
+// GAME_CONTEXT_SATELLITES_REMOVED event
+func NewGameContextMembershipChangedEvent(eventType model.EventType, name string, spaceIDs []string, reason string) (*model.EventRoot, error) {
+	payload := model.GameContextMembershipChanged{
+		Name:      name,
+		SpaceIDs:  spaceIDs,
+		Reason:    reason,
+		ChangedAt: generateEventTimestamp(),
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize context membership changed event payload: %w", err)
+	}
+
+	return &model.EventRoot{
+		EventTimeUtc: generateEventTimestamp(),
+		EventUID:     generateEventUID(),
+		EventType:    eventType.String(),
+		Payload:      string(payloadBytes),
+	}, nil
+}
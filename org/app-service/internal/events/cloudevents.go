@@ -0,0 +1,261 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	model "github.com/org/2112-space-lab/org/app-service/internal/graphql/models/generated"
+)
+
+const (
+	CloudEventsSpecVersion     = "1.0"
+	CloudEventsContentType     = "application/cloudevents+json" // Content type of the structured content mode
+	CloudEventsDataContentType = "application/json"             // Content type of the data of the events
+	CloudEventsHeaderPrefix    = "cloudEvents_"                 // Prefix of the attributes in binary content mode, as in the AMQP binding
+	HeaderContentType          = "content-type"
+	DefaultEventSource         = "/app-service"
+)
+
+// cloudEventsHeaderPrefixes are the attribute prefixes accepted in binary content mode: the AMQP binding, its former
+// version and the Kafka binding.
+var cloudEventsHeaderPrefixes = []string{CloudEventsHeaderPrefix, "cloudEvents:", "ce_"}
+
+// ErrInvalidCloudEvent is returned for a CloudEvent missing a required attribute or of another specification version.
+var ErrInvalidCloudEvent = errors.New("invalid cloud event")
+
+// EnvelopeMode selects how published events are enveloped.
+type EnvelopeMode string
+
+const (
+	// EnvelopeEventRoot publishes the EventRoot JSON document.
+	EnvelopeEventRoot EnvelopeMode = "event-root"
+	// EnvelopeCloudEventsStructured publishes a CloudEvents JSON document.
+	EnvelopeCloudEventsStructured EnvelopeMode = "cloudevents-structured"
+	// EnvelopeCloudEventsBinary publishes the payload as body and the CloudEvents attributes as headers.
+	EnvelopeCloudEventsBinary EnvelopeMode = "cloudevents-binary"
+)
+
+// Envelope configures the envelope of published events. Consumers read every envelope, so publishers can switch
+// envelope without coordinating with them.
+type Envelope struct {
+	Mode   EnvelopeMode
+	Source string // CloudEvents source of the published events
+}
+
+// DefaultEnvelope returns the envelope used until one is configured.
+func DefaultEnvelope() Envelope {
+	return Envelope{Mode: EnvelopeEventRoot, Source: DefaultEventSource}
+}
+
+// CloudEvent is a CloudEvents 1.0 event in the JSON format. The tenant, schema version and comment of an EventRoot
+// are carried as extension attributes.
+type CloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Type            string          `json:"type"`
+	Source          string          `json:"source"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            string          `json:"time,omitempty"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	Data            json.RawMessage `json:"data,omitempty"`
+	TenantID        string          `json:"tenantid,omitempty"`
+	SchemaVersion   *int32          `json:"schemaversion,omitempty"`
+	Comment         string          `json:"comment,omitempty"`
+}
+
+// ToCloudEvent maps an event to a CloudEvent of source: its UID is the id, its type the type, its time the time and
+// the satellite or context it is about the subject. A JSON payload is the data.
+func ToCloudEvent(event model.EventRoot, source string) CloudEvent {
+	data := json.RawMessage(event.Payload)
+	if !json.Valid(data) {
+		data, _ = json.Marshal(event.Payload)
+	}
+	cloudEvent := CloudEvent{
+		SpecVersion:     CloudEventsSpecVersion,
+		ID:              event.EventUID,
+		Type:            event.EventType,
+		Source:          source,
+		Subject:         EventSubject(event),
+		Time:            event.EventTimeUtc,
+		DataContentType: CloudEventsDataContentType,
+		Data:            data,
+		SchemaVersion:   event.SchemaVersion,
+	}
+	if event.TenantID != nil {
+		cloudEvent.TenantID = *event.TenantID
+	}
+	if event.Comment != nil {
+		cloudEvent.Comment = *event.Comment
+	}
+	return cloudEvent
+}
+
+// FromCloudEvent maps a CloudEvent back to an event. Data holding a JSON string is the payload itself.
+func FromCloudEvent(cloudEvent CloudEvent) (model.EventRoot, error) {
+	if cloudEvent.SpecVersion != CloudEventsSpecVersion {
+		return model.EventRoot{}, fmt.Errorf("%w: unsupported specversion %q", ErrInvalidCloudEvent, cloudEvent.SpecVersion)
+	}
+	if cloudEvent.ID == "" || cloudEvent.Type == "" || cloudEvent.Source == "" {
+		return model.EventRoot{}, fmt.Errorf("%w: id, type and source are required", ErrInvalidCloudEvent)
+	}
+
+	payload := string(cloudEvent.Data)
+	if data := bytes.TrimSpace(cloudEvent.Data); len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return model.EventRoot{}, fmt.Errorf("%w: %v", ErrInvalidCloudEvent, err)
+		}
+	}
+	event := model.EventRoot{
+		EventUID:      cloudEvent.ID,
+		EventType:     cloudEvent.Type,
+		EventTimeUtc:  cloudEvent.Time,
+		Payload:       payload,
+		SchemaVersion: cloudEvent.SchemaVersion,
+	}
+	if cloudEvent.TenantID != "" {
+		event.TenantID = &cloudEvent.TenantID
+	}
+	if cloudEvent.Comment != "" {
+		event.Comment = &cloudEvent.Comment
+	}
+	return event, nil
+}
+
+// EventSubject returns the satellite an event is about, or its context, empty when it is about neither.
+func EventSubject(event model.EventRoot) string {
+	var payload struct {
+		SpaceID     string `json:"spaceID"`
+		SatelliteID string `json:"satelliteId"`
+		ContextName string `json:"contextName"`
+		Name        string `json:"name"`
+	}
+	if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+		return ""
+	}
+	for _, subject := range []string{payload.SpaceID, payload.SatelliteID, payload.ContextName, payload.Name} {
+		if subject != "" {
+			return subject
+		}
+	}
+	return ""
+}
+
+// EncodeEvent envelopes an event for the broker. It returns the message body and the headers the envelope adds.
+func EncodeEvent(event model.EventRoot, envelope Envelope) ([]byte, map[string]interface{}, error) {
+	source := envelope.Source
+	if source == "" {
+		source = DefaultEventSource
+	}
+
+	switch envelope.Mode {
+	case EnvelopeCloudEventsStructured:
+		body, err := json.Marshal(ToCloudEvent(event, source))
+		if err != nil {
+			return nil, nil, err
+		}
+		return body, map[string]interface{}{HeaderContentType: CloudEventsContentType}, nil
+	case EnvelopeCloudEventsBinary:
+		cloudEvent := ToCloudEvent(event, source)
+		headers := map[string]interface{}{
+			HeaderContentType:                       cloudEvent.DataContentType,
+			CloudEventsHeaderPrefix + "specversion": cloudEvent.SpecVersion,
+			CloudEventsHeaderPrefix + "id":          cloudEvent.ID,
+			CloudEventsHeaderPrefix + "type":        cloudEvent.Type,
+			CloudEventsHeaderPrefix + "source":      cloudEvent.Source,
+		}
+		for attribute, value := range map[string]string{
+			"subject":  cloudEvent.Subject,
+			"time":     cloudEvent.Time,
+			"tenantid": cloudEvent.TenantID,
+			"comment":  cloudEvent.Comment,
+		} {
+			if value != "" {
+				headers[CloudEventsHeaderPrefix+attribute] = value
+			}
+		}
+		if cloudEvent.SchemaVersion != nil {
+			headers[CloudEventsHeaderPrefix+"schemaversion"] = strconv.Itoa(int(*cloudEvent.SchemaVersion))
+		}
+		return cloudEvent.Data, headers, nil
+	case EnvelopeEventRoot, "":
+		body, err := json.Marshal(event)
+		return body, map[string]interface{}{}, err
+	}
+	return nil, nil, fmt.Errorf("unknown event envelope %q", envelope.Mode)
+}
+
+// DecodeEvent reads an event from a broker message in any envelope: a CloudEvent in binary content mode when the
+// headers carry its attributes, in structured content mode when the body is a CloudEvents document, and an
+// EventRoot otherwise.
+func DecodeEvent(body []byte, headers map[string]interface{}) (model.EventRoot, error) {
+	if attributes := cloudEventsAttributes(headers); len(attributes) > 0 {
+		return decodeBinaryCloudEvent(body, attributes)
+	}
+
+	var probe struct {
+		SpecVersion *string `json:"specversion"`
+	}
+	if err := json.Unmarshal(body, &probe); err != nil {
+		return model.EventRoot{}, err
+	}
+	contentType, _ := headers[HeaderContentType].(string)
+	if probe.SpecVersion != nil || strings.HasPrefix(contentType, CloudEventsContentType) {
+		var cloudEvent CloudEvent
+		if err := json.Unmarshal(body, &cloudEvent); err != nil {
+			return model.EventRoot{}, err
+		}
+		return FromCloudEvent(cloudEvent)
+	}
+
+	var event model.EventRoot
+	err := json.Unmarshal(body, &event)
+	return event, err
+}
+
+// cloudEventsAttributes returns the CloudEvents attributes of binary content mode headers, by attribute name.
+func cloudEventsAttributes(headers map[string]interface{}) map[string]string {
+	attributes := map[string]string{}
+	for key, value := range headers {
+		for _, prefix := range cloudEventsHeaderPrefixes {
+			if strings.HasPrefix(key, prefix) {
+				attributes[strings.ToLower(strings.TrimPrefix(key, prefix))] = fmt.Sprint(value)
+				break
+			}
+		}
+	}
+	if _, ok := attributes["specversion"]; !ok {
+		return nil
+	}
+	return attributes
+}
+
+func decodeBinaryCloudEvent(body []byte, attributes map[string]string) (model.EventRoot, error) {
+	cloudEvent := CloudEvent{
+		SpecVersion: attributes["specversion"],
+		ID:          attributes["id"],
+		Type:        attributes["type"],
+		Source:      attributes["source"],
+		Subject:     attributes["subject"],
+		Time:        attributes["time"],
+		TenantID:    attributes["tenantid"],
+		Comment:     attributes["comment"],
+		Data:        body,
+	}
+	if version, ok := attributes["schemaversion"]; ok {
+		parsed, err := strconv.ParseInt(version, 10, 32)
+		if err != nil {
+			return model.EventRoot{}, fmt.Errorf("%w: schemaversion %q", ErrInvalidCloudEvent, version)
+		}
+		schemaVersion := int32(parsed)
+		cloudEvent.SchemaVersion = &schemaVersion
+	}
+	if !json.Valid(body) {
+		// Binary data of another content type is the payload itself.
+		cloudEvent.Data, _ = json.Marshal(string(body))
+	}
+	return FromCloudEvent(cloudEvent)
+}
@@ -0,0 +1,123 @@
+package events
+
+import (
+	"errors"
+	"testing"
+
+	model "github.com/org/2112-space-lab/org/app-service/internal/graphql/models/generated"
+)
+
+func TestEnvelopeRoundTrip(t *testing.T) {
+	tenant, comment, version := "org_alpha", "propagated", int32(2)
+	event := model.EventRoot{
+		EventUID:      "event-uid",
+		EventType:     string(model.EventTypeSatelliteTlePropagated),
+		EventTimeUtc:  "2026-10-18T10:00:00Z",
+		Payload:       `{"spaceID":"25544","redisKey":"tle:25544"}`,
+		TenantID:      &tenant,
+		Comment:       &comment,
+		SchemaVersion: &version,
+	}
+
+	tests := []struct {
+		name                string
+		mode                EnvelopeMode
+		expectedContentType string
+	}{
+		{name: "EventRoot", mode: EnvelopeEventRoot},
+		{name: "Structured CloudEvent", mode: EnvelopeCloudEventsStructured, expectedContentType: CloudEventsContentType},
+		{name: "Binary CloudEvent", mode: EnvelopeCloudEventsBinary, expectedContentType: CloudEventsDataContentType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, headers, err := EncodeEvent(event, Envelope{Mode: tt.mode, Source: "/test"})
+			if err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+			if contentType, _ := headers[HeaderContentType].(string); contentType != tt.expectedContentType {
+				t.Errorf("Expected content type %q, but got %q", tt.expectedContentType, contentType)
+			}
+
+			decoded, err := DecodeEvent(body, headers)
+			if err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+			if decoded.EventUID != event.EventUID || decoded.EventType != event.EventType || decoded.EventTimeUtc != event.EventTimeUtc {
+				t.Errorf("Expected identity %s/%s/%s, but got %s/%s/%s", event.EventUID, event.EventType, event.EventTimeUtc, decoded.EventUID, decoded.EventType, decoded.EventTimeUtc)
+			}
+			if decoded.Payload != event.Payload {
+				t.Errorf("Expected payload %s, but got %s", event.Payload, decoded.Payload)
+			}
+			if EventTenant(decoded) != EventTenant(event) {
+				t.Errorf("Expected tenant %s, but got %s", EventTenant(event), EventTenant(decoded))
+			}
+			if decoded.SchemaVersion == nil || *decoded.SchemaVersion != version {
+				t.Errorf("Expected schema version %d, but got %v", version, decoded.SchemaVersion)
+			}
+			if decoded.Comment == nil || *decoded.Comment != comment {
+				t.Errorf("Expected comment %s, but got %v", comment, decoded.Comment)
+			}
+		})
+	}
+}
+
+func TestDecodeForeignCloudEvents(t *testing.T) {
+	tests := []struct {
+		name            string
+		body            string
+		headers         map[string]interface{}
+		expectedType    string
+		expectedPayload string
+		expectedErr     error
+	}{
+		{
+			name:            "Structured with object data",
+			body:            `{"specversion":"1.0","id":"1","type":"SATELLITE_POSITION_UPDATED","source":"/other","data":{"id":"25544"}}`,
+			expectedType:    "SATELLITE_POSITION_UPDATED",
+			expectedPayload: `{"id":"25544"}`,
+		},
+		{
+			name:            "Structured with string data",
+			body:            `{"specversion":"1.0","id":"1","type":"SYSTEM_HEALTH_CHECKED","source":"/other","data":"{}"}`,
+			expectedType:    "SYSTEM_HEALTH_CHECKED",
+			expectedPayload: `{}`,
+		},
+		{
+			name:            "Binary with Kafka attribute prefix",
+			body:            `{"id":"25544"}`,
+			headers:         map[string]interface{}{"ce_specversion": "1.0", "ce_id": "1", "ce_type": "SATELLITE_POSITION_UPDATED", "ce_source": "/other"},
+			expectedType:    "SATELLITE_POSITION_UPDATED",
+			expectedPayload: `{"id":"25544"}`,
+		},
+		{
+			name:        "Other specification version",
+			body:        `{"specversion":"0.3","id":"1","type":"SYSTEM_HEALTH_CHECKED","source":"/other"}`,
+			expectedErr: ErrInvalidCloudEvent,
+		},
+		{
+			name:        "Missing source",
+			headers:     map[string]interface{}{"cloudEvents_specversion": "1.0", "cloudEvents_id": "1", "cloudEvents_type": "SYSTEM_HEALTH_CHECKED"},
+			body:        `{}`,
+			expectedErr: ErrInvalidCloudEvent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := DecodeEvent([]byte(tt.body), tt.headers)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("Expected error %v, but got %v", tt.expectedErr, err)
+			}
+			if tt.expectedErr != nil {
+				return
+			}
+			if event.EventType != tt.expectedType {
+				t.Errorf("Expected type %s, but got %s", tt.expectedType, event.EventType)
+			}
+			if event.Payload != tt.expectedPayload {
+				t.Errorf("Expected payload %s, but got %s", tt.expectedPayload, event.Payload)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package events
+
+// HandlerDelivery states what a handler tolerates when the same event is delivered more than once.
+type HandlerDelivery string
+
+const (
+	// DeliveryIdempotent handlers run for every delivery of an event: running them again has no further effect.
+	DeliveryIdempotent HandlerDelivery = "idempotent"
+	// DeliveryExactlyOnceEffect handlers run once per event UID. Duplicate deliveries are skipped and recorded,
+	// except for replays, which are requested on purpose. They run in a transaction recording the event as
+	// processed, so their effects must be written through ctx, and their events enqueued in the outbox.
+	DeliveryExactlyOnceEffect HandlerDelivery = "exactly-once-effect"
+)
+
+// DeliveryAwareHandler is implemented by handlers declaring how they handle duplicate deliveries.
+type DeliveryAwareHandler interface {
+	Delivery() HandlerDelivery
+}
+
+// HandlerDeliveryOf returns the delivery of a handler, DeliveryIdempotent when it declares none.
+func HandlerDeliveryOf(handler EventHandler) HandlerDelivery {
+	if aware, ok := handler.(DeliveryAwareHandler); ok {
+		return aware.Delivery()
+	}
+	return DeliveryIdempotent
+}
@@ -0,0 +1,142 @@
+package events
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/clients/broker"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	domainenum "github.com/org/2112-space-lab/org/app-service/internal/domain/domain-enums"
+	model "github.com/org/2112-space-lab/org/app-service/internal/graphql/models/generated"
+)
+
+type onceHandler struct{}
+
+func (h onceHandler) Run(ctx context.Context, event model.EventRoot) error { return nil }
+
+func (h onceHandler) HandlerName() string { return "OnceHandler" }
+
+func (h onceHandler) Delivery() HandlerDelivery { return DeliveryExactlyOnceEffect }
+
+// countingOnceHandler is an exactly-once-effect handler counting its runs.
+type countingOnceHandler struct {
+	runs chan struct{}
+}
+
+func (h countingOnceHandler) Run(ctx context.Context, event model.EventRoot) error {
+	h.runs <- struct{}{}
+	return nil
+}
+
+func (h countingOnceHandler) HandlerName() string { return "CountingOnceHandler" }
+
+func (h countingOnceHandler) Delivery() HandlerDelivery { return DeliveryExactlyOnceEffect }
+
+func (h countingOnceHandler) RetryPolicy() RetryPolicy { return RetryPolicy{MaxAttempts: 1} }
+
+type recordingHandlerLogs struct {
+	mu       sync.Mutex
+	statuses []domainenum.HandlerState
+}
+
+func (r *recordingHandlerLogs) Save(ctx context.Context, handler domain.EventHandler) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.statuses = append(r.statuses, handler.Status)
+	return nil
+}
+
+func (r *recordingHandlerLogs) last() domainenum.HandlerState {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.statuses[len(r.statuses)-1]
+}
+
+func TestExactlyOnceEffectAfterCrash(t *testing.T) {
+	claims := newMemoryClaims()
+	claims.crashes = 1
+	handlerLogs := &recordingHandlerLogs{}
+	monitor, _ := NewEventMonitor(context.Background(), broker.NewMemoryBroker(), discardEvents{}, handlerLogs, &memoryDeadLetters{}, claims, claims)
+	handler := countingOnceHandler{runs: make(chan struct{}, 3)}
+	event := model.EventRoot{EventUID: "event-uid", EventType: model.EventTypeSystemHealthChecked.String()}
+
+	// The first delivery runs the handler, then the process dies before the claim commits.
+	crashCtx, crash := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		monitor.executeHandler(crashCtx, handler, event, false)
+	}()
+	select {
+	case <-handler.runs:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Expected the handler to run, but it did not")
+	}
+	crash()
+	<-done
+
+	tests := []struct {
+		name           string
+		expectedRuns   int
+		expectedStatus domainenum.HandlerState
+	}{
+		{name: "Redelivery after the crash", expectedRuns: 1, expectedStatus: domainenum.HandlerStates.Completed()},
+		{name: "Duplicate delivery", expectedRuns: 0, expectedStatus: domainenum.HandlerStates.Skipped()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := monitor.executeHandler(context.Background(), handler, event, false); err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+			if got := len(handler.runs); got != tt.expectedRuns {
+				t.Errorf("Expected %d runs, but got %d", tt.expectedRuns, got)
+			}
+			for len(handler.runs) > 0 {
+				<-handler.runs
+			}
+			if got := handlerLogs.last(); got != tt.expectedStatus {
+				t.Errorf("Expected status %v, but got %v", tt.expectedStatus, got)
+			}
+		})
+	}
+}
+
+func TestHandlerDeliveryOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		handler  EventHandler
+		expected HandlerDelivery
+	}{
+		{name: "Undeclared", handler: &flakyHandler{}, expected: DeliveryIdempotent},
+		{name: "Exactly once", handler: onceHandler{}, expected: DeliveryExactlyOnceEffect},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HandlerDeliveryOf(tt.handler); got != tt.expected {
+				t.Errorf("Expected %s, but got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestWithEventIdentity(t *testing.T) {
+	stamped := WithEventIdentity(model.EventRoot{EventType: "TEST"})
+	if stamped.EventUID == "" {
+		t.Errorf("Expected a UID, but got none")
+	}
+	if stamped.EventTimeUtc == "" {
+		t.Errorf("Expected an event time, but got none")
+	}
+	if other := WithEventIdentity(model.EventRoot{EventType: "TEST"}); other.EventUID == stamped.EventUID {
+		t.Errorf("Expected distinct UIDs, but got %s twice", other.EventUID)
+	}
+
+	kept := WithEventIdentity(model.EventRoot{EventUID: "uid-1", EventTimeUtc: "2026-10-01T12:00:00Z"})
+	if kept.EventUID != "uid-1" || kept.EventTimeUtc != "2026-10-01T12:00:00Z" {
+		t.Errorf("Expected identity kept, but got %s at %s", kept.EventUID, kept.EventTimeUtc)
+	}
+}
@@ -4,43 +4,68 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
-	"github.com/org/2112-space-lab/org/app-service/internal/clients/rabbitmq"
+	"github.com/google/uuid"
+	"github.com/org/2112-space-lab/org/app-service/internal/clients/broker"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
 	model "github.com/org/2112-space-lab/org/app-service/internal/graphql/models/generated"
 	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
 	"github.com/org/2112-space-lab/org/app-service/pkg/tracing"
 )
 
-// EventEmitter wraps the RabbitMQ client and integrates with the EventProcessor.
+// EventEmitter wraps the message broker and integrates with the EventProcessor. Events are published in the
+// configured envelope.
 type EventEmitter struct {
-	rabbitClient *rabbitmq.RabbitMQClient
-	processor    *EventProcessor
+	broker     broker.Broker
+	processor  *EventProcessor
+	outboxRepo domain.OutboxRepository
+	envelope   Envelope
 }
 
-// NewEventEmitter initializes a new EventEmitter using RabbitMQ and an EventProcessor.
-func NewEventEmitter(ctx context.Context, rabbitClient *rabbitmq.RabbitMQClient, processor *EventProcessor) (*EventEmitter, error) {
+// NewEventEmitter initializes a new EventEmitter using a message broker, an EventProcessor, the transactional outbox
+// and the envelope of published events.
+func NewEventEmitter(ctx context.Context, messageBroker broker.Broker, processor *EventProcessor, outboxRepo domain.OutboxRepository, envelope Envelope) (*EventEmitter, error) {
 	_, span := tracing.NewSpan(ctx, "EventEmitter.NewEventEmitter")
 	defer span.End()
 
 	return &EventEmitter{
-		rabbitClient: rabbitClient,
-		processor:    processor,
+		broker:     messageBroker,
+		processor:  processor,
+		outboxRepo: outboxRepo,
+		envelope:   envelope,
 	}, nil
 }
 
-// PublishEvent sends an event to RabbitMQ and also to the local EventProcessor.
+// WithEventIdentity stamps an event without UID with a new one, and an event without time with the current time.
+// Consumers deduplicate events by UID, so every published event needs its own.
+func WithEventIdentity(event model.EventRoot) model.EventRoot {
+	if event.EventUID == "" {
+		event.EventUID = uuid.NewString()
+	}
+	if event.EventTimeUtc == "" {
+		event.EventTimeUtc = time.Now().UTC().Format(time.RFC3339)
+	}
+	return event
+}
+
+// PublishEvent sends an event to the broker and also to the local EventProcessor.
+// Events without a tenant are stamped with the tenant of ctx and routed with a tenant header; events without UID
+// or time are stamped with new ones. Payloads are migrated to the current schema version and validated against
+// it, so an invalid event is never published.
 func (e *EventEmitter) PublishEvent(ctx context.Context, event model.EventRoot) error {
 	ctx, span := tracing.NewSpan(ctx, "PublishEvent")
 	defer span.End()
 
-	body, err := json.Marshal(event)
+	event, err := NormalizePayload(WithEventIdentity(WithEventTenant(ctx, event)))
 	if err != nil {
-		return fmt.Errorf("failed to marshal event: %w", err)
+		log.Errorf("❌ Refusing to publish event %s: %v", event.EventType, err)
+		return err
 	}
 
-	err = e.rabbitClient.PublishMessage(ctx, body, rabbitmq.NewHeader())
+	err = e.publish(ctx, event, TenantHeader(event))
 	if err != nil {
-		log.Errorf("❌ Failed to publish event to RabbitMQ: %v", err)
+		log.Errorf("❌ Failed to publish event to broker: %v", err)
 		return fmt.Errorf("failed to publish event: %w", err)
 	}
 
@@ -53,3 +78,88 @@ func (e *EventEmitter) PublishEvent(ctx context.Context, event model.EventRoot)
 
 	return nil
 }
+
+// Enqueue stores an event in the transactional outbox instead of publishing it, stamping it like PublishEvent. Called within a transaction, the
+// event is kept only if the transaction commits; the outbox relay then publishes it through PublishEvent. Events of
+// the same aggregate are published in the order they were enqueued.
+func (e *EventEmitter) Enqueue(ctx context.Context, aggregateID string, event model.EventRoot) error {
+	ctx, span := tracing.NewSpan(ctx, "Enqueue")
+	defer span.End()
+
+	event, err := NormalizePayload(WithEventIdentity(WithEventTenant(ctx, event)))
+	if err != nil {
+		log.Errorf("❌ Refusing to publish event %s: %v", event.EventType, err)
+		return err
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	err = e.outboxRepo.Enqueue(ctx, domain.OutboxMessage{
+		EventUID:    event.EventUID,
+		AggregateID: aggregateID,
+		EventType:   domain.EventType(event.EventType),
+		TenantID:    EventTenant(event),
+		Event:       string(body),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to enqueue event %s: %w", event.EventUID, err)
+	}
+	log.Tracef("[x] Event enqueued in outbox: %s", event.EventType)
+	return nil
+}
+
+// Redrive publishes a dead-lettered event to the broker again, for the named handler only, or for every handler of
+// its consumers when handlerName is empty (a message rejected before dispatch). It is not broadcast to the local
+// EventProcessor, whose handlers did not fail.
+func (e *EventEmitter) Redrive(ctx context.Context, event model.EventRoot, handlerName string) error {
+	ctx, span := tracing.NewSpan(ctx, "Redrive")
+	defer span.End()
+
+	header := TenantHeader(event)
+	if handlerName != "" {
+		header.AddField(broker.HeaderTargetHandler, handlerName)
+	}
+	if err := e.republish(ctx, event, header); err != nil {
+		return fmt.Errorf("failed to re-drive event %s: %w", event.EventUID, err)
+	}
+	log.Infof("🔁 Event %s re-driven to handler %s", event.EventUID, handlerName)
+	return nil
+}
+
+// Replay publishes a stored event to the broker again, marked as replayed, for the named handler or for every
+// handler of its consumers when handlerName is empty.
+func (e *EventEmitter) Replay(ctx context.Context, event model.EventRoot, handlerName string) error {
+	ctx, span := tracing.NewSpan(ctx, "Replay")
+	defer span.End()
+
+	header := TenantHeader(event)
+	header.AddField(broker.HeaderReplay, "true")
+	if handlerName != "" {
+		header.AddField(broker.HeaderTargetHandler, handlerName)
+	}
+	if err := e.republish(ctx, event, header); err != nil {
+		return fmt.Errorf("failed to replay event %s: %w", event.EventUID, err)
+	}
+	log.Debugf("🔁 Event %s replayed", event.EventUID)
+	return nil
+}
+
+// republish sends an already published event to the broker only.
+func (e *EventEmitter) republish(ctx context.Context, event model.EventRoot, header *broker.Header) error {
+	return e.publish(ctx, event, header)
+}
+
+// publish envelopes an event and sends it to the broker with header and the headers of the envelope.
+func (e *EventEmitter) publish(ctx context.Context, event model.EventRoot, header *broker.Header) error {
+	body, envelopeHeaders, err := EncodeEvent(event, e.envelope)
+	if err != nil {
+		return fmt.Errorf("failed to envelope event: %w", err)
+	}
+	for key, value := range envelopeHeaders {
+		header.AddField(key, value)
+	}
+	return e.broker.Publish(ctx, body, header)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
 	model "github.com/org/2112-space-lab/org/app-service/internal/graphql/models/generated"
 	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
 )
@@ -15,6 +16,16 @@ type EventHandler interface {
 	HandlerName() string                                  // Returns the name of the handler
 }
 
+// EventStore stores received events, ignoring events already stored.
+type EventStore interface {
+	Save(ctx context.Context, event domain.Event) error
+}
+
+// HandlerLogStore stores the executions of event handlers.
+type HandlerLogStore interface {
+	Save(ctx context.Context, handler domain.EventHandler) error
+}
+
 // BaseHandler provides reusable logic for event handlers (uses generics for payloads).
 type BaseHandler[T any] struct{}
 
@@ -3,47 +3,78 @@ package events
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
-	"github.com/org/2112-space-lab/org/app-service/internal/clients/rabbitmq"
+	"github.com/org/2112-space-lab/org/app-service/internal/clients/broker"
 	"github.com/org/2112-space-lab/org/app-service/internal/domain"
 	domainenum "github.com/org/2112-space-lab/org/app-service/internal/domain/domain-enums"
 	model "github.com/org/2112-space-lab/org/app-service/internal/graphql/models/generated"
-	repository "github.com/org/2112-space-lab/org/app-service/internal/repositories"
 	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
 	fx "github.com/org/2112-space-lab/org/app-service/pkg/option"
 	xtime "github.com/org/2112-space-lab/org/app-service/pkg/time"
 	"github.com/org/2112-space-lab/org/app-service/pkg/tracing"
-	amqp "github.com/rabbitmq/amqp091-go"
 )
 
 const (
 	DefaultEventQueueSize = 100
+	// DefaultDeadLetterRequeueDelay spaces the attempts to record a rejected message while the store is unavailable.
+	DefaultDeadLetterRequeueDelay = 5 * time.Second
 )
 
-// EventMonitor handles event subscription, processing, and persistence.
+// EventMonitor handles event subscription, processing, and persistence on a message broker. A message is
+// acknowledged once every handler processed its event, retrying failures within the handler's retry policy, or
+// recorded the event it gave up on as a dead letter that can be re-driven. Messages that cannot be parsed or
+// recorded are rejected to the broker's dead-letter queue, which the monitor drains into the dead-letter store when
+// the broker supports it. Exactly-once-effect handlers skip events they already processed.
+// Each handler processes events on its own worker pool, in order for the events of a same satellite; a full pool
+// stops the consumption of messages, and the broker holds at most as many unacknowledged messages as the pools hold.
 type EventMonitor struct {
-	rabbitClient  *rabbitmq.RabbitMQClient
-	eventQueue    chan []byte
-	eventHandlers map[model.EventType][]EventHandler
-	mutex         sync.Mutex
-	eventRepo     repository.EventRepository
-	handlerRepo   repository.EventHandlerRepository
+	broker         broker.Broker
+	eventQueue     chan broker.Message
+	eventHandlers  map[model.EventType][]EventHandler
+	mutex          sync.Mutex
+	eventRepo      EventStore
+	handlerRepo    HandlerLogStore
+	deadLetterRepo domain.DeadLetterRepository
+	processedRepo  domain.ProcessedEventRepository
+	transactor     Transactor
+	poolConfig     WorkerPoolConfig
+	pools          map[EventHandler]*workerPool
+}
+
+// Transactor runs repository calls in one database transaction.
+type Transactor interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 // NewEventMonitor initializes an EventMonitor with persistence.
-func NewEventMonitor(ctx context.Context, rabbitClient *rabbitmq.RabbitMQClient, eventRepo repository.EventRepository, handlerRepo repository.EventHandlerRepository) (e *EventMonitor, err error) {
+func NewEventMonitor(
+	ctx context.Context,
+	messageBroker broker.Broker,
+	eventRepo EventStore,
+	handlerRepo HandlerLogStore,
+	deadLetterRepo domain.DeadLetterRepository,
+	processedRepo domain.ProcessedEventRepository,
+	transactor Transactor,
+) (e *EventMonitor, err error) {
 	_, span := tracing.NewSpan(ctx, "EventMonitor.NewEventMonitor")
 	defer span.EndWithError(err)
 
 	return &EventMonitor{
-		rabbitClient:  rabbitClient,
-		eventQueue:    make(chan []byte, DefaultEventQueueSize),
-		eventHandlers: make(map[model.EventType][]EventHandler),
-		eventRepo:     eventRepo,
-		handlerRepo:   handlerRepo,
+		broker:         messageBroker,
+		eventQueue:     make(chan broker.Message, DefaultEventQueueSize),
+		eventHandlers:  make(map[model.EventType][]EventHandler),
+		eventRepo:      eventRepo,
+		handlerRepo:    handlerRepo,
+		deadLetterRepo: deadLetterRepo,
+		processedRepo:  processedRepo,
+		transactor:     transactor,
+		poolConfig:     DefaultWorkerPoolConfig(),
+		pools:          make(map[EventHandler]*workerPool),
 	}, nil
 }
 
@@ -64,29 +95,65 @@ func (m *EventMonitor) RegisterHandler(ctx context.Context, eventType model.Even
 	return nil
 }
 
-// StartMonitoring continuously listens for RabbitMQ messages and processes events.
-func (m *EventMonitor) StartMonitoring(ctx context.Context, header *rabbitmq.Header) (err error) {
+// ConfigureWorkerPools sizes the worker pools of the handlers. It must be called before StartMonitoring.
+func (m *EventMonitor) ConfigureWorkerPools(config WorkerPoolConfig) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.poolConfig = config
+}
+
+// Prefetch returns the number of messages the worker pools can take before blocking the consumption. A message is
+// submitted to every handler of its event type, so the messages of a type are bounded by the smallest pool of its
+// handlers; the prefetch is the largest of these bounds, so that each type can fill its pools.
+func (m *EventMonitor) Prefetch() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	prefetch := 0
+	for _, handlers := range m.eventHandlers {
+		bound := 0
+		for _, handler := range handlers {
+			if capacity := m.poolConfig.capacity(handler); bound == 0 || capacity < bound {
+				bound = capacity
+			}
+		}
+		if bound > prefetch {
+			prefetch = bound
+		}
+	}
+	return prefetch
+}
+
+// StartMonitoring continuously listens for broker messages matching header and processes events.
+func (m *EventMonitor) StartMonitoring(ctx context.Context, header *broker.Header) (err error) {
 	ctx, span := tracing.NewSpan(ctx, "StartMonitoring")
 	defer span.EndWithError(err)
 
 	log.Info("📡 Event Monitor started. Waiting for messages...")
 
+	if prefetcher, ok := m.broker.(broker.Prefetcher); ok && m.Prefetch() > 0 {
+		prefetcher.SetPrefetch(m.Prefetch())
+	}
+
 	go m.processEvents(ctx)
+	if subscriber, ok := m.broker.(broker.DeadLetterSubscriber); ok {
+		go m.consumeDeadLetters(ctx, subscriber)
+	}
 
 	retryPolicy := createBackoff()
 	for {
 		select {
 		case <-ctx.Done():
 			log.Info("🛑 Event Monitor shutting down gracefully...")
-			m.rabbitClient.Close()
+			m.broker.Close()
 			return nil
 		default:
 		}
 
-		var msgs <-chan amqp.Delivery
+		var msgs <-chan broker.Message
 		err := backoff.Retry(func() error {
 			var err error
-			msgs, err = m.rabbitClient.ConsumeMessages(header)
+			msgs, err = m.broker.Subscribe(ctx, header)
 			if err != nil {
 				log.Warnf("❌ Failed to consume messages: %v. Retrying...", err)
 				return err
@@ -100,18 +167,20 @@ func (m *EventMonitor) StartMonitoring(ctx context.Context, header *rabbitmq.Hea
 		}
 
 		retryPolicy.Reset()
+	consume:
 		for {
 			select {
 			case <-ctx.Done():
 				log.Info("🛑 Event Monitor stopping message consumption...")
-				m.rabbitClient.Close()
+				m.broker.Close()
 				return nil
 			case msg, ok := <-msgs:
 				if !ok {
-					log.Warn("⚠️ Message channel closed unexpectedly. Reconnecting to RabbitMQ...")
-					break
+					log.Warn("⚠️ Message channel closed unexpectedly. Subscribing again...")
+					break consume
 				}
-				m.eventQueue <- msg.Body
+				eventQueueDepth.Inc()
+				m.eventQueue <- msg
 			}
 		}
 	}
@@ -130,44 +199,139 @@ func createBackoff() *backoff.ExponentialBackOff {
 
 // processEvents processes events asynchronously from the queue and stores them.
 func (m *EventMonitor) processEvents(ctx context.Context) {
-	for msgBody := range m.eventQueue {
-		var eventRoot model.EventRoot
-		if err := json.Unmarshal(msgBody, &eventRoot); err != nil {
-			log.Errorf("❌ Failed to parse event: %v", err)
+	for msg := range m.eventQueue {
+		eventQueueDepth.Dec()
+		eventRoot, err := DecodeEvent(msg.Body, msg.Headers)
+		if err != nil {
+			log.Errorf("❌ Failed to parse event, dead-lettering it: %v", err)
+			m.reject(msg)
 			continue
 		}
 
+		if eventRoot.EventUID == "" {
+			// Without a UID the event could neither be stored nor deduplicated.
+			eventRoot = WithEventIdentity(eventRoot)
+			log.Warnf("⚠️ Received event %s without UID, assigned %s", eventRoot.EventType, eventRoot.EventUID)
+		}
+
 		log.Infof("🔹 Received event: %s | UID: %s", eventRoot.EventType, eventRoot.EventUID)
 
+		eventRoot, err = NormalizePayload(eventRoot)
+		if err != nil {
+			log.Errorf("❌ Invalid payload of event %s, dead-lettering it: %v", eventRoot.EventUID, err)
+			m.reject(msg)
+			continue
+		}
+
 		event, err := ConvertToDomainEvent(eventRoot)
 		if err != nil {
-			log.Errorf("❌ Failed to convert event to domain: %v", err)
+			log.Errorf("❌ Failed to convert event to domain, dead-lettering it: %v", err)
+			m.reject(msg)
 			continue
 		}
 
 		if err := m.eventRepo.Save(ctx, event); err != nil {
+			// Requeued once, then dead-lettered, so a database outage does not loop forever.
 			log.Errorf("❌ Failed to store event in database: %v", err)
+			if nackErr := msg.Nack(!msg.Redelivered); nackErr != nil {
+				log.Errorf("❌ Failed to reject event %s: %v", eventRoot.EventUID, nackErr)
+			}
 			continue
 		}
 
-		m.mutex.Lock()
-		handlers, exists := m.eventHandlers[model.EventType(eventRoot.EventType)]
-		m.mutex.Unlock()
-
-		if !exists {
+		handlers := m.handlersFor(eventRoot, msg)
+		if len(handlers) == 0 {
 			log.Warnf("⚠️ No handler registered for event type: %s", eventRoot.EventType)
+			m.ack(msg)
 			continue
 		}
 
-		for _, handler := range handlers {
-			go m.executeHandler(ctx, handler, eventRoot)
+		m.dispatch(ctx, msg, handlers, eventRoot)
+	}
+}
+
+// handlersFor returns the handlers of an event, only the targeted one for a re-driven message.
+func (m *EventMonitor) handlersFor(event model.EventRoot, msg broker.Message) []EventHandler {
+	m.mutex.Lock()
+	handlers := m.eventHandlers[model.EventType(event.EventType)]
+	m.mutex.Unlock()
+
+	target := msg.Header(broker.HeaderTargetHandler)
+	if target == "" {
+		return handlers
+	}
+	for _, handler := range handlers {
+		if handler.HandlerName() == target {
+			return []EventHandler{handler}
+		}
+	}
+	return nil
+}
+
+// dispatch submits an event to the worker pool of each of its handlers, blocking while a pool is full. The message
+// is acknowledged once every handler processed the event or recorded it as a dead letter, and rejected to the
+// dead-letter queue if a failure could not be recorded. A
+// message left unsubmitted when the monitor stops is not acknowledged, so the broker delivers it again.
+func (m *EventMonitor) dispatch(ctx context.Context, msg broker.Message, handlers []EventHandler, event model.EventRoot) {
+	replay := isReplay(msg)
+	key := OrderingKey(event)
+	pending := int32(len(handlers))
+	var failed atomic.Bool
+	done := func(err error) {
+		if err != nil {
+			failed.Store(true)
+		}
+		if atomic.AddInt32(&pending, -1) > 0 {
+			return
+		}
+		if failed.Load() {
+			m.reject(msg)
+			return
+		}
+		m.ack(msg)
+	}
+
+	for _, handler := range handlers {
+		handler := handler
+		err := m.poolFor(ctx, handler).submit(ctx, key, handlerJob{
+			ctx:   EventContext(ctx, event),
+			event: event,
+			run: func(ctx context.Context, event model.EventRoot) error {
+				return m.executeHandler(ctx, handler, event, replay)
+			},
+			done: done,
+		})
+		if err != nil {
+			log.Warnf("⚠️ Event %s not dispatched to handler %s: %v", event.EventUID, handler.HandlerName(), err)
+			return
 		}
 	}
 }
 
-// executeHandler processes an event with a handler and logs execution.
-func (m *EventMonitor) executeHandler(ctx context.Context, handler EventHandler, event model.EventRoot) {
+// poolFor returns the worker pool of a handler, starting it on first use.
+func (m *EventMonitor) poolFor(ctx context.Context, handler EventHandler) *workerPool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	pool, ok := m.pools[handler]
+	if !ok {
+		pool = newWorkerPool(ctx, handler, m.poolConfig)
+		m.pools[handler] = pool
+	}
+	return pool
+}
+
+// isReplay reports whether a message replays a stored event.
+func isReplay(msg broker.Message) bool {
+	return msg.Header(broker.HeaderReplay) == "true"
+}
+
+// executeHandler processes an event with a handler within its retry policy and logs execution. A handler that
+// gives up is recorded as a dead letter; an error is returned only when the dead letter could not be recorded. An
+// exactly-once-effect handler skips an event it already processed, unless it is replayed.
+func (m *EventMonitor) executeHandler(ctx context.Context, handler EventHandler, event model.EventRoot, replay bool) error {
 	handlerLog := domain.EventHandler{
+		ModelBase:   domain.NewModelBaseDefault(), // Identifies the execution, so completion updates its record
 		EventID:     event.EventUID,
 		HandlerName: handler.HandlerName(),
 		StartedAt:   xtime.UtcNow(),
@@ -178,13 +342,31 @@ func (m *EventMonitor) executeHandler(ctx context.Context, handler EventHandler,
 		log.Errorf("❌ Failed to log handler start: %v", err)
 	}
 
-	if err := handler.Run(ctx, event); err != nil {
-		log.Errorf("❌ Error processing event %s: %v", event.EventType, err)
+	run, duplicate := handler.Run, false
+	if HandlerDeliveryOf(handler) == DeliveryExactlyOnceEffect && !replay {
+		run = func(ctx context.Context, event model.EventRoot) error {
+			return m.runClaimed(ctx, handler, event, &duplicate)
+		}
+	}
 
-		errorMsg := err.Error()
+	attempts, runErr := runWithRetry(ctx, handler, event, run)
+	switch {
+	case runErr == nil && duplicate:
+		log.Infof("⏭️ Skipping duplicate event %s for handler %s", event.EventUID, handler.HandlerName())
+		handlerLog.Status = domainenum.HandlerStates.Skipped()
+		handlerLog.Error = fx.NewValueOption("duplicate delivery")
+	case runErr != nil:
+		log.Errorf("❌ Error processing event %s after %d attempts: %v", event.EventType, attempts, runErr)
+		errorMsg := fmt.Sprintf("%v (after %d attempts)", runErr, attempts)
 		handlerLog.Status = domainenum.HandlerStates.Failed()
 		handlerLog.Error = fx.NewValueOption(errorMsg)
-	} else {
+		if err := m.deadLetter(ctx, handler, event, attempts, runErr); err != nil {
+			log.Errorf("❌ Failed to record dead letter of event %s: %v", event.EventUID, err)
+		} else {
+			handlerLog.Status = domainenum.HandlerStates.DeadLettered()
+			runErr = nil
+		}
+	default:
 		handlerLog.Status = domainenum.HandlerStates.Completed()
 	}
 
@@ -193,4 +375,108 @@ func (m *EventMonitor) executeHandler(ctx context.Context, handler EventHandler,
 	if err := m.handlerRepo.Save(ctx, handlerLog); err != nil {
 		log.Errorf("❌ Failed to update handler execution log: %v", err)
 	}
+	return runErr
+}
+
+// runClaimed runs an exactly-once-effect handler in a transaction claiming the event, so the claim is kept only
+// along with the effects of a successful run: a failed run or a crash rolls it back and the event is processed
+// again when redelivered or re-driven. A concurrent delivery waits on the claim of the first and is flagged as a
+// duplicate once it commits.
+func (m *EventMonitor) runClaimed(ctx context.Context, handler EventHandler, event model.EventRoot, duplicate *bool) error {
+	return m.transactor.Transaction(ctx, func(ctx context.Context) error {
+		first, err := m.processedRepo.Claim(ctx, event.EventUID, handler.HandlerName())
+		if err != nil {
+			return fmt.Errorf("failed to claim event %s for handler %s: %w", event.EventUID, handler.HandlerName(), err)
+		}
+		*duplicate = !first
+		if !first {
+			return nil
+		}
+		return handler.Run(ctx, event)
+	})
+}
+
+// deadLetter records the event a handler gave up on, so it can be inspected and re-driven.
+func (m *EventMonitor) deadLetter(ctx context.Context, handler EventHandler, event model.EventRoot, attempts int, runErr error) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return m.deadLetterRepo.Save(ctx, domain.DeadLetter{
+		EventUID:    event.EventUID,
+		EventType:   domain.EventType(event.EventType),
+		TenantID:    EventTenant(event),
+		HandlerName: handler.HandlerName(),
+		Attempts:    attempts,
+		LastError:   runErr.Error(),
+		Event:       string(body),
+	})
+}
+
+// consumeDeadLetters records the messages rejected to the broker's dead-letter queue in the dead-letter store, so
+// they are inspected and re-driven like handler failures instead of piling up in the queue. A message that cannot
+// be recorded is delivered again after a delay.
+func (m *EventMonitor) consumeDeadLetters(ctx context.Context, subscriber broker.DeadLetterSubscriber) {
+	msgs, err := subscriber.SubscribeDeadLetters(ctx)
+	if err != nil {
+		log.Errorf("❌ Failed to consume dead-letter queue: %v", err)
+		return
+	}
+	for msg := range msgs {
+		if err := m.recordRejected(ctx, msg); err != nil {
+			log.Errorf("❌ Failed to record rejected message, keeping it in the dead-letter queue: %v", err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(DefaultDeadLetterRequeueDelay):
+			}
+			if nackErr := msg.Nack(true); nackErr != nil {
+				log.Errorf("❌ Failed to requeue rejected message: %v", nackErr)
+			}
+			continue
+		}
+		m.ack(msg)
+	}
+}
+
+// recordRejected stores a rejected message as a dead letter of no handler, so re-driving it reaches every handler
+// of its event. A message that is not an event is kept as a JSON string to be inspected.
+func (m *EventMonitor) recordRejected(ctx context.Context, msg broker.Message) error {
+	deadLetter := domain.DeadLetter{Attempts: 1, LastError: "rejected by the event monitor"}
+	event, err := DecodeEvent(msg.Body, msg.Headers)
+	if err != nil {
+		body, _ := json.Marshal(string(msg.Body))
+		deadLetter.EventUID = WithEventIdentity(model.EventRoot{}).EventUID
+		deadLetter.TenantID = domain.DefaultTenantID
+		if tenantID := msg.Header(broker.HeaderTenantID); tenantID != "" {
+			deadLetter.TenantID = domain.TenantID(tenantID)
+		}
+		deadLetter.LastError = fmt.Sprintf("rejected by the event monitor: %v", err)
+		deadLetter.Event = string(body)
+		return m.deadLetterRepo.Save(ctx, deadLetter)
+	}
+
+	event = WithEventIdentity(event)
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	deadLetter.EventUID = event.EventUID
+	deadLetter.EventType = domain.EventType(event.EventType)
+	deadLetter.TenantID = EventTenant(event)
+	deadLetter.Event = string(body)
+	return m.deadLetterRepo.Save(ctx, deadLetter)
+}
+
+// ack acknowledges a processed message.
+func (m *EventMonitor) ack(msg broker.Message) {
+	if err := msg.Ack(); err != nil {
+		log.Errorf("❌ Failed to acknowledge message: %v", err)
+	}
+}
+
+// reject routes a message to the dead-letter queue.
+func (m *EventMonitor) reject(msg broker.Message) {
+	if err := msg.Nack(false); err != nil {
+		log.Errorf("❌ Failed to reject message: %v", err)
+	}
 }
@@ -55,13 +55,38 @@ func ConvertToDomainEvent(eventRoot model.EventRoot) (domain.Event, error) {
 	}
 
 	domainEvent := domain.Event{
-		ModelBase:   domain.NewModelBaseDefault(),
-		EventType:   domain.EventType(eventRoot.EventType),
-		EventUID:    eventRoot.EventUID,
-		Payload:     fx.NewValueOption(eventRoot.Payload),
-		PublishedAt: xtime.NewUtcTimeIgnoreZone(eventTime),
-		Comment:     fx.AsOption(eventRoot.Comment),
+		ModelBase:     domain.NewModelBaseDefault(),
+		EventType:     domain.EventType(eventRoot.EventType),
+		EventUID:      eventRoot.EventUID,
+		Payload:       fx.NewValueOption(eventRoot.Payload),
+		PublishedAt:   xtime.NewUtcTimeIgnoreZone(eventTime),
+		Comment:       fx.AsOption(eventRoot.Comment),
+		TenantID:      EventTenant(eventRoot),
+		SchemaVersion: SchemaVersion(eventRoot),
 	}
 
 	return domainEvent, nil
 }
+
+// ConvertToEventRoot converts a stored domain.Event back to the model.EventRoot it was received as.
+func ConvertToEventRoot(event domain.Event) model.EventRoot {
+	tenantID := string(event.TenantID)
+	eventRoot := model.EventRoot{
+		EventTimeUtc: event.PublishedAt.Inner().Format(time.RFC3339),
+		EventUID:     event.EventUID,
+		EventType:    string(event.EventType),
+		TenantID:     &tenantID,
+	}
+	if event.SchemaVersion > 0 {
+		version := int32(event.SchemaVersion)
+		eventRoot.SchemaVersion = &version
+	}
+	if event.Payload.HasValue {
+		eventRoot.Payload = event.Payload.Value
+	}
+	if event.Comment.HasValue {
+		comment := event.Comment.Value
+		eventRoot.Comment = &comment
+	}
+	return eventRoot
+}
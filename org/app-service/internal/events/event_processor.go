@@ -9,31 +9,43 @@ import (
 	"github.com/org/2112-space-lab/org/app-service/internal/domain"
 	domainenum "github.com/org/2112-space-lab/org/app-service/internal/domain/domain-enums"
 	model "github.com/org/2112-space-lab/org/app-service/internal/graphql/models/generated"
-	repository "github.com/org/2112-space-lab/org/app-service/internal/repositories"
 	fx "github.com/org/2112-space-lab/org/app-service/pkg/option"
 	xtime "github.com/org/2112-space-lab/org/app-service/pkg/time"
 )
 
-// EventProcessor manages event dispatching and execution with persistence.
+// EventProcessor manages event dispatching and execution with persistence. Each handler processes events on its own
+// worker pool, in order for the events of a same satellite.
 type EventProcessor struct {
 	eventQueue    chan model.EventRoot
 	eventHandlers map[model.EventType][]EventHandler
-	eventRepo     repository.EventRepository
-	handlerRepo   repository.EventHandlerRepository
+	eventRepo     EventStore
+	handlerRepo   HandlerLogStore
 	mutex         sync.Mutex
 	wg            sync.WaitGroup
+	poolConfig    WorkerPoolConfig
+	pools         map[EventHandler]*workerPool
+	stopPools     context.CancelFunc
 }
 
 // NewEventProcessor initializes an EventProcessor with event persistence.
-func NewEventProcessor(eventRepo repository.EventRepository, handlerRepo repository.EventHandlerRepository) *EventProcessor {
+func NewEventProcessor(eventRepo EventStore, handlerRepo HandlerLogStore) *EventProcessor {
 	return &EventProcessor{
 		eventQueue:    make(chan model.EventRoot, DefaultEventQueueSize),
 		eventHandlers: make(map[model.EventType][]EventHandler),
 		eventRepo:     eventRepo,
 		handlerRepo:   handlerRepo,
+		poolConfig:    DefaultWorkerPoolConfig(),
+		pools:         make(map[EventHandler]*workerPool),
 	}
 }
 
+// ConfigureWorkerPools sizes the worker pools of the handlers. It must be called before StartProcessing.
+func (ep *EventProcessor) ConfigureWorkerPools(config WorkerPoolConfig) {
+	ep.mutex.Lock()
+	defer ep.mutex.Unlock()
+	ep.poolConfig = config
+}
+
 // RegisterHandler registers an event handler for a specific event type.
 func (ep *EventProcessor) RegisterHandler(eventType model.EventType, handler EventHandler) {
 	ep.mutex.Lock()
@@ -45,6 +57,7 @@ func (ep *EventProcessor) RegisterHandler(eventType model.EventType, handler Eve
 
 // EmitEvent stores and queues an event for processing.
 func (ep *EventProcessor) BroadcastEvent(ctx context.Context, event model.EventRoot) error {
+	event = WithEventTenant(ctx, event)
 	ev, err := ConvertToDomainEvent(event)
 	if err != nil {
 		log.Errorf("❌ Failed to convert event to domain event: %v", err)
@@ -68,6 +81,10 @@ func (ep *EventProcessor) BroadcastEvent(ctx context.Context, event model.EventR
 func (ep *EventProcessor) StartProcessing(ctx context.Context) {
 	log.Info("📡 Event Processor started, listening for events...")
 
+	// The workers outlive ctx until the queued events are drained on shutdown.
+	poolCtx, stopPools := context.WithCancel(context.WithoutCancel(ctx))
+	ep.stopPools = stopPools
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -75,13 +92,15 @@ func (ep *EventProcessor) StartProcessing(ctx context.Context) {
 			ep.shutdown()
 			return
 		case event := <-ep.eventQueue:
-			ep.processEvent(ctx, event)
+			ep.processEvent(ctx, poolCtx, event)
 		}
 	}
 }
 
-// processEvent dispatches the event to all registered handlers and logs execution.
-func (ep *EventProcessor) processEvent(ctx context.Context, event model.EventRoot) {
+// processEvent submits the event to the worker pool of each of its handlers, within the tenant of the event,
+// blocking while a pool is full.
+func (ep *EventProcessor) processEvent(ctx context.Context, poolCtx context.Context, event model.EventRoot) {
+	ctx = EventContext(ctx, event)
 	ep.mutex.Lock()
 	handlers, exists := ep.eventHandlers[model.EventType(event.EventType)]
 	ep.mutex.Unlock()
@@ -91,39 +110,67 @@ func (ep *EventProcessor) processEvent(ctx context.Context, event model.EventRoo
 		return
 	}
 
+	// Queued jobs are drained on shutdown: they keep the values of ctx but not its cancellation.
+	jobCtx := context.WithoutCancel(ctx)
+	key := OrderingKey(event)
 	for _, handler := range handlers {
 		ep.wg.Add(1)
+		err := ep.poolFor(poolCtx, handler).submit(ctx, key, handlerJob{
+			ctx:   jobCtx,
+			event: event,
+			run:   func(ctx context.Context, event model.EventRoot) error { return ep.executeHandler(ctx, handler, event) },
+			done:  func(error) { ep.wg.Done() },
+		})
+		if err != nil {
+			ep.wg.Done()
+			log.Warnf("⚠️ Event %s not dispatched to handler %s: %v", event.EventUID, handler.HandlerName(), err)
+		}
+	}
+}
+
+// poolFor returns the worker pool of a handler, starting it on first use.
+func (ep *EventProcessor) poolFor(ctx context.Context, handler EventHandler) *workerPool {
+	ep.mutex.Lock()
+	defer ep.mutex.Unlock()
 
-		go func(h EventHandler) {
-			defer ep.wg.Done()
-
-			handler := domain.EventHandler{
-				EventID:     event.EventUID,
-				HandlerName: h.HandlerName(),
-				StartedAt:   xtime.UtcNow(),
-				Status:      domainenum.HandlerStates.Started(),
-			}
-
-			if err := ep.handlerRepo.Save(ctx, handler); err != nil {
-				log.Errorf("❌ Failed to log handler start: %v", err)
-			}
-
-			if err := h.Run(ctx, event); err != nil {
-				log.Errorf("❌ Error processing event %s: %v", event.EventType, err)
-
-				errorMsg := err.Error()
-				handler.Status = domainenum.HandlerStates.Failed()
-				handler.Error = fx.NewValueOption(errorMsg)
-			} else {
-				handler.Status = domainenum.HandlerStates.Completed()
-			}
-
-			handler.CompletedAt = fx.NewValueOption(xtime.UtcNow())
-			if err := ep.handlerRepo.Save(ctx, handler); err != nil {
-				log.Errorf("❌ Failed to update handler execution log: %v", err)
-			}
-		}(handler)
+	pool, ok := ep.pools[handler]
+	if !ok {
+		pool = newWorkerPool(ctx, handler, ep.poolConfig)
+		ep.pools[handler] = pool
 	}
+	return pool
+}
+
+// executeHandler runs a handler on an event and logs execution.
+func (ep *EventProcessor) executeHandler(ctx context.Context, h EventHandler, event model.EventRoot) error {
+	handler := domain.EventHandler{
+		ModelBase:   domain.NewModelBaseDefault(), // Identifies the execution, so completion updates its record
+		EventID:     event.EventUID,
+		HandlerName: h.HandlerName(),
+		StartedAt:   xtime.UtcNow(),
+		Status:      domainenum.HandlerStates.Started(),
+	}
+
+	if err := ep.handlerRepo.Save(ctx, handler); err != nil {
+		log.Errorf("❌ Failed to log handler start: %v", err)
+	}
+
+	runErr := h.Run(ctx, event)
+	if runErr != nil {
+		log.Errorf("❌ Error processing event %s: %v", event.EventType, runErr)
+
+		errorMsg := runErr.Error()
+		handler.Status = domainenum.HandlerStates.Failed()
+		handler.Error = fx.NewValueOption(errorMsg)
+	} else {
+		handler.Status = domainenum.HandlerStates.Completed()
+	}
+
+	handler.CompletedAt = fx.NewValueOption(xtime.UtcNow())
+	if err := ep.handlerRepo.Save(ctx, handler); err != nil {
+		log.Errorf("❌ Failed to update handler execution log: %v", err)
+	}
+	return runErr
 }
 
 // shutdown ensures all ongoing event processing completes before shutting down.
@@ -131,5 +178,8 @@ func (ep *EventProcessor) shutdown() {
 	log.Info("⚠️ Draining event queue before shutdown...")
 	close(ep.eventQueue)
 	ep.wg.Wait()
+	if ep.stopPools != nil {
+		ep.stopPools()
+	}
 	log.Info("✅ Event Processor shutdown complete.")
 }
@@ -0,0 +1,56 @@
+package event_handlers
+
+import (
+	"context"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/events"
+	model "github.com/org/2112-space-lab/org/app-service/internal/graphql/models/generated"
+	"github.com/org/2112-space-lab/org/app-service/internal/services"
+	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
+	"github.com/org/2112-space-lab/org/app-service/pkg/tracing"
+)
+
+// CoverageRefreshHandler refreshes cached coverage analytics on SATELLITE_TLE_PROPAGATED events. The passes of the
+// new track are refreshed again by the TileService once their mappings are computed.
+type CoverageRefreshHandler struct {
+	events.BaseHandler[model.SatelliteTlePropagated]
+	coverageService services.CoverageService
+}
+
+// NewCoverageRefreshHandler creates a new handler instance.
+func NewCoverageRefreshHandler(coverageService services.CoverageService) *CoverageRefreshHandler {
+	return &CoverageRefreshHandler{coverageService: coverageService}
+}
+
+func (h *CoverageRefreshHandler) HandlerName() string {
+	return "CoverageRefreshHandler"
+}
+
+// Delivery declares the handler idempotent: refreshing a coverage twice yields the same coverage.
+func (h *CoverageRefreshHandler) Delivery() events.HandlerDelivery {
+	return events.DeliveryIdempotent
+}
+
+// Run refreshes the coverage of every running context the propagated satellite belongs to,
+// or only of the context named by the event within the tenant of the event.
+func (h *CoverageRefreshHandler) Run(ctx context.Context, event model.EventRoot) (err error) {
+	ctx, span := tracing.NewSpan(ctx, "RunCoverageRefresh")
+	defer span.EndWithError(err)
+
+	payload, err := h.Parse(event.Payload)
+	if err != nil {
+		log.Errorf("❌ Failed to parse payload for SatelliteTlePropagated: %v", err)
+		return err
+	}
+
+	log.Debugf("🔄 Refreshing coverage after TLE propagation of %s", payload.SpaceID)
+	contextName := ""
+	if payload.ContextName != nil {
+		contextName = *payload.ContextName
+	}
+	if err = h.coverageService.RefreshForSatellite(ctx, payload.SpaceID, events.EventTenant(event), contextName); err != nil {
+		log.Errorf("❌ Failed to refresh coverage for SPACE ID %s: %v", payload.SpaceID, err)
+		return err
+	}
+	return nil
+}
@@ -12,7 +12,6 @@ import (
 	repository "github.com/org/2112-space-lab/org/app-service/internal/repositories"
 	"github.com/org/2112-space-lab/org/app-service/internal/services"
 	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
-	xtime "github.com/org/2112-space-lab/org/app-service/pkg/time"
 	"github.com/org/2112-space-lab/org/app-service/pkg/tracing"
 )
 
@@ -20,23 +19,29 @@ import (
 type RehydrateGameContextHandler struct {
 	events.BaseHandler[model.RehydrateGameContextRequested]
 	gameContextService services.ContextService
+	clockService       services.SimulationClockService
 	globalRepo         repository.GlobalPropertyRepository
 	eventEmitter       *events.EventEmitter
 	tleRepo            repository.TleRepository
+	rehydrationService *services.RehydrationService
 }
 
 // NewRehydrateGameContextHandler creates a new instance of the handler.
 func NewRehydrateGameContextHandler(
 	gameContextService services.ContextService,
+	clockService services.SimulationClockService,
 	eventEmitter *events.EventEmitter,
 	globalRepo repository.GlobalPropertyRepository,
 	tleRepo repository.TleRepository,
+	rehydrationService *services.RehydrationService,
 ) *RehydrateGameContextHandler {
 	return &RehydrateGameContextHandler{
 		gameContextService: gameContextService,
+		clockService:       clockService,
 		eventEmitter:       eventEmitter,
 		globalRepo:         globalRepo,
 		tleRepo:            tleRepo,
+		rehydrationService: rehydrationService,
 	}
 }
 
@@ -44,6 +49,17 @@ func (h *RehydrateGameContextHandler) HandlerName() string {
 	return "SatellitePositionHandler"
 }
 
+// Delivery runs a rehydration once per event: a duplicate would restart the propagation of every satellite of the
+// context.
+func (h *RehydrateGameContextHandler) Delivery() events.HandlerDelivery {
+	return events.DeliveryExactlyOnceEffect
+}
+
+// Concurrency rehydrates one context at a time: a rehydration publishes an event per TLE of its context.
+func (h *RehydrateGameContextHandler) Concurrency() int {
+	return 1
+}
+
 // Run processes the REHYDRATE_GAME_CONTEXT event.
 func (h *RehydrateGameContextHandler) Run(ctx context.Context, event model.EventRoot) (err error) {
 	ctx, span := tracing.NewSpan(ctx, "RunRehydrateEvent")
@@ -60,7 +76,9 @@ func (h *RehydrateGameContextHandler) Run(ctx context.Context, event model.Event
 	return h.HandleRehydrateGameContextEvent(ctx, event, payload)
 }
 
-// HandleRehydrateGameContextEvent rehydrates the game context and updates the repository.
+// HandleRehydrateGameContextEvent starts the rehydration of the game context and requests the propagation of each
+// of its satellites. The rehydration completes once every satellite is propagated and mapped, or times out. The
+// requests are enqueued in the outbox, so they are published only if the rehydration is recorded as started.
 func (h *RehydrateGameContextHandler) HandleRehydrateGameContextEvent(ctx context.Context, event model.EventRoot, payload *model.RehydrateGameContextRequested) (err error) {
 	_, span := tracing.NewSpan(ctx, "HandleRehydrateGameContextEvent")
 	defer span.EndWithError(err)
@@ -70,18 +88,13 @@ func (h *RehydrateGameContextHandler) HandleRehydrateGameContextEvent(ctx contex
 	var failureReason string
 	var tleCount int32
 	defer func() {
-		if err != nil {
-			ev, eventErr := event_builder.NewRehydrateGameContextFailedEvent(payload.Name, failureReason, tleCount)
-			if eventErr == nil {
-				_ = h.eventEmitter.PublishEvent(ctx, *ev)
-			}
-			log.Errorf("❌ Rehydration failed for context: %s | Reason: %s", payload.Name, failureReason)
-		} else {
-			ev, eventErr := event_builder.NewRehydrateGameContextSuccessEvent(payload.Name, tleCount)
-			if eventErr == nil {
-				_ = h.eventEmitter.PublishEvent(ctx, *ev)
-			}
-			log.Infof("✅ Rehydration process completed successfully for context: %s", payload.Name)
+		if err == nil {
+			return
+		}
+		log.Errorf("❌ Rehydration failed for context: %s | Reason: %s", payload.Name, failureReason)
+		ev, eventErr := event_builder.NewRehydrateGameContextFailedEvent(payload.Name, failureReason, tleCount)
+		if eventErr == nil {
+			_ = h.eventEmitter.PublishEvent(ctx, *ev)
 		}
 	}()
 
@@ -105,6 +118,24 @@ func (h *RehydrateGameContextHandler) HandleRehydrateGameContextEvent(ctx contex
 
 	tleCount = int32(len(tles))
 
+	// Positions start from the simulated time of the context, which may be paused or replaying the past.
+	startTime, err := h.clockService.Now(ctx, gameContext.ID)
+	if err != nil {
+		failureReason = fmt.Sprintf("Failed to read the clock of context %s: %v", gameContext.Name, err)
+		log.Errorf("❌ %s", failureReason)
+		return err
+	}
+
+	spaceIDs := make([]string, len(tles))
+	for i, tle := range tles {
+		spaceIDs[i] = tle.SpaceID
+	}
+	if _, err = h.rehydrationService.Start(ctx, gameContext, event.EventUID, startTime, spaceIDs); err != nil {
+		failureReason = fmt.Sprintf("Failed to start the rehydration of context %s: %v", gameContext.Name, err)
+		log.Errorf("❌ %s", failureReason)
+		return err
+	}
+
 	for _, tle := range tles {
 		msg := fmt.Sprintf("🛰 Rehydrating TLE for SPACE ID %s", tle.SpaceID)
 
@@ -113,25 +144,23 @@ func (h *RehydrateGameContextHandler) HandleRehydrateGameContextEvent(ctx contex
 			TleLine1:     tle.Line1,
 			TleLine2:     tle.Line2,
 			RedisKey:     fmt.Sprintf("tle:%s", tle.SpaceID),
-			StartTimeUtc: xtime.UtcNow().Inner().Format(time.RFC3339),
+			StartTimeUtc: startTime.UTC().Format(time.RFC3339),
+			ContextName:  &payload.Name,
 		}
 
 		ev, err := event_builder.NewSatelliteTlePropagationRequestedEvent(propagationPayload, &msg)
-		if err != nil {
-			failureReason = fmt.Sprintf("Failed to create propagation event for SPACE ID %s: %v", tle.SpaceID, err)
-			log.Errorf("❌ %s", failureReason)
-			return err
+		if err == nil {
+			err = h.eventEmitter.Enqueue(ctx, fmt.Sprintf("rehydration:%s", gameContext.Name), *ev)
 		}
-
-		err = h.eventEmitter.PublishEvent(ctx, *ev)
 		if err != nil {
-			failureReason = fmt.Sprintf("Failed to publish propagation event for SPACE ID %s: %v", tle.SpaceID, err)
+			failureReason = fmt.Sprintf("Failed to request the propagation of SPACE ID %s: %v", tle.SpaceID, err)
 			log.Errorf("❌ %s", failureReason)
 			return err
 		}
 
-		log.Infof("📤 Propagation event sent for SPACE ID %s", tle.SpaceID)
+		log.Infof("📤 Propagation event enqueued for SPACE ID %s", tle.SpaceID)
 	}
 
+	log.Infof("📤 Rehydration of context %s started: %d propagations requested", gameContext.Name, tleCount)
 	return nil
 }
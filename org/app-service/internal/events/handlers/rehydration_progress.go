@@ -0,0 +1,57 @@
+package event_handlers
+
+import (
+	"context"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	"github.com/org/2112-space-lab/org/app-service/internal/events"
+	model "github.com/org/2112-space-lab/org/app-service/internal/graphql/models/generated"
+	"github.com/org/2112-space-lab/org/app-service/internal/services"
+	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
+	"github.com/org/2112-space-lab/org/app-service/pkg/tracing"
+)
+
+// RehydrationProgressHandler advances the rehydration of a context on the SATELLITE_TLE_PROPAGATED events of its
+// satellites.
+type RehydrationProgressHandler struct {
+	events.BaseHandler[model.SatelliteTlePropagated]
+	rehydrationService *services.RehydrationService
+}
+
+// NewRehydrationProgressHandler creates a new handler instance.
+func NewRehydrationProgressHandler(rehydrationService *services.RehydrationService) *RehydrationProgressHandler {
+	return &RehydrationProgressHandler{rehydrationService: rehydrationService}
+}
+
+func (h *RehydrationProgressHandler) HandlerName() string {
+	return "RehydrationProgressHandler"
+}
+
+// Delivery declares the handler idempotent: a step advances once, whatever the number of deliveries.
+func (h *RehydrationProgressHandler) Delivery() events.HandlerDelivery {
+	return events.DeliveryIdempotent
+}
+
+// Run records the propagation of a satellite in the rehydration of the context named by the event, or of every
+// context awaiting it, within the tenant of the event.
+func (h *RehydrationProgressHandler) Run(ctx context.Context, event model.EventRoot) (err error) {
+	ctx, span := tracing.NewSpan(ctx, "RunRehydrationProgress")
+	defer span.EndWithError(err)
+
+	payload, err := h.Parse(event.Payload)
+	if err != nil {
+		log.Errorf("❌ Failed to parse payload for SatelliteTlePropagated: %v", err)
+		return err
+	}
+	contextName := ""
+	if payload.ContextName != nil {
+		contextName = *payload.ContextName
+	}
+
+	ctx = domain.WithTenant(ctx, events.EventTenant(event))
+	if err = h.rehydrationService.RecordPropagated(ctx, domain.GameContextName(contextName), payload.SpaceID); err != nil {
+		log.Errorf("❌ Failed to record the propagation of SPACE ID %s in a rehydration: %v", payload.SpaceID, err)
+		return err
+	}
+	return nil
+}
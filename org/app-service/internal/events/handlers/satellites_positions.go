@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"sync"
 	"time"
 
 	clients "github.com/org/2112-space-lab/org/app-service/internal/clients/redis"
@@ -17,27 +19,47 @@ import (
 	"github.com/org/2112-space-lab/org/app-service/pkg/tracing"
 )
 
-// SatellitePositionHandler listens for SATELLITE_TLE_PROPAGATED events.
+// minSimulationTick bounds how often a simulation checks its clock when it runs much faster than real time.
+const minSimulationTick = 100 * time.Millisecond
+
+// SatellitePositionHandler listens for SATELLITE_TLE_PROPAGATED events. Each satellite runs a single simulation: a new
+// propagation of the satellite replaces its running simulation.
 type SatellitePositionHandler struct {
 	events.BaseHandler[model.SatelliteTlePropagated]
 	satelliteService     services.SatelliteService
+	contextRepo          repository.ContextRepository
+	clockService         services.SimulationClockService
 	globalRepo           repository.GlobalPropertyRepository
 	eventEmitter         *events.EventEmitter
 	redisClient          *clients.RedisClient
 	mSatellitesPositions map[domain.SatelliteID][]model.SatellitePosition
+	simulations          map[domain.SatelliteID]*simulation
+	mutex                sync.Mutex
+}
+
+// simulation is the running simulation of a satellite.
+type simulation struct {
+	cancel context.CancelFunc
 }
 
 // NewSatellitePositionHandler creates a new handler instance.
 func NewSatellitePositionHandler(
 	satelliteService services.SatelliteService,
+	contextRepo repository.ContextRepository,
+	clockService services.SimulationClockService,
+	globalRepo repository.GlobalPropertyRepository,
 	eventEmitter *events.EventEmitter,
 	redisClient *clients.RedisClient,
 ) *SatellitePositionHandler {
 	return &SatellitePositionHandler{
 		satelliteService:     satelliteService,
+		contextRepo:          contextRepo,
+		clockService:         clockService,
+		globalRepo:           globalRepo,
 		eventEmitter:         eventEmitter,
 		redisClient:          redisClient,
 		mSatellitesPositions: make(map[domain.SatelliteID][]model.SatellitePosition),
+		simulations:          make(map[domain.SatelliteID]*simulation),
 	}
 }
 
@@ -45,6 +67,20 @@ func (h *SatellitePositionHandler) HandlerName() string {
 	return "SatellitePositionHandler"
 }
 
+// RetryPolicy retries Redis hiccups for longer than the default policy: a dropped update is never propagated again.
+func (h *SatellitePositionHandler) RetryPolicy() events.RetryPolicy {
+	return events.RetryPolicy{
+		MaxAttempts:     5,
+		InitialInterval: 500 * time.Millisecond,
+		MaxInterval:     15 * time.Second,
+	}
+}
+
+// Delivery declares the handler idempotent: a duplicate event overwrites the positions it already stored.
+func (h *SatellitePositionHandler) Delivery() events.HandlerDelivery {
+	return events.DeliveryIdempotent
+}
+
 // Run processes the SATELLITE_TLE_PROPAGATED event.
 func (h *SatellitePositionHandler) Run(ctx context.Context, event model.EventRoot) (err error) {
 	ctx, span := tracing.NewSpan(ctx, "Run")
@@ -62,10 +98,16 @@ func (h *SatellitePositionHandler) Run(ctx context.Context, event model.EventRoo
 }
 
 // HandleSatellitePositionEvent fetches positions from Redis, updates the in-memory list, and starts simulation.
+// The simulation follows the clock of the context named by the event, wall-clock time when it names none.
 func (h *SatellitePositionHandler) HandleSatellitePositionEvent(ctx context.Context, event model.EventRoot, payload *model.SatelliteTlePropagated) (err error) {
 	ctx, span := tracing.NewSpan(ctx, "HandleSatellitePositionEvent")
 	defer span.EndWithError(err)
 
+	contextID, err := h.contextID(ctx, payload)
+	if err != nil {
+		return err
+	}
+
 	log.Infof("🔍 Fetching positions from Redis for key: %s", payload.RedisKey)
 	positionsJSON, err := h.redisClient.Get(ctx, payload.RedisKey)
 	if err != nil {
@@ -85,95 +127,208 @@ func (h *SatellitePositionHandler) HandleSatellitePositionEvent(ctx context.Cont
 		log.Errorf("❌ Failed to fetch GetEventDetectorSimulationBufferDuration. Using default: %s", bufferDuration)
 	}
 
-	cutoffTime := time.Now().UTC().Add(-bufferDuration)
+	clock, err := h.clock(ctx, contextID)
+	if err != nil {
+		return err
+	}
+	cutoffTime := clock.Now(time.Now().UTC()).Add(-bufferDuration)
 	satelliteID := domain.SatelliteID(payload.SpaceID)
-	existingPositions, exists := h.mSatellitesPositions[satelliteID]
-	if exists {
-		existingPositions = append(existingPositions, newPositions...)
-		filteredPositions := []model.SatellitePosition{}
-		for _, pos := range existingPositions {
-
-			posTimeUtc, err := xtime.FromString(xtime.DateTimeFormat(pos.Timestamp))
-			if err != nil {
-				return err
-			}
-
-			if posTimeUtc.Inner().After(cutoffTime) {
-				filteredPositions = append(filteredPositions, pos)
-			}
+	h.mutex.Lock()
+	positions := append(h.mSatellitesPositions[satelliteID], newPositions...)
+	h.mutex.Unlock()
+	filteredPositions := []model.SatellitePosition{}
+	for _, pos := range positions {
+		posTimeUtc, err := xtime.FromString(xtime.DateTimeFormat(pos.Timestamp))
+		if err != nil {
+			return err
 		}
-
-		h.mSatellitesPositions[satelliteID] = filteredPositions
-	} else {
-		filteredPositions := []model.SatellitePosition{}
-
-		for _, pos := range newPositions {
-			posTimeUtc, err := xtime.FromString(xtime.DateTimeFormat(pos.Timestamp))
-			if err != nil {
-				return err
-			}
-			if posTimeUtc.Inner().After(cutoffTime) {
-				filteredPositions = append(filteredPositions, pos)
-			}
+		if posTimeUtc.Inner().After(cutoffTime) {
+			filteredPositions = append(filteredPositions, pos)
 		}
-		h.mSatellitesPositions[satelliteID] = filteredPositions
 	}
+	sort.SliceStable(filteredPositions, func(i, j int) bool {
+		return filteredPositions[i].Timestamp < filteredPositions[j].Timestamp
+	})
+	h.mutex.Lock()
+	h.mSatellitesPositions[satelliteID] = filteredPositions
+	h.mutex.Unlock()
 
-	log.Infof("✅ Stored %d positions in-memory for satellite %s (Buffer Duration: %s)", len(h.mSatellitesPositions[satelliteID]), payload.RedisKey, bufferDuration)
+	log.Infof("✅ Stored %d positions in-memory for satellite %s (Buffer Duration: %s)", len(filteredPositions), payload.RedisKey, bufferDuration)
 
 	startTimeUtc, err := xtime.FromString(xtime.DateTimeFormat(payload.StartTimeUtc))
 	if err != nil {
 		return err
 	}
 
-	timeInterval := time.Duration(*payload.IntervalSeconds * int32(time.Second))
-	simulationDuration := time.Duration(*payload.DurationMinutes * int32(time.Second))
-	startIndex := 0
+	timeInterval, err := h.globalRepo.GetEventDetectorSimulationInterval(ctx, repository.DefaultSimulationInterval)
+	if err != nil {
+		log.Tracef("Using default simulation interval [%s]: %v", timeInterval, err)
+	}
+	if payload.IntervalSeconds != nil {
+		timeInterval = time.Duration(*payload.IntervalSeconds) * time.Second
+	}
+	simulationDuration, err := h.globalRepo.GetEventDetectorSimulationDuration(ctx, repository.DefdaultSimulationDuration)
+	if err != nil {
+		log.Tracef("Using default simulation duration [%s]: %v", simulationDuration, err)
+	}
+	if payload.DurationMinutes != nil {
+		simulationDuration = time.Duration(*payload.DurationMinutes) * time.Minute
+	}
 
-	go h.startSimulation(ctx, payload.RedisKey, startTimeUtc, timeInterval, simulationDuration, startIndex, h.mSatellitesPositions[satelliteID])
+	simulationCtx, running := h.replaceSimulation(ctx, satelliteID)
+	go func() {
+		defer h.endSimulation(satelliteID, running)
+		h.startSimulation(simulationCtx, contextID, payload.RedisKey, startTimeUtc.Inner().Add(simulationDuration), timeInterval, filteredPositions)
+	}()
 
 	return nil
 }
 
-// startSimulation simulates the movement of the satellite over time.
-func (h *SatellitePositionHandler) startSimulation(ctx context.Context, satelliteKey string, startTime xtime.UtcTime, simulationInterval time.Duration, simulationDuration time.Duration, positionIndex int, positions []model.SatellitePosition) (err error) {
+// replaceSimulation stops the running simulation of a satellite and returns the context of the next one.
+func (h *SatellitePositionHandler) replaceSimulation(ctx context.Context, satelliteID domain.SatelliteID) (context.Context, *simulation) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if running, ok := h.simulations[satelliteID]; ok {
+		running.cancel()
+	}
+	simulationCtx, cancel := context.WithCancel(ctx)
+	running := &simulation{cancel: cancel}
+	h.simulations[satelliteID] = running
+	return simulationCtx, running
+}
+
+// endSimulation releases a simulation once it returned, unless it was already replaced.
+func (h *SatellitePositionHandler) endSimulation(satelliteID domain.SatelliteID, running *simulation) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	running.cancel()
+	if h.simulations[satelliteID] == running {
+		delete(h.simulations, satelliteID)
+	}
+}
+
+// startSimulation publishes the position of the satellite at the simulated time of its context until endTime.
+// Each check publishes the latest position at or before the clock, so pausing, seeking and changing the speed of
+// the clock take effect once the clock is read again, every refresh period of the clock. A failed read is retried
+// on the next check.
+func (h *SatellitePositionHandler) startSimulation(ctx context.Context, contextID string, satelliteKey string, endTime time.Time, simulationInterval time.Duration, positions []model.SatellitePosition) (err error) {
 	ctx, span := tracing.NewSpan(ctx, "startSimulation")
 	defer span.EndWithError(err)
 
 	log.Infof("🛰 Starting simulation for satellite %s", satelliteKey)
 
-	simulationSteps, err := h.globalRepo.GetEventDetectorSimulationSteps(ctx, repository.DefaultSimulationSteps)
+	positionTimes := make([]time.Time, len(positions))
+	for i, pos := range positions {
+		posTimeUtc, err := xtime.FromString(xtime.DateTimeFormat(pos.Timestamp))
+		if err != nil {
+			return err
+		}
+		positionTimes[i] = posTimeUtc.Inner()
+	}
+
+	refresh, err := h.globalRepo.GetSimulationClockRefresh(ctx, repository.DefaultSimulationClockRefresh)
 	if err != nil {
-		log.Errorf("❌ Failed to fetch GetEventDetectorSimulationInterval take default value: %t", simulationSteps)
+		log.Tracef("Using default simulation clock refresh [%s]: %v", refresh, err)
+	}
+	if refresh <= 0 {
+		refresh = repository.DefaultSimulationClockRefresh
 	}
 
-	totalPositions := len(positions)
+	var clock domain.SimulationClock
+	var clockReadAt time.Time
+	published := -1
+	for {
+		now := time.Now().UTC()
+		if clockReadAt.IsZero() || now.Sub(clockReadAt) >= refresh {
+			current, err := h.clock(ctx, contextID)
+			switch {
+			case err == nil:
+				clock, clockReadAt = current, now
+			case clockReadAt.IsZero():
+				log.Warnf("⚠️ Failed to read clock for %s, retrying: %v", satelliteKey, err)
+				if err := waitSimulationTick(ctx, refresh); err != nil {
+					return err
+				}
+				continue
+			default:
+				log.Warnf("⚠️ Failed to read clock for %s, keeping the last one: %v", satelliteKey, err)
+			}
+		}
+		simulatedNow := clock.Now(now)
+		if !simulatedNow.Before(endTime) {
+			break
+		}
 
-	for time.Since(startTime.Inner()) < simulationDuration {
-		if positionIndex >= totalPositions {
+		positionIndex := sort.Search(len(positionTimes), func(i int) bool {
+			return positionTimes[i].After(simulatedNow)
+		}) - 1
+		if positionIndex == len(positions)-1 && published == positionIndex {
 			log.Warnf("⏳ No more positions to simulate for %s", satelliteKey)
 			break
 		}
 
-		currentPosition := positions[positionIndex]
-		log.Tracef("📍 Satellite %s - Position: Lat=%.6f, Lon=%.6f, Alt=%.2f", satelliteKey, currentPosition.Latitude, currentPosition.Longitude, currentPosition.Altitude)
+		if positionIndex >= 0 && positionIndex != published {
+			currentPosition := positions[positionIndex]
+			log.Tracef("📍 Satellite %s - Position: Lat=%.6f, Lon=%.6f, Alt=%.2f", satelliteKey, currentPosition.Latitude, currentPosition.Longitude, currentPosition.Altitude)
 
-		eventJSON, err := json.Marshal(currentPosition)
-		if err != nil {
-			log.Errorf("❌ Failed to parse satellite positions JSON: %v", err)
-			return err
-		}
+			eventJSON, err := json.Marshal(currentPosition)
+			if err != nil {
+				log.Errorf("❌ Failed to parse satellite positions JSON: %v", err)
+				return err
+			}
 
-		h.eventEmitter.PublishEvent(ctx, model.EventRoot{
-			EventType: string(model.EventTypeSatellitePositionUpdated),
-			EventUID:  fmt.Sprintf("%s-%d", satelliteKey, positionIndex),
-			Payload:   string(eventJSON),
-		})
+			h.eventEmitter.PublishEvent(ctx, model.EventRoot{
+				EventType: string(model.EventTypeSatellitePositionUpdated),
+				EventUID:  fmt.Sprintf("%s-%d", satelliteKey, positionIndex),
+				Payload:   string(eventJSON),
+			})
+			published = positionIndex
+		}
 
-		time.Sleep(simulationInterval)
-		positionIndex += int(simulationSteps)
+		wait := clock.WallDuration(simulationInterval)
+		if clock.Paused {
+			wait = simulationInterval
+		}
+		if err := waitSimulationTick(ctx, wait); err != nil {
+			return err
+		}
 	}
 
 	log.Infof("✅ Simulation completed for satellite %s", satelliteKey)
 	return nil
 }
+
+// contextID resolves the context named by the event within the tenant of the event, empty when it names none.
+func (h *SatellitePositionHandler) contextID(ctx context.Context, payload *model.SatelliteTlePropagated) (string, error) {
+	if payload.ContextName == nil || *payload.ContextName == "" {
+		return "", nil
+	}
+	gameContext, err := h.contextRepo.FindByUniqueName(ctx, domain.GameContextName(*payload.ContextName))
+	if err != nil {
+		return "", fmt.Errorf("failed to find context %s: %w", *payload.ContextName, err)
+	}
+	return gameContext.ID, nil
+}
+
+// clock returns the simulation clock of a context, wall-clock time without one.
+func (h *SatellitePositionHandler) clock(ctx context.Context, contextID string) (domain.SimulationClock, error) {
+	if contextID == "" {
+		return domain.RealTimeClock("", time.Now().UTC()), nil
+	}
+	return h.clockService.ClockFor(ctx, contextID)
+}
+
+// waitSimulationTick waits for the next check of a simulation, at least minSimulationTick.
+func waitSimulationTick(ctx context.Context, wait time.Duration) error {
+	if wait < minSimulationTick {
+		wait = minSimulationTick
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(wait):
+		return nil
+	}
+}
@@ -0,0 +1,32 @@
+package events
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	eventQueueDepth = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "event_monitor_queue_depth",
+			Help: "Broker messages received and waiting to be dispatched to handlers.",
+		},
+	)
+	handlerQueueDepth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "event_handler_queue_depth",
+			Help: "Events waiting for a worker of a handler.",
+		},
+		[]string{"handler"},
+	)
+	handlerInFlight = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "event_handler_in_flight",
+			Help: "Events being processed by a handler.",
+		},
+		[]string{"handler"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(eventQueueDepth)
+	prometheus.MustRegister(handlerQueueDepth)
+	prometheus.MustRegister(handlerInFlight)
+}
@@ -0,0 +1,99 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/events/schemas"
+	model "github.com/org/2112-space-lab/org/app-service/internal/graphql/models/generated"
+)
+
+// DefaultSchemaVersion is the schema version of events published without one.
+const DefaultSchemaVersion = 1
+
+var (
+	// ErrInvalidPayload is returned for payloads not matching the schema of their event type.
+	ErrInvalidPayload = errors.New("invalid event payload")
+	// ErrUnsupportedSchemaVersion is returned for payloads of a schema version this service cannot migrate.
+	ErrUnsupportedSchemaVersion = errors.New("unsupported event schema version")
+)
+
+// Upcaster migrates the decoded payload of an event from one schema version to the next.
+type Upcaster func(event model.EventRoot, payload map[string]any) (map[string]any, error)
+
+var (
+	upcastersMu sync.RWMutex
+	upcasters   = map[model.EventType]map[int]Upcaster{}
+)
+
+// RegisterUpcaster registers the migration of the payloads of an event type from fromVersion to fromVersion+1.
+func RegisterUpcaster(eventType model.EventType, fromVersion int, upcaster Upcaster) {
+	upcastersMu.Lock()
+	defer upcastersMu.Unlock()
+
+	if upcasters[eventType] == nil {
+		upcasters[eventType] = map[int]Upcaster{}
+	}
+	upcasters[eventType][fromVersion] = upcaster
+}
+
+func upcasterFor(eventType model.EventType, fromVersion int) (Upcaster, bool) {
+	upcastersMu.RLock()
+	defer upcastersMu.RUnlock()
+
+	upcaster, ok := upcasters[eventType][fromVersion]
+	return upcaster, ok
+}
+
+// SchemaVersion returns the payload schema version of an event, DefaultSchemaVersion when unset.
+func SchemaVersion(event model.EventRoot) int {
+	if event.SchemaVersion == nil {
+		return DefaultSchemaVersion
+	}
+	return int(*event.SchemaVersion)
+}
+
+// NormalizePayload migrates the payload of an event to the current schema version of its event type through the
+// registered upcasters, stamps that version and validates the payload against its schema. Events without a schema
+// are returned unchanged.
+func NormalizePayload(event model.EventRoot) (model.EventRoot, error) {
+	schema, ok, err := schemas.ForEventType(event.EventType)
+	if err != nil || !ok {
+		return event, err
+	}
+
+	version := SchemaVersion(event)
+	if version > schema.Version {
+		return event, fmt.Errorf("%w: %s version %d, latest known is %d", ErrUnsupportedSchemaVersion, event.EventType, version, schema.Version)
+	}
+
+	if version < schema.Version {
+		var payload map[string]any
+		if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+			return event, fmt.Errorf("%w: %s: %v", ErrInvalidPayload, event.EventType, err)
+		}
+		for ; version < schema.Version; version++ {
+			upcaster, ok := upcasterFor(model.EventType(event.EventType), version)
+			if !ok {
+				return event, fmt.Errorf("%w: no upcaster for %s version %d", ErrUnsupportedSchemaVersion, event.EventType, version)
+			}
+			if payload, err = upcaster(event, payload); err != nil {
+				return event, fmt.Errorf("failed to upcast %s from version %d: %w", event.EventType, version, err)
+			}
+		}
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return event, err
+		}
+		event.Payload = string(body)
+	}
+
+	if err := schema.Validate([]byte(event.Payload)); err != nil {
+		return event, fmt.Errorf("%w: %s version %d: %v", ErrInvalidPayload, event.EventType, version, err)
+	}
+	current := int32(schema.Version)
+	event.SchemaVersion = &current
+	return event, nil
+}
@@ -0,0 +1,96 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	model "github.com/org/2112-space-lab/org/app-service/internal/graphql/models/generated"
+)
+
+func TestNormalizePayload(t *testing.T) {
+	v1, v2, v3 := int32(1), int32(2), int32(3)
+	current := `{"spaceID":"25544","tleLine1":"1 25544U","tleLine2":"2 25544","redis_key":"k","startTimeUtc":"2026-10-01T12:00:00Z"}`
+
+	tests := []struct {
+		name            string
+		event           model.EventRoot
+		expectedErr     error
+		expectedVersion int
+		expectedFields  map[string]any
+	}{
+		{
+			name:            "Current version",
+			event:           model.EventRoot{EventType: "SATELLITE_TLE_PROPAGATED", SchemaVersion: &v2, Payload: current},
+			expectedVersion: 2,
+			expectedFields:  map[string]any{"spaceID": "25544"},
+		},
+		{
+			name:            "Unversioned payload with current names",
+			event:           model.EventRoot{EventType: "SATELLITE_TLE_PROPAGATED", Payload: current},
+			expectedVersion: 2,
+			expectedFields:  map[string]any{"spaceID": "25544", "startTimeUtc": "2026-10-01T12:00:00Z"},
+		},
+		{
+			name: "Version 1 upcast",
+			event: model.EventRoot{
+				EventType:     "SATELLITE_TLE_PROPAGATION_REQUESTED",
+				EventTimeUtc:  "2026-10-01T13:00:00Z",
+				SchemaVersion: &v1,
+				Payload:       `{"noradId":"25544","tleLine1":"1 25544U","tleLine2":"2 25544","store_key":"k","timeInterval":15}`,
+			},
+			expectedVersion: 2,
+			expectedFields:  map[string]any{"spaceID": "25544", "redis_key": "k", "intervalSeconds": float64(15), "startTimeUtc": "2026-10-01T13:00:00Z"},
+		},
+		{
+			name:        "Missing required property",
+			event:       model.EventRoot{EventType: "SATELLITE_TLE_PROPAGATED", SchemaVersion: &v2, Payload: `{"spaceID":"25544"}`},
+			expectedErr: ErrInvalidPayload,
+		},
+		{
+			name:        "Wrong property type",
+			event:       model.EventRoot{EventType: "REHYDRATE_GAME_CONTEXT_SUCCESS", Payload: `{"name":"ctx","nbSatellites":"12","completedAt":"2026-10-01T12:00:00Z"}`},
+			expectedErr: ErrInvalidPayload,
+		},
+		{
+			name:        "Newer version",
+			event:       model.EventRoot{EventType: "SATELLITE_TLE_PROPAGATED", SchemaVersion: &v3, Payload: current},
+			expectedErr: ErrUnsupportedSchemaVersion,
+		},
+		{
+			name:            "Event type without schema",
+			event:           model.EventRoot{EventType: "SYSTEM_HEALTH_CHECKED", Payload: `not json`},
+			expectedVersion: DefaultSchemaVersion,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizePayload(tt.event)
+			if tt.expectedErr != nil {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Fatalf("Expected %v, but got %v", tt.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+			if version := SchemaVersion(got); version != tt.expectedVersion {
+				t.Errorf("Expected version %d, but got %d", tt.expectedVersion, version)
+			}
+			if len(tt.expectedFields) == 0 {
+				return
+			}
+			var payload map[string]any
+			if err := json.Unmarshal([]byte(got.Payload), &payload); err != nil {
+				t.Fatalf("Expected a JSON payload, but got %v", err)
+			}
+			for field, expected := range tt.expectedFields {
+				if payload[field] != expected {
+					t.Errorf("Expected %s to be %v, but got %v", field, expected, payload[field])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	model "github.com/org/2112-space-lab/org/app-service/internal/graphql/models/generated"
+	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
+)
+
+// RetryPolicy bounds how often and how fast a failing handler is run again for the same event.
+type RetryPolicy struct {
+	MaxAttempts     int           // Runs including the first one
+	InitialInterval time.Duration // Wait after the first failure, doubled after each next one
+	MaxInterval     time.Duration // Longest wait between two runs
+}
+
+// DefaultRetryPolicy applies to handlers without a policy of their own.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:     3,
+	InitialInterval: 500 * time.Millisecond,
+	MaxInterval:     10 * time.Second,
+}
+
+// RetryableHandler is implemented by handlers choosing their retry policy. A handler returning an error wrapped
+// with backoff.Permanent is not retried.
+type RetryableHandler interface {
+	RetryPolicy() RetryPolicy
+}
+
+// HandlerRetryPolicy returns the retry policy of a handler, DefaultRetryPolicy when it has none.
+func HandlerRetryPolicy(handler EventHandler) RetryPolicy {
+	if retryable, ok := handler.(RetryableHandler); ok {
+		return retryable.RetryPolicy()
+	}
+	return DefaultRetryPolicy
+}
+
+// newBackOff returns the backoff of the policy; the first retry waits InitialInterval.
+func (p RetryPolicy) newBackOff(ctx context.Context) backoff.BackOff {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = p.InitialInterval
+	b.MaxInterval = p.MaxInterval
+	b.Multiplier = 2
+	b.RandomizationFactor = 0
+	b.MaxElapsedTime = 0
+
+	retries := p.MaxAttempts - 1
+	if retries < 0 {
+		retries = 0
+	}
+	return backoff.WithContext(backoff.WithMaxRetries(b, uint64(retries)), ctx)
+}
+
+// runWithRetry runs an event through run until it succeeds, fails permanently or exhausts the retry policy of
+// handler. It returns the number of runs and the error of the last one.
+func runWithRetry(ctx context.Context, handler EventHandler, event model.EventRoot, run func(ctx context.Context, event model.EventRoot) error) (attempts int, err error) {
+	err = backoff.RetryNotify(func() error {
+		attempts++
+		return run(ctx, event)
+	}, HandlerRetryPolicy(handler).newBackOff(ctx), func(err error, wait time.Duration) {
+		log.Warnf("⚠️ Handler %s failed on event %s (attempt %d), retrying in %s: %v", handler.HandlerName(), event.EventUID, attempts, wait, err)
+	})
+	return attempts, err
+}
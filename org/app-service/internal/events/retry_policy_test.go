@@ -0,0 +1,60 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	model "github.com/org/2112-space-lab/org/app-service/internal/graphql/models/generated"
+)
+
+type flakyHandler struct {
+	failures int
+	err      error
+	policy   RetryPolicy
+	runs     int
+}
+
+func (h *flakyHandler) Run(ctx context.Context, event model.EventRoot) error {
+	h.runs++
+	if h.runs <= h.failures {
+		return h.err
+	}
+	return nil
+}
+
+func (h *flakyHandler) HandlerName() string { return "FlakyHandler" }
+
+func (h *flakyHandler) RetryPolicy() RetryPolicy { return h.policy }
+
+func TestRunWithRetry(t *testing.T) {
+	transient := errors.New("redis: connection reset")
+	policy := RetryPolicy{MaxAttempts: 3, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond}
+
+	tests := []struct {
+		name             string
+		handler          *flakyHandler
+		expectedAttempts int
+		expectedErr      bool
+	}{
+		{name: "Succeeds at once", handler: &flakyHandler{policy: policy}, expectedAttempts: 1},
+		{name: "Recovers from transient failure", handler: &flakyHandler{failures: 2, err: transient, policy: policy}, expectedAttempts: 3},
+		{name: "Gives up after max attempts", handler: &flakyHandler{failures: 5, err: transient, policy: policy}, expectedAttempts: 3, expectedErr: true},
+		{name: "Permanent failure is not retried", handler: &flakyHandler{failures: 5, err: backoff.Permanent(transient), policy: policy}, expectedAttempts: 1, expectedErr: true},
+		{name: "Single attempt policy", handler: &flakyHandler{failures: 1, err: transient, policy: RetryPolicy{MaxAttempts: 1}}, expectedAttempts: 1, expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts, err := runWithRetry(context.Background(), tt.handler, model.EventRoot{EventUID: "event-uid"}, tt.handler.Run)
+			if attempts != tt.expectedAttempts {
+				t.Errorf("Expected %d attempts, but got %d", tt.expectedAttempts, attempts)
+			}
+			if (err != nil) != tt.expectedErr {
+				t.Errorf("Expected error %t, but got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// Package schemas holds the JSON Schemas of event payloads, generated from the GraphQL event types by
+// packages/graphql-apis (nx run app-service:update), and validates payloads against them.
+package schemas
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+//go:embed *.schema.json
+var files embed.FS
+
+// Schema is the subset of JSON Schema generated for event payloads.
+type Schema struct {
+	ID         string             `json:"$id"`
+	Type       Types              `json:"type"`
+	EventTypes []string           `json:"x-event-types"`
+	Version    int                `json:"x-schema-version"`
+	Properties map[string]*Schema `json:"properties"`
+	Required   []string           `json:"required"`
+	Items      *Schema            `json:"items"`
+	Enum       []any              `json:"enum"`
+}
+
+// Types lists the JSON types a value may have, written as a single type or an array of types.
+type Types []string
+
+// UnmarshalJSON accepts a single type as well as an array of types.
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = Types{single}
+		return nil
+	}
+	var several []string
+	if err := json.Unmarshal(data, &several); err != nil {
+		return err
+	}
+	*t = several
+	return nil
+}
+
+var (
+	loadOnce sync.Once
+	byEvent  map[string]*Schema
+	loadErr  error
+)
+
+// ForEventType returns the payload schema of an event type; false when the event type has no schema.
+func ForEventType(eventType string) (*Schema, bool, error) {
+	loadOnce.Do(func() { byEvent, loadErr = load() })
+	if loadErr != nil {
+		return nil, false, loadErr
+	}
+	schema, ok := byEvent[eventType]
+	return schema, ok, nil
+}
+
+// load indexes the embedded schemas by the event types they describe.
+func load() (map[string]*Schema, error) {
+	entries, err := files.ReadDir(".")
+	if err != nil {
+		return nil, err
+	}
+	index := map[string]*Schema{}
+	for _, entry := range entries {
+		body, err := files.ReadFile(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		var schema Schema
+		if err := json.Unmarshal(body, &schema); err != nil {
+			return nil, fmt.Errorf("invalid schema %s: %w", entry.Name(), err)
+		}
+		for _, eventType := range schema.EventTypes {
+			if other, ok := index[eventType]; ok {
+				return nil, fmt.Errorf("event type %s described by both %s and %s", eventType, other.ID, schema.ID)
+			}
+			index[eventType] = &schema
+		}
+	}
+	return index, nil
+}
+
+// Validate checks a JSON payload against the schema and reports every violation.
+func (s *Schema) Validate(payload []byte) error {
+	var value any
+	if err := json.Unmarshal(payload, &value); err != nil {
+		return fmt.Errorf("payload is not valid JSON: %w", err)
+	}
+	var violations []string
+	s.validate("$", value, &violations)
+	if len(violations) > 0 {
+		return errors.New(strings.Join(violations, "; "))
+	}
+	return nil
+}
+
+func (s *Schema) validate(path string, value any, violations *[]string) {
+	kind := jsonType(value)
+	if len(s.Type) > 0 && !s.allows(kind) {
+		*violations = append(*violations, fmt.Sprintf("%s: expected %s, got %s", path, strings.Join(s.Type, " or "), kind))
+		return
+	}
+	if len(s.Enum) > 0 && !s.enumerates(value) {
+		*violations = append(*violations, fmt.Sprintf("%s: %v is not one of %v", path, value, s.Enum))
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				*violations = append(*violations, fmt.Sprintf("%s: missing required property %s", path, name))
+			}
+		}
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		// Unknown properties are allowed, so producers can add optional fields before consumers know them.
+		for _, name := range names {
+			if property, ok := s.Properties[name]; ok {
+				property.validate(path+"."+name, v[name], violations)
+			}
+		}
+	case []any:
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, violations)
+			}
+		}
+	}
+}
+
+// allows reports whether the schema accepts a value of the JSON type kind; integers are numbers too.
+func (s *Schema) allows(kind string) bool {
+	for _, t := range s.Type {
+		if t == kind || (t == "number" && kind == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Schema) enumerates(value any) bool {
+	for _, allowed := range s.Enum {
+		if allowed == value {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonType names the JSON type of a decoded value.
+func jsonType(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if v == math.Trunc(v) && !math.IsInf(v, 0) {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
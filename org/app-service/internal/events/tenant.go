@@ -0,0 +1,38 @@
+package events
+
+import (
+	"context"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/clients/broker"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	model "github.com/org/2112-space-lab/org/app-service/internal/graphql/models/generated"
+)
+
+// EventTenant returns the tenant owning an event, the default tenant for events published without one.
+func EventTenant(event model.EventRoot) domain.TenantID {
+	if event.TenantID == nil || *event.TenantID == "" {
+		return domain.DefaultTenantID
+	}
+	return domain.TenantID(*event.TenantID)
+}
+
+// WithEventTenant stamps an event without a tenant with the tenant of ctx.
+func WithEventTenant(ctx context.Context, event model.EventRoot) model.EventRoot {
+	if event.TenantID == nil || *event.TenantID == "" {
+		tenantID := string(domain.TenantFromContext(ctx))
+		event.TenantID = &tenantID
+	}
+	return event
+}
+
+// EventContext scopes ctx to the tenant of an event, so its handlers only reach that tenant's data.
+func EventContext(ctx context.Context, event model.EventRoot) context.Context {
+	return domain.WithTenant(ctx, EventTenant(event))
+}
+
+// TenantHeader returns the broker headers routing an event to consumers bound to its tenant.
+func TenantHeader(event model.EventRoot) *broker.Header {
+	header := broker.NewHeader()
+	header.AddField(broker.HeaderTenantID, string(EventTenant(event)))
+	return header
+}
@@ -0,0 +1,42 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/clients/broker"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	model "github.com/org/2112-space-lab/org/app-service/internal/graphql/models/generated"
+)
+
+func TestEventTenantIsolation(t *testing.T) {
+	alpha, empty := "org_alpha", ""
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		event    model.EventRoot
+		expected domain.TenantID
+	}{
+		{name: "Unscoped event", ctx: context.Background(), event: model.EventRoot{}, expected: domain.DefaultTenantID},
+		{name: "Empty tenant", ctx: context.Background(), event: model.EventRoot{TenantID: &empty}, expected: domain.DefaultTenantID},
+		{name: "Stamped by publisher", ctx: domain.WithTenant(context.Background(), "org_beta"), event: model.EventRoot{}, expected: "org_beta"},
+		{name: "Publisher keeps owner", ctx: domain.WithTenant(context.Background(), "org_beta"), event: model.EventRoot{TenantID: &alpha}, expected: "org_alpha"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := WithEventTenant(tt.ctx, tt.event)
+
+			if got := EventTenant(event); got != tt.expected {
+				t.Errorf("Expected event tenant %s, but got %s", tt.expected, got)
+			}
+			if got := domain.TenantFromContext(EventContext(context.Background(), event)); got != tt.expected {
+				t.Errorf("Expected handlers scoped to %s, but got %s", tt.expected, got)
+			}
+			if got := TenantHeader(event).Fields[broker.HeaderTenantID]; got != string(tt.expected) {
+				t.Errorf("Expected header %s, but got %v", tt.expected, got)
+			}
+		})
+	}
+}
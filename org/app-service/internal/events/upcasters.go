@@ -0,0 +1,41 @@
+package events
+
+import (
+	model "github.com/org/2112-space-lab/org/app-service/internal/graphql/models/generated"
+)
+
+func init() {
+	RegisterUpcaster(model.EventTypeSatelliteTlePropagated, 1, upcastSatelliteTlePropagatedV1)
+	RegisterUpcaster(model.EventTypeSatelliteTlePropagationRequested, 1, upcastSatelliteTlePropagatedV1)
+}
+
+// satelliteTlePropagatedV1Renames maps the version 1 field names still sent by older propagators, in camel or
+// snake case, to their version 2 name.
+var satelliteTlePropagatedV1Renames = map[string]string{
+	"noradId":       "spaceID",
+	"space_id":      "spaceID",
+	"tle_line_1":    "tleLine1",
+	"tle_line_2":    "tleLine2",
+	"store_key":     "redis_key",
+	"timeInterval":  "intervalSeconds",
+	"time_interval": "intervalSeconds",
+}
+
+// upcastSatelliteTlePropagatedV1 renames version 1 fields and starts the propagation at the event time when the
+// payload has no start time. Events stored before versioning already use version 2 names and are kept as is.
+func upcastSatelliteTlePropagatedV1(event model.EventRoot, payload map[string]any) (map[string]any, error) {
+	for from, to := range satelliteTlePropagatedV1Renames {
+		value, ok := payload[from]
+		if !ok {
+			continue
+		}
+		delete(payload, from)
+		if _, exists := payload[to]; !exists {
+			payload[to] = value
+		}
+	}
+	if _, ok := payload["startTimeUtc"]; !ok {
+		payload["startTimeUtc"] = event.EventTimeUtc
+	}
+	return payload, nil
+}
@@ -0,0 +1,134 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"hash/fnv"
+
+	model "github.com/org/2112-space-lab/org/app-service/internal/graphql/models/generated"
+)
+
+const (
+	DefaultHandlerWorkers   = 4  // Events a handler processes at once
+	DefaultHandlerQueueSize = 16 // Events waiting per worker before dispatching blocks
+)
+
+// WorkerPoolConfig sizes the worker pool of each handler.
+type WorkerPoolConfig struct {
+	Workers   int // Workers of the handlers not declaring their own concurrency
+	QueueSize int // Events waiting per worker
+}
+
+// DefaultWorkerPoolConfig returns the worker pool configuration used until one is configured.
+func DefaultWorkerPoolConfig() WorkerPoolConfig {
+	return WorkerPoolConfig{Workers: DefaultHandlerWorkers, QueueSize: DefaultHandlerQueueSize}
+}
+
+// ConcurrentHandler is implemented by handlers declaring how many events they process at once.
+type ConcurrentHandler interface {
+	Concurrency() int
+}
+
+// HandlerWorkers returns the number of workers of a handler: its declared concurrency, the configured number of
+// workers otherwise, and at least one.
+func HandlerWorkers(handler EventHandler, config WorkerPoolConfig) int {
+	workers := config.Workers
+	if concurrent, ok := handler.(ConcurrentHandler); ok && concurrent.Concurrency() > 0 {
+		workers = concurrent.Concurrency()
+	}
+	if workers < 1 {
+		return 1
+	}
+	return workers
+}
+
+// capacity returns the number of events a handler pool holds, processed or waiting.
+func (c WorkerPoolConfig) capacity(handler EventHandler) int {
+	queueSize := c.QueueSize
+	if queueSize < 0 {
+		queueSize = 0
+	}
+	return HandlerWorkers(handler, c) * (queueSize + 1)
+}
+
+// OrderingKey returns the key of the events processed in order: the satellite the event is about, or its UID when
+// it is about no satellite.
+func OrderingKey(event model.EventRoot) string {
+	var payload struct {
+		SpaceID     string `json:"spaceID"`
+		SatelliteID string `json:"satelliteId"`
+	}
+	if err := json.Unmarshal([]byte(event.Payload), &payload); err == nil {
+		if payload.SpaceID != "" {
+			return payload.SpaceID
+		}
+		if payload.SatelliteID != "" {
+			return payload.SatelliteID
+		}
+	}
+	return event.EventUID
+}
+
+// handlerJob is an event waiting for a worker of a handler.
+type handlerJob struct {
+	ctx   context.Context
+	event model.EventRoot
+	run   func(ctx context.Context, event model.EventRoot) error
+	done  func(err error)
+}
+
+// workerPool runs the events of a handler on a fixed set of workers. Events with the same ordering key go to the
+// same worker, so they are processed in the order they were submitted.
+type workerPool struct {
+	handlerName string
+	workers     []chan handlerJob
+}
+
+// newWorkerPool starts the workers of a handler, which stop when ctx is done.
+func newWorkerPool(ctx context.Context, handler EventHandler, config WorkerPoolConfig) *workerPool {
+	pool := &workerPool{handlerName: handler.HandlerName()}
+	queueSize := config.QueueSize
+	if queueSize < 0 {
+		queueSize = 0
+	}
+	for i := 0; i < HandlerWorkers(handler, config); i++ {
+		jobs := make(chan handlerJob, queueSize)
+		pool.workers = append(pool.workers, jobs)
+		go pool.work(ctx, jobs)
+	}
+	return pool
+}
+
+// work processes the jobs of a worker one at a time.
+func (p *workerPool) work(ctx context.Context, jobs chan handlerJob) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-jobs:
+			handlerQueueDepth.WithLabelValues(p.handlerName).Dec()
+			handlerInFlight.WithLabelValues(p.handlerName).Inc()
+			err := job.run(job.ctx, job.event)
+			handlerInFlight.WithLabelValues(p.handlerName).Dec()
+			if job.done != nil {
+				job.done(err)
+			}
+		}
+	}
+}
+
+// submit queues a job on the worker of key, blocking while that worker is full. It fails when ctx is done first.
+func (p *workerPool) submit(ctx context.Context, key string, job handlerJob) error {
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+	jobs := p.workers[hash.Sum32()%uint32(len(p.workers))]
+
+	handlerQueueDepth.WithLabelValues(p.handlerName).Inc()
+	select {
+	case jobs <- job:
+		return nil
+	case <-ctx.Done():
+		handlerQueueDepth.WithLabelValues(p.handlerName).Dec()
+		return ctx.Err()
+	}
+}
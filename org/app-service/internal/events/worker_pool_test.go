@@ -0,0 +1,155 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	model "github.com/org/2112-space-lab/org/app-service/internal/graphql/models/generated"
+)
+
+type concurrentHandler struct {
+	recordingHandler
+	concurrency int
+}
+
+func (h *concurrentHandler) Concurrency() int { return h.concurrency }
+
+func TestOrderingKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		event       model.EventRoot
+		expectedKey string
+	}{
+		{name: "Propagated satellite", event: model.EventRoot{EventUID: "uid", Payload: `{"spaceID":"25544"}`}, expectedKey: "25544"},
+		{name: "Satellite of a mapping", event: model.EventRoot{EventUID: "uid", Payload: `{"satelliteId":"sat-1"}`}, expectedKey: "sat-1"},
+		{name: "No satellite", event: model.EventRoot{EventUID: "uid", Payload: `{"name":"ctx"}`}, expectedKey: "uid"},
+		{name: "Invalid payload", event: model.EventRoot{EventUID: "uid", Payload: `not json`}, expectedKey: "uid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if key := OrderingKey(tt.event); key != tt.expectedKey {
+				t.Errorf("Expected key %s, but got %s", tt.expectedKey, key)
+			}
+		})
+	}
+}
+
+func TestHandlerWorkers(t *testing.T) {
+	config := WorkerPoolConfig{Workers: 4, QueueSize: 2}
+
+	tests := []struct {
+		name            string
+		handler         EventHandler
+		config          WorkerPoolConfig
+		expectedWorkers int
+	}{
+		{name: "Configured workers", handler: &recordingHandler{}, config: config, expectedWorkers: 4},
+		{name: "Declared concurrency", handler: &concurrentHandler{concurrency: 1}, config: config, expectedWorkers: 1},
+		{name: "No declared concurrency", handler: &concurrentHandler{}, config: config, expectedWorkers: 4},
+		{name: "At least one worker", handler: &recordingHandler{}, config: WorkerPoolConfig{}, expectedWorkers: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if workers := HandlerWorkers(tt.handler, tt.config); workers != tt.expectedWorkers {
+				t.Errorf("Expected %d workers, but got %d", tt.expectedWorkers, workers)
+			}
+		})
+	}
+}
+
+func TestEventMonitorPrefetch(t *testing.T) {
+	config := WorkerPoolConfig{Workers: 4, QueueSize: 2} // 12 events per pool, 3 with a single worker
+
+	tests := []struct {
+		name             string
+		handlers         map[model.EventType][]EventHandler
+		expectedPrefetch int
+	}{
+		{name: "No handler", expectedPrefetch: 0},
+		{
+			name:             "Single pool",
+			handlers:         map[model.EventType][]EventHandler{model.EventTypeSatelliteTlePropagated: {&recordingHandler{}}},
+			expectedPrefetch: 12,
+		},
+		{
+			name: "Smallest pool of a type",
+			handlers: map[model.EventType][]EventHandler{
+				model.EventTypeSatelliteTlePropagated: {&recordingHandler{}, &concurrentHandler{concurrency: 1}, &recordingHandler{}},
+			},
+			expectedPrefetch: 3,
+		},
+		{
+			name: "Largest bound of the types",
+			handlers: map[model.EventType][]EventHandler{
+				model.EventTypeSatelliteTlePropagated: {&concurrentHandler{concurrency: 1}},
+				model.EventTypeSystemHealthChecked:    {&recordingHandler{}},
+			},
+			expectedPrefetch: 12,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			monitor, _ := NewEventMonitor(context.Background(), nil, discardEvents{}, discardHandlerLogs{}, &memoryDeadLetters{}, nil, nil)
+			monitor.ConfigureWorkerPools(config)
+			for eventType, handlers := range tt.handlers {
+				for _, handler := range handlers {
+					monitor.RegisterHandler(context.Background(), eventType, handler)
+				}
+			}
+			if got := monitor.Prefetch(); got != tt.expectedPrefetch {
+				t.Errorf("Expected a prefetch of %d, but got %d", tt.expectedPrefetch, got)
+			}
+		})
+	}
+}
+
+func TestWorkerPoolKeepsOrderPerKey(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pool := newWorkerPool(ctx, &recordingHandler{}, WorkerPoolConfig{Workers: 4, QueueSize: 1})
+
+	const perKey = 50
+	keys := []string{"25544", "43013", "48274"}
+	var mu sync.Mutex
+	processed := map[string][]int{}
+	var wg sync.WaitGroup
+	for i := 0; i < perKey; i++ {
+		for _, key := range keys {
+			wg.Add(1)
+			event := model.EventRoot{EventUID: fmt.Sprintf("%s-%d", key, i), Payload: fmt.Sprintf(`{"spaceID":"%s"}`, key)}
+			sequence := i
+			err := pool.submit(ctx, OrderingKey(event), handlerJob{
+				ctx:   ctx,
+				event: event,
+				run: func(ctx context.Context, event model.EventRoot) error {
+					mu.Lock()
+					defer mu.Unlock()
+					processed[key] = append(processed[key], sequence)
+					return nil
+				},
+				done: func(error) { wg.Done() },
+			})
+			if err != nil {
+				t.Fatalf("Expected event %s to be submitted, but got %v", event.EventUID, err)
+			}
+		}
+	}
+	wg.Wait()
+
+	for _, key := range keys {
+		if len(processed[key]) != perKey {
+			t.Fatalf("Expected %d events of %s, but got %d", perKey, key, len(processed[key]))
+		}
+		for i, sequence := range processed[key] {
+			if sequence != i {
+				t.Errorf("Expected event %d of %s at position %d, but got event %d", i, key, i, sequence)
+				break
+			}
+		}
+	}
+}
@@ -16,11 +16,28 @@ type DependencyStatus struct {
 }
 
 type EventRoot struct {
-	EventTimeUtc string  `json:"eventTimeUtc"`
-	EventUID     string  `json:"eventUid"`
-	EventType    string  `json:"eventType"`
-	Comment      *string `json:"comment,omitempty"`
-	Payload      string  `json:"payload"`
+	EventTimeUtc  string  `json:"eventTimeUtc"`
+	EventUID      string  `json:"eventUid"`
+	EventType     string  `json:"eventType"`
+	Comment       *string `json:"comment,omitempty"`
+	Payload       string  `json:"payload"`
+	TenantID      *string `json:"tenantId,omitempty"`
+	SchemaVersion *int32  `json:"schemaVersion,omitempty"`
+}
+
+type GameContextMembershipChanged struct {
+	Name      string   `json:"name"`
+	SpaceIDs  []string `json:"spaceIDs"`
+	Reason    string   `json:"reason"`
+	ChangedAt string   `json:"changedAt"`
+}
+
+type GameContextStateChanged struct {
+	Name      string `json:"name"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Reason    string `json:"reason"`
+	ChangedAt string `json:"changedAt"`
 }
 
 // Represents the overall health of the service.
@@ -84,13 +101,14 @@ type SatelliteTle struct {
 }
 
 type SatelliteTlePropagated struct {
-	SpaceID         string `json:"spaceID"`
-	TleLine1        string `json:"tleLine1"`
-	TleLine2        string `json:"tleLine2"`
-	RedisKey        string `json:"redis_key"`
-	StartTimeUtc    string `json:"startTimeUtc"`
-	DurationMinutes *int32 `json:"durationMinutes,omitempty"`
-	IntervalSeconds *int32 `json:"intervalSeconds,omitempty"`
+	SpaceID         string  `json:"spaceID"`
+	TleLine1        string  `json:"tleLine1"`
+	TleLine2        string  `json:"tleLine2"`
+	RedisKey        string  `json:"redis_key"`
+	StartTimeUtc    string  `json:"startTimeUtc"`
+	DurationMinutes *int32  `json:"durationMinutes,omitempty"`
+	IntervalSeconds *int32  `json:"intervalSeconds,omitempty"`
+	ContextName     *string `json:"contextName,omitempty"`
 }
 
 type SatelliteVisibility struct {
@@ -105,6 +123,13 @@ type SatelliteVisibility struct {
 type Subscription struct {
 }
 
+type TleBatchUploaded struct {
+	Category      string `json:"category"`
+	MaxRequested  int32  `json:"maxRequested"`
+	ProcessedTLEs int32  `json:"processedTLEs"`
+	Timestamp     string `json:"timestamp"`
+}
+
 type UserLocation struct {
 	UID       string  `json:"uid"`
 	Latitude  float64 `json:"latitude"`
@@ -135,6 +160,10 @@ const (
 	EventTypeRehydrateGameContextRequested    EventType = "REHYDRATE_GAME_CONTEXT_REQUESTED"
 	EventTypeRehydrateGameContextSuccess      EventType = "REHYDRATE_GAME_CONTEXT_SUCCESS"
 	EventTypeRehydrateGameContextFailed       EventType = "REHYDRATE_GAME_CONTEXT_FAILED"
+	EventTypeGameContextStateChanged          EventType = "GAME_CONTEXT_STATE_CHANGED"
+	EventTypeGameContextSatellitesAdded       EventType = "GAME_CONTEXT_SATELLITES_ADDED"
+	EventTypeGameContextSatellitesRemoved     EventType = "GAME_CONTEXT_SATELLITES_REMOVED"
+	EventTypeTleBatchUploaded                 EventType = "TLE_BATCH_UPLOADED"
 )
 
 var AllEventType = []EventType{
@@ -149,11 +178,15 @@ var AllEventType = []EventType{
 	EventTypeRehydrateGameContextRequested,
 	EventTypeRehydrateGameContextSuccess,
 	EventTypeRehydrateGameContextFailed,
+	EventTypeGameContextStateChanged,
+	EventTypeGameContextSatellitesAdded,
+	EventTypeGameContextSatellitesRemoved,
+	EventTypeTleBatchUploaded,
 }
 
 func (e EventType) IsValid() bool {
 	switch e {
-	case EventTypeSatelliteTlePropagated, EventTypeSatellitePositionUpdated, EventTypeSatelliteVisibilityChecked, EventTypeSatelliteOrbitPredicted, EventTypeSystemHealthChecked, EventTypeDataStoredInRedis, EventTypeMessagePublishedToRabbitmq, EventTypeSatelliteTlePropagationRequested, EventTypeRehydrateGameContextRequested, EventTypeRehydrateGameContextSuccess, EventTypeRehydrateGameContextFailed:
+	case EventTypeSatelliteTlePropagated, EventTypeSatellitePositionUpdated, EventTypeSatelliteVisibilityChecked, EventTypeSatelliteOrbitPredicted, EventTypeSystemHealthChecked, EventTypeDataStoredInRedis, EventTypeMessagePublishedToRabbitmq, EventTypeSatelliteTlePropagationRequested, EventTypeRehydrateGameContextRequested, EventTypeRehydrateGameContextSuccess, EventTypeRehydrateGameContextFailed, EventTypeGameContextStateChanged, EventTypeGameContextSatellitesAdded, EventTypeGameContextSatellitesRemoved, EventTypeTleBatchUploaded:
 		return true
 	}
 	return false
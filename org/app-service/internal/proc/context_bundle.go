@@ -0,0 +1,150 @@
+package proc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/config"
+	"github.com/org/2112-space-lab/org/app-service/internal/dependencies"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	"github.com/org/2112-space-lab/org/app-service/internal/services"
+	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
+)
+
+// ContextExportOptions holds the flags of `context export`.
+type ContextExportOptions struct {
+	Tenant          string
+	Format          string
+	Out             string
+	IncludeMappings bool
+}
+
+// ContextImportOptions holds the flags of `context import`.
+type ContextImportOptions struct {
+	Tenant          string
+	Name            string
+	Strategy        string
+	IncludeMappings bool
+}
+
+// ContextCloneOptions holds the flags of `context clone`.
+type ContextCloneOptions struct {
+	Tenant          string
+	IncludeMappings bool
+}
+
+// ContextExport writes a context bundle to a file or stdout.
+func ContextExport(ctx context.Context, name string, opts ContextExportOptions) {
+	service, err := contextBundleService(ctx)
+	if err != nil {
+		log.Error(err.Error())
+		return
+	}
+	ctx = domain.WithTenant(ctx, domain.TenantID(opts.Tenant))
+
+	bundle, err := service.Export(ctx, domain.GameContextName(name), opts.IncludeMappings)
+	if err != nil {
+		log.Errorf("Failed to export context %s: %v", name, err)
+		return
+	}
+
+	var out io.Writer = os.Stdout
+	if opts.Out != "" {
+		file, err := os.Create(opts.Out)
+		if err != nil {
+			log.Errorf("Failed to create %s: %v", opts.Out, err)
+			return
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if err := services.EncodeContextBundle(out, bundle, services.ContextBundleFormat(opts.Format)); err != nil {
+		log.Errorf("Failed to write context bundle: %v", err)
+		return
+	}
+	if opts.Out != "" {
+		log.Infof("📦 Exported context %s to %s", name, opts.Out)
+	}
+}
+
+// ContextImport reads a context bundle from a file, or stdin when path is "-".
+func ContextImport(ctx context.Context, path string, opts ContextImportOptions) {
+	strategy, err := domain.ParseContextConflictStrategy(opts.Strategy)
+	if err != nil {
+		log.Error(err.Error())
+		return
+	}
+
+	var in io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			log.Errorf("Failed to open %s: %v", path, err)
+			return
+		}
+		defer file.Close()
+		in = file
+	}
+
+	bundle, err := services.DecodeContextBundle(in)
+	if err != nil {
+		log.Errorf("Failed to read context bundle: %v", err)
+		return
+	}
+
+	service, err := contextBundleService(ctx)
+	if err != nil {
+		log.Error(err.Error())
+		return
+	}
+	ctx = domain.WithTenant(ctx, domain.TenantID(opts.Tenant))
+
+	result, err := service.Import(ctx, bundle, domain.ContextImportOptions{
+		Name:            domain.GameContextName(opts.Name),
+		Strategy:        strategy,
+		IncludeMappings: opts.IncludeMappings,
+	})
+	if err != nil {
+		log.Errorf("Failed to import context: %v", err)
+		return
+	}
+	printImportResult(result)
+}
+
+// ContextClone copies a context under a new name.
+func ContextClone(ctx context.Context, source, target string, opts ContextCloneOptions) {
+	service, err := contextBundleService(ctx)
+	if err != nil {
+		log.Error(err.Error())
+		return
+	}
+	ctx = domain.WithTenant(ctx, domain.TenantID(opts.Tenant))
+
+	result, err := service.Clone(ctx, domain.GameContextName(source), domain.GameContextName(target), opts.IncludeMappings)
+	if err != nil {
+		log.Errorf("Failed to clone context %s: %v", source, err)
+		return
+	}
+	printImportResult(result)
+}
+
+func contextBundleService(ctx context.Context) (services.ContextBundleService, error) {
+	deps, err := dependencies.NewDependencies(ctx, config.Env)
+	if err != nil {
+		return services.ContextBundleService{}, err
+	}
+	return deps.Services.ContextBundleService, nil
+}
+
+func printImportResult(result domain.ContextImportResult) {
+	out, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		log.Error(err.Error())
+		return
+	}
+	fmt.Println(string(out))
+}
@@ -0,0 +1,93 @@
+package proc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/config"
+	"github.com/org/2112-space-lab/org/app-service/internal/dependencies"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
+	fx "github.com/org/2112-space-lab/org/app-service/pkg/option"
+	xtime "github.com/org/2112-space-lab/org/app-service/pkg/time"
+)
+
+// EventReplayOptions holds the flags of `events replay`.
+type EventReplayOptions struct {
+	Tenant        string
+	EventTypes    []string
+	EventUIDs     []string
+	ContextName   string
+	From          string
+	To            string
+	HandlerName   string
+	DryRun        bool
+	RatePerSecond float64
+	Limit         int
+}
+
+// EventReplay replays stored events and prints the replayed UIDs.
+func EventReplay(ctx context.Context, opts EventReplayOptions) {
+	replayOpts, err := eventReplayOptions(opts)
+	if err != nil {
+		log.Error(err.Error())
+		return
+	}
+
+	deps, err := dependencies.NewDependencies(ctx, config.Env)
+	if err != nil {
+		log.Error(err.Error())
+		return
+	}
+	ctx = domain.WithTenant(ctx, domain.TenantID(opts.Tenant))
+
+	result, err := deps.Services.EventReplayService.Replay(ctx, replayOpts)
+	if err != nil {
+		log.Errorf("Failed to replay events: %v", err)
+		return
+	}
+
+	out, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		log.Error(err.Error())
+		return
+	}
+	fmt.Println(string(out))
+}
+
+func eventReplayOptions(opts EventReplayOptions) (domain.EventReplayOptions, error) {
+	eventTypes := make([]domain.EventType, len(opts.EventTypes))
+	for i, eventType := range opts.EventTypes {
+		eventTypes[i] = domain.EventType(eventType)
+	}
+
+	filter := domain.EventReplayFilter{
+		EventTypes:  eventTypes,
+		EventUIDs:   opts.EventUIDs,
+		ContextName: domain.GameContextName(opts.ContextName),
+	}
+	if opts.From != "" {
+		from, err := time.Parse(time.RFC3339, opts.From)
+		if err != nil {
+			return domain.EventReplayOptions{}, fmt.Errorf("invalid --from: %w", err)
+		}
+		filter.From = fx.NewValueOption(xtime.NewUtcTimeIgnoreZone(from))
+	}
+	if opts.To != "" {
+		to, err := time.Parse(time.RFC3339, opts.To)
+		if err != nil {
+			return domain.EventReplayOptions{}, fmt.Errorf("invalid --to: %w", err)
+		}
+		filter.To = fx.NewValueOption(xtime.NewUtcTimeIgnoreZone(to))
+	}
+
+	return domain.EventReplayOptions{
+		Filter:        filter,
+		HandlerName:   opts.HandlerName,
+		DryRun:        opts.DryRun,
+		RatePerSecond: opts.RatePerSecond,
+		Limit:         opts.Limit,
+	}, nil
+}
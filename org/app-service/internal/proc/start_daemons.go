@@ -0,0 +1,37 @@
+package proc
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/dependencies"
+	"github.com/org/2112-space-lab/org/app-service/internal/tasks"
+	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
+)
+
+// StartDaemons runs the daemon tasks until the process is interrupted.
+func StartDaemons(deps *dependencies.Dependencies) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	monitor, err := tasks.NewTaskMonitor(ctx, deps)
+	if err != nil {
+		log.Errorf("Failed to start the daemon tasks: %v", err)
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, name := range monitor.Daemons() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			log.Infof("▶️ Starting daemon task %s", name)
+			if err := monitor.Process(ctx, name, map[string]string{}); err != nil {
+				log.Errorf("❌ Daemon task %s failed: %v", name, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
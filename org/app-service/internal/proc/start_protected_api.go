@@ -1,16 +1,26 @@
 package proc
 
 import (
+	"context"
+
 	"github.com/org/2112-space-lab/org/app-service/internal/api/routers"
 	"github.com/org/2112-space-lab/org/app-service/internal/clients/service"
 	"github.com/org/2112-space-lab/org/app-service/internal/config"
 	"github.com/org/2112-space-lab/org/app-service/internal/dependencies"
+	"github.com/org/2112-space-lab/org/app-service/internal/tasks"
+	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
 )
 
 // StartPublicApi starts de protected http server
 func StartProtectedApi(deps *dependencies.Dependencies) {
 	serviceCli := service.GetClient()
 	c := serviceCli.GetConfig()
-	protectedApiRouter := routers.InitProtectedAPIRouter(config.Env, deps)
+	// The monitor tells the tasks schedules may run; its tasks are not started here.
+	monitor, err := tasks.NewTaskMonitor(context.Background(), deps)
+	if err != nil {
+		log.Errorf("Failed to start the protected API: %v", err)
+		return
+	}
+	protectedApiRouter := routers.InitProtectedAPIRouter(config.Env, deps, &monitor)
 	protectedApiRouter.Start(c.Host, c.ProtectedApiPort)
 }
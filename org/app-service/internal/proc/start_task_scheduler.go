@@ -0,0 +1,27 @@
+package proc
+
+import (
+	"context"
+	"os"
+	"os/signal"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/dependencies"
+	"github.com/org/2112-space-lab/org/app-service/internal/tasks"
+	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
+)
+
+// StartTaskScheduler runs the scheduled tasks until the process is interrupted.
+func StartTaskScheduler(deps *dependencies.Dependencies) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	monitor, err := tasks.NewTaskMonitor(ctx, deps)
+	if err != nil {
+		log.Errorf("Failed to start the task scheduler: %v", err)
+		return
+	}
+	scheduler := tasks.NewTaskScheduler(&monitor, deps)
+	if err := scheduler.Run(ctx); err != nil {
+		log.Errorf("Task scheduler failed: %v", err)
+	}
+}
@@ -21,22 +21,50 @@ func NewContextRepository(db *data.Database) ContextRepository {
 	return ContextRepository{db: db}
 }
 
-// Save creates a new context record.
+// Save creates a new context record owned by the tenant of ctx.
 func (r *ContextRepository) Save(ctx context.Context, context domain.GameContext) error {
+	context, err := withContextTenant(ctx, context)
+	if err != nil {
+		return err
+	}
+	if context.State == "" {
+		context.State = domain.GameContextStateDraft
+	}
 	model := models.MapToContextModel(context)
-	return r.db.DbHandler.Create(&model).Error
+	// Select every column so a false is_active is written instead of the column default.
+	return r.db.Conn(ctx).Select("*").Create(&model).Error
 }
 
-// Update modifies an existing context record.
+// Update modifies an existing context record of the tenant of ctx. Lifecycle columns are left to Transition and SetSchedule.
 func (r *ContextRepository) Update(ctx context.Context, context domain.GameContext) error {
+	context, err := withContextTenant(ctx, context)
+	if err != nil {
+		return err
+	}
+	if context.ID == "" {
+		if context.ID, err = r.contextID(ctx, context.Name); err != nil {
+			return err
+		}
+	}
 	model := models.MapToContextModel(context)
-	return r.db.DbHandler.Save(&model).Error
+	result := r.scoped(ctx).
+		Where("id = ?", model.ID).
+		Select("*").
+		Omit(contextLifecycleColumns...).
+		Updates(&model)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("context %s not found: %w", context.Name, gorm.ErrRecordNotFound)
+	}
+	return nil
 }
 
 // FindByUniqueName retrieves a context by its unique name.
 func (r *ContextRepository) FindByUniqueName(ctx context.Context, gameContextName domain.GameContextName) (domain.GameContext, error) {
 	var model models.Context
-	result := r.db.DbHandler.First(&model, "name = ? AND deleted_at IS NULL", string(gameContextName))
+	result := r.scoped(ctx).First(&model, "name = ? AND deleted_at IS NULL", string(gameContextName))
 	if result.Error != nil {
 		return domain.GameContext{}, result.Error
 	}
@@ -46,7 +74,7 @@ func (r *ContextRepository) FindByUniqueName(ctx context.Context, gameContextNam
 // FindAll retrieves all contexts.
 func (r *ContextRepository) FindAll(ctx context.Context) ([]domain.GameContext, error) {
 	var results []models.Context
-	result := r.db.DbHandler.Find(&results, "deleted_at IS NULL")
+	result := r.scoped(ctx).Find(&results, "deleted_at IS NULL")
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -64,11 +92,11 @@ func (r *ContextRepository) FindAllWithPagination(ctx context.Context, page int,
 	var results []models.Context
 
 	// Construct the query with pagination
-	query := r.db.DbHandler.Scopes(models.Paginate(page, pageSize)).Where("deleted_at IS NULL")
+	query := r.scoped(ctx).Scopes(models.Paginate(page, pageSize)).Where("deleted_at IS NULL")
 
 	// Apply wildcard filter for both name and description if a wildcard is provided
 	if wildcard != "" {
-		query = query.Where("name LIKE ? OR description LIKE ?", "%"+wildcard+"%", "%"+wildcard+"%")
+		query = query.Where("(name LIKE ? OR description LIKE ?)", "%"+wildcard+"%", "%"+wildcard+"%")
 	}
 
 	// Execute the query
@@ -89,7 +117,7 @@ func (r *ContextRepository) FindAllWithPagination(ctx context.Context, page int,
 
 // DeleteByUniqueName marks a context record as deleted by unique name.
 func (r *ContextRepository) DeleteByUniqueName(ctx context.Context, name string) error {
-	return r.db.DbHandler.Model(&models.Context{}).
+	return r.scoped(ctx).Model(&models.Context{}).
 		Where("name = ?", name).
 		Update("deleted_at", gorm.Expr("NOW()")).Error
 }
@@ -98,7 +126,7 @@ func (r *ContextRepository) DeleteByUniqueName(ctx context.Context, name string)
 // Raises an error if multiple active contexts are found.
 func (r *ContextRepository) FindActiveBySatelliteID(ctx context.Context, satelliteID domain.SatelliteID) (domain.GameContext, error) {
 	var query []models.Context
-	result := r.db.DbHandler.Table("contexts").
+	result := r.scoped(ctx).
 		Joins("JOIN context_satellites ON contexts.id = context_satellites.context_id").
 		Where("context_satellites.satellite_id = ? AND contexts.is_active = TRUE AND contexts.deleted_at IS NULL", string(satelliteID)).
 		Find(&query)
@@ -121,40 +149,45 @@ func (r *ContextRepository) FindActiveBySatelliteID(ctx context.Context, satelli
 	return models.MapToContextDomain(query[0]), nil
 }
 
-// AssignSatellite associates a satellite with a context.
+// AssignSatellite associates a satellite with a context of the tenant of ctx.
 func (r *ContextRepository) AssignSatellite(ctx context.Context, gameContextName domain.GameContextName, satelliteID domain.SatelliteID) error {
-	contextSatellite := models.ContextSatellite{
-		ContextID:   string(gameContextName),
-		SatelliteID: string(satelliteID),
-	}
-	return r.db.DbHandler.Create(&contextSatellite).Error
+	return r.AssignSatellites(ctx, gameContextName, []domain.SatelliteID{satelliteID})
 }
 
-// AssignSatellites associates a list of satellites with a GameContext.
+// AssignSatellites associates a list of satellites with a GameContext of the tenant of ctx.
 func (r *ContextRepository) AssignSatellites(ctx context.Context, gameContextName domain.GameContextName, satelliteIDs []domain.SatelliteID) error {
+	contextID, err := r.contextID(ctx, gameContextName)
+	if err != nil {
+		return err
+	}
+
 	var contextSatellites []models.ContextSatellite
 
 	// Create ContextSatellite records for each satellite ID
 	for _, satelliteID := range satelliteIDs {
 		contextSatellite := models.ContextSatellite{
-			ContextID:   string(gameContextName),
+			ContextID:   contextID,
 			SatelliteID: string(satelliteID),
 		}
 		contextSatellites = append(contextSatellites, contextSatellite)
 	}
 
 	// Use GORM's Create method to insert all records in a single operation
-	return r.db.DbHandler.Create(&contextSatellites).Error
+	return r.db.Conn(ctx).Create(&contextSatellites).Error
 }
 
-// RemoveSatellite removes the association between a satellite and a context.
+// RemoveSatellite removes the association between a satellite and a context of the tenant of ctx.
 func (r *ContextRepository) RemoveSatellite(ctx context.Context, gameContextName domain.GameContextName, satelliteID domain.SatelliteID) error {
-	return r.db.DbHandler.Where("context_id = ? AND satellite_id = ?", string(gameContextName), string(satelliteID)).
-		Delete(&models.ContextSatellite{}).Error
+	return r.RemoveSatellites(ctx, gameContextName, []domain.SatelliteID{satelliteID})
 }
 
-// RemoveSatellites removes the associations between a list of satellites and a GameContext.
+// RemoveSatellites removes the associations between a list of satellites and a GameContext of the tenant of ctx.
 func (r *ContextRepository) RemoveSatellites(ctx context.Context, gameContextName domain.GameContextName, satelliteIDs []domain.SatelliteID) error {
+	contextID, err := r.contextID(ctx, gameContextName)
+	if err != nil {
+		return err
+	}
+
 	// Convert satelliteIDs to a slice of strings for query compatibility
 	var satelliteIDStrings []string
 	for _, satelliteID := range satelliteIDs {
@@ -162,113 +195,257 @@ func (r *ContextRepository) RemoveSatellites(ctx context.Context, gameContextNam
 	}
 
 	// Perform batch delete operation
-	return r.db.DbHandler.
-		Where("context_id = ? AND satellite_id IN ?", string(gameContextName), satelliteIDStrings).
+	return r.db.Conn(ctx).
+		Where("context_id = ? AND satellite_id IN ?", contextID, satelliteIDStrings).
 		Delete(&models.ContextSatellite{}).Error
 }
 
+// ClearAssociations removes the satellites, TLEs, TLE pins and tiles associated with a context of the tenant of ctx.
+func (r *ContextRepository) ClearAssociations(ctx context.Context, gameContextName domain.GameContextName) error {
+	contextID, err := r.contextID(ctx, gameContextName)
+	if err != nil {
+		return err
+	}
+
+	return r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, association := range []interface{}{&models.ContextSatellite{}, &models.ContextTLE{}, &models.ContextTLEPin{}, &models.ContextTile{}} {
+			if err := tx.Where("context_id = ?", contextID).Delete(association).Error; err != nil {
+				return fmt.Errorf("failed to clear associations of context %s: %w", gameContextName, err)
+			}
+		}
+		return tx.Model(&models.Context{}).Where("id = ?", contextID).
+			Update("tle_pinned_epoch", gorm.Expr("NULL")).Error
+	})
+}
+
 // DesactiveContext marks a context as inactive.
 func (r *ContextRepository) DesactiveContext(ctx context.Context, gameContextName domain.GameContextName) error {
-	return r.db.DbHandler.Model(&models.Context{}).
+	return r.scoped(ctx).
 		Where("name = ?", string(gameContextName)).
 		Update("is_active", false).Error
 }
 
 // ActivateContext marks a context as active.
 func (r *ContextRepository) ActivateContext(ctx context.Context, gameContextName domain.GameContextName) error {
-	return r.db.DbHandler.Model(&models.Context{}).
+	return r.scoped(ctx).
 		Where("name = ?", string(gameContextName)).
 		Update("is_active", true).Error
 }
 
-// GetActiveContext retrieves the currently active context.
-func (r *ContextRepository) GetActiveContext(ctx context.Context) (domain.GameContext, error) {
+// FindActiveContexts retrieves every active context of the tenant of ctx. Any number of contexts may run at once.
+func (r *ContextRepository) FindActiveContexts(ctx context.Context) ([]domain.GameContext, error) {
 	var query []models.Context
-	result := r.db.DbHandler.Table("contexts").
-		Where("is_active = TRUE AND deleted_at IS NULL").
+	result := r.scoped(ctx).
+		Where("state = ? AND deleted_at IS NULL", domain.GameContextStateActive).
+		Order("name").
 		Find(&query)
-
 	if result.Error != nil {
-		return domain.GameContext{}, result.Error
+		return nil, result.Error
 	}
 
-	if len(query) > 1 {
-		return domain.GameContext{}, fmt.Errorf("multiple contexts active at the same time [%+v]", query)
+	contexts := make([]domain.GameContext, len(query))
+	for i, model := range query {
+		contexts[i] = models.MapToContextDomain(model)
 	}
+	return contexts, nil
+}
 
-	if len(query) == 0 {
-		return domain.GameContext{}, fmt.Errorf("no active context found")
+// FindAllActiveContexts retrieves the active contexts of every tenant, for workers serving all of them.
+// Callers acting on a context must scope it to its tenant with domain.WithTenant.
+func (r *ContextRepository) FindAllActiveContexts(ctx context.Context) ([]domain.GameContext, error) {
+	var query []models.Context
+	result := r.db.Conn(ctx).
+		Where("state = ? AND deleted_at IS NULL", domain.GameContextStateActive).
+		Order("tenant_id, name").
+		Find(&query)
+	if result.Error != nil {
+		return nil, result.Error
 	}
 
-	return models.MapToContextDomain(query[0]), nil
+	contexts := make([]domain.GameContext, len(query))
+	for i, model := range query {
+		contexts[i] = models.MapToContextDomain(model)
+	}
+	return contexts, nil
 }
 
 // SetActivatedAt sets the ActivatedAt timestamp for a context.
 func (r *ContextRepository) SetActivatedAt(ctx context.Context, gameContextName domain.GameContextName, activatedAt time.Time) error {
-	return r.db.DbHandler.Model(&models.Context{}).
+	return r.scoped(ctx).
 		Where("name = ?", string(gameContextName)).
 		Update("activated_at", activatedAt).Error
 }
 
 // UnsetActivatedAt clears the ActivatedAt timestamp for a context.
 func (r *ContextRepository) UnsetActivatedAt(ctx context.Context, gameContextName domain.GameContextName) error {
-	return r.db.DbHandler.Model(&models.Context{}).
+	return r.scoped(ctx).
 		Where("name = ?", string(gameContextName)).
 		Update("activated_at", nil).Error
 }
 
 // SetDesactivatedAt sets the DesactivatedAt timestamp for a context.
 func (r *ContextRepository) SetDesactivatedAt(ctx context.Context, gameContextName domain.GameContextName, desactivatedAt time.Time) error {
-	return r.db.DbHandler.Model(&models.Context{}).
+	return r.scoped(ctx).
 		Where("name = ?", string(gameContextName)).
 		Update("desactivated_at", desactivatedAt).Error
 }
 
 // UnsetDesactivatedAt clears the DesactivatedAt timestamp for a context.
 func (r *ContextRepository) UnsetDesactivatedAt(ctx context.Context, gameContextName domain.GameContextName) error {
-	return r.db.DbHandler.Model(&models.Context{}).
+	return r.scoped(ctx).
 		Where("name = ?", string(gameContextName)).
 		Update("desactivated_at", nil).Error
 }
 
 // SetTriggerGeneratedMappingAt sets the TriggerGeneratedMappingAt timestamp for a context.
 func (r *ContextRepository) SetTriggerGeneratedMappingAt(ctx context.Context, gameContextName domain.GameContextName, timestamp time.Time) error {
-	return r.db.DbHandler.Model(&models.Context{}).
+	return r.scoped(ctx).
 		Where("name = ?", string(gameContextName)).
 		Update("trigger_generated_mapping_at", timestamp).Error
 }
 
 // UnsetTriggerGeneratedMappingAt clears the TriggerGeneratedMappingAt timestamp for a context.
 func (r *ContextRepository) UnsetTriggerGeneratedMappingAt(ctx context.Context, gameContextName domain.GameContextName) error {
-	return r.db.DbHandler.Model(&models.Context{}).
+	return r.scoped(ctx).
 		Where("name = ?", string(gameContextName)).
 		Update("trigger_generated_mapping_at", nil).Error
 }
 
 // SetTriggerImportedTLEAt sets the TriggerImportedTLEAt timestamp for a context.
 func (r *ContextRepository) SetTriggerImportedTLEAt(ctx context.Context, gameContextName domain.GameContextName, timestamp time.Time) error {
-	return r.db.DbHandler.Model(&models.Context{}).
+	return r.scoped(ctx).
 		Where("name = ?", string(gameContextName)).
 		Update("trigger_imported_tle_at", timestamp).Error
 }
 
 // UnsetTriggerImportedTLEAt clears the TriggerImportedTLEAt timestamp for a context.
 func (r *ContextRepository) UnsetTriggerImportedTLEAt(ctx context.Context, gameContextName domain.GameContextName) error {
-	return r.db.DbHandler.Model(&models.Context{}).
+	return r.scoped(ctx).
 		Where("name = ?", string(gameContextName)).
 		Update("trigger_imported_tle_at", nil).Error
 }
 
 // SetTriggerImportedSatelliteAt sets the TriggerImportedSatelliteAt timestamp for a context.
 func (r *ContextRepository) SetTriggerImportedSatelliteAt(ctx context.Context, gameContextName domain.GameContextName, timestamp time.Time) error {
-	return r.db.DbHandler.Model(&models.Context{}).
+	return r.scoped(ctx).
 		Where("name = ?", string(gameContextName)).
 		Update("trigger_imported_satellite_at", timestamp).Error
 }
 
 // UnsetTriggerImportedSatelliteAt clears the TriggerImportedSatelliteAt timestamp for a context.
 func (r *ContextRepository) UnsetTriggerImportedSatelliteAt(ctx context.Context, gameContextName domain.GameContextName) error {
-	return r.db.DbHandler.Model(&models.Context{}).
+	return r.scoped(ctx).
 		Where("name = ?", string(gameContextName)).
 		Update("trigger_imported_satellite_at", nil).Error
 }
+
+// Transition moves a context of the tenant of ctx from one lifecycle state to another. The update only applies
+// while the context is still in state from, so two concurrent transitions cannot both succeed.
+func (r *ContextRepository) Transition(ctx context.Context, gameContextName domain.GameContextName, from, to domain.GameContextState, at time.Time) error {
+	updates := map[string]interface{}{
+		"state":     string(to),
+		"is_active": to.IsRunning(),
+	}
+	switch {
+	case to == domain.GameContextStateActive:
+		updates["activated_at"] = at
+		updates["desactivated_at"] = nil
+		updates["scheduled_activation_at"] = nil
+	case from == domain.GameContextStateActive:
+		updates["desactivated_at"] = at
+		updates["scheduled_deactivation_at"] = nil
+	}
+	if to == domain.GameContextStateDraft || to == domain.GameContextStateArchived {
+		updates["scheduled_activation_at"] = nil
+		updates["scheduled_deactivation_at"] = nil
+	}
+
+	result := r.scoped(ctx).
+		Where("name = ? AND state = ? AND deleted_at IS NULL", string(gameContextName), string(from)).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("context %s is no longer %s: %w", gameContextName, from, domain.ErrInvalidContextTransition)
+	}
+	return nil
+}
+
+// SetSchedule sets the times the scheduler activates and deactivates a context. Nil times clear the schedule.
+func (r *ContextRepository) SetSchedule(ctx context.Context, gameContextName domain.GameContextName, activateAt, deactivateAt *time.Time) error {
+	return r.scoped(ctx).
+		Where("name = ? AND deleted_at IS NULL", string(gameContextName)).
+		Updates(map[string]interface{}{
+			"scheduled_activation_at":   activateAt,
+			"scheduled_deactivation_at": deactivateAt,
+		}).Error
+}
+
+// FindDueForActivation retrieves the scheduled contexts of all tenants whose activation time is before the given time.
+// It is meant for the scheduler, which scopes each context to its own tenant before acting on it.
+func (r *ContextRepository) FindDueForActivation(ctx context.Context, before time.Time) ([]domain.GameContext, error) {
+	return r.findDue(ctx, domain.GameContextStateScheduled, "scheduled_activation_at", before)
+}
+
+// FindDueForDeactivation retrieves the active contexts of all tenants whose deactivation time is before the given time.
+// Like FindDueForActivation, it is not scoped to a tenant.
+func (r *ContextRepository) FindDueForDeactivation(ctx context.Context, before time.Time) ([]domain.GameContext, error) {
+	return r.findDue(ctx, domain.GameContextStateActive, "scheduled_deactivation_at", before)
+}
+
+func (r *ContextRepository) findDue(ctx context.Context, state domain.GameContextState, column string, before time.Time) ([]domain.GameContext, error) {
+	var results []models.Context
+	err := r.db.Conn(ctx).
+		Where("state = ? AND deleted_at IS NULL", string(state)).
+		Where(column+" IS NOT NULL AND "+column+" <= ?", before).
+		Order(column).
+		Find(&results).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find contexts due on %s: %w", column, err)
+	}
+
+	contexts := make([]domain.GameContext, len(results))
+	for i, result := range results {
+		contexts[i] = models.MapToContextDomain(result)
+	}
+	return contexts, nil
+}
+
+// contextLifecycleColumns are owned by the lifecycle state machine and TLE pinning, and never written by Update.
+var contextLifecycleColumns = []string{
+	"state",
+	"is_active",
+	"scheduled_activation_at",
+	"scheduled_deactivation_at",
+	"activated_at",
+	"desactivated_at",
+	"trigger_generated_mapping_at",
+	"trigger_imported_tle_at",
+	"trigger_imported_satellite_at",
+	"tle_pinned_epoch",
+}
+
+// scoped starts a query on the contexts of the tenant of ctx.
+func (r *ContextRepository) scoped(ctx context.Context) *gorm.DB {
+	return r.db.Conn(ctx).Model(&models.Context{}).Scopes(tenantScope(ctx, "contexts"))
+}
+
+// contextID resolves the ID of a context by name within the tenant of ctx.
+func (r *ContextRepository) contextID(ctx context.Context, gameContextName domain.GameContextName) (string, error) {
+	var model models.Context
+	if err := r.scoped(ctx).First(&model, "name = ? AND deleted_at IS NULL", string(gameContextName)).Error; err != nil {
+		return "", fmt.Errorf("context %s not found: %w", gameContextName, err)
+	}
+	return model.ID, nil
+}
+
+// withContextTenant stamps a context with the tenant of ctx, refusing contexts of another tenant.
+func withContextTenant(ctx context.Context, gameContext domain.GameContext) (domain.GameContext, error) {
+	tenantID := domain.TenantFromContext(ctx)
+	if gameContext.TenantID != "" && gameContext.TenantID != tenantID {
+		return gameContext, fmt.Errorf("context %s: %w", gameContext.Name, domain.ErrTenantMismatch)
+	}
+	gameContext.TenantID = tenantID
+	return gameContext, nil
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/clients/redis"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+)
+
+const (
+	coverageCacheKeyPrefix    = "coverage:"
+	coverageSnapshotKeyPrefix = "coverage-snapshot:"
+)
+
+// CoverageCacheRepository caches coverage reports in Redis, one hash per context keyed by window.
+type CoverageCacheRepository struct {
+	redisClient *redis.RedisClient
+	ttl         time.Duration
+}
+
+// NewCoverageCacheRepository creates a new CoverageCacheRepository instance.
+func NewCoverageCacheRepository(redisClient *redis.RedisClient, ttl time.Duration) CoverageCacheRepository {
+	return CoverageCacheRepository{redisClient: redisClient, ttl: ttl}
+}
+
+// Get returns the cached report for a context and window, if any.
+func (r *CoverageCacheRepository) Get(ctx context.Context, contextID string, from, to time.Time, step time.Duration) (domain.CoverageReport, bool, error) {
+	var report domain.CoverageReport
+	entries, err := r.redisClient.HGetAll(ctx, coverageCacheKey(ctx, contextID))
+	if err != nil {
+		return report, false, err
+	}
+	raw, ok := entries[coverageCacheField(from, to, step)]
+	if !ok {
+		return report, false, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &report); err != nil {
+		return report, false, fmt.Errorf("failed to decode cached coverage report: %w", err)
+	}
+	return report, true, nil
+}
+
+// Save stores a report and refreshes the context hash TTL.
+func (r *CoverageCacheRepository) Save(ctx context.Context, report domain.CoverageReport) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to encode coverage report: %w", err)
+	}
+	key := coverageCacheKey(ctx, report.ContextID)
+	field := coverageCacheField(report.From, report.To, time.Duration(report.StepSeconds*float64(time.Second)))
+	if err := r.redisClient.HSet(ctx, key, map[string]interface{}{field: string(data)}); err != nil {
+		return err
+	}
+	return r.redisClient.Expire(ctx, key, r.ttl)
+}
+
+// Invalidate drops every cached report of a context.
+func (r *CoverageCacheRepository) Invalidate(ctx context.Context, contextID string) error {
+	return r.redisClient.Del(ctx, coverageCacheKey(ctx, contextID))
+}
+
+// GetSnapshot returns the cached snapshot of a context if it covers exactly [from, to].
+func (r *CoverageCacheRepository) GetSnapshot(ctx context.Context, contextID string, from, to time.Time) (domain.CoverageSnapshot, bool, error) {
+	var snapshot domain.CoverageSnapshot
+	raw, err := r.redisClient.Get(ctx, coverageSnapshotKey(ctx, contextID))
+	if err != nil || raw == "" {
+		return snapshot, false, err
+	}
+	if err := json.Unmarshal([]byte(raw), &snapshot); err != nil {
+		return snapshot, false, fmt.Errorf("failed to decode cached coverage snapshot: %w", err)
+	}
+	if !snapshot.From.Equal(from) || !snapshot.To.Equal(to) {
+		return snapshot, false, nil
+	}
+	return snapshot, true, nil
+}
+
+// SaveSnapshot replaces the cached snapshot of a context.
+func (r *CoverageCacheRepository) SaveSnapshot(ctx context.Context, snapshot domain.CoverageSnapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode coverage snapshot: %w", err)
+	}
+	key := coverageSnapshotKey(ctx, snapshot.ContextID)
+	if err := r.redisClient.Set(ctx, key, string(data)); err != nil {
+		return err
+	}
+	return r.redisClient.Expire(ctx, key, r.ttl)
+}
+
+func coverageSnapshotKey(ctx context.Context, contextID string) string {
+	return domain.TenantKey(ctx, coverageSnapshotKeyPrefix+contextID)
+}
+
+func coverageCacheKey(ctx context.Context, contextID string) string {
+	return domain.TenantKey(ctx, coverageCacheKeyPrefix+contextID)
+}
+
+func coverageCacheField(from, to time.Time, step time.Duration) string {
+	return fmt.Sprintf("%d:%d:%d", from.UTC().Unix(), to.UTC().Unix(), int64(step.Seconds()))
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/org/2112-space-lab/org/app-service/internal/data"
+	"github.com/org/2112-space-lab/org/app-service/internal/data/models"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+)
+
+// DeadLetterRepository manages dead-lettered events.
+type DeadLetterRepository struct {
+	db *data.Database
+}
+
+// NewDeadLetterRepository creates a new DeadLetterRepository instance.
+func NewDeadLetterRepository(db *data.Database) DeadLetterRepository {
+	return DeadLetterRepository{db: db}
+}
+
+// Save records a dead-lettered event. It keeps the tenant of the event, the monitor saving it runs in that tenant.
+func (r *DeadLetterRepository) Save(ctx context.Context, deadLetter domain.DeadLetter) error {
+	model := models.MapToDeadLetterModel(deadLetter)
+	if model.ID == "" {
+		model.ID = uuid.NewString()
+	}
+	if model.DeadLetteredAt.IsZero() {
+		model.DeadLetteredAt = time.Now().UTC()
+	}
+	return r.db.DbHandler.WithContext(ctx).Create(&model).Error
+}
+
+// FindByID retrieves a dead-lettered event of the tenant of ctx.
+func (r *DeadLetterRepository) FindByID(ctx context.Context, id string) (domain.DeadLetter, error) {
+	var model models.DeadLetter
+	if err := r.db.DbHandler.WithContext(ctx).
+		Scopes(tenantScope(ctx, "dead_letters")).
+		First(&model, "id = ?", id).Error; err != nil {
+		return domain.DeadLetter{}, err
+	}
+	return models.MapToDeadLetterDomain(model), nil
+}
+
+// FindPage retrieves dead-lettered events of the tenant of ctx, latest first, with their total count.
+// Re-driven events are left out unless includeRedriven is set.
+func (r *DeadLetterRepository) FindPage(ctx context.Context, page, pageSize int, includeRedriven bool) ([]domain.DeadLetter, int64, error) {
+	query := r.db.DbHandler.WithContext(ctx).
+		Model(&models.DeadLetter{}).
+		Scopes(tenantScope(ctx, "dead_letters"))
+	if !includeRedriven {
+		query = query.Where("redriven_at IS NULL")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var records []models.DeadLetter
+	if err := query.
+		Order("dead_lettered_at DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&records).Error; err != nil {
+		return nil, 0, err
+	}
+
+	deadLetters := make([]domain.DeadLetter, len(records))
+	for i, record := range records {
+		deadLetters[i] = models.MapToDeadLetterDomain(record)
+	}
+	return deadLetters, total, nil
+}
+
+// MarkRedriven records the re-drive of a dead-lettered event of the tenant of ctx.
+func (r *DeadLetterRepository) MarkRedriven(ctx context.Context, id string, at time.Time) error {
+	return r.db.DbHandler.WithContext(ctx).
+		Model(&models.DeadLetter{}).
+		Scopes(tenantScope(ctx, "dead_letters")).
+		Where("id = ?", id).
+		Update("redriven_at", at.UTC()).Error
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/org/2112-space-lab/org/app-service/internal/data"
 	"github.com/org/2112-space-lab/org/app-service/internal/data/models"
 	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	domainenum "github.com/org/2112-space-lab/org/app-service/internal/domain/domain-enums"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -20,15 +21,71 @@ func NewEventHandlerRepository(db *data.Database) EventHandlerRepository {
 	return EventHandlerRepository{db: db}
 }
 
-// Save inserts a new event handler execution record, ignoring duplicates.
+// Save inserts an event handler execution record, or updates the status, completion and error of a recorded one.
 func (r *EventHandlerRepository) Save(ctx context.Context, handler domain.EventHandler) error {
 	return r.db.DbHandler.Transaction(func(tx *gorm.DB) error {
 		model := models.MapToEventHandlerModel(handler)
 
 		err := tx.Clauses(clause.OnConflict{
-			DoNothing: true,
+			Columns:   []clause.Column{{Name: "id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"status", "completed_at", "error", "updated_at"}),
 		}).Create(&model).Error
 
 		return err
 	})
 }
+
+// FindByEventUIDs retrieves the handler executions of events, in start order.
+func (r *EventHandlerRepository) FindByEventUIDs(ctx context.Context, eventUIDs []string) ([]domain.EventHandler, error) {
+	if len(eventUIDs) == 0 {
+		return nil, nil
+	}
+
+	var records []models.EventHandler
+	if err := r.db.DbHandler.WithContext(ctx).
+		Where("event_id IN ?", eventUIDs).
+		Order("started_at ASC").
+		Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	handlers := make([]domain.EventHandler, 0, len(records))
+	for _, record := range records {
+		handler, err := models.MapToEventHandlerDomain(record)
+		if err != nil {
+			return nil, err
+		}
+		handlers = append(handlers, handler)
+	}
+	return handlers, nil
+}
+
+// CountByStatus counts the handler executions of the events of the tenant of ctx matching filter, by handler and
+// status.
+func (r *EventHandlerRepository) CountByStatus(ctx context.Context, filter domain.EventReplayFilter) ([]domain.EventHandlerStatusCount, error) {
+	var rows []struct {
+		HandlerName string
+		Status      string
+		Count       int64
+	}
+	if err := r.db.DbHandler.WithContext(ctx).
+		Table("event_handlers").
+		Select("event_handlers.handler_name, event_handlers.status, COUNT(*) AS count").
+		Joins("JOIN events ON events.event_uid = event_handlers.event_id").
+		Scopes(tenantScope(ctx, "events"), eventFilterScope(filter)).
+		Group("event_handlers.handler_name, event_handlers.status").
+		Order("event_handlers.handler_name, event_handlers.status").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make([]domain.EventHandlerStatusCount, 0, len(rows))
+	for _, row := range rows {
+		status, err := domainenum.PotentialHandlerState(row.Status).Validate()
+		if err != nil {
+			return nil, err
+		}
+		counts = append(counts, domain.EventHandlerStatusCount{HandlerName: row.HandlerName, Status: status, Count: row.Count})
+	}
+	return counts, nil
+}
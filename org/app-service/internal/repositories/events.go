@@ -32,3 +32,84 @@ func (r *EventRepository) Save(ctx context.Context, event domain.Event) error {
 		return err
 	})
 }
+
+// FindByUID retrieves an event of the tenant of ctx by its unique identifier.
+func (r *EventRepository) FindByUID(ctx context.Context, eventUID string) (domain.Event, error) {
+	var model models.Event
+	err := r.db.DbHandler.WithContext(ctx).
+		Scopes(tenantScope(ctx, "events")).
+		First(&model, "event_uid = ?", eventUID).Error
+	if err != nil {
+		return domain.Event{}, err
+	}
+	return models.MapToEventDomain(model), nil
+}
+
+// FindForReplay retrieves up to limit events of the tenant of ctx matching a replay filter, in publication order.
+func (r *EventRepository) FindForReplay(ctx context.Context, filter domain.EventReplayFilter, limit int) ([]domain.Event, error) {
+	query := r.db.DbHandler.WithContext(ctx).
+		Scopes(tenantScope(ctx, "events"), eventFilterScope(filter))
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var records []models.Event
+	if err := query.Order("published_at ASC").Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	events := make([]domain.Event, len(records))
+	for i, record := range records {
+		events[i] = models.MapToEventDomain(record)
+	}
+	return events, nil
+}
+
+// FindPage retrieves a page of the events of the tenant of ctx matching filter, latest first, with their total count.
+func (r *EventRepository) FindPage(ctx context.Context, filter domain.EventReplayFilter, page, pageSize int) ([]domain.Event, int64, error) {
+	query := r.db.DbHandler.WithContext(ctx).
+		Model(&models.Event{}).
+		Scopes(tenantScope(ctx, "events"), eventFilterScope(filter))
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var records []models.Event
+	if err := query.
+		Order("published_at DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&records).Error; err != nil {
+		return nil, 0, err
+	}
+
+	events := make([]domain.Event, len(records))
+	for i, record := range records {
+		events[i] = models.MapToEventDomain(record)
+	}
+	return events, total, nil
+}
+
+// eventFilterScope restricts a query on the events table to the events matching filter.
+func eventFilterScope(filter domain.EventReplayFilter) func(*gorm.DB) *gorm.DB {
+	return func(query *gorm.DB) *gorm.DB {
+		if len(filter.EventTypes) > 0 {
+			query = query.Where("events.event_type IN ?", filter.EventTypes)
+		}
+		if len(filter.EventUIDs) > 0 {
+			query = query.Where("events.event_uid IN ?", filter.EventUIDs)
+		}
+		if filter.ContextName != "" {
+			query = query.Where("(events.payload::jsonb ->> 'contextName' = ? OR events.payload::jsonb ->> 'name' = ?)", filter.ContextName, filter.ContextName)
+		}
+		if filter.From.HasValue {
+			query = query.Where("events.published_at >= ?", filter.From.Value.Inner())
+		}
+		if filter.To.HasValue {
+			query = query.Where("events.published_at <= ?", filter.To.Value.Inner())
+		}
+		return query
+	}
+}
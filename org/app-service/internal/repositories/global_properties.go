@@ -16,6 +16,29 @@ const (
 	DefaultSimulationSteps               = 10
 	DefdaultSimulationDuration           = 60 * time.Minute
 	DefaultSimulationBufferDuration      = 3 * time.Hour
+	DefaultMappingMode                   = "nadir"
+	DefaultSwathMinElevationDeg          = 10.0
+	DefaultMappingRetention              = 24 * time.Hour
+	DefaultMappingRetentionPurgeInterval = 10 * time.Minute
+	DefaultContextSchedulerInterval      = 30 * time.Second
+	DefaultContextPreparationLeadTime    = 15 * time.Minute
+	DefaultContextImportTleCategory      = "active"
+	DefaultContextImportMaxCount         = 100
+	DefaultPinnedPropagationStep         = 30 * time.Second
+	DefaultSimulationClockRefresh        = 2 * time.Second
+	DefaultOutboxRelayInterval           = time.Second
+	DefaultOutboxRelayBatchSize          = 100
+	DefaultOutboxRetryBaseDelay          = time.Second
+	DefaultOutboxRetryMaxDelay           = 5 * time.Minute
+	DefaultOutboxMaxAttempts             = 20
+	DefaultOutboxRetention               = 7 * 24 * time.Hour
+	DefaultOutboxRetentionPurgeInterval  = time.Hour
+	DefaultRehydrationTimeout            = 30 * time.Minute
+	DefaultRehydrationWatchdogInterval   = 30 * time.Second
+	DefaultRehydrationPropagationTimeout = 5 * time.Minute
+	DefaultTaskSchedulerInterval         = 15 * time.Second
+	DefaultTaskScheduleLease             = time.Hour
+	DefaultTaskScheduleMissedRunGrace    = 2 * time.Minute
 )
 
 // GlobalPropertyRepository manages retrieval of configuration properties.
@@ -89,6 +112,15 @@ func (r *GlobalPropertyRepository) GetInt(ctx context.Context, key string, defau
 	return parsed, nil
 }
 
+// GetString retrieves a string property.
+func (r *GlobalPropertyRepository) GetString(ctx context.Context, key string, defaultValue string) (string, error) {
+	prop, err := r.GetProperty(ctx, key)
+	if err != nil {
+		return defaultValue, err
+	}
+	return prop.Value, nil
+}
+
 // GetFloat retrieves a float property.
 func (r *GlobalPropertyRepository) GetFloat(ctx context.Context, key string, defaultValue float64) (float64, error) {
 	prop, err := r.GetProperty(ctx, key)
@@ -139,3 +171,125 @@ func (r *GlobalPropertyRepository) GetEventDetectorSimulationSteps(ctx context.C
 func (r *GlobalPropertyRepository) GetEventDetectorSimulationBufferDuration(ctx context.Context, defaultValue time.Duration) (time.Duration, error) {
 	return r.GetDuration(ctx, "event_detector_simulation_buffer_duration", defaultValue)
 }
+
+// GetMappingMode retrieves the tile mapping mode ("nadir" or "swath").
+func (r *GlobalPropertyRepository) GetMappingMode(ctx context.Context, defaultValue string) (string, error) {
+	return r.GetString(ctx, "mapping_mode", defaultValue)
+}
+
+// GetSwathMinElevation retrieves the minimum elevation (degrees) bounding the swath footprint.
+func (r *GlobalPropertyRepository) GetSwathMinElevation(ctx context.Context, defaultValue float64) (float64, error) {
+	return r.GetFloat(ctx, "mapping_swath_min_elevation_deg", defaultValue)
+}
+
+// GetMappingRetention retrieves how long mappings are kept after they end. A non-positive value disables the purge.
+func (r *GlobalPropertyRepository) GetMappingRetention(ctx context.Context, defaultValue time.Duration) (time.Duration, error) {
+	return r.GetDuration(ctx, "mapping_retention_window", defaultValue)
+}
+
+// GetMappingRetentionPurgeInterval retrieves the interval between two purges of expired mappings.
+func (r *GlobalPropertyRepository) GetMappingRetentionPurgeInterval(ctx context.Context, defaultValue time.Duration) (time.Duration, error) {
+	return r.GetDuration(ctx, "mapping_retention_purge_interval", defaultValue)
+}
+
+// GetContextSchedulerInterval retrieves the interval between two runs of the context scheduler.
+func (r *GlobalPropertyRepository) GetContextSchedulerInterval(ctx context.Context, defaultValue time.Duration) (time.Duration, error) {
+	return r.GetDuration(ctx, "context_scheduler_interval", defaultValue)
+}
+
+// GetContextPreparationLeadTime retrieves how long before its activation a scheduled context imports its data.
+func (r *GlobalPropertyRepository) GetContextPreparationLeadTime(ctx context.Context, defaultValue time.Duration) (time.Duration, error) {
+	return r.GetDuration(ctx, "context_preparation_lead_time", defaultValue)
+}
+
+// GetContextImportTleCategory retrieves the CelesTrak category imported when preparing a context.
+func (r *GlobalPropertyRepository) GetContextImportTleCategory(ctx context.Context, defaultValue string) (string, error) {
+	return r.GetString(ctx, "context_import_tle_category", defaultValue)
+}
+
+// GetContextImportMaxCount retrieves the maximum number of satellites and TLEs imported when preparing a context.
+func (r *GlobalPropertyRepository) GetContextImportMaxCount(ctx context.Context, defaultValue int64) (int64, error) {
+	return r.GetInt(ctx, "context_import_max_count", defaultValue)
+}
+
+// GetPinnedPropagationStep retrieves the sampling step used to propagate the pinned TLEs of a context.
+func (r *GlobalPropertyRepository) GetPinnedPropagationStep(ctx context.Context, defaultValue time.Duration) (time.Duration, error) {
+	return r.GetDuration(ctx, "pinned_propagation_step", defaultValue)
+}
+
+// GetSimulationClockRefresh retrieves how long a simulation clock read from the database is reused before it is read again.
+func (r *GlobalPropertyRepository) GetSimulationClockRefresh(ctx context.Context, defaultValue time.Duration) (time.Duration, error) {
+	return r.GetDuration(ctx, "simulation_clock_refresh", defaultValue)
+}
+
+// GetOutboxRelayInterval retrieves the interval between two polls of the transactional outbox.
+func (r *GlobalPropertyRepository) GetOutboxRelayInterval(ctx context.Context, defaultValue time.Duration) (time.Duration, error) {
+	return r.GetDuration(ctx, "outbox_relay_interval", defaultValue)
+}
+
+// GetOutboxRelayBatchSize retrieves the maximum number of outbox messages the relay reads per poll.
+func (r *GlobalPropertyRepository) GetOutboxRelayBatchSize(ctx context.Context, defaultValue int64) (int64, error) {
+	return r.GetInt(ctx, "outbox_relay_batch_size", defaultValue)
+}
+
+// GetOutboxMaxAttempts retrieves how many times the relay tries to publish an outbox message before parking it.
+func (r *GlobalPropertyRepository) GetOutboxMaxAttempts(ctx context.Context, defaultValue int64) (int64, error) {
+	return r.GetInt(ctx, "outbox_max_attempts", defaultValue)
+}
+
+// GetOutboxRetryMaxDelay retrieves the longest wait between two publication attempts of an outbox message.
+func (r *GlobalPropertyRepository) GetOutboxRetryMaxDelay(ctx context.Context, defaultValue time.Duration) (time.Duration, error) {
+	return r.GetDuration(ctx, "outbox_retry_max_delay", defaultValue)
+}
+
+// GetOutboxRetention retrieves how long published outbox messages are kept, deduplicating their event UIDs. A
+// non-positive value disables the purge.
+func (r *GlobalPropertyRepository) GetOutboxRetention(ctx context.Context, defaultValue time.Duration) (time.Duration, error) {
+	return r.GetDuration(ctx, "outbox_retention_window", defaultValue)
+}
+
+// GetOutboxRetentionPurgeInterval retrieves the interval between two purges of published outbox messages.
+func (r *GlobalPropertyRepository) GetOutboxRetentionPurgeInterval(ctx context.Context, defaultValue time.Duration) (time.Duration, error) {
+	return r.GetDuration(ctx, "outbox_retention_purge_interval", defaultValue)
+}
+
+// GetEventHandlerWorkers retrieves the number of events an event handler processes at once, unless it declares its own concurrency.
+func (r *GlobalPropertyRepository) GetEventHandlerWorkers(ctx context.Context, defaultValue int64) (int64, error) {
+	return r.GetInt(ctx, "event_handler_workers", defaultValue)
+}
+
+// GetEventHandlerQueueSize retrieves the number of events waiting per worker of an event handler before the event monitor stops consuming.
+func (r *GlobalPropertyRepository) GetEventHandlerQueueSize(ctx context.Context, defaultValue int64) (int64, error) {
+	return r.GetInt(ctx, "event_handler_queue_size", defaultValue)
+}
+
+// GetRehydrationTimeout retrieves how long the satellites of a context rehydration have to be propagated and mapped before they fail.
+func (r *GlobalPropertyRepository) GetRehydrationTimeout(ctx context.Context, defaultValue time.Duration) (time.Duration, error) {
+	return r.GetDuration(ctx, "rehydration_timeout", defaultValue)
+}
+
+// GetRehydrationWatchdogInterval retrieves the interval between two searches for timed out context rehydrations.
+func (r *GlobalPropertyRepository) GetRehydrationWatchdogInterval(ctx context.Context, defaultValue time.Duration) (time.Duration, error) {
+	return r.GetDuration(ctx, "rehydration_watchdog_interval", defaultValue)
+}
+
+// GetRehydrationPropagationTimeout retrieves how long the propagation of a satellite of a context rehydration may go unreported before its step fails.
+func (r *GlobalPropertyRepository) GetRehydrationPropagationTimeout(ctx context.Context, defaultValue time.Duration) (time.Duration, error) {
+	return r.GetDuration(ctx, "rehydration_propagation_timeout", defaultValue)
+}
+
+// GetTaskSchedulerInterval retrieves the interval between two searches for due task schedules.
+func (r *GlobalPropertyRepository) GetTaskSchedulerInterval(ctx context.Context, defaultValue time.Duration) (time.Duration, error) {
+	return r.GetDuration(ctx, "task_scheduler_interval", defaultValue)
+}
+
+// GetTaskScheduleLease retrieves how long a scheduled run may last without renewing its lease before another scheduler
+// may start the task again.
+func (r *GlobalPropertyRepository) GetTaskScheduleLease(ctx context.Context, defaultValue time.Duration) (time.Duration, error) {
+	return r.GetDuration(ctx, "task_schedule_lease", defaultValue)
+}
+
+// GetTaskScheduleMissedRunGrace retrieves how late a scheduled run may start before it counts as missed.
+func (r *GlobalPropertyRepository) GetTaskScheduleMissedRunGrace(ctx context.Context, defaultValue time.Duration) (time.Duration, error) {
+	return r.GetDuration(ctx, "task_schedule_missed_run_grace", defaultValue)
+}
@@ -3,13 +3,17 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/org/2112-space-lab/org/app-service/internal/data"
 	"github.com/org/2112-space-lab/org/app-service/internal/data/models"
 	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	"gorm.io/gorm"
 )
 
+// TileSatelliteMappingRepository manages the mappings of the satellites of contexts to tiles. Mappings are read and
+// written through contexts of the tenant of ctx only; an unknown or foreign context is not found.
 type TileSatelliteMappingRepository struct {
 	db *data.Database
 }
@@ -20,53 +24,83 @@ func NewTileSatelliteMappingRepository(db *data.Database) TileSatelliteMappingRe
 }
 
 func (r *TileSatelliteMappingRepository) FindBySpaceIDAndTile(ctx context.Context, contextID, spaceID, tileID string) ([]domain.TileSatelliteMapping, error) {
+	if err := r.ensureContext(ctx, contextID); err != nil {
+		return nil, err
+	}
 	var mappings []domain.TileSatelliteMapping
-	result := r.db.DbHandler.WithContext(ctx).
+	result := r.db.Conn(ctx).
 		Where("context_id = ? AND space_id = ? AND tile_id = ?", contextID, spaceID, tileID).
 		Find(&mappings)
 	return mappings, result.Error
 }
 
 func (r *TileSatelliteMappingRepository) FindAll(ctx context.Context, contextID string) ([]domain.TileSatelliteMapping, error) {
+	if err := r.ensureContext(ctx, contextID); err != nil {
+		return nil, err
+	}
 	var mappings []domain.TileSatelliteMapping
-	result := r.db.DbHandler.WithContext(ctx).
+	result := r.db.Conn(ctx).
 		Where("context_id = ?", contextID).
 		Find(&mappings)
 	return mappings, result.Error
 }
 
 func (r *TileSatelliteMappingRepository) Save(ctx context.Context, mapping domain.TileSatelliteMapping) error {
+	if err := r.ensureContext(ctx, mapping.ContextID); err != nil {
+		return err
+	}
 	if mapping.ID == "" {
 		mapping.ID = uuid.NewString()
 	}
-	return r.db.DbHandler.WithContext(ctx).Create(&mapping).Error
+	return r.db.Conn(ctx).Create(&mapping).Error
 }
 
 func (r *TileSatelliteMappingRepository) Update(ctx context.Context, mapping domain.TileSatelliteMapping) error {
-	return r.db.DbHandler.WithContext(ctx).Save(&mapping).Error
+	if err := r.ensureContext(ctx, mapping.ContextID); err != nil {
+		return err
+	}
+	return r.db.Conn(ctx).
+		Model(&domain.TileSatelliteMapping{}).
+		Where("id = ? AND context_id IN (?)", mapping.ID, tenantContextIDs(ctx, r.db.Conn(ctx))).
+		Select("*").
+		Updates(&mapping).Error
 }
 
 func (r *TileSatelliteMappingRepository) Delete(ctx context.Context, id string) error {
-	return r.db.DbHandler.WithContext(ctx).
-		Where("id = ?", id).
+	return r.db.Conn(ctx).
+		Where("id = ? AND context_id IN (?)", id, tenantContextIDs(ctx, r.db.Conn(ctx))).
 		Delete(&domain.TileSatelliteMapping{}).Error
 }
 
+// SaveBatch inserts mappings that already carry their context ID.
 func (r *TileSatelliteMappingRepository) SaveBatch(ctx context.Context, mappings []domain.TileSatelliteMapping) error {
 	if len(mappings) == 0 {
 		return nil
 	}
+	checked := map[string]bool{}
 	for i := range mappings {
+		if mappings[i].ContextID == "" {
+			return fmt.Errorf("mapping of SPACE ID %s on tile %s has no context", mappings[i].SpaceID, mappings[i].TileID)
+		}
+		if !checked[mappings[i].ContextID] {
+			if err := r.ensureContext(ctx, mappings[i].ContextID); err != nil {
+				return err
+			}
+			checked[mappings[i].ContextID] = true
+		}
 		if mappings[i].ID == "" {
 			mappings[i].ID = uuid.NewString()
 		}
 	}
-	return r.db.DbHandler.WithContext(ctx).Create(&mappings).Error
+	return r.db.Conn(ctx).Create(&mappings).Error
 }
 
 func (r *TileSatelliteMappingRepository) FindSatellitesForTiles(ctx context.Context, contextID string, tileIDs []string) ([]domain.Satellite, error) {
+	if err := r.ensureContext(ctx, contextID); err != nil {
+		return nil, err
+	}
 	var satellites []models.Satellite
-	err := r.db.DbHandler.WithContext(ctx).
+	err := r.db.Conn(ctx).
 		Table("tile_satellite_mappings").
 		Select("satellites.*").
 		Joins("JOIN satellites ON tile_satellite_mappings.space_id = satellites.space_id").
@@ -83,57 +117,32 @@ func (r *TileSatelliteMappingRepository) FindSatellitesForTiles(ctx context.Cont
 }
 
 func (r *TileSatelliteMappingRepository) FindAllVisibleTilesBySpaceIDSortedByAOSTime(ctx context.Context, contextID, spaceID string) ([]domain.TileSatelliteInfo, error) {
+	if err := r.ensureContext(ctx, contextID); err != nil {
+		return nil, err
+	}
 	var mappings []domain.TileSatelliteMapping
-	result := r.db.DbHandler.WithContext(ctx).
+	result := r.db.Conn(ctx).
 		Where("context_id = ? AND space_id = ?", contextID, spaceID).
-		Order("aos ASC").
+		Order("entered_at ASC").
 		Find(&mappings)
 	if result.Error != nil {
 		return nil, result.Error
 	}
-
-	tileIDs := make([]string, len(mappings))
-	for i, mapping := range mappings {
-		tileIDs[i] = mapping.TileID
-	}
-
-	var tiles []models.Tile
-	err := r.db.DbHandler.WithContext(ctx).Where("id IN ?", tileIDs).Find(&tiles).Error
-	if err != nil {
-		return nil, err
-	}
-
-	tileMap := make(map[string]models.Tile)
-	for _, tile := range tiles {
-		tileMap[tile.ID] = tile
-	}
-
-	var infos []domain.TileSatelliteInfo
-	for _, mapping := range mappings {
-		tile := tileMap[mapping.TileID]
-		infos = append(infos, domain.TileSatelliteInfo{
-			MappingID:     mapping.ID,
-			TileID:        tile.ID,
-			TileQuadkey:   tile.Quadkey,
-			TileCenterLat: tile.CenterLat,
-			TileCenterLon: tile.CenterLon,
-			TileZoomLevel: tile.ZoomLevel,
-			SpaceID:       mapping.SpaceID,
-		})
-	}
-	return infos, nil
+	return r.toTileSatelliteInfos(ctx, mappings)
 }
 
 func (r *TileSatelliteMappingRepository) ListSatellitesMappingWithPagination(ctx context.Context, contextID string, page, pageSize int, search *domain.SearchRequest) ([]domain.TileSatelliteInfo, int64, error) {
+	if err := r.ensureContext(ctx, contextID); err != nil {
+		return nil, 0, err
+	}
 	var (
-		mappings           []domain.TileSatelliteMapping
-		totalRecords       int64
-		tileSatelliteInfos []domain.TileSatelliteInfo
+		mappings     []domain.TileSatelliteMapping
+		totalRecords int64
 	)
 
 	offset := (page - 1) * pageSize
 
-	query := r.db.DbHandler.WithContext(ctx).Table("tile_satellite_mappings").
+	query := r.db.Conn(ctx).Table("tile_satellite_mappings").
 		Where("context_id = ?", contextID)
 
 	if search != nil && search.Wildcard != "" {
@@ -149,54 +158,176 @@ func (r *TileSatelliteMappingRepository) ListSatellitesMappingWithPagination(ctx
 		return nil, 0, err
 	}
 
-	tileIDs := make([]string, len(mappings))
-	for i, mapping := range mappings {
-		tileIDs[i] = mapping.TileID
+	tileSatelliteInfos, err := r.toTileSatelliteInfos(ctx, mappings)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	var tiles []models.Tile
-	err := r.db.DbHandler.WithContext(ctx).Where("id IN ?", tileIDs).Find(&tiles).Error
+	return tileSatelliteInfos, totalRecords, nil
+}
+
+func (r *TileSatelliteMappingRepository) GetSatelliteMappingsBySpaceID(ctx context.Context, contextID, spaceID string) ([]domain.TileSatelliteInfo, error) {
+	if err := r.ensureContext(ctx, contextID); err != nil {
+		return nil, err
+	}
+	var mappings []domain.TileSatelliteMapping
+	err := r.db.Conn(ctx).
+		Where("context_id = ? AND space_id = ?", contextID, spaceID).
+		Order("entered_at ASC").
+		Find(&mappings).Error
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
+	return r.toTileSatelliteInfos(ctx, mappings)
+}
 
-	tileMap := make(map[string]models.Tile)
-	for _, tile := range tiles {
-		tileMap[tile.ID] = tile
+// FindMappingsInWindow retrieves mappings whose pass overlaps the filter's time window, ordered by enter time.
+func (r *TileSatelliteMappingRepository) FindMappingsInWindow(ctx context.Context, contextID string, filter domain.MappingWindowFilter) ([]domain.TileSatelliteInfo, error) {
+	if err := r.ensureContext(ctx, contextID); err != nil {
+		return nil, err
 	}
+	query := r.db.Conn(ctx).
+		Where("context_id = ?", contextID)
 
-	for _, mapping := range mappings {
-		tile := tileMap[mapping.TileID]
-		tileSatelliteInfos = append(tileSatelliteInfos, domain.TileSatelliteInfo{
-			MappingID:     mapping.ID,
-			TileID:        tile.ID,
-			TileQuadkey:   tile.Quadkey,
-			TileCenterLat: tile.CenterLat,
-			TileCenterLon: tile.CenterLon,
-			TileZoomLevel: tile.ZoomLevel,
-			SpaceID:       mapping.SpaceID,
-		})
+	if filter.SpaceID != "" {
+		query = query.Where("space_id = ?", filter.SpaceID)
+	}
+	if filter.TileID != "" {
+		query = query.Where("tile_id = ?", filter.TileID)
+	}
+	if filter.From.HasValue {
+		query = query.Where("exited_at >= ?", filter.From.Value)
+	}
+	if filter.To.HasValue {
+		query = query.Where("entered_at <= ?", filter.To.Value)
 	}
 
-	return tileSatelliteInfos, totalRecords, nil
+	var mappings []domain.TileSatelliteMapping
+	if err := query.Order("entered_at ASC").Find(&mappings).Error; err != nil {
+		return nil, fmt.Errorf("failed to find mappings in window: %w", err)
+	}
+	return r.toTileSatelliteInfos(ctx, mappings)
 }
 
-func (r *TileSatelliteMappingRepository) GetSatelliteMappingsBySpaceID(ctx context.Context, contextID, spaceID string) ([]domain.TileSatelliteInfo, error) {
+// FindOpenMappings retrieves the mappings of a satellite still in progress at the given time.
+func (r *TileSatelliteMappingRepository) FindOpenMappings(ctx context.Context, contextID, spaceID string, at time.Time) ([]domain.TileSatelliteMapping, error) {
+	if err := r.ensureContext(ctx, contextID); err != nil {
+		return nil, err
+	}
 	var mappings []domain.TileSatelliteMapping
-	err := r.db.DbHandler.WithContext(ctx).
-		Where("context_id = ? AND space_id = ?", contextID, spaceID).
+	err := r.db.Conn(ctx).
+		Where("context_id = ? AND space_id = ? AND exited_at >= ?", contextID, spaceID, at).
+		Order("entered_at ASC").
 		Find(&mappings).Error
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to find open mappings: %w", err)
 	}
+	return mappings, nil
+}
+
+// ApplyMappingIncrement updates extended mappings and inserts new ones in a single transaction,
+// joining the transaction of ctx if any.
+func (r *TileSatelliteMappingRepository) ApplyMappingIncrement(ctx context.Context, contextID, spaceID string, extended, added []domain.TileSatelliteMapping) error {
+	if err := r.ensureContext(ctx, contextID); err != nil {
+		return err
+	}
+	return r.db.Transaction(ctx, func(ctx context.Context) error {
+		tx := r.db.Conn(ctx)
+		for _, mapping := range extended {
+			err := tx.Model(&domain.TileSatelliteMapping{}).
+				Where("id = ? AND context_id = ? AND space_id = ?", mapping.ID, contextID, spaceID).
+				Updates(map[string]interface{}{
+					"exited_at":              mapping.ExitedAt,
+					"duration_seconds":       mapping.DurationSeconds,
+					"intersected_at":         mapping.IntersectedAt,
+					"intersection_latitude":  mapping.IntersectionLatitude,
+					"intersection_longitude": mapping.IntersectionLongitude,
+				}).Error
+			if err != nil {
+				return fmt.Errorf("failed to extend mapping [%s]: %w", mapping.ID, err)
+			}
+		}
+		return createMappings(tx, contextID, added)
+	})
+}
 
+// ReplaceMappings swaps the mappings of a satellite ending at or after from for the given ones in a
+// single transaction, joining the transaction of ctx if any, so readers never see the satellite without mappings.
+func (r *TileSatelliteMappingRepository) ReplaceMappings(ctx context.Context, contextID, spaceID string, from time.Time, mappings []domain.TileSatelliteMapping) error {
+	if err := r.ensureContext(ctx, contextID); err != nil {
+		return err
+	}
+	return r.db.Transaction(ctx, func(ctx context.Context) error {
+		tx := r.db.Conn(ctx)
+		err := tx.Where("context_id = ? AND space_id = ? AND (exited_at IS NULL OR exited_at >= ?)", contextID, spaceID, from).
+			Delete(&domain.TileSatelliteMapping{}).Error
+		if err != nil {
+			return fmt.Errorf("failed to delete mappings: %w", err)
+		}
+		return createMappings(tx, contextID, mappings)
+	})
+}
+
+// PurgeMappingsBefore deletes mappings that ended before the cutoff, in all contexts, and returns how many were removed.
+func (r *TileSatelliteMappingRepository) PurgeMappingsBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := r.db.Conn(ctx).
+		Where("COALESCE(exited_at, intersected_at) < ?", cutoff).
+		Delete(&domain.TileSatelliteMapping{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to purge mappings: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *TileSatelliteMappingRepository) DeleteMappingsBySpaceID(ctx context.Context, contextID, spaceID string) error {
+	if err := r.ensureContext(ctx, contextID); err != nil {
+		return err
+	}
+	return r.db.Conn(ctx).
+		Where("context_id = ? AND space_id = ?", contextID, spaceID).
+		Delete(&domain.TileSatelliteMapping{}).Error
+}
+
+// ensureContext fails with gorm.ErrRecordNotFound unless the context belongs to the tenant of ctx.
+func (r *TileSatelliteMappingRepository) ensureContext(ctx context.Context, contextID string) error {
+	var count int64
+	if err := tenantContextIDs(ctx, r.db.Conn(ctx)).
+		Where("contexts.id = ?", contextID).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("context %s not found: %w", contextID, gorm.ErrRecordNotFound)
+	}
+	return nil
+}
+
+// createMappings inserts mappings computed for a context, stamping them with its ID.
+func createMappings(tx *gorm.DB, contextID string, mappings []domain.TileSatelliteMapping) error {
+	if len(mappings) == 0 {
+		return nil
+	}
+	for i := range mappings {
+		mappings[i].ContextID = contextID
+		if mappings[i].ID == "" {
+			mappings[i].ID = uuid.NewString()
+		}
+	}
+	if err := tx.Create(&mappings).Error; err != nil {
+		return fmt.Errorf("failed to save mappings: %w", err)
+	}
+	return nil
+}
+
+// toTileSatelliteInfos joins mappings with their tiles.
+func (r *TileSatelliteMappingRepository) toTileSatelliteInfos(ctx context.Context, mappings []domain.TileSatelliteMapping) ([]domain.TileSatelliteInfo, error) {
 	tileIDs := make([]string, len(mappings))
 	for i, mapping := range mappings {
 		tileIDs[i] = mapping.TileID
 	}
 
 	var tiles []models.Tile
-	err = r.db.DbHandler.WithContext(ctx).Where("id IN ?", tileIDs).Find(&tiles).Error
+	err := r.db.Conn(ctx).Where("id IN ?", tileIDs).Find(&tiles).Error
 	if err != nil {
 		return nil, err
 	}
@@ -217,13 +348,15 @@ func (r *TileSatelliteMappingRepository) GetSatelliteMappingsBySpaceID(ctx conte
 			TileCenterLon: tile.CenterLon,
 			TileZoomLevel: tile.ZoomLevel,
 			SpaceID:       mapping.SpaceID,
+			Intersection: domain.Point{
+				Longitude: mapping.IntersectionLongitude,
+				Latitude:  mapping.IntersectionLatitude,
+			},
+			IntersectedAt:   mapping.IntersectedAt,
+			EnteredAt:       mapping.EnteredAt,
+			ExitedAt:        mapping.ExitedAt,
+			DurationSeconds: mapping.DurationSeconds,
 		})
 	}
 	return infos, nil
 }
-
-func (r *TileSatelliteMappingRepository) DeleteMappingsBySpaceID(ctx context.Context, contextID, spaceID string) error {
-	return r.db.DbHandler.WithContext(ctx).
-		Where("context_id = ? AND space_id = ?", contextID, spaceID).
-		Delete(&domain.TileSatelliteMapping{}).Error
-}
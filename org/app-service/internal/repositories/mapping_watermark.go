@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/data"
+	"github.com/org/2112-space-lab/org/app-service/internal/data/models"
+	fx "github.com/org/2112-space-lab/org/app-service/pkg/option"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MappingWatermarkRepository tracks, per context and satellite, the time up to which mappings were computed.
+// Calls made within a transaction of ctx join it, so the watermark moves together with the mappings.
+type MappingWatermarkRepository struct {
+	db *data.Database
+}
+
+// NewMappingWatermarkRepository creates a new MappingWatermarkRepository instance.
+func NewMappingWatermarkRepository(db *data.Database) MappingWatermarkRepository {
+	return MappingWatermarkRepository{db: db}
+}
+
+// Get returns the watermark of a satellite in a context, if mappings were computed before.
+func (r *MappingWatermarkRepository) Get(ctx context.Context, contextID, spaceID string) (fx.Option[time.Time], error) {
+	var watermark models.MappingWatermark
+	err := r.db.Conn(ctx).
+		Where("context_id = ? AND space_id = ?", contextID, spaceID).
+		First(&watermark).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fx.NewEmptyOption[time.Time](), nil
+	}
+	if err != nil {
+		return fx.NewEmptyOption[time.Time](), fmt.Errorf("failed to read mapping watermark: %w", err)
+	}
+	return fx.NewValueOption(watermark.Watermark.UTC()), nil
+}
+
+// Set moves the watermark of a satellite in a context.
+func (r *MappingWatermarkRepository) Set(ctx context.Context, contextID, spaceID string, watermark time.Time) error {
+	err := r.db.Conn(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "context_id"}, {Name: "space_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"watermark", "updated_at"}),
+		}).
+		Create(&models.MappingWatermark{
+			ContextID: contextID,
+			SpaceID:   spaceID,
+			Watermark: watermark.UTC(),
+			UpdatedAt: time.Now().UTC(),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update mapping watermark: %w", err)
+	}
+	return nil
+}
+
+// Clear forgets the watermark, so the next computation starts from scratch.
+func (r *MappingWatermarkRepository) Clear(ctx context.Context, contextID, spaceID string) error {
+	return r.db.Conn(ctx).
+		Where("context_id = ? AND space_id = ?", contextID, spaceID).
+		Delete(&models.MappingWatermark{}).Error
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/org/2112-space-lab/org/app-service/internal/data"
+	"github.com/org/2112-space-lab/org/app-service/internal/data/models"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MembershipRuleRepository stores the membership rules of contexts and applies their results.
+type MembershipRuleRepository struct {
+	db *data.Database
+}
+
+// NewMembershipRuleRepository creates a new MembershipRuleRepository instance.
+func NewMembershipRuleRepository(db *data.Database) MembershipRuleRepository {
+	return MembershipRuleRepository{db: db}
+}
+
+// SaveRule creates or replaces the rule of the same name on a context of the tenant of ctx.
+func (r *MembershipRuleRepository) SaveRule(ctx context.Context, rule domain.MembershipRule) error {
+	if err := r.ensureContext(ctx, rule.ContextID); err != nil {
+		return err
+	}
+	if rule.ID == "" {
+		rule.ID = uuid.NewString()
+	}
+	model, err := models.MapToMembershipRuleModel(rule)
+	if err != nil {
+		return fmt.Errorf("failed to serialize filters of rule %s: %w", rule.Name, err)
+	}
+	now := time.Now().UTC()
+	model.CreatedAt, model.UpdatedAt = now, now
+
+	return r.db.DbHandler.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "context_id"}, {Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"filters", "updated_at"}),
+		}).
+		Create(&model).Error
+}
+
+// DeleteRule removes a rule from a context of the tenant of ctx.
+func (r *MembershipRuleRepository) DeleteRule(ctx context.Context, contextID, name string) error {
+	result := r.db.DbHandler.WithContext(ctx).
+		Where("context_id = ? AND name = ?", contextID, name).
+		Where("context_id IN (?)", tenantContextIDs(ctx, r.db.DbHandler.WithContext(ctx))).
+		Delete(&models.ContextMembershipRule{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("rule %s not found: %w", name, gorm.ErrRecordNotFound)
+	}
+	return nil
+}
+
+// FindRulesByContext retrieves the rules of a context of the tenant of ctx, ordered by name.
+func (r *MembershipRuleRepository) FindRulesByContext(ctx context.Context, contextID string) ([]domain.MembershipRule, error) {
+	var rules []models.ContextMembershipRule
+	if err := r.db.DbHandler.WithContext(ctx).
+		Where("context_id = ?", contextID).
+		Where("context_id IN (?)", tenantContextIDs(ctx, r.db.DbHandler.WithContext(ctx))).
+		Order("name").
+		Find(&rules).Error; err != nil {
+		return nil, err
+	}
+
+	domainRules := make([]domain.MembershipRule, 0, len(rules))
+	for _, rule := range rules {
+		domainRule, err := models.MapToMembershipRuleDomain(rule)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse filters of rule %s: %w", rule.Name, err)
+		}
+		domainRules = append(domainRules, domainRule)
+	}
+	return domainRules, nil
+}
+
+// FindContextsWithRules retrieves the non-archived contexts of every tenant that have at least one rule.
+// Callers acting on the result must scope each context to its tenant.
+func (r *MembershipRuleRepository) FindContextsWithRules(ctx context.Context) ([]domain.GameContext, error) {
+	var contexts []models.Context
+	if err := r.db.DbHandler.WithContext(ctx).
+		Where("id IN (?)", r.db.DbHandler.Model(&models.ContextMembershipRule{}).Select("context_id")).
+		Where("state <> ? AND deleted_at IS NULL", string(domain.GameContextStateArchived)).
+		Order("tenant_id, name").
+		Find(&contexts).Error; err != nil {
+		return nil, err
+	}
+
+	domainContexts := make([]domain.GameContext, 0, len(contexts))
+	for _, c := range contexts {
+		domainContexts = append(domainContexts, models.MapToContextDomain(c))
+	}
+	return domainContexts, nil
+}
+
+// FindMembers retrieves the satellite associations of a context of the tenant of ctx.
+func (r *MembershipRuleRepository) FindMembers(ctx context.Context, contextID string) ([]domain.GameContextSatellite, error) {
+	var members []models.ContextSatellite
+	if err := r.db.DbHandler.WithContext(ctx).
+		Preload("Satellite").
+		Where("context_id = ?", contextID).
+		Where("context_id IN (?)", tenantContextIDs(ctx, r.db.DbHandler.WithContext(ctx))).
+		Find(&members).Error; err != nil {
+		return nil, err
+	}
+
+	domainMembers := make([]domain.GameContextSatellite, 0, len(members))
+	for _, member := range members {
+		domainMembers = append(domainMembers, models.MapToContextSatelliteDomain(member))
+	}
+	return domainMembers, nil
+}
+
+// ApplyMembership assigns the satellites of add to a context of the tenant of ctx as rule members and removes the
+// rule members listed in remove, in one transaction. Satellites assigned by hand are never removed.
+func (r *MembershipRuleRepository) ApplyMembership(ctx context.Context, contextID string, add, remove []domain.SatelliteID) error {
+	if err := r.ensureContext(ctx, contextID); err != nil {
+		return err
+	}
+
+	return r.db.DbHandler.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(remove) > 0 {
+			if err := tx.Where("context_id = ? AND rule_assigned = TRUE AND satellite_id IN ?", contextID, satelliteIDStrings(remove)).
+				Delete(&models.ContextSatellite{}).Error; err != nil {
+				return fmt.Errorf("failed to remove rule members: %w", err)
+			}
+		}
+		if len(add) == 0 {
+			return nil
+		}
+
+		members := make([]models.ContextSatellite, len(add))
+		for i, satelliteID := range add {
+			members[i] = models.ContextSatellite{
+				ContextID:    contextID,
+				SatelliteID:  string(satelliteID),
+				RuleAssigned: true,
+			}
+		}
+		if err := tx.Omit(clause.Associations).CreateInBatches(&members, 500).Error; err != nil {
+			return fmt.Errorf("failed to add rule members: %w", err)
+		}
+		return nil
+	})
+}
+
+// ensureContext fails unless the context belongs to the tenant of ctx.
+func (r *MembershipRuleRepository) ensureContext(ctx context.Context, contextID string) error {
+	var count int64
+	if err := tenantContextIDs(ctx, r.db.DbHandler.WithContext(ctx)).
+		Where("contexts.id = ?", contextID).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("context %s not found: %w", contextID, gorm.ErrRecordNotFound)
+	}
+	return nil
+}
+
+func satelliteIDStrings(satelliteIDs []domain.SatelliteID) []string {
+	ids := make([]string, len(satelliteIDs))
+	for i, satelliteID := range satelliteIDs {
+		ids[i] = string(satelliteID)
+	}
+	return ids
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/data"
+	"github.com/org/2112-space-lab/org/app-service/internal/data/models"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	"gorm.io/gorm/clause"
+)
+
+// outboxRelayLockKey identifies the advisory lock held by the outbox relay publishing a batch.
+const outboxRelayLockKey = "outbox_relay"
+
+// OutboxRepository manages the transactional outbox. The outbox spans every tenant: messages carry their tenant
+// and only the relay reads them.
+type OutboxRepository struct {
+	db *data.Database
+}
+
+// NewOutboxRepository creates a new OutboxRepository instance.
+func NewOutboxRepository(db *data.Database) OutboxRepository {
+	return OutboxRepository{db: db}
+}
+
+// Enqueue stores a message in the transaction of ctx, if any, so it is kept only when the state change it
+// announces is committed. A message with an already stored event UID is ignored.
+func (r *OutboxRepository) Enqueue(ctx context.Context, message domain.OutboxMessage) error {
+	model := models.MapToOutboxMessageModel(message)
+	model.Sequence = 0
+	if model.Kind == "" {
+		model.Kind = string(domain.OutboxKindEvent)
+	}
+	if model.CreatedAt.IsZero() {
+		model.CreatedAt = time.Now().UTC()
+	}
+	if model.NextAttemptAt.IsZero() {
+		model.NextAttemptAt = model.CreatedAt
+	}
+	return r.db.Conn(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "event_uid"}}, DoNothing: true}).
+		Create(&model).Error
+}
+
+// FindPending retrieves up to limit unsent messages of every tenant in publication order, including messages not
+// yet due for retry: the relay needs them to hold back the later messages of their aggregate. Parked messages are
+// left out.
+func (r *OutboxRepository) FindPending(ctx context.Context, limit int) ([]domain.OutboxMessage, error) {
+	var records []models.OutboxMessage
+	if err := r.db.Conn(ctx).
+		Where("sent_at IS NULL AND parked_at IS NULL").
+		Order("sequence ASC").
+		Limit(limit).
+		Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	messages := make([]domain.OutboxMessage, len(records))
+	for i, record := range records {
+		messages[i] = models.MapToOutboxMessageDomain(record)
+	}
+	return messages, nil
+}
+
+// MarkSent records the publication of a message.
+func (r *OutboxRepository) MarkSent(ctx context.Context, sequence int64, sentAt time.Time) error {
+	return r.db.Conn(ctx).
+		Model(&models.OutboxMessage{}).
+		Where("sequence = ?", sequence).
+		Updates(map[string]interface{}{"sent_at": sentAt.UTC(), "last_error": ""}).Error
+}
+
+// MarkFailed records a failed publication and when to retry it.
+func (r *OutboxRepository) MarkFailed(ctx context.Context, sequence int64, attempts int, nextAttemptAt time.Time, lastError string) error {
+	return r.db.Conn(ctx).
+		Model(&models.OutboxMessage{}).
+		Where("sequence = ?", sequence).
+		Updates(map[string]interface{}{
+			"attempts":        attempts,
+			"next_attempt_at": nextAttemptAt.UTC(),
+			"last_error":      lastError,
+		}).Error
+}
+
+// MarkParked records that the relay gave up on a message, which no longer holds back its aggregate.
+func (r *OutboxRepository) MarkParked(ctx context.Context, sequence int64, attempts int, parkedAt time.Time, lastError string) error {
+	return r.db.Conn(ctx).
+		Model(&models.OutboxMessage{}).
+		Where("sequence = ?", sequence).
+		Updates(map[string]interface{}{
+			"attempts":   attempts,
+			"parked_at":  parkedAt.UTC(),
+			"last_error": lastError,
+		}).Error
+}
+
+// PurgeSentBefore deletes the messages of every tenant published before the cutoff and returns how many were
+// removed. Pending and parked messages are kept.
+func (r *OutboxRepository) PurgeSentBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := r.db.Conn(ctx).
+		Where("sent_at IS NOT NULL AND sent_at < ?", cutoff.UTC()).
+		Delete(&models.OutboxMessage{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to purge outbox messages: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// WithRelayLock runs fn in a transaction holding the relay lock, so a single relay publishes at a time and the
+// order of each aggregate is kept across relay instances. It returns false, without running fn, when another
+// relay holds the lock.
+func (r *OutboxRepository) WithRelayLock(ctx context.Context, fn func(ctx context.Context) error) (bool, error) {
+	acquired := false
+	err := r.db.Transaction(ctx, func(ctx context.Context) error {
+		if err := r.db.Conn(ctx).
+			Raw("SELECT pg_try_advisory_xact_lock(hashtext(?))", outboxRelayLockKey).
+			Scan(&acquired).Error; err != nil {
+			return err
+		}
+		if !acquired {
+			return nil
+		}
+		return fn(ctx)
+	})
+	return acquired, err
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/data"
+	"github.com/org/2112-space-lab/org/app-service/internal/data/models"
+	"gorm.io/gorm/clause"
+)
+
+// ProcessedEventRepository manages the claims of exactly-once-effect handlers on events. Event UIDs are unique
+// across tenants, so claims are not tenant-scoped.
+type ProcessedEventRepository struct {
+	db *data.Database
+}
+
+// NewProcessedEventRepository creates a new ProcessedEventRepository instance.
+func NewProcessedEventRepository(db *data.Database) ProcessedEventRepository {
+	return ProcessedEventRepository{db: db}
+}
+
+// Claim records that handlerName processes eventUID, relying on the primary key to let a single delivery win.
+// It returns false when the event was already claimed by that handler. Within a transaction, a concurrent claim of
+// the same event waits for it to commit or roll back.
+func (r *ProcessedEventRepository) Claim(ctx context.Context, eventUID, handlerName string) (bool, error) {
+	model := models.ProcessedEvent{
+		EventUID:    eventUID,
+		HandlerName: handlerName,
+		ProcessedAt: time.Now().UTC(),
+	}
+	result := r.db.Conn(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&model)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/data"
+	"github.com/org/2112-space-lab/org/app-service/internal/data/models"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RehydrationRepository stores the rehydrations of contexts and the steps of their satellites.
+type RehydrationRepository struct {
+	db *data.Database
+}
+
+// NewRehydrationRepository creates a new RehydrationRepository instance.
+func NewRehydrationRepository(db *data.Database) RehydrationRepository {
+	return RehydrationRepository{db: db}
+}
+
+// Start stores a rehydration of a context of the tenant of ctx with its steps, replacing the previous rehydration
+// of the context.
+func (r *RehydrationRepository) Start(ctx context.Context, rehydration domain.Rehydration) error {
+	return r.db.Transaction(ctx, func(ctx context.Context) error {
+		var count int64
+		if err := tenantContextIDs(ctx, r.db.Conn(ctx)).
+			Where("contexts.id = ?", rehydration.ContextID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return fmt.Errorf("context %s not found: %w", rehydration.ContextID, gorm.ErrRecordNotFound)
+		}
+
+		rehydration.TenantID = domain.TenantFromContext(ctx)
+		model, steps := models.MapToRehydrationModel(rehydration)
+		model.UpdatedAt = time.Now().UTC()
+		if err := r.db.Conn(ctx).
+			Omit(clause.Associations).
+			Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "context_id"}}, UpdateAll: true}).
+			Create(&model).Error; err != nil {
+			return err
+		}
+
+		if err := r.db.Conn(ctx).
+			Where("context_id = ?", rehydration.ContextID).
+			Delete(&models.ContextRehydrationStep{}).Error; err != nil {
+			return err
+		}
+		if len(steps) == 0 {
+			return nil
+		}
+		for i := range steps {
+			steps[i].UpdatedAt = model.UpdatedAt
+		}
+		return r.db.Conn(ctx).Omit(clause.Associations).Create(&steps).Error
+	})
+}
+
+// FindByContext retrieves the rehydration of a context of the tenant of ctx with its steps.
+func (r *RehydrationRepository) FindByContext(ctx context.Context, contextID string) (domain.Rehydration, error) {
+	var model models.ContextRehydration
+	if err := r.db.Conn(ctx).
+		Scopes(tenantScope(ctx, "context_rehydrations")).
+		Where("context_id = ?", contextID).
+		First(&model).Error; err != nil {
+		return domain.Rehydration{}, err
+	}
+
+	var steps []models.ContextRehydrationStep
+	if err := r.db.Conn(ctx).
+		Where("context_id = ?", contextID).
+		Order("space_id").
+		Find(&steps).Error; err != nil {
+		return domain.Rehydration{}, err
+	}
+	return models.MapToRehydrationDomain(model, steps), nil
+}
+
+// AdvanceStep moves the step of a satellite in the running rehydration of a context of the tenant of ctx to a new
+// status, provided it is in one of the from statuses. It reports whether the step moved, so a duplicate event
+// advances it once.
+func (r *RehydrationRepository) AdvanceStep(ctx context.Context, contextID, spaceID string, from []domain.RehydrationStepStatus, to domain.RehydrationStepStatus, stepErr string) (bool, error) {
+	result := r.db.Conn(ctx).
+		Model(&models.ContextRehydrationStep{}).
+		Where("context_id = ? AND space_id = ? AND status IN ?", contextID, spaceID, stepStatuses(from)).
+		Where("context_id IN (?)", r.running(ctx)).
+		Updates(map[string]interface{}{
+			"status":     string(to),
+			"error":      stepErr,
+			"updated_at": time.Now().UTC(),
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// FindAwaitingPropagation retrieves the contexts of the tenant of ctx whose running rehydration awaits the
+// propagation of a satellite.
+func (r *RehydrationRepository) FindAwaitingPropagation(ctx context.Context, spaceID string) ([]string, error) {
+	var contextIDs []string
+	err := r.db.Conn(ctx).
+		Model(&models.ContextRehydrationStep{}).
+		Where("space_id = ? AND status = ?", spaceID, string(domain.RehydrationStepRequested)).
+		Where("context_id IN (?)", r.running(ctx)).
+		Pluck("context_id", &contextIDs).Error
+	return contextIDs, err
+}
+
+// FailPendingSteps fails the steps not done yet of the running rehydration of a context of the tenant of ctx.
+func (r *RehydrationRepository) FailPendingSteps(ctx context.Context, contextID, stepErr string) error {
+	return r.db.Conn(ctx).
+		Model(&models.ContextRehydrationStep{}).
+		Where("context_id = ? AND status IN ?", contextID, stepStatuses([]domain.RehydrationStepStatus{domain.RehydrationStepRequested, domain.RehydrationStepPropagated})).
+		Where("context_id IN (?)", r.running(ctx)).
+		Updates(map[string]interface{}{
+			"status":     string(domain.RehydrationStepFailed),
+			"error":      stepErr,
+			"updated_at": time.Now().UTC(),
+		}).Error
+}
+
+// Complete ends the running rehydration of a context of the tenant of ctx. It reports whether the rehydration was
+// still running, so a single caller announces its completion.
+func (r *RehydrationRepository) Complete(ctx context.Context, contextID string, status domain.RehydrationStatus, reason string, completedAt time.Time) (bool, error) {
+	result := r.db.Conn(ctx).
+		Model(&models.ContextRehydration{}).
+		Scopes(tenantScope(ctx, "context_rehydrations")).
+		Where("context_id = ? AND status = ?", contextID, string(domain.RehydrationStatusRunning)).
+		Updates(map[string]interface{}{
+			"status":       string(status),
+			"reason":       reason,
+			"completed_at": completedAt.UTC(),
+			"updated_at":   time.Now().UTC(),
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// FindOverdue retrieves the running rehydrations of every tenant whose deadline passed at now, without their steps.
+func (r *RehydrationRepository) FindOverdue(ctx context.Context, now time.Time) ([]domain.Rehydration, error) {
+	var records []models.ContextRehydration
+	if err := r.db.Conn(ctx).
+		Where("status = ? AND deadline < ?", string(domain.RehydrationStatusRunning), now.UTC()).
+		Order("deadline").
+		Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	rehydrations := make([]domain.Rehydration, len(records))
+	for i, record := range records {
+		rehydrations[i] = models.MapToRehydrationDomain(record, nil)
+	}
+	return rehydrations, nil
+}
+
+// FindStalled retrieves the running rehydrations of every tenant with a satellite whose propagation was requested
+// before before and is still unreported, without their steps.
+func (r *RehydrationRepository) FindStalled(ctx context.Context, before time.Time) ([]domain.Rehydration, error) {
+	var records []models.ContextRehydration
+	if err := r.db.Conn(ctx).
+		Where("status = ?", string(domain.RehydrationStatusRunning)).
+		Where("context_id IN (?)", r.db.Conn(ctx).
+			Model(&models.ContextRehydrationStep{}).
+			Select("context_id").
+			Where("status = ? AND updated_at < ?", string(domain.RehydrationStepRequested), before.UTC())).
+		Order("started_at").
+		Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	rehydrations := make([]domain.Rehydration, len(records))
+	for i, record := range records {
+		rehydrations[i] = models.MapToRehydrationDomain(record, nil)
+	}
+	return rehydrations, nil
+}
+
+// FailStalledSteps fails the steps of the running rehydration of a context of the tenant of ctx whose propagation
+// was requested before before and is still unreported.
+func (r *RehydrationRepository) FailStalledSteps(ctx context.Context, contextID string, before time.Time, stepErr string) error {
+	return r.db.Conn(ctx).
+		Model(&models.ContextRehydrationStep{}).
+		Where("context_id = ? AND status = ? AND updated_at < ?", contextID, string(domain.RehydrationStepRequested), before.UTC()).
+		Where("context_id IN (?)", r.running(ctx)).
+		Updates(map[string]interface{}{
+			"status":     string(domain.RehydrationStepFailed),
+			"error":      stepErr,
+			"updated_at": time.Now().UTC(),
+		}).Error
+}
+
+// running is a subquery selecting the contexts of the tenant of ctx with a running rehydration.
+func (r *RehydrationRepository) running(ctx context.Context) *gorm.DB {
+	return r.db.Conn(ctx).
+		Model(&models.ContextRehydration{}).
+		Select("context_rehydrations.context_id").
+		Scopes(tenantScope(ctx, "context_rehydrations")).
+		Where("context_rehydrations.status = ?", string(domain.RehydrationStatusRunning))
+}
+
+func stepStatuses(statuses []domain.RehydrationStepStatus) []string {
+	result := make([]string, len(statuses))
+	for i, status := range statuses {
+		result[i] = string(status)
+	}
+	return result
+}
@@ -60,7 +60,7 @@ func NewSatelliteRepository(db *data.Database, redis *redis.RedisClient, lockTTL
 // FindBySpaceID retrieves a satellite by its SPACE ID, excluding deleted ones.
 func (r *SatelliteRepository) FindBySpaceID(ctx context.Context, spaceID string) (domain.Satellite, error) {
 	var satellite models.Satellite
-	result := r.db.DbHandler.Where("space_id = ? AND deleted_at IS NULL", spaceID).First(&satellite)
+	result := r.db.Conn(ctx).Where("space_id = ? AND deleted_at IS NULL", spaceID).First(&satellite)
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		return domain.Satellite{}, nil
 	}
@@ -70,7 +70,7 @@ func (r *SatelliteRepository) FindBySpaceID(ctx context.Context, spaceID string)
 // FindAll retrieves all satellites excluding deleted ones.
 func (r *SatelliteRepository) FindAll(ctx context.Context) ([]domain.Satellite, error) {
 	var satellites []models.Satellite
-	result := r.db.DbHandler.Where("deleted_at IS NULL").Find(&satellites)
+	result := r.db.Conn(ctx).Where("deleted_at IS NULL").Find(&satellites)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -85,18 +85,18 @@ func (r *SatelliteRepository) FindAll(ctx context.Context) ([]domain.Satellite,
 // Save creates a new satellite record.
 func (r *SatelliteRepository) Save(ctx context.Context, satellite domain.Satellite) error {
 	model := models.MapToSatelliteModel(satellite)
-	return r.db.DbHandler.Create(&model).Error
+	return r.db.Conn(ctx).Create(&model).Error
 }
 
 // Update modifies an existing satellite record.
 func (r *SatelliteRepository) Update(ctx context.Context, satellite domain.Satellite) error {
 	model := models.MapToSatelliteModel(satellite)
-	return r.db.DbHandler.Save(&model).Error
+	return r.db.Conn(ctx).Save(&model).Error
 }
 
 // DeleteBySpaceID marks a satellite record as deleted.
 func (r *SatelliteRepository) DeleteBySpaceID(ctx context.Context, spaceID string) error {
-	return r.db.DbHandler.Model(&models.Satellite{}).
+	return r.db.Conn(ctx).Model(&models.Satellite{}).
 		Where("space_id = ?", spaceID).
 		Update("deleted_at", gorm.Expr("NOW()")).Error
 }
@@ -112,14 +112,32 @@ func (r *SatelliteRepository) SaveBatch(ctx context.Context, satellites []domain
 		modelsBatch = append(modelsBatch, models.MapToSatelliteModel(satellite))
 	}
 
-	return r.db.DbHandler.WithContext(ctx).
+	return r.db.Conn(ctx).
 		Clauses(clause.OnConflict{
-			Columns:   []clause.Column{{Name: "space_id"}},
-			UpdateAll: true,
+			Columns: []clause.Column{{Name: "space_id"}},
+			// Catalogue imports must not reset operator-provided sensor parameters.
+			DoUpdates: clause.AssignmentColumns([]string{
+				"updated_at", "name", "type", "launch_date", "decay_date", "intl_designator", "owner",
+				"object_type", "period", "inclination", "apogee", "perigee", "rcs", "altitude", "orbit_type",
+			}),
 		}).
 		CreateInBatches(modelsBatch, 100).Error
 }
 
+// SetSensorHalfAngle sets (or clears, when nil) the sensor cone half-angle of a satellite.
+func (r *SatelliteRepository) SetSensorHalfAngle(ctx context.Context, spaceID string, halfAngleDeg *float64) error {
+	result := r.db.Conn(ctx).Model(&models.Satellite{}).
+		Where("space_id = ?", spaceID).
+		Update("sensor_half_angle", halfAngleDeg)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update sensor half-angle for SPACE ID %s: %w", spaceID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("satellite with SPACE ID %s not found", spaceID)
+	}
+	return nil
+}
+
 func (r *SatelliteRepository) FindSatelliteInfoWithPagination(ctx context.Context, page, pageSize int, searchRequest *domain.SearchRequest) ([]domain.SatelliteInfo, int64, error) {
 	var results []SatelliteTLEAggregate
 	var totalRecords int64
@@ -129,7 +147,7 @@ func (r *SatelliteRepository) FindSatelliteInfoWithPagination(ctx context.Contex
 		offset = 0
 	}
 
-	query := r.db.DbHandler.Table("satellites").
+	query := r.db.Conn(ctx).Table("satellites").
 		Select(`
 			satellites.id, satellites.name, satellites.space_id, satellites.owner, satellites.type,
 			satellites.launch_date, satellites.decay_date, satellites.international_designator,
@@ -200,25 +218,39 @@ func (r *SatelliteRepository) FindSatelliteInfoWithPagination(ctx context.Contex
 	return satelliteInfos, totalRecords, nil
 }
 
-// AssignSatelliteToContext associates a satellite with a context.
+// AssignSatelliteToContext associates a satellite with a context of the tenant of ctx.
 func (r *SatelliteRepository) AssignSatelliteToContext(ctx context.Context, contextID, satelliteID string) error {
+	var count int64
+	err := tenantContextIDs(ctx, r.db.Conn(ctx)).
+		Where("contexts.id = ?", contextID).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("context %s not found: %w", contextID, gorm.ErrRecordNotFound)
+	}
+
 	association := models.ContextSatellite{
 		ContextID:   contextID,
 		SatelliteID: satelliteID,
 	}
-	return r.db.DbHandler.Create(&association).Error
+	return r.db.Conn(ctx).Create(&association).Error
 }
 
-// RemoveSatelliteFromContext removes the association between a satellite and a context.
+// RemoveSatelliteFromContext removes the association between a satellite and a context of the tenant of ctx.
 func (r *SatelliteRepository) RemoveSatelliteFromContext(ctx context.Context, contextID, satelliteID string) error {
-	return r.db.DbHandler.Where("context_id = ? AND satellite_id = ?", contextID, satelliteID).
+	return r.db.Conn(ctx).
+		Where("context_id = ? AND satellite_id = ?", contextID, satelliteID).
+		Where("context_id IN (?)", tenantContextIDs(ctx, r.db.Conn(ctx))).
 		Delete(&models.ContextSatellite{}).Error
 }
 
-// FindContextsBySatellite retrieves contexts associated with a given satellite.
+// FindContextsBySatellite retrieves the contexts of every tenant associated with a given satellite.
+// Satellites are shared between tenants, so callers acting on the result must scope each context to its tenant.
 func (r *SatelliteRepository) FindContextsBySatellite(ctx context.Context, satelliteID string) ([]domain.GameContext, error) {
 	var contexts []models.Context
-	result := r.db.DbHandler.Table("contexts").
+	result := r.db.Conn(ctx).Table("contexts").
 		Joins("JOIN context_satellites ON contexts.id = context_satellites.context_id").
 		Where("context_satellites.satellite_id = ?", satelliteID).
 		Find(&contexts)
@@ -234,12 +266,13 @@ func (r *SatelliteRepository) FindContextsBySatellite(ctx context.Context, satel
 	return domainContexts, nil
 }
 
-// FindSatellitesByContext retrieves satellites associated with a given context.
+// FindSatellitesByContext retrieves satellites associated with a given context of the tenant of ctx.
 func (r *SatelliteRepository) FindSatellitesByContext(ctx context.Context, contextID string) ([]domain.Satellite, error) {
 	var satellites []models.Satellite
-	result := r.db.DbHandler.Table("satellites").
+	result := r.db.Conn(ctx).Table("satellites").
 		Joins("JOIN context_satellites ON satellites.id = context_satellites.satellite_id").
 		Where("context_satellites.context_id = ?", contextID).
+		Where("context_satellites.context_id IN (?)", tenantContextIDs(ctx, r.db.Conn(ctx))).
 		Find(&satellites)
 
 	if result.Error != nil {
@@ -260,7 +293,7 @@ func (r *SatelliteRepository) FindAllWithPagination(ctx context.Context, page in
 	// Calculate the offset
 	offset := (page - 1) * pageSize
 
-	query := r.db.DbHandler.Table("satellites").
+	query := r.db.Conn(ctx).Table("satellites").
 		Where("deleted_at IS NULL")
 
 	// Apply search filtering if a wildcard is provided
@@ -292,7 +325,8 @@ func (r *SatelliteRepository) FindAllWithPagination(ctx context.Context, page in
 }
 
 // FetchAndLockSatellites retrieves and locks available satellites within active contexts or returns satellites already locked by the same lockedBy.
-func (r *SatelliteRepository) FetchAndLockSatellites(ctx context.Context, lockedBy string, maxNbSatellites int64) ([]string, error) {
+// A non-empty contextID restricts the satellites to that context.
+func (r *SatelliteRepository) FetchAndLockSatellites(ctx context.Context, lockedBy string, maxNbSatellites int64, contextID string) ([]string, error) {
 	query := `
 		WITH existing_locks AS (
 			SELECT s.space_id
@@ -303,6 +337,7 @@ func (r *SatelliteRepository) FetchAndLockSatellites(ctx context.Context, locked
 			AND s.locked_by = $1
 			AND c.is_active = TRUE
 			AND c.deleted_at IS NULL
+			AND ($3 = '' OR c.id = $3)
 		), new_locks AS (
 			UPDATE satellites s
 			SET locked = TRUE, locked_by = $1
@@ -312,6 +347,7 @@ func (r *SatelliteRepository) FetchAndLockSatellites(ctx context.Context, locked
 			AND s.locked = FALSE
 			AND c.is_active = TRUE
 			AND c.deleted_at IS NULL
+			AND ($3 = '' OR c.id = $3)
 			RETURNING s.space_id
 		)
 		SELECT space_id FROM existing_locks
@@ -322,7 +358,7 @@ func (r *SatelliteRepository) FetchAndLockSatellites(ctx context.Context, locked
 
 	var lockedSatellites []string
 
-	err := r.db.DbHandler.Select(ctx, &lockedSatellites, query, lockedBy, maxNbSatellites)
+	err := r.db.Conn(ctx).Select(ctx, &lockedSatellites, query, lockedBy, maxNbSatellites, contextID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch and lock satellites: %w", err.Error)
 	}
@@ -349,7 +385,7 @@ func (r *SatelliteRepository) ReleaseSatellites(ctx context.Context, satelliteID
 		WHERE space_id = ANY($1);
 	`
 
-	err := r.db.DbHandler.Exec(query, pq.Array(satelliteIDs))
+	err := r.db.Conn(ctx).Exec(query, pq.Array(satelliteIDs))
 	if err != nil {
 		return fmt.Errorf("failed to release satellite locks: %w", err.Error)
 	}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/data"
+	"github.com/org/2112-space-lab/org/app-service/internal/data/models"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SimulationClockRepository stores the simulation clocks of contexts.
+type SimulationClockRepository struct {
+	db *data.Database
+}
+
+// NewSimulationClockRepository creates a new SimulationClockRepository instance.
+func NewSimulationClockRepository(db *data.Database) SimulationClockRepository {
+	return SimulationClockRepository{db: db}
+}
+
+// FindByContext retrieves the clock of a context of the tenant of ctx. found is false when the context follows
+// wall-clock time.
+func (r *SimulationClockRepository) FindByContext(ctx context.Context, contextID string) (clock domain.SimulationClock, found bool, err error) {
+	var model models.ContextSimulationClock
+	err = r.db.DbHandler.WithContext(ctx).
+		Where("context_id = ?", contextID).
+		Where("context_id IN (?)", tenantContextIDs(ctx, r.db.DbHandler.WithContext(ctx))).
+		First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return clock, false, nil
+	}
+	if err != nil {
+		return clock, false, err
+	}
+	return models.MapToSimulationClockDomain(model), true, nil
+}
+
+// Save creates or replaces the clock of a context of the tenant of ctx.
+func (r *SimulationClockRepository) Save(ctx context.Context, clock domain.SimulationClock) error {
+	var count int64
+	if err := tenantContextIDs(ctx, r.db.DbHandler.WithContext(ctx)).
+		Where("contexts.id = ?", clock.ContextID).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("context %s not found: %w", clock.ContextID, gorm.ErrRecordNotFound)
+	}
+
+	model := models.MapToSimulationClockModel(clock)
+	model.UpdatedAt = time.Now().UTC()
+	return r.db.DbHandler.WithContext(ctx).
+		Omit(clause.Associations).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "context_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"epoch", "anchored_at", "speed", "paused", "updated_at"}),
+		}).
+		Create(&model).Error
+}
+
+// Delete removes the clock of a context of the tenant of ctx, returning it to wall-clock time.
+func (r *SimulationClockRepository) Delete(ctx context.Context, contextID string) error {
+	return r.db.DbHandler.WithContext(ctx).
+		Where("context_id = ?", contextID).
+		Where("context_id IN (?)", tenantContextIDs(ctx, r.db.DbHandler.WithContext(ctx))).
+		Delete(&models.ContextSimulationClock{}).Error
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/data"
+	"github.com/org/2112-space-lab/org/app-service/internal/data/models"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TaskScheduleRepository manages task schedules. Schedules span every tenant: a task runs for the tenant given in
+// its arguments.
+type TaskScheduleRepository struct {
+	db *data.Database
+}
+
+// NewTaskScheduleRepository creates a new TaskScheduleRepository instance.
+func NewTaskScheduleRepository(db *data.Database) TaskScheduleRepository {
+	return TaskScheduleRepository{db: db}
+}
+
+// FindAll retrieves every schedule by name.
+func (r *TaskScheduleRepository) FindAll(ctx context.Context) ([]domain.TaskSchedule, error) {
+	var records []models.TaskSchedule
+	if err := r.db.Conn(ctx).Order("name").Find(&records).Error; err != nil {
+		return nil, err
+	}
+	return mapTaskSchedules(records), nil
+}
+
+// FindByName retrieves a schedule by name.
+func (r *TaskScheduleRepository) FindByName(ctx context.Context, name string) (domain.TaskSchedule, error) {
+	var record models.TaskSchedule
+	if err := r.db.Conn(ctx).Where("name = ?", name).First(&record).Error; err != nil {
+		return domain.TaskSchedule{}, err
+	}
+	return models.MapToTaskScheduleDomain(record), nil
+}
+
+// Save creates a schedule or replaces the definition and next run of an existing one, keeping the state of its runs.
+func (r *TaskScheduleRepository) Save(ctx context.Context, schedule domain.TaskSchedule) error {
+	model := models.MapToTaskScheduleModel(schedule)
+	now := time.Now().UTC()
+	model.CreatedAt, model.UpdatedAt = now, now
+	return r.db.Conn(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"task_name", "cron", "args", "enabled", "missed_run_policy", "jitter_seconds", "next_run_at", "updated_at",
+			}),
+		}).
+		Create(&model).Error
+}
+
+// DeleteByName removes a schedule. A run in progress completes.
+func (r *TaskScheduleRepository) DeleteByName(ctx context.Context, name string) error {
+	result := r.db.Conn(ctx).Where("name = ?", name).Delete(&models.TaskSchedule{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// FindDue retrieves the enabled schedules whose next run is due at now, earliest first, running or not.
+func (r *TaskScheduleRepository) FindDue(ctx context.Context, now time.Time) ([]domain.TaskSchedule, error) {
+	var records []models.TaskSchedule
+	if err := r.db.Conn(ctx).
+		Where("enabled = ? AND next_run_at <= ?", true, now.UTC()).
+		Order("next_run_at").
+		Find(&records).Error; err != nil {
+		return nil, err
+	}
+	return mapTaskSchedules(records), nil
+}
+
+// Claim starts the run of a schedule due at dueAt for owner, unless another scheduler claimed it first or its
+// previous run is still within its lease. The next run is moved to nextRunAt. It reports whether the run is owner's.
+func (r *TaskScheduleRepository) Claim(ctx context.Context, name string, dueAt, nextRunAt, now, leaseUntil time.Time, owner string) (bool, error) {
+	result := r.db.Conn(ctx).
+		Model(&models.TaskSchedule{}).
+		Where("name = ? AND enabled = ? AND next_run_at = ?", name, true, dueAt.UTC()).
+		Where("running_since IS NULL OR lease_until < ?", now.UTC()).
+		Updates(map[string]interface{}{
+			"next_run_at":   nextRunAt.UTC(),
+			"running_since": now.UTC(),
+			"running_owner": owner,
+			"lease_until":   leaseUntil.UTC(),
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// Reschedule moves the next run of a schedule due at dueAt to nextRunAt, without running it. It reports whether
+// the schedule was still due at dueAt.
+func (r *TaskScheduleRepository) Reschedule(ctx context.Context, name string, dueAt, nextRunAt time.Time) (bool, error) {
+	result := r.db.Conn(ctx).
+		Model(&models.TaskSchedule{}).
+		Where("name = ? AND next_run_at = ?", name, dueAt.UTC()).
+		Updates(map[string]interface{}{"next_run_at": nextRunAt.UTC(), "updated_at": time.Now().UTC()})
+	return result.RowsAffected > 0, result.Error
+}
+
+// Renew extends the lease of the run of a schedule by owner to leaseUntil. It reports whether the run is still
+// owner's.
+func (r *TaskScheduleRepository) Renew(ctx context.Context, name, owner string, leaseUntil time.Time) (bool, error) {
+	result := r.db.Conn(ctx).
+		Model(&models.TaskSchedule{}).
+		Where("name = ? AND running_owner = ? AND running_since IS NOT NULL", name, owner).
+		Update("lease_until", leaseUntil.UTC())
+	return result.RowsAffected > 0, result.Error
+}
+
+// Finish records the outcome of the run of a schedule by owner and releases the schedule. A run whose lease was
+// taken over by another scheduler records nothing.
+func (r *TaskScheduleRepository) Finish(ctx context.Context, name, owner string, run domain.TaskRun) error {
+	return r.db.Conn(ctx).
+		Model(&models.TaskSchedule{}).
+		Where("name = ? AND running_owner = ? AND running_since IS NOT NULL", name, owner).
+		Updates(map[string]interface{}{
+			"running_since":    nil,
+			"running_owner":    "",
+			"lease_until":      nil,
+			"last_run_at":      run.StartedAt.UTC(),
+			"last_status":      string(run.Status),
+			"last_error":       run.Error,
+			"last_duration_ms": run.Duration.Milliseconds(),
+		}).Error
+}
+
+func mapTaskSchedules(records []models.TaskSchedule) []domain.TaskSchedule {
+	schedules := make([]domain.TaskSchedule, len(records))
+	for i, record := range records {
+		schedules[i] = models.MapToTaskScheduleDomain(record)
+	}
+	return schedules
+}
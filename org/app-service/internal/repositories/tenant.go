@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	"gorm.io/gorm"
+)
+
+// tenantScope restricts a query to the rows of a tenant-owned table belonging to the tenant of ctx.
+func tenantScope(ctx context.Context, table string) func(*gorm.DB) *gorm.DB {
+	tenantID := string(domain.TenantFromContext(ctx))
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(table+".tenant_id = ?", tenantID)
+	}
+}
+
+// tenantContextIDs is a subquery selecting the IDs of the contexts owned by the tenant of ctx,
+// used to scope the association tables keyed by context ID.
+func tenantContextIDs(ctx context.Context, db *gorm.DB) *gorm.DB {
+	return db.Table("contexts").Select("contexts.id").Scopes(tenantScope(ctx, "contexts"))
+}
@@ -0,0 +1,457 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/data"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const (
+	tenantA domain.TenantID = "org_alpha"
+	tenantB domain.TenantID = "org_beta"
+)
+
+// capturedStatement is a SQL statement built by gorm, with its bound variables.
+type capturedStatement struct {
+	sql  string
+	vars []interface{}
+}
+
+// newDryRunDatabase returns a database that builds statements without executing them, and the statements it built.
+func newDryRunDatabase(t *testing.T) (*data.Database, *[]capturedStatement) {
+	t.Helper()
+	db, err := gorm.Open(
+		postgres.New(postgres.Config{DSN: "host=127.0.0.1 user=test dbname=test sslmode=disable"}),
+		&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true},
+	)
+	if err != nil {
+		t.Fatalf("Failed to open dry run database: %v", err)
+	}
+
+	var statements []capturedStatement
+	capture := func(tx *gorm.DB) {
+		statements = append(statements, capturedStatement{sql: tx.Statement.SQL.String(), vars: tx.Statement.Vars})
+	}
+	callbacks := db.Callback()
+	for name, err := range map[string]error{
+		"query":  callbacks.Query().After("gorm:query").Register("test:capture", capture),
+		"create": callbacks.Create().After("gorm:create").Register("test:capture", capture),
+		"update": callbacks.Update().After("gorm:update").Register("test:capture", capture),
+		"delete": callbacks.Delete().After("gorm:delete").Register("test:capture", capture),
+		"row":    callbacks.Row().After("gorm:row").Register("test:capture", capture),
+	} {
+		if err != nil {
+			t.Fatalf("Failed to register %s capture: %v", name, err)
+		}
+	}
+	return &data.Database{DbHandler: db}, &statements
+}
+
+// assertScopedTo checks that every statement filters on the tenant and never mentions another one.
+func assertScopedTo(t *testing.T, statements []capturedStatement, tenant, other domain.TenantID) {
+	t.Helper()
+	if len(statements) == 0 {
+		t.Fatalf("Expected at least one statement, but got none")
+	}
+	for _, stmt := range statements {
+		if !strings.Contains(stmt.sql, "tenant_id = ") {
+			t.Errorf("Expected statement to filter on tenant_id, but got %q", stmt.sql)
+		}
+		bound := stringVars(stmt)
+		if !contains(bound, string(tenant)) {
+			t.Errorf("Expected statement to be bound to tenant %s, but got %v (%q)", tenant, bound, stmt.sql)
+		}
+		if contains(bound, string(other)) {
+			t.Errorf("Expected statement not to reach tenant %s, but got %v (%q)", other, bound, stmt.sql)
+		}
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestContextRepositoryIsolatesTenants(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(ctx context.Context, r *ContextRepository) error
+	}{
+		{name: "FindAll", call: func(ctx context.Context, r *ContextRepository) error {
+			_, err := r.FindAll(ctx)
+			return err
+		}},
+		{name: "FindByUniqueName", call: func(ctx context.Context, r *ContextRepository) error {
+			_, err := r.FindByUniqueName(ctx, "shared-name")
+			return err
+		}},
+		{name: "FindAllWithPagination", call: func(ctx context.Context, r *ContextRepository) error {
+			_, err := r.FindAllWithPagination(ctx, 1, 20, "shared")
+			return err
+		}},
+		{name: "FindActiveContexts", call: func(ctx context.Context, r *ContextRepository) error {
+			_, err := r.FindActiveContexts(ctx)
+			return err
+		}},
+		{name: "FindActiveBySatelliteID", call: func(ctx context.Context, r *ContextRepository) error {
+			_, err := r.FindActiveBySatelliteID(ctx, "25544")
+			return err
+		}},
+		{name: "ActivateContext", call: func(ctx context.Context, r *ContextRepository) error {
+			return r.ActivateContext(ctx, "shared-name")
+		}},
+		{name: "DesactiveContext", call: func(ctx context.Context, r *ContextRepository) error {
+			return r.DesactiveContext(ctx, "shared-name")
+		}},
+		{name: "DeleteByUniqueName", call: func(ctx context.Context, r *ContextRepository) error {
+			return r.DeleteByUniqueName(ctx, "shared-name")
+		}},
+	}
+
+	for _, tt := range tests {
+		for _, tenants := range [][2]domain.TenantID{{tenantA, tenantB}, {tenantB, tenantA}} {
+			t.Run(fmt.Sprintf("%s as %s", tt.name, tenants[0]), func(t *testing.T) {
+				db, statements := newDryRunDatabase(t)
+				repo := NewContextRepository(db)
+
+				// Result errors are expected: dry runs return no rows.
+				_ = tt.call(domain.WithTenant(context.Background(), tenants[0]), &repo)
+				assertScopedTo(t, *statements, tenants[0], tenants[1])
+			})
+		}
+	}
+}
+
+func TestContextRepositorySaveOwnsContextByTenant(t *testing.T) {
+	tests := []struct {
+		name        string
+		tenant      domain.TenantID
+		owner       domain.TenantID
+		expectedErr error
+	}{
+		{name: "Stamps tenant of caller", tenant: tenantA, owner: ""},
+		{name: "Accepts own tenant", tenant: tenantA, owner: tenantA},
+		{name: "Refuses other tenant", tenant: tenantA, owner: tenantB, expectedErr: domain.ErrTenantMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, statements := newDryRunDatabase(t)
+			repo := NewContextRepository(db)
+
+			now := domain.NewModelBaseDefault()
+			err := repo.Save(domain.WithTenant(context.Background(), tt.tenant), domain.GameContext{
+				ModelBase: now,
+				Name:      "shared-name",
+				TenantID:  tt.owner,
+			})
+
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("Expected error %v, but got %v", tt.expectedErr, err)
+			}
+			if tt.expectedErr != nil {
+				if len(*statements) != 0 {
+					t.Errorf("Expected nothing to be written, but got %d statements", len(*statements))
+				}
+				return
+			}
+			if len(*statements) != 1 || !contains(stringVars((*statements)[0]), string(tt.tenant)) {
+				t.Errorf("Expected the context to be inserted for tenant %s, but got %+v", tt.tenant, *statements)
+			}
+		})
+	}
+}
+
+func TestSatelliteAssignmentsIsolateTenants(t *testing.T) {
+	// Context assignments resolve the context within the tenant first, then write by ID.
+	tests := []struct {
+		name       string
+		lookupOnly bool
+		call       func(ctx context.Context, db *data.Database) error
+	}{
+		{name: "Context assignment", lookupOnly: true, call: func(ctx context.Context, db *data.Database) error {
+			repo := NewContextRepository(db)
+			return repo.AssignSatellites(ctx, "shared-name", []domain.SatelliteID{"25544"})
+		}},
+		{name: "Context removal", lookupOnly: true, call: func(ctx context.Context, db *data.Database) error {
+			repo := NewContextRepository(db)
+			return repo.RemoveSatellites(ctx, "shared-name", []domain.SatelliteID{"25544"})
+		}},
+		{name: "Satellite assignment", call: func(ctx context.Context, db *data.Database) error {
+			repo := NewSatelliteRepository(db, nil, 0)
+			return repo.AssignSatelliteToContext(ctx, "context-id", "25544")
+		}},
+		{name: "Satellite removal", call: func(ctx context.Context, db *data.Database) error {
+			repo := NewSatelliteRepository(db, nil, 0)
+			return repo.RemoveSatelliteFromContext(ctx, "context-id", "25544")
+		}},
+		{name: "Satellites of context", call: func(ctx context.Context, db *data.Database) error {
+			repo := NewSatelliteRepository(db, nil, 0)
+			_, err := repo.FindSatellitesByContext(ctx, "context-id")
+			return err
+		}},
+		{name: "Membership rules of context", call: func(ctx context.Context, db *data.Database) error {
+			repo := NewMembershipRuleRepository(db)
+			_, err := repo.FindRulesByContext(ctx, "context-id")
+			return err
+		}},
+		{name: "Membership rule removal", call: func(ctx context.Context, db *data.Database) error {
+			repo := NewMembershipRuleRepository(db)
+			return repo.DeleteRule(ctx, "context-id", "starlink")
+		}},
+		{name: "Members of context", call: func(ctx context.Context, db *data.Database) error {
+			repo := NewMembershipRuleRepository(db)
+			_, err := repo.FindMembers(ctx, "context-id")
+			return err
+		}},
+		{name: "Simulation clock of context", call: func(ctx context.Context, db *data.Database) error {
+			repo := NewSimulationClockRepository(db)
+			_, _, err := repo.FindByContext(ctx, "context-id")
+			return err
+		}},
+		{name: "Simulation clock removal", call: func(ctx context.Context, db *data.Database) error {
+			repo := NewSimulationClockRepository(db)
+			return repo.Delete(ctx, "context-id")
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, statements := newDryRunDatabase(t)
+
+			_ = tt.call(domain.WithTenant(context.Background(), tenantA), db)
+			scoped := *statements
+			if tt.lookupOnly && len(scoped) > 0 {
+				scoped = scoped[:1]
+				for _, stmt := range (*statements)[1:] {
+					if contains(stringVars(stmt), "shared-name") {
+						t.Errorf("Expected writes to use the resolved context ID, but got the name in %q", stmt.sql)
+					}
+				}
+			}
+			assertScopedTo(t, scoped, tenantA, tenantB)
+		})
+	}
+}
+
+func TestSatelliteAssignmentRefusesUnknownContext(t *testing.T) {
+	db, statements := newDryRunDatabase(t)
+	repo := NewSatelliteRepository(db, nil, 0)
+
+	// The dry run finds no context of tenant B, as if the context belonged to tenant A.
+	err := repo.AssignSatelliteToContext(domain.WithTenant(context.Background(), tenantB), "context-of-a", "25544")
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("Expected %v, but got %v", gorm.ErrRecordNotFound, err)
+	}
+	for _, stmt := range *statements {
+		if strings.HasPrefix(stmt.sql, "INSERT") {
+			t.Errorf("Expected no association to be written, but got %q", stmt.sql)
+		}
+	}
+}
+
+func TestEventRepositoryIsolatesTenants(t *testing.T) {
+	db, statements := newDryRunDatabase(t)
+	repo := NewEventRepository(db)
+
+	_, _ = repo.FindByUID(domain.WithTenant(context.Background(), tenantB), "event-uid")
+	assertScopedTo(t, *statements, tenantB, tenantA)
+}
+
+func TestEventReplayIsolatesTenants(t *testing.T) {
+	db, statements := newDryRunDatabase(t)
+	repo := NewEventRepository(db)
+
+	filter := domain.EventReplayFilter{EventTypes: []domain.EventType{"SATELLITE_TLE_PROPAGATED"}, ContextName: "shared-name"}
+	_, _ = repo.FindForReplay(domain.WithTenant(context.Background(), tenantB), filter, 10)
+	assertScopedTo(t, *statements, tenantB, tenantA)
+}
+
+func TestEventHistoryIsolatesTenants(t *testing.T) {
+	filter := domain.EventReplayFilter{ContextName: "shared-name"}
+	tests := []struct {
+		name string
+		call func(ctx context.Context, db *data.Database) error
+	}{
+		{name: "FindPage", call: func(ctx context.Context, db *data.Database) error {
+			repo := NewEventRepository(db)
+			_, _, err := repo.FindPage(ctx, filter, 1, 10)
+			return err
+		}},
+		{name: "CountByStatus", call: func(ctx context.Context, db *data.Database) error {
+			repo := NewEventHandlerRepository(db)
+			_, err := repo.CountByStatus(ctx, filter)
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, statements := newDryRunDatabase(t)
+
+			_ = tt.call(domain.WithTenant(context.Background(), tenantB), db)
+			assertScopedTo(t, *statements, tenantB, tenantA)
+		})
+	}
+}
+
+func TestDeadLetterRepositoryIsolatesTenants(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(ctx context.Context, r *DeadLetterRepository) error
+	}{
+		{name: "FindByID", call: func(ctx context.Context, r *DeadLetterRepository) error {
+			_, err := r.FindByID(ctx, "dead-letter-id")
+			return err
+		}},
+		{name: "FindPage", call: func(ctx context.Context, r *DeadLetterRepository) error {
+			_, _, err := r.FindPage(ctx, 1, 10, true)
+			return err
+		}},
+		{name: "MarkRedriven", call: func(ctx context.Context, r *DeadLetterRepository) error {
+			return r.MarkRedriven(ctx, "dead-letter-id", time.Now())
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, statements := newDryRunDatabase(t)
+			repo := NewDeadLetterRepository(db)
+
+			_ = tt.call(domain.WithTenant(context.Background(), tenantB), &repo)
+			assertScopedTo(t, *statements, tenantB, tenantA)
+		})
+	}
+}
+
+func TestRehydrationRepositoryIsolatesTenants(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(ctx context.Context, r *RehydrationRepository) error
+	}{
+		{name: "AdvanceStep", call: func(ctx context.Context, r *RehydrationRepository) error {
+			_, err := r.AdvanceStep(ctx, "context-id", "25544",
+				[]domain.RehydrationStepStatus{domain.RehydrationStepRequested}, domain.RehydrationStepPropagated, "")
+			return err
+		}},
+		{name: "FindAwaitingPropagation", call: func(ctx context.Context, r *RehydrationRepository) error {
+			_, err := r.FindAwaitingPropagation(ctx, "25544")
+			return err
+		}},
+		{name: "FailPendingSteps", call: func(ctx context.Context, r *RehydrationRepository) error {
+			return r.FailPendingSteps(ctx, "context-id", "timed out")
+		}},
+		{name: "Complete", call: func(ctx context.Context, r *RehydrationRepository) error {
+			_, err := r.Complete(ctx, "context-id", domain.RehydrationStatusSucceeded, "", time.Now())
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, statements := newDryRunDatabase(t)
+			repo := NewRehydrationRepository(db)
+
+			_ = tt.call(domain.WithTenant(context.Background(), tenantB), &repo)
+			assertScopedTo(t, *statements, tenantB, tenantA)
+		})
+	}
+}
+
+func TestMappingRepositoryIsolatesTenants(t *testing.T) {
+	mapping := domain.TileSatelliteMapping{ContextID: "context-of-a", SpaceID: "25544", TileID: "tile-id"}
+	mapping.ID = "mapping-id"
+	tests := []struct {
+		name             string
+		call             func(ctx context.Context, r *TileSatelliteMappingRepository) error
+		expectedNotFound bool
+	}{
+		{name: "FindBySpaceIDAndTile", expectedNotFound: true, call: func(ctx context.Context, r *TileSatelliteMappingRepository) error {
+			_, err := r.FindBySpaceIDAndTile(ctx, "context-of-a", "25544", "tile-id")
+			return err
+		}},
+		{name: "FindAll", expectedNotFound: true, call: func(ctx context.Context, r *TileSatelliteMappingRepository) error {
+			_, err := r.FindAll(ctx, "context-of-a")
+			return err
+		}},
+		{name: "Save", expectedNotFound: true, call: func(ctx context.Context, r *TileSatelliteMappingRepository) error {
+			return r.Save(ctx, mapping)
+		}},
+		{name: "Update", expectedNotFound: true, call: func(ctx context.Context, r *TileSatelliteMappingRepository) error {
+			return r.Update(ctx, mapping)
+		}},
+		{name: "Delete", call: func(ctx context.Context, r *TileSatelliteMappingRepository) error {
+			return r.Delete(ctx, "mapping-id")
+		}},
+		{name: "SaveBatch", expectedNotFound: true, call: func(ctx context.Context, r *TileSatelliteMappingRepository) error {
+			return r.SaveBatch(ctx, []domain.TileSatelliteMapping{mapping})
+		}},
+		{name: "FindSatellitesForTiles", expectedNotFound: true, call: func(ctx context.Context, r *TileSatelliteMappingRepository) error {
+			_, err := r.FindSatellitesForTiles(ctx, "context-of-a", []string{"tile-id"})
+			return err
+		}},
+		{name: "FindAllVisibleTilesBySpaceIDSortedByAOSTime", expectedNotFound: true, call: func(ctx context.Context, r *TileSatelliteMappingRepository) error {
+			_, err := r.FindAllVisibleTilesBySpaceIDSortedByAOSTime(ctx, "context-of-a", "25544")
+			return err
+		}},
+		{name: "ListSatellitesMappingWithPagination", expectedNotFound: true, call: func(ctx context.Context, r *TileSatelliteMappingRepository) error {
+			_, _, err := r.ListSatellitesMappingWithPagination(ctx, "context-of-a", 1, 10, nil)
+			return err
+		}},
+		{name: "GetSatelliteMappingsBySpaceID", expectedNotFound: true, call: func(ctx context.Context, r *TileSatelliteMappingRepository) error {
+			_, err := r.GetSatelliteMappingsBySpaceID(ctx, "context-of-a", "25544")
+			return err
+		}},
+		{name: "FindMappingsInWindow", expectedNotFound: true, call: func(ctx context.Context, r *TileSatelliteMappingRepository) error {
+			_, err := r.FindMappingsInWindow(ctx, "context-of-a", domain.MappingWindowFilter{})
+			return err
+		}},
+		{name: "FindOpenMappings", expectedNotFound: true, call: func(ctx context.Context, r *TileSatelliteMappingRepository) error {
+			_, err := r.FindOpenMappings(ctx, "context-of-a", "25544", time.Now())
+			return err
+		}},
+		{name: "ApplyMappingIncrement", expectedNotFound: true, call: func(ctx context.Context, r *TileSatelliteMappingRepository) error {
+			return r.ApplyMappingIncrement(ctx, "context-of-a", "25544", []domain.TileSatelliteMapping{mapping}, []domain.TileSatelliteMapping{mapping})
+		}},
+		{name: "ReplaceMappings", expectedNotFound: true, call: func(ctx context.Context, r *TileSatelliteMappingRepository) error {
+			return r.ReplaceMappings(ctx, "context-of-a", "25544", time.Now(), []domain.TileSatelliteMapping{mapping})
+		}},
+		{name: "DeleteMappingsBySpaceID", expectedNotFound: true, call: func(ctx context.Context, r *TileSatelliteMappingRepository) error {
+			return r.DeleteMappingsBySpaceID(ctx, "context-of-a", "25544")
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, statements := newDryRunDatabase(t)
+			repo := NewTileSatelliteMappingRepository(db)
+
+			// The dry run finds no context of tenant B, as if the context belonged to tenant A.
+			err := tt.call(domain.WithTenant(context.Background(), tenantB), &repo)
+			if tt.expectedNotFound && !errors.Is(err, gorm.ErrRecordNotFound) {
+				t.Fatalf("Expected %v, but got %v", gorm.ErrRecordNotFound, err)
+			}
+			assertScopedTo(t, *statements, tenantB, tenantA)
+		})
+	}
+}
+
+func stringVars(stmt capturedStatement) []string {
+	var out []string
+	for _, v := range stmt.vars {
+		out = append(out, fmt.Sprint(v))
+	}
+	return out
+}
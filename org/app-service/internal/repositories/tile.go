@@ -4,14 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/org/2112-space-lab/org/app-service/internal/data"
 	"github.com/org/2112-space-lab/org/app-service/internal/data/models"
 	"github.com/org/2112-space-lab/org/app-service/internal/domain"
 	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
+	"github.com/org/2112-space-lab/org/go-utils/pkg/fx/xspace"
 	"gorm.io/gorm"
 )
 
@@ -29,16 +32,18 @@ func (r *TileRepository) FindTilesInRegion(ctx context.Context, contextID string
 	var tiles []models.Tile
 
 	// Execute the query with context filtering
-	result := r.db.DbHandler.WithContext(ctx).Raw(`
+	result := r.db.Conn(ctx).Raw(`
 		SELECT t.*
 		FROM tiles t
 		INNER JOIN context_tiles ct ON t.id = ct.tile_id
+		INNER JOIN contexts c ON c.id = ct.context_id
 		WHERE ct.context_id = ?
+		AND c.tenant_id = ?
 		AND ST_Intersects(
 			t.spatial_index,
 			ST_MakeEnvelope(?, ?, ?, ?, 4326)
 		)
-	`, contextID, minLon, minLat, maxLon, maxLat).Scan(&tiles)
+	`, contextID, string(domain.TenantFromContext(ctx)), minLon, minLat, maxLon, maxLat).Scan(&tiles)
 
 	if result.Error != nil {
 		if errors.Is(result.Error, context.Canceled) {
@@ -64,17 +69,19 @@ func (r *TileRepository) FindTilesIntersectingLocation(ctx context.Context, cont
 	var tiles []models.Tile
 
 	// Query tiles that intersect the user's location and are associated with the given context
-	result := r.db.DbHandler.WithContext(ctx).Raw(`
+	result := r.db.Conn(ctx).Raw(`
 		SELECT t.*
 		FROM tiles t
 		INNER JOIN context_tiles ct ON t.id = ct.tile_id
+		INNER JOIN contexts c ON c.id = ct.context_id
 		WHERE ct.context_id = ?
+		AND c.tenant_id = ?
 		AND ST_DWithin(
 			t.spatial_index,
 			ST_MakePoint(?, ?)::geography,
 			?
 		)
-	`, contextID, lon, lat, radius).Scan(&tiles)
+	`, contextID, string(domain.TenantFromContext(ctx)), lon, lat, radius).Scan(&tiles)
 
 	if result.Error != nil {
 		if errors.Is(result.Error, context.Canceled) {
@@ -98,7 +105,7 @@ func (r *TileRepository) FindTilesIntersectingLocation(ctx context.Context, cont
 // FindByQuadkey retrieves a Tile by its quadkey.
 func (r *TileRepository) FindByQuadkey(ctx context.Context, quadkey string) (*domain.Tile, error) {
 	var tile models.Tile
-	result := r.db.DbHandler.Where("quadkey = ?", quadkey).First(&tile)
+	result := r.db.Conn(ctx).Where("quadkey = ?", quadkey).First(&tile)
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		return nil, nil
 	} else if result.Error != nil {
@@ -112,7 +119,7 @@ func (r *TileRepository) FindByQuadkey(ctx context.Context, quadkey string) (*do
 // FindBySpatialLocation retrieves a Tile by a geographical location using spatial indexing.
 func (r *TileRepository) FindBySpatialLocation(ctx context.Context, lat, lon float64) (*domain.Tile, error) {
 	var tile models.Tile
-	result := r.db.DbHandler.Raw(`
+	result := r.db.Conn(ctx).Raw(`
 		SELECT *
 		FROM tiles
 		WHERE ST_Contains(spatial_index, ST_SetSRID(ST_Point(?, ?), 4326))
@@ -131,7 +138,7 @@ func (r *TileRepository) FindBySpatialLocation(ctx context.Context, lat, lon flo
 // FindAll retrieves all Tiles.
 func (r *TileRepository) FindAll(ctx context.Context) ([]domain.Tile, error) {
 	var tiles []models.Tile
-	result := r.db.DbHandler.Find(&tiles)
+	result := r.db.Conn(ctx).Find(&tiles)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -146,7 +153,7 @@ func (r *TileRepository) FindAll(ctx context.Context) ([]domain.Tile, error) {
 // Save creates a new Tile record.
 func (r *TileRepository) Save(ctx context.Context, tile domain.Tile) error {
 	modelTile := models.MapFromDomain(tile)
-	return r.db.DbHandler.Create(&modelTile).Error
+	return r.db.Conn(ctx).Create(&modelTile).Error
 }
 
 // SaveBatch allows batch insertion of tiles for optimized performance.
@@ -155,38 +162,53 @@ func (r *TileRepository) SaveBatch(ctx context.Context, tiles []domain.Tile) err
 	for i, t := range tiles {
 		modelTiles[i] = models.MapFromDomain(t)
 	}
-	return r.db.DbHandler.Create(&modelTiles).Error
+	return r.db.Conn(ctx).Create(&modelTiles).Error
 }
 
 // Update modifies an existing Tile record.
 func (r *TileRepository) Update(ctx context.Context, tile domain.Tile) error {
 	modelTile := models.MapFromDomain(tile)
-	return r.db.DbHandler.Save(&modelTile).Error
+	return r.db.Conn(ctx).Save(&modelTile).Error
 }
 
 // DeleteByQuadkey removes a Tile record by its quadkey.
 func (r *TileRepository) DeleteByQuadkey(ctx context.Context, key string) error {
-	return r.db.DbHandler.Where("quadkey = ?", key).Delete(&models.Tile{}).Error
+	return r.db.Conn(ctx).Where("quadkey = ?", key).Delete(&models.Tile{}).Error
 }
 
-// AssociateTileWithContext associates a Tile with a specific Context.
+// AssociateTileWithContext associates a Tile with a specific Context of the tenant of ctx.
 func (r *TileRepository) AssociateTileWithContext(ctx context.Context, contextID string, tileID string) error {
+	var count int64
+	err := tenantContextIDs(ctx, r.db.Conn(ctx)).
+		Where("contexts.id = ?", contextID).
+		Count(&count).Error
+	if err != nil {
+		return fmt.Errorf("failed to check context: %w", err)
+	}
+	if count == 0 {
+		return fmt.Errorf("failed to associate Tile with context: context %s not found", contextID)
+	}
+
 	contextTile := models.ContextTile{
 		ContextID: contextID,
 		TileID:    tileID,
 	}
 
-	if err := r.db.DbHandler.Create(&contextTile).Error; err != nil {
+	if err := r.db.Conn(ctx).Create(&contextTile).Error; err != nil {
 		return fmt.Errorf("failed to associate Tile with context: %w", err)
 	}
 	return nil
 }
 
-// GetTilesByContext retrieves all Tiles associated with a specific Context.
+// GetTilesByContext retrieves all Tiles associated with a specific Context of the tenant of ctx.
 func (r *TileRepository) GetTilesByContext(ctx context.Context, contextID string) ([]domain.Tile, error) {
 	var contextTiles []models.ContextTile
 
-	if err := r.db.DbHandler.Where("context_id = ?", contextID).Find(&contextTiles).Error; err != nil {
+	err := r.db.Conn(ctx).
+		Where("context_id = ?", contextID).
+		Where("context_id IN (?)", tenantContextIDs(ctx, r.db.Conn(ctx))).
+		Find(&contextTiles).Error
+	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve Tiles by context: %w", err)
 	}
 
@@ -196,7 +218,7 @@ func (r *TileRepository) GetTilesByContext(ctx context.Context, contextID string
 	}
 
 	var tiles []models.Tile
-	if err := r.db.DbHandler.Where("id IN ?", tileIDs).Find(&tiles).Error; err != nil {
+	if err := r.db.Conn(ctx).Where("id IN ?", tileIDs).Find(&tiles).Error; err != nil {
 		return nil, fmt.Errorf("failed to retrieve Tile details: %w", err)
 	}
 
@@ -210,14 +232,19 @@ func (r *TileRepository) GetTilesByContext(ctx context.Context, contextID string
 
 // RemoveTileFromContext removes the association between a Tile and a Context.
 func (r *TileRepository) RemoveTileFromContext(ctx context.Context, contextID string, tileID string) error {
-	if err := r.db.DbHandler.Where("context_id = ? AND tile_id = ?", contextID, tileID).
-		Delete(&models.ContextTile{}).Error; err != nil {
+	err := r.db.Conn(ctx).
+		Where("context_id = ? AND tile_id = ?", contextID, tileID).
+		Where("context_id IN (?)", tenantContextIDs(ctx, r.db.Conn(ctx))).
+		Delete(&models.ContextTile{}).Error
+	if err != nil {
 		return fmt.Errorf("failed to remove Tile from context: %w", err)
 	}
 	return nil
 }
 
 // FindTilesVisibleFromLine retrieves Tiles intersecting a satellite's trajectory.
+// Each separate crossing of a tile yields one mapping, with enter/exit times interpolated
+// from the timestamps of the track samples.
 func (r *TileRepository) FindTilesVisibleFromLine(ctx context.Context, sat domain.Satellite, points []domain.SatellitePosition) ([]domain.TileSatelliteMapping, error) {
 	if len(points) < 2 {
 		return nil, fmt.Errorf("at least two points are required to create a line")
@@ -232,19 +259,34 @@ func (r *TileRepository) FindTilesVisibleFromLine(ctx context.Context, sat domai
 	query := `
         WITH line_geom AS (
             SELECT ST_GeomFromText(?, 4326) AS geom
+        ),
+        crossings AS (
+            SELECT
+                tiles.id AS crossed_tile_id,
+                (ST_Dump(ST_Intersection(line_geom.geom, spatial_index))).geom AS piece,
+                line_geom.geom AS line
+            FROM tiles, line_geom
+            WHERE ST_Intersects(spatial_index, line_geom.geom)
         )
-        SELECT 
+        SELECT
             tiles.*,
-            ST_AsText(ST_PointOnSurface(ST_Intersection(line_geom.geom, spatial_index))) AS intersection_geom
-        FROM tiles, line_geom
-        WHERE ST_Intersects(spatial_index, line_geom.geom)
+            ST_AsText(ST_PointOnSurface(piece)) AS intersection_geom,
+            ST_LineLocatePoint(line, ST_PointOnSurface(piece)) AS intersection_fraction,
+            ST_LineLocatePoint(line, CASE WHEN GeometryType(piece) = 'POINT' THEN piece ELSE ST_StartPoint(piece) END) AS enter_fraction,
+            ST_LineLocatePoint(line, CASE WHEN GeometryType(piece) = 'POINT' THEN piece ELSE ST_EndPoint(piece) END) AS exit_fraction
+        FROM crossings
+        JOIN tiles ON tiles.id = crossings.crossed_tile_id
+        WHERE GeometryType(piece) IN ('POINT', 'LINESTRING')
     `
 
 	var results []struct {
 		models.Tile
-		IntersectionGeom string `gorm:"column:intersection_geom"`
+		IntersectionGeom     string  `gorm:"column:intersection_geom"`
+		IntersectionFraction float64 `gorm:"column:intersection_fraction"`
+		EnterFraction        float64 `gorm:"column:enter_fraction"`
+		ExitFraction         float64 `gorm:"column:exit_fraction"`
 	}
-	result := r.db.DbHandler.Raw(query, lineString).Scan(&results)
+	result := r.db.Conn(ctx).Raw(query, lineString).Scan(&results)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -258,21 +300,203 @@ func (r *TileRepository) FindTilesVisibleFromLine(ctx context.Context, sat domai
 			continue
 		}
 
-		nowUtc := time.Now().UTC()
-		mapping := domain.NewMapping(
+		mappings = append(mappings, newLineMapping(sat, tile.ID, interestPoint, points, res.IntersectionFraction, res.EnterFraction, res.ExitFraction))
+	}
+
+	return mappings, nil
+}
+
+// FindTilesVisibleFromSwath retrieves Tiles inside the footprint swept along a satellite's trajectory.
+// The footprint radius comes from the satellite's sensor half-angle when set, otherwise from minElevationDeg.
+// Each contiguous run of track segments covering a tile yields one mapping.
+func (r *TileRepository) FindTilesVisibleFromSwath(ctx context.Context, sat domain.Satellite, points []domain.SatellitePosition, minElevationDeg float64) ([]domain.TileSatelliteMapping, error) {
+	if len(points) < 2 {
+		return nil, fmt.Errorf("at least two points are required to create a swath")
+	}
+
+	lons := make([]float64, len(points))
+	lats := make([]float64, len(points))
+	for i, point := range points {
+		lons[i] = point.Longitude
+		lats[i] = point.Latitude
+	}
+	radii := swathRadiiMeters(sat, points, minElevationDeg)
+
+	// Segments are matched in geography so a swath crossing the antimeridian stays narrow. The closest point is
+	// located on the planar segment, shifted to 0..360 when the segment crosses the antimeridian.
+	query := `
+        WITH samples AS (
+            SELECT lon, lat, radius_m, idx
+            FROM unnest(?::float8[], ?::float8[], ?::float8[]) WITH ORDINALITY AS s(lon, lat, radius_m, idx)
+        ),
+        segments AS (
+            SELECT
+                a.idx - 1 AS segment_index,
+                ST_SetSRID(ST_MakePoint(a.lon, a.lat), 4326)::geography AS start_point,
+                ST_SetSRID(ST_MakePoint(b.lon, b.lat), 4326)::geography AS end_point,
+                ST_MakeLine(ST_SetSRID(ST_MakePoint(a.lon, a.lat), 4326), ST_SetSRID(ST_MakePoint(b.lon, b.lat), 4326)) AS line,
+                abs(b.lon - a.lon) > 180 AS crosses_seam,
+                GREATEST(a.radius_m, b.radius_m) AS radius_m
+            FROM samples a
+            JOIN samples b ON b.idx = a.idx + 1
+        )
+        SELECT
+            tiles.id AS tile_id,
+            tiles.center_lat,
+            tiles.center_lon,
+            segments.segment_index,
+            segments.radius_m,
+            ST_Distance(tiles.spatial_index::geography, segments.start_point) AS start_distance,
+            ST_Distance(tiles.spatial_index::geography, segments.end_point) AS end_distance,
+            CASE WHEN segments.crosses_seam
+                THEN ST_LineLocatePoint(ST_ShiftLongitude(segments.line), ST_ClosestPoint(ST_ShiftLongitude(segments.line), ST_ShiftLongitude(tiles.spatial_index)))
+                ELSE ST_LineLocatePoint(segments.line, ST_ClosestPoint(segments.line, tiles.spatial_index))
+            END AS closest_fraction
+        FROM tiles
+        JOIN segments ON ST_DWithin(tiles.spatial_index::geography, segments.line::geography, segments.radius_m)
+        ORDER BY tiles.id, segments.segment_index
+    `
+
+	var results []swathHit
+	result := r.db.Conn(ctx).Raw(query, pq.Array(lons), pq.Array(lats), pq.Array(radii)).Scan(&results)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return swathMappings(sat, points, results), nil
+}
+
+// swathHit is a track segment whose footprint covers a tile. Distances are from the tile to the segment's
+// endpoints in meters; ClosestFraction locates the segment's point closest to the tile.
+type swathHit struct {
+	TileID          string  `gorm:"column:tile_id"`
+	CenterLat       float64 `gorm:"column:center_lat"`
+	CenterLon       float64 `gorm:"column:center_lon"`
+	SegmentIndex    int     `gorm:"column:segment_index"`
+	Radius          float64 `gorm:"column:radius_m"`
+	StartDistance   float64 `gorm:"column:start_distance"`
+	EndDistance     float64 `gorm:"column:end_distance"`
+	ClosestFraction float64 `gorm:"column:closest_fraction"`
+}
+
+// enterFraction returns where along the segment the tile enters the footprint, the distance to the tile being
+// interpolated between the segment's endpoints.
+func (h swathHit) enterFraction() float64 {
+	switch {
+	case h.StartDistance <= h.Radius:
+		return 0
+	case h.EndDistance <= h.Radius:
+		return (h.StartDistance - h.Radius) / (h.StartDistance - h.EndDistance)
+	default:
+		return h.ClosestFraction
+	}
+}
+
+// exitFraction returns where along the segment the tile leaves the footprint.
+func (h swathHit) exitFraction() float64 {
+	switch {
+	case h.EndDistance <= h.Radius:
+		return 1
+	case h.StartDistance <= h.Radius:
+		return (h.Radius - h.StartDistance) / (h.EndDistance - h.StartDistance)
+	default:
+		return h.ClosestFraction
+	}
+}
+
+// swathRadiiMeters returns the footprint radius at each track sample, from the sensor half-angle when set.
+func swathRadiiMeters(sat domain.Satellite, points []domain.SatellitePosition, minElevationDeg float64) []float64 {
+	radii := make([]float64, len(points))
+	for i, point := range points {
+		halfAngle := xspace.FootprintHalfAngleFromElevation(point.Altitude, minElevationDeg)
+		if sat.SensorHalfAngle.HasValue {
+			halfAngle = xspace.FootprintHalfAngleFromSensor(point.Altitude, sat.SensorHalfAngle.Value)
+		}
+		radii[i] = xspace.FootprintRadiusKm(halfAngle) * 1000
+	}
+	return radii
+}
+
+// swathMappings turns hits sorted by tile then segment into one mapping per contiguous run of segments.
+func swathMappings(sat domain.Satellite, points []domain.SatellitePosition, results []swathHit) []domain.TileSatelliteMapping {
+	var mappings []domain.TileSatelliteMapping
+	nowUtc := time.Now().UTC()
+	for start := 0; start < len(results); {
+		// Rows are ordered by tile then segment: extend the run while segments stay contiguous.
+		end := start
+		for end+1 < len(results) &&
+			results[end+1].TileID == results[start].TileID &&
+			results[end+1].SegmentIndex == results[end].SegmentIndex+1 {
+			end++
+		}
+
+		res := results[start]
+		first, last := res.SegmentIndex, results[end].SegmentIndex+1
+		enter := segmentTime(points, first, res.enterFraction())
+		exit := segmentTime(points, last-1, results[end].exitFraction())
+		closest := closestSample(points[first:last+1], res.CenterLat, res.CenterLon).Timestamp
+		if closest.Before(enter) {
+			closest = enter
+		} else if closest.After(exit) {
+			closest = exit
+		}
+
+		mappings = append(mappings, domain.NewMapping(
 			sat.SpaceID,
-			tile.ID,
-			interestPoint,
-			nowUtc,
+			res.TileID,
+			domain.Point{Longitude: res.CenterLon, Latitude: res.CenterLat},
+			closest,
+			enter,
+			exit,
 			nowUtc,
 			"",
 			true,
 			false,
-		)
-		mappings = append(mappings, mapping)
+		))
+		start = end + 1
 	}
 
-	return mappings, nil
+	return mappings
+}
+
+// segmentTime returns the time at a fraction along the track segment starting at sample i.
+func segmentTime(points []domain.SatellitePosition, i int, fraction float64) time.Time {
+	fraction = math.Min(math.Max(fraction, 0), 1)
+	span := points[i+1].Timestamp.Sub(points[i].Timestamp)
+	return points[i].Timestamp.Add(time.Duration(fraction * float64(span)))
+}
+
+// newLineMapping builds the mapping of one tile crossing from fractions along the track's planar length.
+func newLineMapping(sat domain.Satellite, tileID string, intersection domain.Point, points []domain.SatellitePosition, intersectionFraction, enterFraction, exitFraction float64) domain.TileSatelliteMapping {
+	if enterFraction > exitFraction {
+		enterFraction, exitFraction = exitFraction, enterFraction
+	}
+
+	nowUtc := time.Now().UTC()
+	return domain.NewMapping(
+		sat.SpaceID,
+		tileID,
+		intersection,
+		domain.TrackTimeAt(points, intersectionFraction),
+		domain.TrackTimeAt(points, enterFraction),
+		domain.TrackTimeAt(points, exitFraction),
+		nowUtc,
+		"",
+		true,
+		false,
+	)
+}
+
+// closestSample returns the track sample nearest to the given location.
+func closestSample(points []domain.SatellitePosition, lat, lon float64) domain.SatellitePosition {
+	best := points[0]
+	bestDistance := xspace.HaversineDistance(lat, lon, best.Latitude, best.Longitude, 0, 0)
+	for _, p := range points[1:] {
+		if d := xspace.HaversineDistance(lat, lon, p.Latitude, p.Longitude, 0, 0); d < bestDistance {
+			best, bestDistance = p, d
+		}
+	}
+	return best
 }
 
 // parseIntersectionGeometry parses WKT intersection points.
@@ -306,7 +530,7 @@ func parseIntersectionGeometry(wkt string) (domain.Point, error) {
 // DeleteBySpatialLocation removes a Tile record by its geographical location.
 func (r *TileRepository) DeleteBySpatialLocation(ctx context.Context, lat, lon float64) error {
 	var tile models.Tile
-	result := r.db.DbHandler.Raw(`
+	result := r.db.Conn(ctx).Raw(`
 		SELECT *
 		FROM tiles
 		WHERE ST_Contains(spatial_index, ST_SetSRID(ST_Point(?, ?), 4326))
@@ -321,7 +545,7 @@ func (r *TileRepository) DeleteBySpatialLocation(ctx context.Context, lat, lon f
 	}
 
 	// Delete the tile
-	return r.db.DbHandler.Delete(&tile).Error
+	return r.db.Conn(ctx).Delete(&tile).Error
 }
 
 // Upsert inserts or updates a Tile record in the database.
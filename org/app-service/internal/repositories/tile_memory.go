@@ -0,0 +1,682 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	"github.com/org/2112-space-lab/org/go-utils/pkg/fx/xconstants"
+	"github.com/org/2112-space-lab/org/go-utils/pkg/fx/xpolygon"
+	"github.com/org/2112-space-lab/org/go-utils/pkg/fx/xrtree"
+)
+
+// kmPerDegree is the length of one degree of latitude on the mean Earth sphere.
+const kmPerDegree = xconstants.EARTH_RADIUS_KM * xconstants.PI_DIVIDE_BY_180
+
+// MemoryTileRepository answers spatial tile queries from an in-memory R-tree instead of PostGIS.
+// With a store, writes go through to it and the index is loaded from it on first use, then reloaded
+// once ttl has elapsed or after Invalidate so tiles written by other processes become visible.
+// Without one, tiles and context associations only live in memory, which suits tests and small deployments.
+type MemoryTileRepository struct {
+	store domain.TileRepository
+	index *memoryTileIndex
+}
+
+type memoryTileIndex struct {
+	mu             sync.RWMutex
+	ttl            time.Duration // zero keeps the index until Invalidate
+	loaded         bool
+	loadedAt       time.Time
+	tree           *xrtree.RTree[string]
+	tiles          map[string]domain.Tile
+	rects          map[string]xrtree.Rect
+	byQuadkey      map[string]string
+	contexts       map[string]map[string]struct{}
+	loadedContexts map[string]time.Time
+}
+
+// NewMemoryTileRepository creates a new in-memory tile repository. store may be nil, in which case ttl is ignored.
+func NewMemoryTileRepository(store domain.TileRepository, ttl time.Duration) MemoryTileRepository {
+	if store == nil {
+		ttl = 0
+	}
+	index := &memoryTileIndex{ttl: ttl, loaded: store == nil}
+	index.reset()
+	return MemoryTileRepository{store: store, index: index}
+}
+
+// Invalidate drops the tiles and context memberships loaded from the store; they are reloaded on next use.
+func (r *MemoryTileRepository) Invalidate() {
+	if r.store == nil {
+		return
+	}
+	r.index.mu.Lock()
+	defer r.index.mu.Unlock()
+
+	r.index.loaded = false
+	r.index.loadedContexts = make(map[string]time.Time)
+}
+
+// FindByQuadkey retrieves a Tile by its quadkey.
+func (r *MemoryTileRepository) FindByQuadkey(ctx context.Context, key string) (*domain.Tile, error) {
+	if err := r.ensureLoaded(ctx); err != nil {
+		return nil, err
+	}
+	r.index.mu.RLock()
+	defer r.index.mu.RUnlock()
+
+	id, ok := r.index.byQuadkey[key]
+	if !ok {
+		return nil, nil
+	}
+	tile := r.index.tiles[id]
+	return &tile, nil
+}
+
+// FindBySpatialLocation retrieves the Tile containing a location.
+func (r *MemoryTileRepository) FindBySpatialLocation(ctx context.Context, lat, lon float64) (*domain.Tile, error) {
+	if err := r.ensureLoaded(ctx); err != nil {
+		return nil, err
+	}
+	r.index.mu.RLock()
+	defer r.index.mu.RUnlock()
+
+	return r.index.tileAt(lat, lon), nil
+}
+
+// FindTilesInRegion retrieves tiles that intersect a given bounding box and belong to a specific context.
+func (r *MemoryTileRepository) FindTilesInRegion(ctx context.Context, contextID string, minLat, minLon, maxLat, maxLon float64) ([]domain.Tile, error) {
+	members, err := r.contextMembers(ctx, contextID)
+	if err != nil {
+		return nil, err
+	}
+	r.index.mu.RLock()
+	defer r.index.mu.RUnlock()
+
+	envelope := []xpolygon.Point{
+		{Latitude: minLat, Longitude: minLon},
+		{Latitude: minLat, Longitude: maxLon},
+		{Latitude: maxLat, Longitude: maxLon},
+		{Latitude: maxLat, Longitude: minLon},
+	}
+
+	var tiles []domain.Tile
+	r.index.tree.Search(xrtree.NewRect(minLon, minLat, maxLon, maxLat), func(_ xrtree.Rect, id string) bool {
+		if _, ok := members[id]; !ok {
+			return true
+		}
+		if tile := r.index.tiles[id]; xpolygon.PolygonsIntersect(tile.Vertices, envelope) {
+			tiles = append(tiles, tile)
+		}
+		return true
+	})
+	return sortTiles(tiles), nil
+}
+
+// FindTilesIntersectingLocation retrieves the tiles of a context within radius meters of a location.
+func (r *MemoryTileRepository) FindTilesIntersectingLocation(ctx context.Context, contextID string, lat, lon, radius float64) ([]domain.Tile, error) {
+	members, err := r.contextMembers(ctx, contextID)
+	if err != nil {
+		return nil, err
+	}
+	r.index.mu.RLock()
+	defer r.index.mu.RUnlock()
+
+	point := xpolygon.Point{Latitude: lat, Longitude: lon}
+	query := expandByKm(xrtree.NewRect(lon, lat, lon, lat), radius/1000)
+
+	var tiles []domain.Tile
+	r.index.searchWrapped(query, func(id string) {
+		if _, ok := members[id]; !ok {
+			return
+		}
+		if tile := r.index.tiles[id]; xpolygon.DistanceToPolygonKm(point, tile.Vertices)*1000 <= radius {
+			tiles = append(tiles, tile)
+		}
+	})
+	return sortTiles(tiles), nil
+}
+
+// FindAll retrieves all Tiles.
+func (r *MemoryTileRepository) FindAll(ctx context.Context) ([]domain.Tile, error) {
+	if err := r.ensureLoaded(ctx); err != nil {
+		return nil, err
+	}
+	r.index.mu.RLock()
+	defer r.index.mu.RUnlock()
+
+	tiles := make([]domain.Tile, 0, len(r.index.tiles))
+	for _, tile := range r.index.tiles {
+		tiles = append(tiles, tile)
+	}
+	return sortTiles(tiles), nil
+}
+
+// Save creates a new Tile record.
+func (r *MemoryTileRepository) Save(ctx context.Context, tile domain.Tile) error {
+	if err := r.ensureLoaded(ctx); err != nil {
+		return err
+	}
+	if r.store != nil {
+		if err := r.store.Save(ctx, tile); err != nil {
+			return err
+		}
+	}
+	r.index.mu.Lock()
+	defer r.index.mu.Unlock()
+
+	r.index.put(tile)
+	return nil
+}
+
+// Update modifies an existing Tile record.
+func (r *MemoryTileRepository) Update(ctx context.Context, tile domain.Tile) error {
+	if err := r.ensureLoaded(ctx); err != nil {
+		return err
+	}
+	if r.store != nil {
+		if err := r.store.Update(ctx, tile); err != nil {
+			return err
+		}
+	}
+	r.index.mu.Lock()
+	defer r.index.mu.Unlock()
+
+	r.index.put(tile)
+	return nil
+}
+
+// Upsert inserts or updates a Tile record.
+func (r *MemoryTileRepository) Upsert(ctx context.Context, tile domain.Tile) error {
+	if err := r.ensureLoaded(ctx); err != nil {
+		return err
+	}
+	if r.store != nil {
+		if err := r.store.Upsert(ctx, tile); err != nil {
+			return err
+		}
+	}
+	r.index.mu.Lock()
+	defer r.index.mu.Unlock()
+
+	// Tiles are matched on quadkey, so an upsert with a new ID replaces the previous entry.
+	if id, ok := r.index.byQuadkey[tile.Quadkey]; ok && id != tile.ID {
+		r.index.remove(id)
+	}
+	r.index.put(tile)
+	return nil
+}
+
+// DeleteByQuadkey removes a Tile record by its quadkey.
+func (r *MemoryTileRepository) DeleteByQuadkey(ctx context.Context, key string) error {
+	if err := r.ensureLoaded(ctx); err != nil {
+		return err
+	}
+	if r.store != nil {
+		if err := r.store.DeleteByQuadkey(ctx, key); err != nil {
+			return err
+		}
+	}
+	r.index.mu.Lock()
+	defer r.index.mu.Unlock()
+
+	if id, ok := r.index.byQuadkey[key]; ok {
+		r.index.remove(id)
+	}
+	return nil
+}
+
+// DeleteBySpatialLocation removes the Tile containing a location.
+func (r *MemoryTileRepository) DeleteBySpatialLocation(ctx context.Context, lat, lon float64) error {
+	tile, err := r.FindBySpatialLocation(ctx, lat, lon)
+	if err != nil {
+		return err
+	}
+	if tile == nil {
+		return fmt.Errorf("tile not found at the specified location")
+	}
+	// Deleting by quadkey keeps the store free of spatial SQL.
+	return r.DeleteByQuadkey(ctx, tile.Quadkey)
+}
+
+// FindTilesVisibleFromLine retrieves Tiles crossed by a satellite's trajectory. The track is treated as a
+// planar lon/lat line, matching the PostGIS implementation, and each separate crossing yields one mapping.
+func (r *MemoryTileRepository) FindTilesVisibleFromLine(ctx context.Context, sat domain.Satellite, points []domain.SatellitePosition) ([]domain.TileSatelliteMapping, error) {
+	if len(points) < 2 {
+		return nil, fmt.Errorf("at least two points are required to create a line")
+	}
+	if err := r.ensureLoaded(ctx); err != nil {
+		return nil, err
+	}
+
+	// Cumulative planar length, so per-segment parameters can be turned into fractions of the whole line.
+	offsets := make([]float64, len(points))
+	for i := 1; i < len(points); i++ {
+		offsets[i] = offsets[i-1] + math.Hypot(points[i].Longitude-points[i-1].Longitude, points[i].Latitude-points[i-1].Latitude)
+	}
+	total := offsets[len(offsets)-1]
+	if total == 0 {
+		return nil, nil
+	}
+
+	r.index.mu.RLock()
+	crossings := make(map[string][]xpolygon.Interval)
+	for i := 0; i < len(points)-1; i++ {
+		select {
+		case <-ctx.Done():
+			r.index.mu.RUnlock()
+			return nil, ctx.Err()
+		default:
+		}
+
+		a, b := trackPoint(points[i]), trackPoint(points[i+1])
+		length := offsets[i+1] - offsets[i]
+		if length == 0 {
+			continue
+		}
+		r.index.tree.Search(xrtree.NewRect(a.Longitude, a.Latitude, b.Longitude, b.Latitude), func(_ xrtree.Rect, id string) bool {
+			for _, in := range xpolygon.SegmentInsidePolygon(a, b, r.index.tiles[id].Vertices) {
+				crossings[id] = append(crossings[id], xpolygon.Interval{
+					Start: (offsets[i] + in.Start*length) / total,
+					End:   (offsets[i] + in.End*length) / total,
+				})
+			}
+			return true
+		})
+	}
+	r.index.mu.RUnlock()
+
+	var mappings []domain.TileSatelliteMapping
+	for _, id := range sortedKeys(crossings) {
+		for _, in := range mergeIntervals(crossings[id]) {
+			mid := (in.Start + in.End) / 2
+			mappings = append(mappings, newLineMapping(sat, id, trackPointAt(points, offsets, mid*total), points, mid, in.Start, in.End))
+		}
+	}
+	return mappings, nil
+}
+
+// FindTilesVisibleFromSwath retrieves Tiles inside the footprint swept along a satellite's trajectory.
+// The footprint radius comes from the satellite's sensor half-angle when set, otherwise from minElevationDeg.
+// Each contiguous run of track segments covering a tile yields one mapping.
+func (r *MemoryTileRepository) FindTilesVisibleFromSwath(ctx context.Context, sat domain.Satellite, points []domain.SatellitePosition, minElevationDeg float64) ([]domain.TileSatelliteMapping, error) {
+	if len(points) < 2 {
+		return nil, fmt.Errorf("at least two points are required to create a swath")
+	}
+	if err := r.ensureLoaded(ctx); err != nil {
+		return nil, err
+	}
+
+	radii := swathRadiiMeters(sat, points, minElevationDeg)
+
+	r.index.mu.RLock()
+	var hits []swathHit
+	for i := 0; i < len(points)-1; i++ {
+		select {
+		case <-ctx.Done():
+			r.index.mu.RUnlock()
+			return nil, ctx.Err()
+		default:
+		}
+
+		radius := math.Max(radii[i], radii[i+1])
+		radiusKm := radius / 1000
+		seen := make(map[string]struct{})
+		for _, segment := range splitAtAntimeridian(trackPoint(points[i]), trackPoint(points[i+1])) {
+			a, b := segment[0], segment[1]
+			query := expandByKm(xrtree.NewRect(a.Longitude, a.Latitude, b.Longitude, b.Latitude), radiusKm)
+			r.index.searchWrapped(query, func(id string) {
+				if _, ok := seen[id]; ok {
+					return
+				}
+				tile := r.index.tiles[id]
+				if xpolygon.DistanceSegmentToPolygonKm(a, b, tile.Vertices) <= radiusKm {
+					seen[id] = struct{}{}
+					hits = append(hits, swathHit{
+						TileID:          id,
+						CenterLat:       tile.CenterLat,
+						CenterLon:       tile.CenterLon,
+						SegmentIndex:    i,
+						Radius:          radius,
+						StartDistance:   xpolygon.DistanceToPolygonKm(trackPoint(points[i]), tile.Vertices) * 1000,
+						EndDistance:     xpolygon.DistanceToPolygonKm(trackPoint(points[i+1]), tile.Vertices) * 1000,
+						ClosestFraction: closestFraction(trackPoint(points[i]), trackPoint(points[i+1]), tile.Vertices),
+					})
+				}
+			})
+		}
+	}
+	r.index.mu.RUnlock()
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].TileID != hits[j].TileID {
+			return hits[i].TileID < hits[j].TileID
+		}
+		return hits[i].SegmentIndex < hits[j].SegmentIndex
+	})
+	return swathMappings(sat, points, hits), nil
+}
+
+// AssociateTileWithContext associates a Tile with a specific Context.
+func (r *MemoryTileRepository) AssociateTileWithContext(ctx context.Context, contextID string, tileID string) error {
+	if _, err := r.contextMembers(ctx, contextID); err != nil {
+		return err
+	}
+	if r.store != nil {
+		if err := r.store.AssociateTileWithContext(ctx, contextID, tileID); err != nil {
+			return err
+		}
+	}
+	r.index.mu.Lock()
+	defer r.index.mu.Unlock()
+
+	key := domain.TenantKey(ctx, contextID)
+	members, ok := r.index.contexts[key]
+	if !ok {
+		members = make(map[string]struct{})
+		r.index.contexts[key] = members
+	}
+	members[tileID] = struct{}{}
+	return nil
+}
+
+// GetTilesByContext retrieves all Tiles associated with a specific Context.
+func (r *MemoryTileRepository) GetTilesByContext(ctx context.Context, contextID string) ([]domain.Tile, error) {
+	members, err := r.contextMembers(ctx, contextID)
+	if err != nil {
+		return nil, err
+	}
+	r.index.mu.RLock()
+	defer r.index.mu.RUnlock()
+
+	var tiles []domain.Tile
+	for id := range members {
+		if tile, ok := r.index.tiles[id]; ok {
+			tiles = append(tiles, tile)
+		}
+	}
+	return sortTiles(tiles), nil
+}
+
+// RemoveTileFromContext removes the association between a Tile and a Context.
+func (r *MemoryTileRepository) RemoveTileFromContext(ctx context.Context, contextID string, tileID string) error {
+	if _, err := r.contextMembers(ctx, contextID); err != nil {
+		return err
+	}
+	if r.store != nil {
+		if err := r.store.RemoveTileFromContext(ctx, contextID, tileID); err != nil {
+			return err
+		}
+	}
+	r.index.mu.Lock()
+	defer r.index.mu.Unlock()
+
+	delete(r.index.contexts[domain.TenantKey(ctx, contextID)], tileID)
+	return nil
+}
+
+// ensureLoaded fills the index from the store on first use and once it has gone stale.
+func (r *MemoryTileRepository) ensureLoaded(ctx context.Context) error {
+	r.index.mu.RLock()
+	fresh := r.index.loaded && r.index.isFresh(r.index.loadedAt)
+	r.index.mu.RUnlock()
+	if fresh {
+		return nil
+	}
+
+	r.index.mu.Lock()
+	defer r.index.mu.Unlock()
+	if r.index.loaded && r.index.isFresh(r.index.loadedAt) {
+		return nil
+	}
+
+	tiles, err := r.store.FindAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load tiles into the spatial index: %w", err)
+	}
+	r.index.reset()
+	for _, tile := range tiles {
+		r.index.put(tile)
+	}
+	r.index.loaded = true
+	r.index.loadedAt = time.Now()
+	return nil
+}
+
+// contextMembers returns a snapshot of the tile IDs of a context, loading them from the store on first use.
+// Memberships are cached per tenant, as the store only returns the contexts of the tenant of ctx.
+func (r *MemoryTileRepository) contextMembers(ctx context.Context, contextID string) (map[string]struct{}, error) {
+	if err := r.ensureLoaded(ctx); err != nil {
+		return nil, err
+	}
+	key := domain.TenantKey(ctx, contextID)
+
+	r.index.mu.RLock()
+	if loadedAt, ok := r.index.loadedContexts[key]; r.store == nil || (ok && r.index.isFresh(loadedAt)) {
+		members := copyMembers(r.index.contexts[key])
+		r.index.mu.RUnlock()
+		return members, nil
+	}
+	r.index.mu.RUnlock()
+
+	tiles, err := r.store.GetTilesByContext(ctx, contextID)
+	if err != nil {
+		return nil, err
+	}
+
+	r.index.mu.Lock()
+	defer r.index.mu.Unlock()
+	if loadedAt, ok := r.index.loadedContexts[key]; !ok || !r.index.isFresh(loadedAt) {
+		members := make(map[string]struct{}, len(tiles))
+		for _, tile := range tiles {
+			members[tile.ID] = struct{}{}
+		}
+		r.index.contexts[key] = members
+		r.index.loadedContexts[key] = time.Now()
+	}
+	return copyMembers(r.index.contexts[key]), nil
+}
+
+// isFresh reports whether data loaded at loadedAt is still within the TTL.
+func (idx *memoryTileIndex) isFresh(loadedAt time.Time) bool {
+	return idx.ttl <= 0 || time.Since(loadedAt) < idx.ttl
+}
+
+// reset empties the index.
+func (idx *memoryTileIndex) reset() {
+	idx.tree = xrtree.New[string]()
+	idx.tiles = make(map[string]domain.Tile)
+	idx.rects = make(map[string]xrtree.Rect)
+	idx.byQuadkey = make(map[string]string)
+	idx.contexts = make(map[string]map[string]struct{})
+	idx.loadedContexts = make(map[string]time.Time)
+}
+
+func (idx *memoryTileIndex) put(tile domain.Tile) {
+	if _, ok := idx.tiles[tile.ID]; ok {
+		idx.remove(tile.ID)
+	}
+	rect := tileRect(tile)
+	idx.tiles[tile.ID] = tile
+	idx.rects[tile.ID] = rect
+	idx.byQuadkey[tile.Quadkey] = tile.ID
+	idx.tree.Insert(rect, tile.ID)
+}
+
+func (idx *memoryTileIndex) remove(id string) {
+	tile, ok := idx.tiles[id]
+	if !ok {
+		return
+	}
+	idx.tree.Delete(idx.rects[id], id)
+	delete(idx.tiles, id)
+	delete(idx.rects, id)
+	if idx.byQuadkey[tile.Quadkey] == id {
+		delete(idx.byQuadkey, tile.Quadkey)
+	}
+	for _, members := range idx.contexts {
+		delete(members, id)
+	}
+}
+
+func (idx *memoryTileIndex) tileAt(lat, lon float64) *domain.Tile {
+	point := xpolygon.Point{Latitude: lat, Longitude: lon}
+	var found *domain.Tile
+	idx.tree.Search(xrtree.NewRect(lon, lat, lon, lat), func(_ xrtree.Rect, id string) bool {
+		tile := idx.tiles[id]
+		if xpolygon.IsPointInPolygon(point, tile.Vertices) {
+			found = &tile
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// searchWrapped searches rect and, when it spills over the antimeridian, its copy shifted by 360 degrees.
+// Each matching ID is reported once.
+func (idx *memoryTileIndex) searchWrapped(rect xrtree.Rect, fn func(id string)) {
+	rects := []xrtree.Rect{rect}
+	if rect.MinX < -180 {
+		rects = append(rects, xrtree.Rect{MinX: rect.MinX + 360, MinY: rect.MinY, MaxX: 180, MaxY: rect.MaxY})
+	}
+	if rect.MaxX > 180 {
+		rects = append(rects, xrtree.Rect{MinX: -180, MinY: rect.MinY, MaxX: rect.MaxX - 360, MaxY: rect.MaxY})
+	}
+
+	seen := make(map[string]struct{})
+	for _, q := range rects {
+		idx.tree.Search(q, func(_ xrtree.Rect, id string) bool {
+			if _, ok := seen[id]; !ok {
+				seen[id] = struct{}{}
+				fn(id)
+			}
+			return true
+		})
+	}
+}
+
+func tileRect(tile domain.Tile) xrtree.Rect {
+	if len(tile.Vertices) == 0 {
+		return xrtree.NewRect(tile.CenterLon, tile.CenterLat, tile.CenterLon, tile.CenterLat)
+	}
+	minLat, minLon, maxLat, maxLon := xpolygon.BoundingBox(tile.Vertices)
+	return xrtree.Rect{MinX: minLon, MinY: minLat, MaxX: maxLon, MaxY: maxLat}
+}
+
+// expandByKm grows a lon/lat rectangle by a distance, widening longitudes for the highest latitude covered.
+func expandByKm(rect xrtree.Rect, km float64) xrtree.Rect {
+	dLat := km / kmPerDegree
+	maxAbsLat := math.Min(89.9, math.Max(math.Abs(rect.MinY), math.Abs(rect.MaxY))+dLat)
+	dLon := math.Min(180, dLat/math.Cos(maxAbsLat*xconstants.PI_DIVIDE_BY_180))
+	return rect.Expand(dLon, dLat)
+}
+
+// splitAtAntimeridian splits a segment whose longitude jumps across ±180 into two segments meeting at the seam.
+func splitAtAntimeridian(a, b xpolygon.Point) [][2]xpolygon.Point {
+	if math.Abs(b.Longitude-a.Longitude) <= 180 {
+		return [][2]xpolygon.Point{{a, b}}
+	}
+
+	seam := 180.0
+	if a.Longitude < 0 {
+		seam = -180
+	}
+	unwrappedB := b.Longitude + 2*seam
+	t := (seam - a.Longitude) / (unwrappedB - a.Longitude)
+	lat := a.Latitude + t*(b.Latitude-a.Latitude)
+	return [][2]xpolygon.Point{
+		{a, {Latitude: lat, Longitude: seam}},
+		{{Latitude: lat, Longitude: -seam}, b},
+	}
+}
+
+// closestFraction returns the fraction along the segment a-b of its point closest to a polygon, searching the
+// segment unwrapped across the antimeridian. The distance to a convex tile is convex along the segment.
+func closestFraction(a, b xpolygon.Point, polygon []xpolygon.Point) float64 {
+	dLon := b.Longitude - a.Longitude
+	if dLon > 180 {
+		dLon -= 360
+	} else if dLon < -180 {
+		dLon += 360
+	}
+	distance := func(f float64) float64 {
+		lon := math.Mod(a.Longitude+f*dLon+540, 360) - 180
+		return xpolygon.DistanceToPolygonKm(xpolygon.Point{Latitude: a.Latitude + f*(b.Latitude-a.Latitude), Longitude: lon}, polygon)
+	}
+
+	low, high := 0.0, 1.0
+	for i := 0; i < 50; i++ {
+		m1, m2 := low+(high-low)/3, high-(high-low)/3
+		if distance(m1) <= distance(m2) {
+			high = m2
+		} else {
+			low = m1
+		}
+	}
+	return (low + high) / 2
+}
+
+func trackPoint(p domain.SatellitePosition) xpolygon.Point {
+	return xpolygon.Point{Latitude: p.Latitude, Longitude: p.Longitude}
+}
+
+// trackPointAt returns the location at a planar distance along the track.
+func trackPointAt(points []domain.SatellitePosition, offsets []float64, distance float64) domain.Point {
+	i := sort.SearchFloat64s(offsets, distance)
+	if i <= 0 {
+		return domain.Point{Longitude: points[0].Longitude, Latitude: points[0].Latitude}
+	}
+	if i >= len(points) {
+		last := points[len(points)-1]
+		return domain.Point{Longitude: last.Longitude, Latitude: last.Latitude}
+	}
+	ratio := 0.0
+	if span := offsets[i] - offsets[i-1]; span > 0 {
+		ratio = (distance - offsets[i-1]) / span
+	}
+	return domain.Point{
+		Longitude: points[i-1].Longitude + ratio*(points[i].Longitude-points[i-1].Longitude),
+		Latitude:  points[i-1].Latitude + ratio*(points[i].Latitude-points[i-1].Latitude),
+	}
+}
+
+// mergeIntervals sorts intervals and joins those touching across segment boundaries.
+func mergeIntervals(intervals []xpolygon.Interval) []xpolygon.Interval {
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i].Start < intervals[j].Start })
+	var merged []xpolygon.Interval
+	for _, in := range intervals {
+		if last := len(merged) - 1; last >= 0 && in.Start-merged[last].End < xconstants.EPSILON {
+			merged[last].End = math.Max(merged[last].End, in.End)
+			continue
+		}
+		merged = append(merged, in)
+	}
+	return merged
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortTiles(tiles []domain.Tile) []domain.Tile {
+	sort.Slice(tiles, func(i, j int) bool { return tiles[i].Quadkey < tiles[j].Quadkey })
+	return tiles
+}
+
+func copyMembers(members map[string]struct{}) map[string]struct{} {
+	out := make(map[string]struct{}, len(members))
+	for id := range members {
+		out[id] = struct{}{}
+	}
+	return out
+}
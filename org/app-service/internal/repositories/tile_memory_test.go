@@ -0,0 +1,231 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	"github.com/org/2112-space-lab/org/go-utils/pkg/fx/xpolygon"
+)
+
+// squareTile returns a one-degree tile whose south-west corner is at lat/lon.
+func squareTile(id, quadkey string, lat, lon float64) domain.Tile {
+	return domain.Tile{
+		ModelBase: domain.ModelBase{ID: id},
+		Quadkey:   quadkey,
+		CenterLat: lat + 0.5,
+		CenterLon: lon + 0.5,
+		Vertices: []xpolygon.Point{
+			{Latitude: lat, Longitude: lon},
+			{Latitude: lat, Longitude: lon + 1},
+			{Latitude: lat + 1, Longitude: lon + 1},
+			{Latitude: lat + 1, Longitude: lon},
+		},
+	}
+}
+
+func newSeededMemoryTileRepository(t *testing.T, tiles ...domain.Tile) *MemoryTileRepository {
+	t.Helper()
+	repo := NewMemoryTileRepository(nil, 0)
+	for _, tile := range tiles {
+		if err := repo.Save(context.Background(), tile); err != nil {
+			t.Fatalf("Failed to save tile %s: %v", tile.ID, err)
+		}
+	}
+	return &repo
+}
+
+func TestMemoryTileRepositoryFindByQuadkey(t *testing.T) {
+	ctx := context.Background()
+	repo := newSeededMemoryTileRepository(t, squareTile("t1", "120", 10, 10), squareTile("t2", "121", 10, 11))
+
+	tile, err := repo.FindByQuadkey(ctx, "121")
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if tile == nil || tile.ID != "t2" {
+		t.Fatalf("Expected tile t2, but got %+v", tile)
+	}
+
+	if tile, _ := repo.FindByQuadkey(ctx, "999"); tile != nil {
+		t.Errorf("Expected no tile for an unknown quadkey, but got %s", tile.ID)
+	}
+
+	// An upsert with a new ID for the same quadkey replaces the previous tile.
+	if err := repo.Upsert(ctx, squareTile("t3", "121", 10, 11)); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if tile, _ := repo.FindByQuadkey(ctx, "121"); tile == nil || tile.ID != "t3" {
+		t.Errorf("Expected tile t3 after upsert, but got %+v", tile)
+	}
+	if all, _ := repo.FindAll(ctx); len(all) != 2 {
+		t.Errorf("Expected 2 tiles after upsert, but got %d", len(all))
+	}
+
+	if err := repo.DeleteByQuadkey(ctx, "120"); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if tile, _ := repo.FindByQuadkey(ctx, "120"); tile != nil {
+		t.Errorf("Expected deleted tile to be gone, but got %s", tile.ID)
+	}
+}
+
+func TestMemoryTileRepositorySpatialLookups(t *testing.T) {
+	ctx := context.Background()
+	repo := newSeededMemoryTileRepository(t,
+		squareTile("t1", "a", 10, 10),
+		squareTile("t2", "b", 10, 11),
+		squareTile("t3", "c", 20, 20),
+		squareTile("t4", "d", 0, 179),
+		squareTile("t5", "e", 0, -180),
+	)
+	for _, id := range []string{"t1", "t2", "t4", "t5"} {
+		if err := repo.AssociateTileWithContext(ctx, "ctx", id); err != nil {
+			t.Fatalf("Failed to associate tile %s: %v", id, err)
+		}
+	}
+
+	if tile, _ := repo.FindBySpatialLocation(ctx, 10.5, 11.5); tile == nil || tile.ID != "t2" {
+		t.Errorf("Expected tile t2 at location, but got %+v", tile)
+	}
+	if tile, _ := repo.FindBySpatialLocation(ctx, -45, -45); tile != nil {
+		t.Errorf("Expected no tile at location, but got %s", tile.ID)
+	}
+
+	tests := []struct {
+		name     string
+		find     func() ([]domain.Tile, error)
+		expected []string
+	}{
+		{
+			name:     "Region restricted to context",
+			find:     func() ([]domain.Tile, error) { return repo.FindTilesInRegion(ctx, "ctx", 9, 9, 25, 25) },
+			expected: []string{"t1", "t2"},
+		},
+		{
+			name:     "Region partially overlapping",
+			find:     func() ([]domain.Tile, error) { return repo.FindTilesInRegion(ctx, "ctx", 10.2, 11.2, 10.8, 11.8) },
+			expected: []string{"t2"},
+		},
+		{
+			name:     "Location within radius",
+			find:     func() ([]domain.Tile, error) { return repo.FindTilesIntersectingLocation(ctx, "ctx", 10.5, 10.5, 1000) },
+			expected: []string{"t1"},
+		},
+		{
+			name: "Location radius reaching the neighbour",
+			find: func() ([]domain.Tile, error) {
+				return repo.FindTilesIntersectingLocation(ctx, "ctx", 10.5, 10.9, 20000)
+			},
+			expected: []string{"t1", "t2"},
+		},
+		{
+			name: "Location radius across the antimeridian",
+			find: func() ([]domain.Tile, error) {
+				return repo.FindTilesIntersectingLocation(ctx, "ctx", 0.5, 179.9, 50000)
+			},
+			expected: []string{"t4", "t5"},
+		},
+		{
+			name:     "Unknown context",
+			find:     func() ([]domain.Tile, error) { return repo.FindTilesInRegion(ctx, "other", -90, -180, 90, 180) },
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tiles, err := tt.find()
+			if err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+			if !sameTileIDs(tiles, tt.expected) {
+				t.Errorf("Expected tiles %v, but got %v", tt.expected, tileIDs(tiles))
+			}
+		})
+	}
+}
+
+func TestMemoryTileRepositoryReloadsFromStore(t *testing.T) {
+	ctx := context.Background()
+	store := newSeededMemoryTileRepository(t, squareTile("t1", "a", 10, 10))
+
+	repo := NewMemoryTileRepository(store, time.Hour)
+	if tile, _ := repo.FindByQuadkey(ctx, "a"); tile == nil {
+		t.Fatalf("Expected tile a to be loaded from the store")
+	}
+
+	// Another process writes to the store behind the index.
+	if err := store.Save(ctx, squareTile("t2", "b", 20, 20)); err != nil {
+		t.Fatalf("Failed to save tile: %v", err)
+	}
+	if tile, _ := repo.FindByQuadkey(ctx, "b"); tile != nil {
+		t.Fatalf("Expected tile b to be invisible before invalidation")
+	}
+
+	repo.Invalidate()
+	if tile, _ := repo.FindByQuadkey(ctx, "b"); tile == nil || tile.ID != "t2" {
+		t.Errorf("Expected tile t2 after invalidation, but got %+v", tile)
+	}
+
+	short := NewMemoryTileRepository(store, time.Millisecond)
+	if tiles, _ := short.GetTilesByContext(ctx, "ctx"); len(tiles) != 0 {
+		t.Fatalf("Expected an empty context, but got %v", tileIDs(tiles))
+	}
+	if err := store.AssociateTileWithContext(ctx, "ctx", "t2"); err != nil {
+		t.Fatalf("Failed to associate tile: %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if tiles, _ := short.GetTilesByContext(ctx, "ctx"); !sameTileIDs(tiles, []string{"t2"}) {
+		t.Errorf("Expected context membership to be reloaded after the TTL, but got %v", tileIDs(tiles))
+	}
+}
+
+func tileIDs(tiles []domain.Tile) []string {
+	ids := make([]string, len(tiles))
+	for i, tile := range tiles {
+		ids[i] = tile.ID
+	}
+	return ids
+}
+
+func sameTileIDs(tiles []domain.Tile, expected []string) bool {
+	ids := tileIDs(tiles)
+	if len(ids) != len(expected) {
+		return false
+	}
+	for i := range ids {
+		if ids[i] != expected[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestMemoryTileRepositorySwathAcrossAntimeridian(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	repo := newSeededMemoryTileRepository(t,
+		squareTile("east", "0", 2, 179),
+		squareTile("west", "1", 2, -180),
+		squareTile("far", "2", 2, 0),
+	)
+	// The track runs along the equator across the antimeridian: the swath stays narrow and misses the far tile.
+	points := []domain.SatellitePosition{
+		{Latitude: 0, Longitude: 178, Altitude: 500, Timestamp: t0},
+		{Latitude: 0, Longitude: -178, Altitude: 500, Timestamp: t0.Add(4 * time.Minute)},
+	}
+
+	mappings, err := repo.FindTilesVisibleFromSwath(context.Background(), domain.Satellite{SpaceID: "25544"}, points, 10)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if len(mappings) != 2 || mappings[0].TileID != "east" || mappings[1].TileID != "west" {
+		t.Fatalf("Expected the east and west tiles, but got %v", mappings)
+	}
+	for _, mapping := range mappings {
+		if !mapping.EnteredAt.Before(mapping.ExitedAt) || mapping.IntersectedAt.Before(mapping.EnteredAt) || mapping.IntersectedAt.After(mapping.ExitedAt) {
+			t.Errorf("Expected tile %s crossed within its pass, but got %s to %s at %s", mapping.TileID, mapping.EnteredAt, mapping.ExitedAt, mapping.IntersectedAt)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+)
+
+func TestSwathMappings(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	points := []domain.SatellitePosition{
+		{Latitude: 0, Longitude: 0, Timestamp: t0},
+		{Latitude: 0, Longitude: 1, Timestamp: t0.Add(60 * time.Second)},
+		{Latitude: 0, Longitude: 2, Timestamp: t0.Add(120 * time.Second)},
+		{Latitude: 0, Longitude: 3, Timestamp: t0.Add(180 * time.Second)},
+	}
+	hit := func(tileID string, segment int, start, end, closest float64) swathHit {
+		return swathHit{TileID: tileID, CenterLon: 1, SegmentIndex: segment, Radius: 1000, StartDistance: start, EndDistance: end, ClosestFraction: closest}
+	}
+	results := []swathHit{
+		// Contiguous run: the tile enters half-way through the first segment and leaves a third of the way
+		// through the second.
+		hit("a", 0, 2000, 0, 1),
+		hit("a", 1, 0, 3000, 0),
+		// Split run: covered from the start of the first segment to its end, then grazed by the third segment.
+		hit("b", 0, 0, 500, 0),
+		hit("b", 2, 1500, 1500, 0.25),
+	}
+
+	expected := []struct {
+		tileID    string
+		enteredAt time.Time
+		exitedAt  time.Time
+	}{
+		{tileID: "a", enteredAt: t0.Add(30 * time.Second), exitedAt: t0.Add(80 * time.Second)},
+		{tileID: "b", enteredAt: t0, exitedAt: t0.Add(60 * time.Second)},
+		{tileID: "b", enteredAt: t0.Add(135 * time.Second), exitedAt: t0.Add(135 * time.Second)},
+	}
+
+	mappings := swathMappings(domain.Satellite{SpaceID: "25544"}, points, results)
+	if len(mappings) != len(expected) {
+		t.Fatalf("Expected %d mappings, but got %d", len(expected), len(mappings))
+	}
+	for i, want := range expected {
+		got := mappings[i]
+		if got.TileID != want.tileID {
+			t.Errorf("Expected mapping %d on tile %s, but got %s", i, want.tileID, got.TileID)
+		}
+		if !got.EnteredAt.Equal(want.enteredAt) || !got.ExitedAt.Equal(want.exitedAt) {
+			t.Errorf("Expected mapping %d from %s to %s, but got %s to %s", i, want.enteredAt, want.exitedAt, got.EnteredAt, got.ExitedAt)
+		}
+		if got.IntersectedAt.Before(got.EnteredAt) || got.IntersectedAt.After(got.ExitedAt) {
+			t.Errorf("Expected mapping %d intersection within its pass, but got %s", i, got.IntersectedAt)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/org/2112-space-lab/org/app-service/internal/clients/redis"
 	"github.com/org/2112-space-lab/org/app-service/internal/data"
 	"github.com/org/2112-space-lab/org/app-service/internal/data/models"
@@ -21,11 +22,12 @@ import (
 type TleRepository struct {
 	db          *data.Database
 	redisClient *redis.RedisClient
+	outbox      OutboxRepository
 }
 
 // NewTLERepository initializes the repository with a cache TTL.
 func NewTLERepository(db *data.Database, redisClient *redis.RedisClient) TleRepository {
-	return TleRepository{db: db, redisClient: redisClient}
+	return TleRepository{db: db, redisClient: redisClient, outbox: NewOutboxRepository(db)}
 }
 
 // mapToDomainTLE converts a models.TLE to a domain.TLE.
@@ -42,10 +44,11 @@ func mapToDomainTLE(model models.TLE) domain.TLE {
 // mapToModelTLE converts a domain.TLE to a models.TLE.
 func mapToModelTLE(domainTLE domain.TLE) models.TLE {
 	return models.TLE{
-		SpaceID: domainTLE.SpaceID,
-		Line1:   domainTLE.Line1,
-		Line2:   domainTLE.Line2,
-		Epoch:   domainTLE.Epoch,
+		ModelBase: models.ModelBase{ID: domainTLE.ID},
+		SpaceID:   domainTLE.SpaceID,
+		Line1:     domainTLE.Line1,
+		Line2:     domainTLE.Line2,
+		Epoch:     domainTLE.Epoch,
 	}
 }
 
@@ -69,7 +72,7 @@ func (r *TleRepository) GetTle(ctx context.Context, spaceID string) (domain.TLE,
 
 	// Fallback to database
 	var modelTLE models.TLE
-	result := r.db.DbHandler.First(&modelTLE, "space_id = ?", spaceID)
+	result := r.db.Conn(ctx).First(&modelTLE, "space_id = ?", spaceID)
 	if result.Error != nil {
 		return domain.TLE{}, result.Error
 	}
@@ -82,74 +85,113 @@ func (r *TleRepository) GetTle(ctx context.Context, spaceID string) (domain.TLE,
 	return tle, nil
 }
 
-// SaveTle saves a TLE to the database and updates the cache.
+// SaveTle saves a TLE to the database and records it in the version history. The cache refresh and the broker
+// notification go through the outbox, so they happen only once the TLE is committed.
 func (r *TleRepository) SaveTle(ctx context.Context, tle domain.TLE) error {
-	modelTLE := mapToModelTLE(tle)
-	if err := r.db.DbHandler.Create(&modelTLE).Error; err != nil {
-		return err
-	}
-
-	key := fmt.Sprintf("satellite:tle:%s", tle.ID)
-	r.updateCache(ctx, key, tle)
-
-	return r.publishTleToBroker(ctx, tle)
+	return r.db.Transaction(ctx, func(ctx context.Context) error {
+		modelTLE := mapToModelTLE(tle)
+		if err := r.db.Conn(ctx).Create(&modelTLE).Error; err != nil {
+			return err
+		}
+		if err := r.recordVersions(ctx, []domain.TLE{tle}); err != nil {
+			return fmt.Errorf("failed to record TLE version for SPACE ID %s: %w", tle.SpaceID, err)
+		}
+		return r.enqueueUpdates(ctx, []domain.TLE{tle})
+	})
 }
 
+// UpdateTleBatch upserts TLEs and records them in the version history. The cache refresh and the broker notification
+// of each TLE go through the outbox, so they happen only once the TLEs are committed. Called within a transaction,
+// every write joins it.
 func (r *TleRepository) UpdateTleBatch(ctx context.Context, tles []domain.TLE) error {
 	if len(tles) == 0 {
 		return fmt.Errorf("no TLEs to update")
 	}
 
 	const batchSize = 1000 // Process TLEs in batches of 1000
-	for i := 0; i < len(tles); i += batchSize {
-		end := i + batchSize
-		if end > len(tles) {
-			end = len(tles)
-		}
-
-		batch := tles[i:end]
-
-		// Map TLEs to the database model
-		modelTLEs := make([]models.TLE, len(batch))
-		for j, tle := range batch {
-			modelTLEs[j] = mapToModelTLE(tle)
-		}
+	return r.db.Transaction(ctx, func(ctx context.Context) error {
+		for i := 0; i < len(tles); i += batchSize {
+			end := i + batchSize
+			if end > len(tles) {
+				end = len(tles)
+			}
 
-		// Batch upsert into the database
-		if err := r.db.DbHandler.Clauses(clause.OnConflict{
-			UpdateAll: true,
-		}).Save(&modelTLEs).Error; err != nil {
-			log.Errorf("Failed to batch upsert TLEs: %v\n", err)
-			return err
-		}
+			batch := tles[i:end]
 
-		// Process Redis caching and broker publishing
-		for _, tle := range batch {
-			key := fmt.Sprintf("satellite:tle:%s", tle.ID)
-			cacheData := map[string]interface{}{
-				"line_1": tle.Line1,
-				"line_2": tle.Line2,
-				"epoch":  tle.Epoch,
-				"id":     tle.SpaceID,
+			// Map TLEs to the database model
+			modelTLEs := make([]models.TLE, len(batch))
+			for j, tle := range batch {
+				modelTLEs[j] = mapToModelTLE(tle)
 			}
 
-			// Update Redis cache
-			if err := r.redisClient.HSet(ctx, key, cacheData); err != nil {
-				log.Errorf("Failed to update Redis cache for key %s: %v\n", key, err)
+			if err := r.db.Conn(ctx).Clauses(clause.OnConflict{
+				UpdateAll: true,
+			}).Save(&modelTLEs).Error; err != nil {
+				log.Errorf("Failed to batch upsert TLEs: %v\n", err)
+				return err
 			}
-			// Publish to the message broker
-			if err := r.publishTleToBroker(ctx, tle); err != nil {
-				log.Errorf("Failed to publish TLE to message broker for SPACE ID %s: %v\n", tle.SpaceID, err)
+			if err := r.recordVersions(ctx, batch); err != nil {
+				log.Errorf("Failed to record TLE versions: %v\n", err)
+				return err
+			}
+			if err := r.enqueueUpdates(ctx, batch); err != nil {
+				log.Errorf("Failed to enqueue TLE updates: %v\n", err)
+				return err
 			}
 		}
-	}
+		return nil
+	})
+}
 
+// tleUpdate is the payload of a TLE update, in the outbox and on the broker.
+type tleUpdate struct {
+	ID    string    `json:"id"`
+	TleID string    `json:"tle_id,omitempty"`
+	Line1 string    `json:"line_1"`
+	Line2 string    `json:"line_2"`
+	Epoch time.Time `json:"epoch"`
+}
+
+// enqueueUpdates stores a TLE update message per TLE in the outbox, within the transaction of ctx. Updates of a
+// satellite are relayed in order.
+func (r *TleRepository) enqueueUpdates(ctx context.Context, tles []domain.TLE) error {
+	tenantID := domain.TenantFromContext(ctx)
+	for _, tle := range tles {
+		payload, err := json.Marshal(tleUpdate{ID: tle.SpaceID, TleID: tle.ID, Line1: tle.Line1, Line2: tle.Line2, Epoch: tle.Epoch})
+		if err != nil {
+			return fmt.Errorf("failed to serialize TLE update of SPACE ID %s: %w", tle.SpaceID, err)
+		}
+		if err := r.outbox.Enqueue(ctx, domain.OutboxMessage{
+			EventUID:    uuid.NewString(),
+			AggregateID: fmt.Sprintf("tle:%s", tle.SpaceID),
+			Kind:        domain.OutboxKindTLEUpdate,
+			EventType:   domain.EventTypeTLEUpdated,
+			TenantID:    tenantID,
+			Event:       string(payload),
+		}); err != nil {
+			return fmt.Errorf("failed to enqueue TLE update of SPACE ID %s: %w", tle.SpaceID, err)
+		}
+	}
 	return nil
 }
 
+// PublishTLEUpdate relays a TLE update stored in the outbox: the cache entry of the TLE is refreshed and the update
+// is broadcast to the propagators. A payload that cannot be read returns domain.ErrOutboxMessageMalformed.
+func (r *TleRepository) PublishTLEUpdate(ctx context.Context, payload string) error {
+	var update tleUpdate
+	if err := json.Unmarshal([]byte(payload), &update); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrOutboxMessageMalformed, err)
+	}
+	tle := domain.TLE{ID: update.TleID, SpaceID: update.ID, Line1: update.Line1, Line2: update.Line2, Epoch: update.Epoch}
+
+	key := fmt.Sprintf("satellite:tle:%s", tle.ID)
+	r.updateCache(ctx, key, tle)
+	return r.publishTleToBroker(ctx, tle)
+}
+
 // DeleteTle deletes a TLE from the database and invalidates the cache.
 func (r *TleRepository) DeleteTle(ctx context.Context, id string) error {
-	if err := r.db.DbHandler.Delete(&models.TLE{}, "id = ?", id).Error; err != nil {
+	if err := r.db.Conn(ctx).Delete(&models.TLE{}, "id = ?", id).Error; err != nil {
 		return err
 	}
 
@@ -168,7 +210,7 @@ func (r *TleRepository) AssociateTLEWithContext(ctx context.Context, contextID s
 	}
 
 	// Insert into context_tles table
-	if err := r.db.DbHandler.Create(&contextTLE).Error; err != nil {
+	if err := r.db.Conn(ctx).Create(&contextTLE).Error; err != nil {
 		return fmt.Errorf("failed to associate TLE with context: %w", err)
 	}
 	return nil
@@ -179,7 +221,7 @@ func (r *TleRepository) GetTLEsByContext(ctx context.Context, contextID string)
 	var contextTLEs []models.ContextTLE
 
 	// Query the many-to-many relationship
-	if err := r.db.DbHandler.Where("context_id = ?", contextID).Find(&contextTLEs).Error; err != nil {
+	if err := r.db.Conn(ctx).Where("context_id = ?", contextID).Find(&contextTLEs).Error; err != nil {
 		return nil, fmt.Errorf("failed to retrieve TLEs by context: %w", err)
 	}
 
@@ -190,7 +232,7 @@ func (r *TleRepository) GetTLEsByContext(ctx context.Context, contextID string)
 	}
 
 	var tles []models.TLE
-	if err := r.db.DbHandler.Where("id IN ?", tleIDs).Find(&tles).Error; err != nil {
+	if err := r.db.Conn(ctx).Where("id IN ?", tleIDs).Find(&tles).Error; err != nil {
 		return nil, fmt.Errorf("failed to retrieve TLE details: %w", err)
 	}
 
@@ -205,7 +247,7 @@ func (r *TleRepository) GetTLEsByContext(ctx context.Context, contextID string)
 
 // RemoveTLEFromContext removes the association between a TLE and a context.
 func (r *TleRepository) RemoveTLEFromContext(ctx context.Context, contextID string, tleID string) error {
-	if err := r.db.DbHandler.Where("context_id = ? AND tle_id = ?", contextID, tleID).
+	if err := r.db.Conn(ctx).Where("context_id = ? AND tle_id = ?", contextID, tleID).
 		Delete(&models.ContextTLE{}).Error; err != nil {
 		return fmt.Errorf("failed to remove TLE from context: %w", err)
 	}
@@ -280,17 +322,23 @@ func (r *TleRepository) publishTleToBroker(ctx context.Context, tle domain.TLE)
 	return nil
 }
 
-// GetTLEsByContextName retrieves TLEs for satellites assigned to a given context name.
+// GetTLEsByContextName retrieves TLEs for satellites assigned to a given context name of the tenant of ctx.
+// A context with pinned TLEs gets its pinned versions instead of the latest ones.
 func (r *TleRepository) GetTLEsByContextName(ctx context.Context, contextName domain.GameContextName) ([]domain.TLE, error) {
 	// Retrieve the context by name
 	var context models.Context
-	if err := r.db.DbHandler.Where("name = ?", contextName).First(&context).Error; err != nil {
+	if err := r.db.Conn(ctx).Scopes(tenantScope(ctx, "contexts")).
+		Where("name = ? AND deleted_at IS NULL", contextName).First(&context).Error; err != nil {
 		return nil, fmt.Errorf("failed to retrieve context '%s': %w", contextName, err)
 	}
 
+	if context.TlePinnedEpoch != nil {
+		return r.pinnedTLEs(ctx, context.ID, nil)
+	}
+
 	// Retrieve the satellites assigned to this context
 	var contextSatellites []models.ContextSatellite
-	if err := r.db.DbHandler.Where("context_id = ?", context.ID).Find(&contextSatellites).Error; err != nil {
+	if err := r.db.Conn(ctx).Where("context_id = ?", context.ID).Find(&contextSatellites).Error; err != nil {
 		return nil, fmt.Errorf("failed to retrieve satellites for context '%s': %w", contextName, err)
 	}
 
@@ -306,7 +354,7 @@ func (r *TleRepository) GetTLEsByContextName(ctx context.Context, contextName do
 
 	// Retrieve the TLEs for the satellites assigned to this context
 	var tles []models.TLE
-	if err := r.db.DbHandler.Where("space_id IN ?", satelliteIDs).Find(&tles).Error; err != nil {
+	if err := r.db.Conn(ctx).Where("space_id IN ?", satelliteIDs).Find(&tles).Error; err != nil {
 		return nil, fmt.Errorf("failed to retrieve TLEs for satellites in context '%s': %w", contextName, err)
 	}
 
@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/org/2112-space-lab/org/app-service/internal/data/models"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// recordVersions appends TLEs to the version history. Epochs already recorded for a satellite are kept as they are,
+// so a pinned version never changes once written.
+func (r *TleRepository) recordVersions(ctx context.Context, tles []domain.TLE) error {
+	if len(tles) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	versions := make([]models.TLEVersion, len(tles))
+	for i, tle := range tles {
+		versions[i] = models.TLEVersion{
+			ID:        uuid.NewString(),
+			SpaceID:   tle.SpaceID,
+			Epoch:     tle.Epoch.UTC(),
+			Line1:     tle.Line1,
+			Line2:     tle.Line2,
+			CreatedAt: now,
+		}
+	}
+
+	return r.db.Conn(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		CreateInBatches(&versions, 1000).Error
+}
+
+// PinContextTLEs pins a context of the tenant of ctx to the TLE versions in effect at epoch: for each assigned
+// satellite, the latest version with an epoch at or before it. Satellites assigned later are left out until the
+// context is pinned again. It returns the number of pinned TLEs.
+func (r *TleRepository) PinContextTLEs(ctx context.Context, contextName domain.GameContextName, epoch time.Time) (int, error) {
+	var pinned int
+	err := r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		var gameContext models.Context
+		if err := tx.Scopes(tenantScope(ctx, "contexts")).
+			Where("name = ? AND deleted_at IS NULL", contextName).First(&gameContext).Error; err != nil {
+			return fmt.Errorf("failed to retrieve context '%s': %w", contextName, err)
+		}
+
+		versions, err := latestVersionsAt(tx, gameContext.ID, epoch)
+		if err != nil {
+			return fmt.Errorf("failed to resolve TLEs of context '%s' at %s: %w", contextName, epoch.Format(time.RFC3339), err)
+		}
+		if len(versions) == 0 {
+			return fmt.Errorf("context '%s' at %s: %w", contextName, epoch.Format(time.RFC3339), domain.ErrNoTLEsAtEpoch)
+		}
+
+		if err := tx.Where("context_id = ?", gameContext.ID).Delete(&models.ContextTLEPin{}).Error; err != nil {
+			return fmt.Errorf("failed to clear TLE pins of context '%s': %w", contextName, err)
+		}
+
+		pins := make([]models.ContextTLEPin, len(versions))
+		for i, version := range versions {
+			pins[i] = models.ContextTLEPin{ContextID: gameContext.ID, SpaceID: version.SpaceID, TLEVersionID: version.ID}
+		}
+		if err := tx.Omit(clause.Associations).Create(&pins).Error; err != nil {
+			return fmt.Errorf("failed to pin TLEs of context '%s': %w", contextName, err)
+		}
+
+		pinnedEpoch := epoch.UTC()
+		if err := tx.Model(&models.Context{}).Where("id = ?", gameContext.ID).
+			Update("tle_pinned_epoch", &pinnedEpoch).Error; err != nil {
+			return fmt.Errorf("failed to record pinned epoch of context '%s': %w", contextName, err)
+		}
+
+		pinned = len(pins)
+		return nil
+	})
+	return pinned, err
+}
+
+// PinContextTLEVersions records the given TLEs in the version history and pins a context of the tenant of ctx to
+// exactly these versions, at the given epoch. The latest TLEs shared by other contexts are left untouched. It returns
+// the number of pinned TLEs.
+func (r *TleRepository) PinContextTLEVersions(ctx context.Context, contextID string, tles []domain.TLE, epoch time.Time) (int, error) {
+	var pinned int
+	err := r.db.Transaction(ctx, func(ctx context.Context) error {
+		tx := r.db.Conn(ctx)
+		var gameContext models.Context
+		if err := tx.Scopes(tenantScope(ctx, "contexts")).
+			Where("id = ? AND deleted_at IS NULL", contextID).First(&gameContext).Error; err != nil {
+			return fmt.Errorf("failed to retrieve context %s: %w", contextID, err)
+		}
+
+		if err := r.recordVersions(ctx, tles); err != nil {
+			return fmt.Errorf("failed to record TLE versions of context %s: %w", contextID, err)
+		}
+
+		// Versions already recorded for an epoch keep their ID, so look them up rather than trusting the new ones.
+		pins := make([]models.ContextTLEPin, 0, len(tles))
+		seen := make(map[string]bool, len(tles))
+		for _, tle := range tles {
+			if seen[tle.SpaceID] {
+				continue
+			}
+			seen[tle.SpaceID] = true
+
+			var version models.TLEVersion
+			if err := tx.Where("space_id = ? AND epoch = ?", tle.SpaceID, tle.Epoch.UTC()).First(&version).Error; err != nil {
+				return fmt.Errorf("failed to resolve TLE version of SPACE ID %s: %w", tle.SpaceID, err)
+			}
+			pins = append(pins, models.ContextTLEPin{ContextID: contextID, SpaceID: tle.SpaceID, TLEVersionID: version.ID})
+		}
+
+		if err := tx.Where("context_id = ?", contextID).Delete(&models.ContextTLEPin{}).Error; err != nil {
+			return fmt.Errorf("failed to clear TLE pins of context %s: %w", contextID, err)
+		}
+		if len(pins) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&pins).Error; err != nil {
+				return fmt.Errorf("failed to pin TLEs of context %s: %w", contextID, err)
+			}
+		}
+
+		pinnedEpoch := epoch.UTC()
+		if err := tx.Model(&models.Context{}).Where("id = ?", contextID).
+			Update("tle_pinned_epoch", &pinnedEpoch).Error; err != nil {
+			return fmt.Errorf("failed to record pinned epoch of context %s: %w", contextID, err)
+		}
+
+		pinned = len(pins)
+		return nil
+	})
+	return pinned, err
+}
+
+// latestVersionsAt reads, for each satellite assigned to a context, the latest TLE version with an epoch at or
+// before epoch. Satellites without such a version are left out.
+func latestVersionsAt(tx *gorm.DB, contextID string, epoch time.Time) ([]models.TLEVersion, error) {
+	var versions []models.TLEVersion
+	err := tx.Raw(`
+		SELECT DISTINCT ON (tle_versions.space_id) tle_versions.*
+		FROM tle_versions
+		WHERE tle_versions.space_id IN (
+			SELECT satellites.space_id FROM satellites
+			JOIN context_satellites ON satellites.id = context_satellites.satellite_id
+			WHERE context_satellites.context_id = ?
+		)
+		AND tle_versions.epoch <= ?
+		ORDER BY tle_versions.space_id, tle_versions.epoch DESC
+	`, contextID, epoch.UTC()).Scan(&versions).Error
+	return versions, err
+}
+
+// UnpinContextTLEs releases the pinned TLEs of a context of the tenant of ctx, which then follows the latest TLEs again.
+func (r *TleRepository) UnpinContextTLEs(ctx context.Context, contextName domain.GameContextName) error {
+	return r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		var gameContext models.Context
+		if err := tx.Scopes(tenantScope(ctx, "contexts")).
+			Where("name = ? AND deleted_at IS NULL", contextName).First(&gameContext).Error; err != nil {
+			return fmt.Errorf("failed to retrieve context '%s': %w", contextName, err)
+		}
+
+		if err := tx.Where("context_id = ?", gameContext.ID).Delete(&models.ContextTLEPin{}).Error; err != nil {
+			return fmt.Errorf("failed to clear TLE pins of context '%s': %w", contextName, err)
+		}
+		return tx.Model(&models.Context{}).Where("id = ?", gameContext.ID).
+			Update("tle_pinned_epoch", gorm.Expr("NULL")).Error
+	})
+}
+
+// GetPinnedTLEs retrieves the pinned TLEs of a context of the tenant of ctx. pinned is false when the context
+// follows the latest TLEs, or is unknown.
+func (r *TleRepository) GetPinnedTLEs(ctx context.Context, contextID string) (tles []domain.TLE, pinned bool, err error) {
+	pinned, err = r.isPinned(ctx, contextID)
+	if err != nil || !pinned {
+		return nil, pinned, err
+	}
+
+	tles, err = r.pinnedTLEs(ctx, contextID, nil)
+	return tles, true, err
+}
+
+// FindTLEPins resolves the pinning of a context of the tenant of ctx once, for the given satellites or for all of
+// them when none is given. A context following the latest TLEs, or unknown, returns the zero TLEPins.
+func (r *TleRepository) FindTLEPins(ctx context.Context, contextID string, spaceIDs ...string) (domain.TLEPins, error) {
+	pinned, err := r.isPinned(ctx, contextID)
+	if err != nil || !pinned {
+		return domain.TLEPins{}, err
+	}
+
+	tles, err := r.pinnedTLEs(ctx, contextID, spaceIDs)
+	if err != nil {
+		return domain.TLEPins{}, err
+	}
+	return domain.NewTLEPins(tles), nil
+}
+
+func (r *TleRepository) isPinned(ctx context.Context, contextID string) (bool, error) {
+	var gameContext models.Context
+	err := r.db.Conn(ctx).Scopes(tenantScope(ctx, "contexts")).
+		Where("id = ? AND deleted_at IS NULL", contextID).First(&gameContext).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to retrieve context %s: %w", contextID, err)
+	}
+	return gameContext.TlePinnedEpoch != nil, nil
+}
+
+// pinnedTLEs reads the pinned versions of a context, restricted to the given satellites when there are any.
+func (r *TleRepository) pinnedTLEs(ctx context.Context, contextID string, spaceIDs []string) ([]domain.TLE, error) {
+	query := r.db.Conn(ctx).Model(&models.TLEVersion{}).
+		Joins("JOIN context_tle_pins ON context_tle_pins.tle_version_id = tle_versions.id").
+		Where("context_tle_pins.context_id = ?", contextID)
+	if len(spaceIDs) > 0 {
+		query = query.Where("context_tle_pins.space_id IN ?", spaceIDs)
+	}
+
+	var versions []models.TLEVersion
+	if err := query.Order("tle_versions.space_id").Find(&versions).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve pinned TLEs of context %s: %w", contextID, err)
+	}
+
+	tles := make([]domain.TLE, len(versions))
+	for i, version := range versions {
+		tles[i] = models.MapTLEVersionToDomain(version)
+	}
+	return tles, nil
+}
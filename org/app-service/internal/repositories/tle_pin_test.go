@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+)
+
+func TestLatestVersionsAtSelectsOneVersionPerSatelliteAtEpoch(t *testing.T) {
+	db, statements := newDryRunDatabase(t)
+	epoch := time.Date(2026, 10, 19, 14, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+	// Dry runs return no rows; the statement is what decides which versions are pinned.
+	_, _ = latestVersionsAt(db.DbHandler, "context-id", epoch)
+
+	if len(*statements) != 1 {
+		t.Fatalf("Expected 1 statement, but got %d", len(*statements))
+	}
+	stmt := (*statements)[0]
+	sql := strings.Join(strings.Fields(stmt.sql), " ")
+	for _, fragment := range []string{
+		"SELECT DISTINCT ON (tle_versions.space_id) tle_versions.*",
+		"WHERE context_satellites.context_id = $1",
+		"AND tle_versions.epoch <= $2",
+		"ORDER BY tle_versions.space_id, tle_versions.epoch DESC",
+	} {
+		if !strings.Contains(sql, fragment) {
+			t.Errorf("Expected statement to contain %q, but got %q", fragment, sql)
+		}
+	}
+	if len(stmt.vars) != 2 || stmt.vars[0] != "context-id" {
+		t.Fatalf("Expected the context ID and the epoch to be bound, but got %v", stmt.vars)
+	}
+	if bound, ok := stmt.vars[1].(time.Time); !ok || !bound.Equal(epoch) || bound.Location() != time.UTC {
+		t.Errorf("Expected epoch %s in UTC, but got %v", epoch.UTC(), stmt.vars[1])
+	}
+}
+
+func TestFindTLEPinsFollowsLatestTLEsForUnknownContext(t *testing.T) {
+	db, statements := newDryRunDatabase(t)
+	repo := NewTLERepository(db, nil)
+
+	// The dry run finds no context, which then follows the latest TLEs.
+	pins, err := repo.FindTLEPins(domain.WithTenant(context.Background(), tenantA), "context-id", "25544", "48274")
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if pins.Pinned {
+		t.Errorf("Expected an unknown context not to be pinned, but got %+v", pins)
+	}
+	if len(*statements) != 1 {
+		t.Fatalf("Expected only the context to be read, but got %d statements", len(*statements))
+	}
+	assertScopedTo(t, *statements, tenantA, tenantB)
+}
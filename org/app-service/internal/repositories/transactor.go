@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/data"
+)
+
+// Transactor runs repository calls in a single database transaction.
+type Transactor struct {
+	db *data.Database
+}
+
+// NewTransactor creates a new Transactor instance.
+func NewTransactor(db *data.Database) Transactor {
+	return Transactor{db: db}
+}
+
+// Transaction runs fn in a transaction joined by the repositories called with the ctx given to fn.
+func (t *Transactor) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return t.db.Transaction(ctx, fn)
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/clients/basemap"
+	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
+	"github.com/org/2112-space-lab/org/app-service/pkg/tracing"
+	"github.com/org/2112-space-lab/org/go-utils/pkg/fx/xpolygon"
+)
+
+const (
+	// MaxBasemapZoom is the deepest zoom level served or seeded.
+	MaxBasemapZoom = 19
+	// maxWebMercatorLat is the latitude limit of the Web Mercator projection.
+	maxWebMercatorLat = 85.05112878
+)
+
+// ErrInvalidBasemapTile is returned for z/x/y coordinates outside the tile pyramid.
+var ErrInvalidBasemapTile = errors.New("invalid basemap tile coordinates")
+
+// ErrBasemapSeedTooLarge is returned when a seeding request covers more tiles than allowed.
+var ErrBasemapSeedTooLarge = errors.New("basemap seed request covers too many tiles")
+
+// BasemapBounds is a lat/lon bounding box used to restrict seeding.
+type BasemapBounds struct {
+	MinLat, MinLon, MaxLat, MaxLon float64
+}
+
+// WorldBasemapBounds covers the whole Web Mercator extent.
+var WorldBasemapBounds = BasemapBounds{MinLat: -maxWebMercatorLat, MinLon: -180, MaxLat: maxWebMercatorLat, MaxLon: 180}
+
+// basemapTileRange is the inclusive x/y range of tiles covered at one zoom level.
+type basemapTileRange struct {
+	Zoom, MinX, MaxX, MinY, MaxY int
+}
+
+// count returns the number of tiles in the range.
+func (r basemapTileRange) count() int {
+	return (r.MaxX - r.MinX + 1) * (r.MaxY - r.MinY + 1)
+}
+
+// BasemapSeedReport summarises a seeding run.
+type BasemapSeedReport struct {
+	Total   int `json:"total"`
+	Cached  int `json:"cached"`
+	Fetched int `json:"fetched"`
+	Failed  int `json:"failed"`
+}
+
+// BasemapService serves basemap tiles and pre-seeds the offline cache.
+type BasemapService struct {
+	client *basemap.BasemapClient
+}
+
+// NewBasemapService creates a new instance of BasemapService.
+func NewBasemapService(client *basemap.BasemapClient) BasemapService {
+	return BasemapService{client: client}
+}
+
+// GetTile returns the PNG for a z/x/y tile.
+func (s *BasemapService) GetTile(ctx context.Context, z, x, y int) (data []byte, err error) {
+	ctx, span := tracing.NewSpan(ctx, "GetTile")
+	defer span.EndWithError(err)
+
+	if err := validateTileCoordinates(z, x, y); err != nil {
+		return nil, err
+	}
+	return s.client.GetTile(ctx, z, x, y)
+}
+
+// SeedZoomRange downloads every tile intersecting bounds for zoom levels minZoom..maxZoom into the cache.
+// Tiles already cached are skipped, so the operation can be resumed.
+func (s *BasemapService) SeedZoomRange(ctx context.Context, minZoom, maxZoom int, bounds BasemapBounds) (report BasemapSeedReport, err error) {
+	ctx, span := tracing.NewSpan(ctx, "SeedZoomRange")
+	defer span.EndWithError(err)
+
+	if minZoom < 0 || maxZoom > MaxBasemapZoom || minZoom > maxZoom {
+		return report, fmt.Errorf("invalid zoom range [%d, %d]", minZoom, maxZoom)
+	}
+	if bounds.MinLat >= bounds.MaxLat || bounds.MinLon >= bounds.MaxLon {
+		return report, fmt.Errorf("invalid bounding box coordinates")
+	}
+	if s.client.IsOffline() {
+		return report, fmt.Errorf("basemap client is offline, seeding requires upstream access")
+	}
+
+	ranges := seedTileRanges(minZoom, maxZoom, bounds)
+	total := 0
+	for _, r := range ranges {
+		total += r.count()
+	}
+	if limit := s.client.MaxSeedTiles(); total > limit {
+		return report, fmt.Errorf("%w: %d tiles requested, at most %d allowed", ErrBasemapSeedTooLarge, total, limit)
+	}
+
+	for _, r := range ranges {
+		z := r.Zoom
+		log.Infof("🔄 Seeding basemap zoom %d: x[%d..%d] y[%d..%d]", z, r.MinX, r.MaxX, r.MinY, r.MaxY)
+		for x := r.MinX; x <= r.MaxX; x++ {
+			for y := r.MinY; y <= r.MaxY; y++ {
+				select {
+				case <-ctx.Done():
+					return report, ctx.Err()
+				default:
+				}
+
+				report.Total++
+				if s.client.HasTile(z, x, y) {
+					report.Cached++
+					continue
+				}
+				if _, err := s.client.GetTile(ctx, z, x, y); err != nil {
+					log.Warnf("⚠️ Failed to seed basemap tile %d/%d/%d: %v", z, x, y, err)
+					report.Failed++
+					continue
+				}
+				report.Fetched++
+			}
+		}
+	}
+
+	log.Infof("✅ Basemap seeding complete: %d tiles (%d cached, %d fetched, %d failed)", report.Total, report.Cached, report.Fetched, report.Failed)
+	return report, nil
+}
+
+// IsNotCached reports whether err signals a tile missing from the offline cache.
+func (s *BasemapService) IsNotCached(err error) bool {
+	return errors.Is(err, basemap.ErrTileNotCached)
+}
+
+func validateTileCoordinates(z, x, y int) error {
+	if z < 0 || z > MaxBasemapZoom {
+		return fmt.Errorf("%w: zoom level %d", ErrInvalidBasemapTile, z)
+	}
+	n := 1 << z
+	if x < 0 || x >= n || y < 0 || y >= n {
+		return fmt.Errorf("%w: %d/%d/%d", ErrInvalidBasemapTile, z, x, y)
+	}
+	return nil
+}
+
+// seedTileRanges returns the tile ranges intersecting bounds for zoom levels minZoom..maxZoom.
+func seedTileRanges(minZoom, maxZoom int, bounds BasemapBounds) []basemapTileRange {
+	minLat := math.Max(bounds.MinLat, -maxWebMercatorLat)
+	maxLat := math.Min(bounds.MaxLat, maxWebMercatorLat)
+
+	ranges := make([]basemapTileRange, 0, maxZoom-minZoom+1)
+	for z := minZoom; z <= maxZoom; z++ {
+		last := 1<<z - 1
+		// Tile Y grows southward, so the north edge gives the smallest Y.
+		minX, minY := xpolygon.LatLonToTileXY(maxLat, bounds.MinLon, z)
+		maxX, maxY := xpolygon.LatLonToTileXY(minLat, bounds.MaxLon, z)
+		ranges = append(ranges, basemapTileRange{
+			Zoom: z,
+			MinX: clampTile(minX, last),
+			MaxX: clampTile(maxX, last),
+			MinY: clampTile(minY, last),
+			MaxY: clampTile(maxY, last),
+		})
+	}
+	return ranges
+}
+
+func clampTile(v, last int) int {
+	if v < 0 {
+		return 0
+	}
+	if v > last {
+		return last
+	}
+	return v
+}
@@ -0,0 +1,30 @@
+package services
+
+import "testing"
+
+func TestSeedTileRanges(t *testing.T) {
+	tests := []struct {
+		name     string
+		minZoom  int
+		maxZoom  int
+		bounds   BasemapBounds
+		expected int
+	}{
+		{name: "World at zoom 0", minZoom: 0, maxZoom: 0, bounds: WorldBasemapBounds, expected: 1},
+		{name: "World up to zoom 3", minZoom: 0, maxZoom: 3, bounds: WorldBasemapBounds, expected: 1 + 4 + 16 + 64},
+		{name: "World at zoom 12", minZoom: 12, maxZoom: 12, bounds: WorldBasemapBounds, expected: 1 << 24},
+		{name: "Single quadrant", minZoom: 1, maxZoom: 1, bounds: BasemapBounds{MinLat: 10, MinLon: 10, MaxLat: 20, MaxLon: 20}, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			total := 0
+			for _, r := range seedTileRanges(tt.minZoom, tt.maxZoom, tt.bounds) {
+				total += r.count()
+			}
+			if total != tt.expected {
+				t.Errorf("Expected %d tiles, but got %d", tt.expected, total)
+			}
+		})
+	}
+}
@@ -2,31 +2,37 @@ package services
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/org/2112-space-lab/org/app-service/internal/domain"
 	"github.com/org/2112-space-lab/org/app-service/internal/events"
 	event_builder "github.com/org/2112-space-lab/org/app-service/internal/events/builder"
-	repository "github.com/org/2112-space-lab/org/app-service/internal/repositories"
 	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
+	xtime "github.com/org/2112-space-lab/org/app-service/pkg/time"
 	"github.com/org/2112-space-lab/org/app-service/pkg/tracing"
 )
 
 // ContextService definition
 type ContextService struct {
-	repo    repository.ContextRepository
+	repo    domain.GameContextRepository
 	emitter *events.EventEmitter
 }
 
 // NewContextService creates a new instance of ContextService.
-func NewContextService(repo repository.ContextRepository, emitter *events.EventEmitter) ContextService {
+func NewContextService(repo domain.GameContextRepository, emitter *events.EventEmitter) ContextService {
 	return ContextService{repo: repo, emitter: emitter}
 }
 
-// Create creates a new GameContext.
+// Create creates a new GameContext. It starts as a draft, or scheduled when an activation time is given.
 func (c *ContextService) Create(ctx context.Context, context domain.GameContext) (cc domain.GameContext, err error) {
 	ctx, span := tracing.NewSpan(ctx, "Create")
 	defer span.EndWithError(err)
+	context.State = domain.GameContextStateDraft
+	context.IsActive = false
+	if context.ScheduledActivationAt.HasValue {
+		context.State = domain.GameContextStateScheduled
+	}
 	err = c.repo.Save(ctx, context)
 	if err != nil {
 		return domain.GameContext{}, err
@@ -71,33 +77,132 @@ func (c *ContextService) DeleteByUniqueName(ctx context.Context, name domain.Gam
 func (c *ContextService) ActiveContext(ctx context.Context, name domain.GameContextName) (err error) {
 	ctx, span := tracing.NewSpan(ctx, "ActiveContext")
 	defer span.EndWithError(err)
-	err = c.repo.ActivateContext(ctx, name)
+	return c.Transition(ctx, name, domain.GameContextStateActive, "manual activation")
+}
+
+// DisableContext deactivates a GameContext by its unique name, pausing it.
+func (c *ContextService) DisableContext(ctx context.Context, name domain.GameContextName) (err error) {
+	ctx, span := tracing.NewSpan(ctx, "DisableContext")
+	defer span.EndWithError(err)
+	return c.Transition(ctx, name, domain.GameContextStatePaused, "manual deactivation")
+}
+
+// ArchiveContext closes a GameContext for good.
+func (c *ContextService) ArchiveContext(ctx context.Context, name domain.GameContextName) (err error) {
+	ctx, span := tracing.NewSpan(ctx, "ArchiveContext")
+	defer span.EndWithError(err)
+	return c.Transition(ctx, name, domain.GameContextStateArchived, "manual archive")
+}
+
+// Transition moves a GameContext to another lifecycle state and publishes a GAME_CONTEXT_STATE_CHANGED event.
+func (c *ContextService) Transition(ctx context.Context, name domain.GameContextName, to domain.GameContextState, reason string) (err error) {
+	ctx, span := tracing.NewSpan(ctx, "Transition")
+	defer span.EndWithError(err)
+
+	gameContext, err := c.repo.FindByUniqueName(ctx, name)
 	if err != nil {
 		return err
 	}
+	from := gameContext.State
+	if err = from.ValidateTransition(to); err != nil {
+		return err
+	}
+	if err = c.repo.Transition(ctx, name, from, to, time.Now().UTC()); err != nil {
+		return err
+	}
+	log.Infof("🔁 Context %s moved from %s to %s: %s", name, from, to, reason)
+
+	// The transition is committed; a lost event must not undo it.
+	ev, err := event_builder.NewGameContextStateChangedEvent(string(name), string(from), string(to), reason)
+	if err == nil {
+		err = c.emitter.PublishEvent(ctx, *ev)
+	}
+	if err != nil {
+		log.Errorf("❌ Failed to publish state change of context %s: %v", name, err)
+	}
 	return nil
 }
 
-// DisableContext deactivates a GameContext by its unique name.
-func (c *ContextService) DisableContext(ctx context.Context, name domain.GameContextName) (err error) {
-	ctx, span := tracing.NewSpan(ctx, "DisableContext")
+// Schedule sets the times a GameContext is activated and deactivated by the scheduler. An activation time moves
+// the context to scheduled and resets its import triggers, so it is prepared again before it starts. A deactivation
+// time alone keeps the current activation time and may be set on a running context.
+func (c *ContextService) Schedule(ctx context.Context, name domain.GameContextName, activateAt, deactivateAt *time.Time) (err error) {
+	ctx, span := tracing.NewSpan(ctx, "Schedule")
 	defer span.EndWithError(err)
-	err = c.repo.DesactiveContext(ctx, name)
+
+	if activateAt == nil && deactivateAt == nil {
+		return fmt.Errorf("%w: no activation or deactivation time", domain.ErrInvalidContextSchedule)
+	}
+	if activateAt != nil && deactivateAt != nil && !deactivateAt.After(*activateAt) {
+		return fmt.Errorf("%w: deactivation must come after activation", domain.ErrInvalidContextSchedule)
+	}
+
+	gameContext, err := c.repo.FindByUniqueName(ctx, name)
 	if err != nil {
 		return err
 	}
-	return nil
+
+	if activateAt == nil {
+		switch gameContext.State {
+		case domain.GameContextStateActive:
+		case domain.GameContextStateScheduled:
+			activateAt = xtime.ToTimePointer(gameContext.ScheduledActivationAt)
+			if activateAt != nil && !deactivateAt.After(*activateAt) {
+				return fmt.Errorf("%w: deactivation must come after activation", domain.ErrInvalidContextSchedule)
+			}
+		default:
+			return fmt.Errorf("%w: context %s is %s", domain.ErrInvalidContextSchedule, name, gameContext.State)
+		}
+		return c.repo.SetSchedule(ctx, name, activateAt, deactivateAt)
+	}
+
+	if gameContext.State != domain.GameContextStateScheduled {
+		if err = gameContext.State.ValidateTransition(domain.GameContextStateScheduled); err != nil {
+			return err
+		}
+	}
+	if err = c.repo.SetSchedule(ctx, name, activateAt, deactivateAt); err != nil {
+		return err
+	}
+	for _, unset := range []func(context.Context, domain.GameContextName) error{
+		c.repo.UnsetTriggerImportedSatelliteAt,
+		c.repo.UnsetTriggerImportedTLEAt,
+		c.repo.UnsetTriggerGeneratedMappingAt,
+	} {
+		if err = unset(ctx, name); err != nil {
+			return err
+		}
+	}
+	if gameContext.State == domain.GameContextStateScheduled {
+		return nil
+	}
+	return c.Transition(ctx, name, domain.GameContextStateScheduled, fmt.Sprintf("scheduled for %s", activateAt.UTC().Format(time.RFC3339)))
 }
 
-// GetActiveContext retrieves the currently active GameContext.
-func (c *ContextService) GetActiveContext(ctx context.Context) (cc domain.GameContext, err error) {
-	ctx, span := tracing.NewSpan(ctx, "GetActiveContext")
+// Unschedule cancels the schedule of a GameContext. A scheduled context goes back to draft.
+func (c *ContextService) Unschedule(ctx context.Context, name domain.GameContextName) (err error) {
+	ctx, span := tracing.NewSpan(ctx, "Unschedule")
 	defer span.EndWithError(err)
-	context, err := c.repo.GetActiveContext(ctx)
+
+	gameContext, err := c.repo.FindByUniqueName(ctx, name)
 	if err != nil {
-		return domain.GameContext{}, err
+		return err
 	}
-	return context, nil
+	if gameContext.State == domain.GameContextStateScheduled {
+		return c.Transition(ctx, name, domain.GameContextStateDraft, "schedule cancelled")
+	}
+	return c.repo.SetSchedule(ctx, name, nil, nil)
+}
+
+// GetActiveContexts retrieves every active GameContext of the tenant of ctx.
+func (c *ContextService) GetActiveContexts(ctx context.Context) (cs []domain.GameContext, err error) {
+	ctx, span := tracing.NewSpan(ctx, "GetActiveContexts")
+	defer span.EndWithError(err)
+	contexts, err := c.repo.FindActiveContexts(ctx)
+	if err != nil {
+		return []domain.GameContext{}, err
+	}
+	return contexts, nil
 }
 
 // GetAllContexts retrieves all GameContexts.
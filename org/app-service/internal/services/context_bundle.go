@@ -0,0 +1,613 @@
+package services
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
+	fx "github.com/org/2112-space-lab/org/app-service/pkg/option"
+	"github.com/org/2112-space-lab/org/app-service/pkg/tracing"
+	"gorm.io/gorm"
+)
+
+// ContextBundleFormat selects how a bundle is serialised.
+type ContextBundleFormat string
+
+const (
+	// ContextBundleFormatJSON writes the bundle as a single JSON document.
+	ContextBundleFormatJSON ContextBundleFormat = "json"
+	// ContextBundleFormatTar writes a gzipped tar with one JSON file per section.
+	ContextBundleFormatTar ContextBundleFormat = "tar"
+)
+
+// maxRenameAttempts bounds the search for a free name under the rename strategy.
+const maxRenameAttempts = 100
+
+// bundleContexts stores the contexts written by imports.
+type bundleContexts interface {
+	FindByUniqueName(ctx context.Context, name domain.GameContextName) (domain.GameContext, error)
+	Save(ctx context.Context, gameContext domain.GameContext) error
+	Update(ctx context.Context, gameContext domain.GameContext) error
+	ClearAssociations(ctx context.Context, name domain.GameContextName) error
+}
+
+// bundleSatellites reads and assigns the satellites of contexts.
+type bundleSatellites interface {
+	FindSatellitesByContext(ctx context.Context, contextID string) ([]domain.Satellite, error)
+	FindBySpaceID(ctx context.Context, spaceID string) (domain.Satellite, error)
+	AssignSatelliteToContext(ctx context.Context, contextID, satelliteID string) error
+}
+
+// bundleTLEs reads the TLEs of contexts and pins imported ones.
+type bundleTLEs interface {
+	GetTLEsByContext(ctx context.Context, contextID string) ([]domain.TLE, error)
+	GetPinnedTLEs(ctx context.Context, contextID string) ([]domain.TLE, bool, error)
+	PinContextTLEVersions(ctx context.Context, contextID string, tles []domain.TLE, epoch time.Time) (int, error)
+}
+
+// bundleMappings reads and writes the mappings of contexts.
+type bundleMappings interface {
+	FindAll(ctx context.Context, contextID string) ([]domain.TileSatelliteMapping, error)
+	DeleteMappingsBySpaceID(ctx context.Context, contextID, spaceID string) error
+	SaveBatch(ctx context.Context, mappings []domain.TileSatelliteMapping) error
+}
+
+// transactor runs repository calls in a single database transaction.
+type transactor interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// ContextBundleService exports game contexts to portable bundles, imports them back and clones contexts.
+type ContextBundleService struct {
+	contextRepo   bundleContexts
+	satelliteRepo bundleSatellites
+	tleRepo       bundleTLEs
+	tileRepo      domain.TileRepository
+	mappingRepo   bundleMappings
+	transactor    transactor
+}
+
+// NewContextBundleService creates a new instance of ContextBundleService.
+func NewContextBundleService(
+	contextRepo bundleContexts,
+	satelliteRepo bundleSatellites,
+	tleRepo bundleTLEs,
+	tileRepo domain.TileRepository,
+	mappingRepo bundleMappings,
+	transactor transactor,
+) ContextBundleService {
+	return ContextBundleService{
+		contextRepo:   contextRepo,
+		satelliteRepo: satelliteRepo,
+		tleRepo:       tleRepo,
+		tileRepo:      tileRepo,
+		mappingRepo:   mappingRepo,
+		transactor:    transactor,
+	}
+}
+
+// Export bundles a context of the tenant of ctx with its satellite assignments, pinned TLEs and tiles,
+// and its mappings when includeMappings is set.
+func (s *ContextBundleService) Export(ctx context.Context, name domain.GameContextName, includeMappings bool) (bundle domain.ContextBundle, err error) {
+	ctx, span := tracing.NewSpan(ctx, "Export")
+	defer span.EndWithError(err)
+
+	gameContext, err := s.contextRepo.FindByUniqueName(ctx, name)
+	if err != nil {
+		return bundle, err
+	}
+
+	bundle = domain.ContextBundle{
+		Version:    domain.ContextBundleVersion,
+		ExportedAt: time.Now().UTC(),
+		Context: domain.ContextBundleContext{
+			Name:        gameContext.Name,
+			DisplayName: gameContext.DisplayName,
+			Description: string(fx.GetOrDefault(gameContext.Description, domain.GameContextDescription(""))),
+			IsFavourite: gameContext.IsFavourite,
+		},
+		Satellites: []string{},
+		TLEs:       []domain.ContextBundleTLE{},
+		Tiles:      []domain.ContextBundleTile{},
+	}
+
+	satellites, err := s.satelliteRepo.FindSatellitesByContext(ctx, gameContext.ID)
+	if err != nil {
+		return bundle, fmt.Errorf("failed to export satellites of context %s: %w", name, err)
+	}
+	for _, satellite := range satellites {
+		bundle.Satellites = append(bundle.Satellites, satellite.SpaceID)
+	}
+
+	tles, err := s.tleRepo.GetTLEsByContext(ctx, gameContext.ID)
+	if err != nil {
+		return bundle, fmt.Errorf("failed to export TLEs of context %s: %w", name, err)
+	}
+	if gameContext.TlePinnedEpoch.HasValue {
+		pinnedEpoch := gameContext.TlePinnedEpoch.Value.Inner()
+		bundle.Context.TlePinnedAt = &pinnedEpoch
+		if tles, _, err = s.tleRepo.GetPinnedTLEs(ctx, gameContext.ID); err != nil {
+			return bundle, fmt.Errorf("failed to export pinned TLEs of context %s: %w", name, err)
+		}
+	}
+	for _, tle := range tles {
+		bundle.TLEs = append(bundle.TLEs, domain.ContextBundleTLE{
+			ID:      tle.ID,
+			SpaceID: tle.SpaceID,
+			Line1:   tle.Line1,
+			Line2:   tle.Line2,
+			Epoch:   tle.Epoch,
+		})
+	}
+
+	tiles, err := s.tileRepo.GetTilesByContext(ctx, gameContext.ID)
+	if err != nil {
+		return bundle, fmt.Errorf("failed to export tiles of context %s: %w", name, err)
+	}
+	quadkeys := make(map[string]string, len(tiles))
+	for _, tile := range tiles {
+		quadkeys[tile.ID] = tile.Quadkey
+		bundle.Tiles = append(bundle.Tiles, domain.ContextBundleTile{
+			Quadkey:   tile.Quadkey,
+			ZoomLevel: tile.ZoomLevel,
+			CenterLat: tile.CenterLat,
+			CenterLon: tile.CenterLon,
+			NbFaces:   tile.NbFaces,
+			Radius:    tile.Radius,
+			Vertices:  tile.Vertices,
+		})
+	}
+
+	if !includeMappings {
+		return bundle, nil
+	}
+
+	mappings, err := s.mappingRepo.FindAll(ctx, gameContext.ID)
+	if err != nil {
+		return bundle, fmt.Errorf("failed to export mappings of context %s: %w", name, err)
+	}
+	bundle.Mappings = []domain.ContextBundleMapping{}
+	for _, mapping := range mappings {
+		quadkey, ok := quadkeys[mapping.TileID]
+		if !ok {
+			log.Debugf("Skipping mapping %s of context %s: tile %s is not part of the context", mapping.ID, name, mapping.TileID)
+			continue
+		}
+		bundle.Mappings = append(bundle.Mappings, domain.ContextBundleMapping{
+			SpaceID:               mapping.SpaceID,
+			Quadkey:               quadkey,
+			IntersectionLatitude:  mapping.IntersectionLatitude,
+			IntersectionLongitude: mapping.IntersectionLongitude,
+			IntersectedAt:         mapping.IntersectedAt,
+			EnteredAt:             mapping.EnteredAt,
+			ExitedAt:              mapping.ExitedAt,
+			DurationSeconds:       mapping.DurationSeconds,
+		})
+	}
+	return bundle, nil
+}
+
+// Import creates a context of the tenant of ctx from a bundle, resolving name conflicts with the given strategy.
+// The import runs in one transaction, so a failing import leaves a replaced context as it was. Satellites unknown
+// to this environment are reported rather than failing the import.
+func (s *ContextBundleService) Import(ctx context.Context, bundle domain.ContextBundle, opts domain.ContextImportOptions) (result domain.ContextImportResult, err error) {
+	ctx, span := tracing.NewSpan(ctx, "Import")
+	defer span.EndWithError(err)
+
+	if err = bundle.Validate(); err != nil {
+		return result, err
+	}
+
+	err = s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		result, err = s.importBundle(ctx, bundle, opts)
+		return err
+	})
+	if err != nil {
+		// An in-memory tile index may hold tiles of the rolled back import.
+		if index, ok := s.tileRepo.(interface{ Invalidate() }); ok {
+			index.Invalidate()
+		}
+		return domain.ContextImportResult{}, err
+	}
+	if !result.Skipped {
+		log.Infof("📦 Imported context %s: %d satellites, %d TLEs, %d tiles, %d mappings",
+			result.Context.Name, result.Satellites, result.TLEs, result.Tiles, result.Mappings)
+	}
+	return result, nil
+}
+
+func (s *ContextBundleService) importBundle(ctx context.Context, bundle domain.ContextBundle, opts domain.ContextImportOptions) (result domain.ContextImportResult, err error) {
+	name := bundle.Context.Name
+	if opts.Name != "" {
+		name = opts.Name
+	}
+
+	existing, err := s.contextRepo.FindByUniqueName(ctx, name)
+	exists := err == nil
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return result, err
+	}
+
+	if exists {
+		switch opts.Strategy {
+		case domain.ContextConflictSkip:
+			log.Infof("⏭ Context %s already exists, skipping import", name)
+			return domain.ContextImportResult{Context: existing, Skipped: true}, nil
+		case domain.ContextConflictRename:
+			if name, err = s.freeName(ctx, name); err != nil {
+				return result, err
+			}
+			exists = false
+		case domain.ContextConflictReplace:
+		default:
+			return result, fmt.Errorf("context %s: %w", name, domain.ErrContextExists)
+		}
+	}
+
+	gameContext := domain.GameContext{
+		ModelBase:   domain.NewModelBaseDefault(),
+		Name:        name,
+		Description: fx.NewValueOption(domain.GameContextDescription(bundle.Context.Description)),
+		State:       domain.GameContextStateDraft,
+	}
+	gameContext.DisplayName = bundle.Context.DisplayName
+	gameContext.IsFavourite = bundle.Context.IsFavourite
+	gameContext.IsActive = false
+
+	if exists {
+		gameContext.ID = existing.ID
+		if err = s.contextRepo.Update(ctx, gameContext); err != nil {
+			return result, fmt.Errorf("failed to replace context %s: %w", name, err)
+		}
+		if err = s.contextRepo.ClearAssociations(ctx, name); err != nil {
+			return result, err
+		}
+		if opts.IncludeMappings {
+			if err = s.clearMappings(ctx, existing.ID); err != nil {
+				return result, err
+			}
+		}
+	} else if err = s.contextRepo.Save(ctx, gameContext); err != nil {
+		return result, fmt.Errorf("failed to create context %s: %w", name, err)
+	}
+
+	if gameContext, err = s.contextRepo.FindByUniqueName(ctx, name); err != nil {
+		return result, err
+	}
+	result.Context = gameContext
+
+	if err = s.importSatellites(ctx, gameContext, bundle.Satellites, &result); err != nil {
+		return result, err
+	}
+	if err = s.importTLEs(ctx, gameContext, bundle.TLEs, bundle.Context.TlePinnedAt, &result); err != nil {
+		return result, err
+	}
+	tileIDs, err := s.importTiles(ctx, gameContext, bundle.Tiles, &result)
+	if err != nil {
+		return result, err
+	}
+	if opts.IncludeMappings {
+		if err = s.importMappings(ctx, gameContext, bundle.Mappings, tileIDs, &result); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// Clone copies a context of the tenant of ctx under a new name. The copy starts as a draft.
+func (s *ContextBundleService) Clone(ctx context.Context, source, target domain.GameContextName, includeMappings bool) (result domain.ContextImportResult, err error) {
+	ctx, span := tracing.NewSpan(ctx, "Clone")
+	defer span.EndWithError(err)
+
+	if target == "" || target == source {
+		return result, fmt.Errorf("clone of context %s needs a new name", source)
+	}
+
+	bundle, err := s.Export(ctx, source, includeMappings)
+	if err != nil {
+		return result, err
+	}
+	return s.Import(ctx, bundle, domain.ContextImportOptions{
+		Name:            target,
+		Strategy:        domain.ContextConflictFail,
+		IncludeMappings: includeMappings,
+	})
+}
+
+func (s *ContextBundleService) freeName(ctx context.Context, name domain.GameContextName) (domain.GameContextName, error) {
+	for i := 1; i <= maxRenameAttempts; i++ {
+		candidate := domain.GameContextName(fmt.Sprintf("%s-copy-%d", name, i))
+		_, err := s.contextRepo.FindByUniqueName(ctx, candidate)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return "", fmt.Errorf("no free name found for context %s: %w", name, domain.ErrContextExists)
+}
+
+func (s *ContextBundleService) clearMappings(ctx context.Context, contextID string) error {
+	mappings, err := s.mappingRepo.FindAll(ctx, contextID)
+	if err != nil {
+		return fmt.Errorf("failed to read mappings to replace: %w", err)
+	}
+	cleared := map[string]bool{}
+	for _, mapping := range mappings {
+		if cleared[mapping.SpaceID] {
+			continue
+		}
+		if err := s.mappingRepo.DeleteMappingsBySpaceID(ctx, contextID, mapping.SpaceID); err != nil {
+			return fmt.Errorf("failed to delete mappings of SPACE ID %s: %w", mapping.SpaceID, err)
+		}
+		cleared[mapping.SpaceID] = true
+	}
+	return nil
+}
+
+func (s *ContextBundleService) importSatellites(ctx context.Context, gameContext domain.GameContext, spaceIDs []string, result *domain.ContextImportResult) error {
+	for _, spaceID := range spaceIDs {
+		satellite, err := s.satelliteRepo.FindBySpaceID(ctx, spaceID)
+		if err != nil {
+			log.Warnf("⚠️ Satellite %s of context %s is unknown here, not assigned: %v", spaceID, gameContext.Name, err)
+			result.MissingSatellites = append(result.MissingSatellites, spaceID)
+			continue
+		}
+		if err := s.satelliteRepo.AssignSatelliteToContext(ctx, gameContext.ID, satellite.ID); err != nil {
+			return fmt.Errorf("failed to assign satellite %s: %w", spaceID, err)
+		}
+		result.Satellites++
+	}
+	return nil
+}
+
+// importTLEs pins the context to the bundled TLEs, recorded as versions, at the pinned epoch of the bundle or the
+// latest bundled epoch. The latest TLEs shared with other contexts are not overwritten.
+func (s *ContextBundleService) importTLEs(ctx context.Context, gameContext domain.GameContext, bundled []domain.ContextBundleTLE, pinnedAt *time.Time, result *domain.ContextImportResult) error {
+	if len(bundled) == 0 {
+		return nil
+	}
+
+	var epoch time.Time
+	tles := make([]domain.TLE, len(bundled))
+	for i, tle := range bundled {
+		tles[i] = domain.TLE{
+			ID:      tle.ID,
+			SpaceID: tle.SpaceID,
+			Line1:   tle.Line1,
+			Line2:   tle.Line2,
+			Epoch:   tle.Epoch,
+		}
+		if tle.Epoch.After(epoch) {
+			epoch = tle.Epoch
+		}
+	}
+	if pinnedAt != nil {
+		epoch = *pinnedAt
+	}
+
+	pinned, err := s.tleRepo.PinContextTLEVersions(ctx, gameContext.ID, tles, epoch)
+	if err != nil {
+		return fmt.Errorf("failed to pin TLEs of context %s: %w", gameContext.Name, err)
+	}
+	result.TLEs = pinned
+	return nil
+}
+
+// importTiles associates the bundled tiles with the context, creating the ones missing here, and returns
+// the local tile ID of each quadkey.
+func (s *ContextBundleService) importTiles(ctx context.Context, gameContext domain.GameContext, bundled []domain.ContextBundleTile, result *domain.ContextImportResult) (map[string]string, error) {
+	tileIDs := make(map[string]string, len(bundled))
+	now := time.Now().UTC()
+	for _, bundledTile := range bundled {
+		tile, err := s.tileRepo.FindByQuadkey(ctx, bundledTile.Quadkey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up tile %s: %w", bundledTile.Quadkey, err)
+		}
+		if tile == nil {
+			tile = &domain.Tile{
+				ModelBase: domain.ModelBase{ID: uuid.NewString(), CreatedAt: now, UpdatedAt: &now, ProcessedAt: &now, IsActive: true},
+				Quadkey:   bundledTile.Quadkey,
+				ZoomLevel: bundledTile.ZoomLevel,
+				CenterLat: bundledTile.CenterLat,
+				CenterLon: bundledTile.CenterLon,
+				NbFaces:   bundledTile.NbFaces,
+				Radius:    bundledTile.Radius,
+				Vertices:  bundledTile.Vertices,
+			}
+			if err := s.tileRepo.Save(ctx, *tile); err != nil {
+				return nil, fmt.Errorf("failed to create tile %s: %w", bundledTile.Quadkey, err)
+			}
+		}
+		if err := s.tileRepo.AssociateTileWithContext(ctx, gameContext.ID, tile.ID); err != nil {
+			return nil, err
+		}
+		tileIDs[bundledTile.Quadkey] = tile.ID
+		result.Tiles++
+	}
+	return tileIDs, nil
+}
+
+func (s *ContextBundleService) importMappings(ctx context.Context, gameContext domain.GameContext, bundled []domain.ContextBundleMapping, tileIDs map[string]string, result *domain.ContextImportResult) error {
+	now := time.Now().UTC()
+	computationID := uuid.NewString()
+	var mappings []domain.TileSatelliteMapping
+	for _, m := range bundled {
+		tileID, ok := tileIDs[m.Quadkey]
+		if !ok {
+			log.Debugf("Skipping mapping of SPACE ID %s in context %s: tile %s is not bundled", m.SpaceID, gameContext.Name, m.Quadkey)
+			continue
+		}
+		mappings = append(mappings, domain.TileSatelliteMapping{
+			ModelBase:             domain.ModelBase{CreatedAt: now, UpdatedAt: &now, ProcessedAt: &now, IsActive: true},
+			ContextID:             gameContext.ID,
+			SpaceID:               m.SpaceID,
+			TileID:                tileID,
+			IntersectionLatitude:  m.IntersectionLatitude,
+			IntersectionLongitude: m.IntersectionLongitude,
+			IntersectedAt:         m.IntersectedAt,
+			EnteredAt:             m.EnteredAt,
+			ExitedAt:              m.ExitedAt,
+			DurationSeconds:       m.DurationSeconds,
+			ComputationID:         computationID,
+		})
+	}
+	if err := s.mappingRepo.SaveBatch(ctx, mappings); err != nil {
+		return fmt.Errorf("failed to store mappings: %w", err)
+	}
+	result.Mappings = len(mappings)
+	return nil
+}
+
+// bundleSections lists the files of a tar bundle and the part of the bundle each one holds.
+func bundleSections(bundle *domain.ContextBundle) []struct {
+	name  string
+	value interface{}
+} {
+	return []struct {
+		name  string
+		value interface{}
+	}{
+		{"manifest.json", &struct {
+			Version    *int                         `json:"version"`
+			ExportedAt *time.Time                   `json:"exportedAt"`
+			Context    *domain.ContextBundleContext `json:"context"`
+		}{&bundle.Version, &bundle.ExportedAt, &bundle.Context}},
+		{"satellites.json", &bundle.Satellites},
+		{"tles.json", &bundle.TLEs},
+		{"tiles.json", &bundle.Tiles},
+		{"mappings.json", &bundle.Mappings},
+	}
+}
+
+// EncodeContextBundle writes a bundle in the given format.
+func EncodeContextBundle(w io.Writer, bundle domain.ContextBundle, format ContextBundleFormat) error {
+	switch format {
+	case "", ContextBundleFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(bundle)
+	case ContextBundleFormatTar:
+	default:
+		return fmt.Errorf("%w: format %s", domain.ErrUnsupportedBundle, format)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, section := range bundleSections(&bundle) {
+		if section.name == "mappings.json" && bundle.Mappings == nil {
+			continue
+		}
+		data, err := json.MarshalIndent(section.value, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to serialize %s: %w", section.name, err)
+		}
+		header := &tar.Header{Name: section.name, Mode: 0o644, Size: int64(len(data)), ModTime: bundle.ExportedAt}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// boundedReader fails with ErrContextBundleTooLarge once more than limit bytes were read.
+type boundedReader struct {
+	r     io.Reader
+	limit int64
+	read  int64
+}
+
+func newBoundedReader(r io.Reader, limit int64) *boundedReader {
+	return &boundedReader{r: io.LimitReader(r, limit+1), limit: limit}
+}
+
+func (b *boundedReader) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	b.read += int64(n)
+	if b.read > b.limit {
+		return n, fmt.Errorf("%w: more than %d bytes", domain.ErrContextBundleTooLarge, b.limit)
+	}
+	return n, err
+}
+
+// decodeError reports a bundle that cannot be read, keeping ErrContextBundleTooLarge distinguishable.
+func decodeError(err error) error {
+	if errors.Is(err, domain.ErrContextBundleTooLarge) {
+		return err
+	}
+	return fmt.Errorf("%w: %v", domain.ErrUnsupportedBundle, err)
+}
+
+// DecodeContextBundle reads a bundle written by EncodeContextBundle, in either format. Bundles larger than
+// MaxContextBundleSize, compressed or not, are rejected with ErrContextBundleTooLarge.
+func DecodeContextBundle(r io.Reader) (bundle domain.ContextBundle, err error) {
+	return decodeContextBundle(r, domain.MaxContextBundleSize)
+}
+
+func decodeContextBundle(r io.Reader, limit int64) (bundle domain.ContextBundle, err error) {
+	buffered := bufio.NewReader(newBoundedReader(r, limit))
+	magic, err := buffered.Peek(2)
+	if err != nil {
+		return bundle, decodeError(err)
+	}
+
+	// Gzip streams start with 0x1f 0x8b; anything else is read as JSON.
+	if !bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		// Read to the end so that bytes past the document still count against the limit.
+		raw, err := io.ReadAll(buffered)
+		if err != nil {
+			return bundle, decodeError(err)
+		}
+		if err := json.Unmarshal(raw, &bundle); err != nil {
+			return bundle, decodeError(err)
+		}
+		return bundle, bundle.Validate()
+	}
+
+	gz, err := gzip.NewReader(buffered)
+	if err != nil {
+		return bundle, decodeError(err)
+	}
+	defer gz.Close()
+
+	sections := map[string]interface{}{}
+	for _, section := range bundleSections(&bundle) {
+		sections[section.name] = section.value
+	}
+
+	tr := tar.NewReader(newBoundedReader(gz, limit))
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return bundle, decodeError(err)
+		}
+		target, ok := sections[header.Name]
+		if !ok {
+			continue
+		}
+		if err := json.NewDecoder(tr).Decode(target); err != nil {
+			return bundle, decodeError(fmt.Errorf("%s: %w", header.Name, err))
+		}
+	}
+	return bundle, bundle.Validate()
+}
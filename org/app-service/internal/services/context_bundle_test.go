@@ -0,0 +1,449 @@
+package services
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	fx "github.com/org/2112-space-lab/org/app-service/pkg/option"
+	"github.com/org/2112-space-lab/org/go-utils/pkg/fx/xpolygon"
+	"gorm.io/gorm"
+)
+
+func testContextBundle() domain.ContextBundle {
+	exportedAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	pinnedAt := exportedAt.Add(-time.Hour)
+	return domain.ContextBundle{
+		Version:    domain.ContextBundleVersion,
+		ExportedAt: exportedAt,
+		Context: domain.ContextBundleContext{
+			Name:        "session",
+			DisplayName: "Session",
+			Description: "bundled",
+			TlePinnedAt: &pinnedAt,
+		},
+		Satellites: []string{"25544", "99999"},
+		TLEs: []domain.ContextBundleTLE{
+			{ID: "tle-1", SpaceID: "25544", Line1: "1 25544U", Line2: "2 25544", Epoch: pinnedAt.Add(-time.Hour)},
+		},
+		Tiles: []domain.ContextBundleTile{
+			{Quadkey: "120", ZoomLevel: 3, CenterLat: 10, CenterLon: 20, NbFaces: 4, Radius: 1000, Vertices: []xpolygon.Point{{Latitude: 1, Longitude: 2}}},
+		},
+		Mappings: []domain.ContextBundleMapping{
+			{SpaceID: "25544", Quadkey: "120", IntersectedAt: pinnedAt, EnteredAt: pinnedAt, ExitedAt: pinnedAt.Add(time.Minute), DurationSeconds: 60},
+		},
+	}
+}
+
+func TestContextBundleRoundTrip(t *testing.T) {
+	withoutMappings := testContextBundle()
+	withoutMappings.Mappings = nil
+
+	tests := []struct {
+		name   string
+		format ContextBundleFormat
+		bundle domain.ContextBundle
+	}{
+		{name: "JSON", format: ContextBundleFormatJSON, bundle: testContextBundle()},
+		{name: "Tar", format: ContextBundleFormatTar, bundle: testContextBundle()},
+		{name: "Tar without mappings", format: ContextBundleFormatTar, bundle: withoutMappings},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := EncodeContextBundle(&buf, tt.bundle, tt.format); err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+			decoded, err := DecodeContextBundle(&buf)
+			if err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+			if !reflect.DeepEqual(decoded, tt.bundle) {
+				t.Errorf("Expected %+v, but got %+v", tt.bundle, decoded)
+			}
+		})
+	}
+}
+
+func TestDecodeContextBundleLimits(t *testing.T) {
+	var plain bytes.Buffer
+	if err := EncodeContextBundle(&plain, testContextBundle(), ContextBundleFormatJSON); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	var compressed bytes.Buffer
+	if err := EncodeContextBundle(&compressed, testContextBundle(), ContextBundleFormatTar); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	// A small archive inflating past the limit.
+	var bomb bytes.Buffer
+	gz := gzip.NewWriter(&bomb)
+	tw := tar.NewWriter(gz)
+	padding := bytes.Repeat([]byte(" "), 64<<10)
+	if err := tw.WriteHeader(&tar.Header{Name: "tiles.json", Mode: 0o644, Size: int64(len(padding))}); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if _, err := tw.Write(padding); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	tw.Close()
+	gz.Close()
+
+	tests := []struct {
+		name     string
+		body     []byte
+		limit    int64
+		expected error
+	}{
+		{name: "JSON within the limit", body: plain.Bytes(), limit: int64(plain.Len()), expected: nil},
+		{name: "JSON above the limit", body: plain.Bytes(), limit: int64(plain.Len()) - 1, expected: domain.ErrContextBundleTooLarge},
+		{name: "Tar within the limit", body: compressed.Bytes(), limit: 16 << 10, expected: nil},
+		{name: "Tar inflating above the limit", body: bomb.Bytes(), limit: 16 << 10, expected: domain.ErrContextBundleTooLarge},
+		{name: "Garbage", body: []byte("not a bundle"), limit: 16 << 10, expected: domain.ErrUnsupportedBundle},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodeContextBundle(bytes.NewReader(tt.body), tt.limit)
+			if tt.expected == nil && err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+			if tt.expected != nil && !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, but got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+// bundleStore is the state written by imports, shared by the repository fakes below.
+type bundleStore struct {
+	contexts    map[domain.GameContextName]domain.GameContext
+	satellites  map[string]string                        // Catalogue: SPACE ID to satellite ID
+	assignments map[string][]string                      // Context ID to satellite IDs
+	pins        map[string][]domain.TLE                  // Context ID to pinned TLEs
+	tiles       map[string]domain.Tile                   // Quadkey to tile
+	contextTile map[string][]string                      // Context ID to tile IDs
+	mappings    map[string][]domain.TileSatelliteMapping // Context ID to mappings
+	failTileAt  string                                   // Quadkey whose creation fails
+}
+
+func newBundleStore() *bundleStore {
+	return &bundleStore{
+		contexts:    map[domain.GameContextName]domain.GameContext{},
+		satellites:  map[string]string{"25544": "sat-25544"},
+		assignments: map[string][]string{},
+		pins:        map[string][]domain.TLE{},
+		tiles:       map[string]domain.Tile{},
+		contextTile: map[string][]string{},
+		mappings:    map[string][]domain.TileSatelliteMapping{},
+	}
+}
+
+func (s *bundleStore) clone() *bundleStore {
+	c := *s
+	c.contexts = map[domain.GameContextName]domain.GameContext{}
+	for k, v := range s.contexts {
+		c.contexts[k] = v
+	}
+	c.assignments = map[string][]string{}
+	for k, v := range s.assignments {
+		c.assignments[k] = append([]string(nil), v...)
+	}
+	c.pins = map[string][]domain.TLE{}
+	for k, v := range s.pins {
+		c.pins[k] = append([]domain.TLE(nil), v...)
+	}
+	c.tiles = map[string]domain.Tile{}
+	for k, v := range s.tiles {
+		c.tiles[k] = v
+	}
+	c.contextTile = map[string][]string{}
+	for k, v := range s.contextTile {
+		c.contextTile[k] = append([]string(nil), v...)
+	}
+	c.mappings = map[string][]domain.TileSatelliteMapping{}
+	for k, v := range s.mappings {
+		c.mappings[k] = append([]domain.TileSatelliteMapping(nil), v...)
+	}
+	return &c
+}
+
+// rollbackTransactor restores the store when the transaction fails.
+type rollbackTransactor struct{ store **bundleStore }
+
+func (t rollbackTransactor) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	saved := (*t.store).clone()
+	if err := fn(ctx); err != nil {
+		*t.store = saved
+		return err
+	}
+	return nil
+}
+
+type bundleContextFake struct{ store **bundleStore }
+
+func (f bundleContextFake) FindByUniqueName(ctx context.Context, name domain.GameContextName) (domain.GameContext, error) {
+	gameContext, ok := (*f.store).contexts[name]
+	if !ok {
+		return domain.GameContext{}, fmt.Errorf("context %s not found: %w", name, gorm.ErrRecordNotFound)
+	}
+	return gameContext, nil
+}
+
+func (f bundleContextFake) Save(ctx context.Context, gameContext domain.GameContext) error {
+	(*f.store).contexts[gameContext.Name] = gameContext
+	return nil
+}
+
+func (f bundleContextFake) Update(ctx context.Context, gameContext domain.GameContext) error {
+	(*f.store).contexts[gameContext.Name] = gameContext
+	return nil
+}
+
+func (f bundleContextFake) ClearAssociations(ctx context.Context, name domain.GameContextName) error {
+	store := *f.store
+	id := store.contexts[name].ID
+	delete(store.assignments, id)
+	delete(store.pins, id)
+	delete(store.contextTile, id)
+	return nil
+}
+
+type bundleSatelliteFake struct{ store **bundleStore }
+
+func (f bundleSatelliteFake) FindSatellitesByContext(ctx context.Context, contextID string) ([]domain.Satellite, error) {
+	return nil, nil
+}
+
+func (f bundleSatelliteFake) FindBySpaceID(ctx context.Context, spaceID string) (domain.Satellite, error) {
+	id, ok := (*f.store).satellites[spaceID]
+	if !ok {
+		return domain.Satellite{}, gorm.ErrRecordNotFound
+	}
+	return domain.Satellite{ModelBase: domain.ModelBase{ID: id}, SpaceID: spaceID}, nil
+}
+
+func (f bundleSatelliteFake) AssignSatelliteToContext(ctx context.Context, contextID, satelliteID string) error {
+	(*f.store).assignments[contextID] = append((*f.store).assignments[contextID], satelliteID)
+	return nil
+}
+
+type bundleTLEFake struct{ store **bundleStore }
+
+func (f bundleTLEFake) GetTLEsByContext(ctx context.Context, contextID string) ([]domain.TLE, error) {
+	return nil, nil
+}
+
+func (f bundleTLEFake) GetPinnedTLEs(ctx context.Context, contextID string) ([]domain.TLE, bool, error) {
+	tles, ok := (*f.store).pins[contextID]
+	return tles, ok, nil
+}
+
+func (f bundleTLEFake) PinContextTLEVersions(ctx context.Context, contextID string, tles []domain.TLE, epoch time.Time) (int, error) {
+	(*f.store).pins[contextID] = tles
+	return len(tles), nil
+}
+
+type bundleTileFake struct {
+	domain.TileRepository
+	store **bundleStore
+}
+
+func (f bundleTileFake) FindByQuadkey(ctx context.Context, key string) (*domain.Tile, error) {
+	tile, ok := (*f.store).tiles[key]
+	if !ok {
+		return nil, nil
+	}
+	return &tile, nil
+}
+
+func (f bundleTileFake) Save(ctx context.Context, tile domain.Tile) error {
+	if tile.Quadkey == (*f.store).failTileAt {
+		return errors.New("tile storage unavailable")
+	}
+	(*f.store).tiles[tile.Quadkey] = tile
+	return nil
+}
+
+func (f bundleTileFake) AssociateTileWithContext(ctx context.Context, contextID string, tileID string) error {
+	(*f.store).contextTile[contextID] = append((*f.store).contextTile[contextID], tileID)
+	return nil
+}
+
+type bundleMappingFake struct{ store **bundleStore }
+
+func (f bundleMappingFake) FindAll(ctx context.Context, contextID string) ([]domain.TileSatelliteMapping, error) {
+	return (*f.store).mappings[contextID], nil
+}
+
+func (f bundleMappingFake) DeleteMappingsBySpaceID(ctx context.Context, contextID, spaceID string) error {
+	var kept []domain.TileSatelliteMapping
+	for _, mapping := range (*f.store).mappings[contextID] {
+		if mapping.SpaceID != spaceID {
+			kept = append(kept, mapping)
+		}
+	}
+	(*f.store).mappings[contextID] = kept
+	return nil
+}
+
+func (f bundleMappingFake) SaveBatch(ctx context.Context, mappings []domain.TileSatelliteMapping) error {
+	for _, mapping := range mappings {
+		(*f.store).mappings[mapping.ContextID] = append((*f.store).mappings[mapping.ContextID], mapping)
+	}
+	return nil
+}
+
+// newBundleTestService returns a service over a store holding an existing "session" context with one satellite,
+// one pinned TLE and one mapping.
+func newBundleTestService() (ContextBundleService, **bundleStore) {
+	store := newBundleStore()
+	store.contexts["session"] = domain.GameContext{
+		ModelBase:   domain.ModelBase{ID: "existing"},
+		Name:        "session",
+		Description: fx.NewValueOption(domain.GameContextDescription("existing")),
+		State:       domain.GameContextStateActive,
+	}
+	store.assignments["existing"] = []string{"sat-old"}
+	store.pins["existing"] = []domain.TLE{{SpaceID: "old"}}
+	store.mappings["existing"] = []domain.TileSatelliteMapping{{ContextID: "existing", SpaceID: "old"}}
+
+	ref := &store
+	return NewContextBundleService(
+		bundleContextFake{ref},
+		bundleSatelliteFake{ref},
+		bundleTLEFake{ref},
+		bundleTileFake{store: ref},
+		bundleMappingFake{ref},
+		rollbackTransactor{ref},
+	), ref
+}
+
+func contextNames(store *bundleStore) []string {
+	var names []string
+	for name := range store.contexts {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	return names
+}
+
+func assertExistingUntouched(t *testing.T, store *bundleStore) {
+	t.Helper()
+	existing := store.contexts["session"]
+	if existing.ID != "existing" || existing.Description.Value != "existing" || existing.State != domain.GameContextStateActive {
+		t.Errorf("Expected the existing context to be untouched, but got %+v", existing)
+	}
+	if !reflect.DeepEqual(store.assignments["existing"], []string{"sat-old"}) {
+		t.Errorf("Expected the existing satellites to be kept, but got %v", store.assignments["existing"])
+	}
+	if len(store.pins["existing"]) != 1 || store.pins["existing"][0].SpaceID != "old" {
+		t.Errorf("Expected the existing pins to be kept, but got %v", store.pins["existing"])
+	}
+	if len(store.mappings["existing"]) != 1 {
+		t.Errorf("Expected the existing mappings to be kept, but got %v", store.mappings["existing"])
+	}
+}
+
+func TestContextBundleImportFail(t *testing.T) {
+	service, store := newBundleTestService()
+
+	_, err := service.Import(context.Background(), testContextBundle(), domain.ContextImportOptions{Strategy: domain.ContextConflictFail})
+	if !errors.Is(err, domain.ErrContextExists) {
+		t.Fatalf("Expected ErrContextExists, but got %v", err)
+	}
+	if names := contextNames(*store); !reflect.DeepEqual(names, []string{"session"}) {
+		t.Errorf("Expected [session], but got %v", names)
+	}
+	assertExistingUntouched(t, *store)
+}
+
+func TestContextBundleImportSkip(t *testing.T) {
+	service, store := newBundleTestService()
+
+	result, err := service.Import(context.Background(), testContextBundle(), domain.ContextImportOptions{Strategy: domain.ContextConflictSkip})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if !result.Skipped || result.Context.ID != "existing" {
+		t.Errorf("Expected the existing context to be skipped, but got %+v", result)
+	}
+	assertExistingUntouched(t, *store)
+}
+
+func TestContextBundleImportRename(t *testing.T) {
+	service, store := newBundleTestService()
+
+	result, err := service.Import(context.Background(), testContextBundle(), domain.ContextImportOptions{Strategy: domain.ContextConflictRename})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if result.Context.Name != "session-copy-1" {
+		t.Errorf("Expected session-copy-1, but got %v", result.Context.Name)
+	}
+	if names := contextNames(*store); !reflect.DeepEqual(names, []string{"session", "session-copy-1"}) {
+		t.Errorf("Expected [session session-copy-1], but got %v", names)
+	}
+	assertExistingUntouched(t, *store)
+
+	copyID := result.Context.ID
+	if !reflect.DeepEqual((*store).assignments[copyID], []string{"sat-25544"}) {
+		t.Errorf("Expected [sat-25544], but got %v", (*store).assignments[copyID])
+	}
+	if !reflect.DeepEqual(result.MissingSatellites, []string{"99999"}) {
+		t.Errorf("Expected [99999], but got %v", result.MissingSatellites)
+	}
+}
+
+func TestContextBundleImportReplace(t *testing.T) {
+	service, store := newBundleTestService()
+	bundle := testContextBundle()
+
+	result, err := service.Import(context.Background(), bundle, domain.ContextImportOptions{Strategy: domain.ContextConflictReplace, IncludeMappings: true})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	replaced := (*store).contexts["session"]
+	if replaced.ID != "existing" {
+		t.Errorf("Expected the context to keep its ID, but got %v", replaced.ID)
+	}
+	if replaced.State != domain.GameContextStateDraft || replaced.Description.Value != "bundled" {
+		t.Errorf("Expected a bundled draft, but got %+v", replaced)
+	}
+	if !reflect.DeepEqual((*store).assignments["existing"], []string{"sat-25544"}) {
+		t.Errorf("Expected [sat-25544], but got %v", (*store).assignments["existing"])
+	}
+	pins := (*store).pins["existing"]
+	if len(pins) != 1 || pins[0].SpaceID != "25544" || !pins[0].Epoch.Equal(bundle.TLEs[0].Epoch) {
+		t.Errorf("Expected the bundled TLE to be pinned, but got %v", pins)
+	}
+	mappings := (*store).mappings["existing"]
+	if len(mappings) != 1 || mappings[0].SpaceID != "25544" {
+		t.Errorf("Expected the bundled mapping only, but got %v", mappings)
+	}
+	if result.Satellites != 1 || result.TLEs != 1 || result.Tiles != 1 || result.Mappings != 1 {
+		t.Errorf("Expected one of each, but got %+v", result)
+	}
+}
+
+func TestContextBundleImportReplaceRollsBack(t *testing.T) {
+	service, store := newBundleTestService()
+	(*store).failTileAt = "120"
+
+	_, err := service.Import(context.Background(), testContextBundle(), domain.ContextImportOptions{Strategy: domain.ContextConflictReplace, IncludeMappings: true})
+	if err == nil || !strings.Contains(err.Error(), "tile storage unavailable") {
+		t.Fatalf("Expected the tile failure, but got %v", err)
+	}
+	assertExistingUntouched(t, *store)
+}
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	repository "github.com/org/2112-space-lab/org/app-service/internal/repositories"
+	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
+	"github.com/org/2112-space-lab/org/app-service/pkg/tracing"
+)
+
+// contextMembers resolves the satellites of a context from its membership rules and manual assignments.
+type contextMembers interface {
+	ResolveMembers(ctx context.Context, gameContext domain.GameContext, reason string) ([]string, error)
+}
+
+// satelliteImporter refreshes catalogue entries from CelesTrak.
+type satelliteImporter interface {
+	FetchAndStoreSatellites(ctx context.Context, spaceIDs []string) ([]domain.Satellite, error)
+}
+
+// tleImporter fetches the TLEs of a CelesTrak category for a context.
+type tleImporter interface {
+	FetchTLEFromSatCatByCategory(ctx context.Context, category string, contextName domain.GameContextName) ([]domain.TLE, error)
+}
+
+// tleStore stores imported TLEs.
+type tleStore interface {
+	UpdateTleBatch(ctx context.Context, tles []domain.TLE) error
+}
+
+// lifecycleSettings provides the tunables of the context scheduler.
+type lifecycleSettings interface {
+	GetContextPreparationLeadTime(ctx context.Context, defaultValue time.Duration) (time.Duration, error)
+	GetContextImportTleCategory(ctx context.Context, defaultValue string) (string, error)
+	GetContextImportMaxCount(ctx context.Context, defaultValue int64) (int64, error)
+}
+
+// ContextLifecycleService runs the schedule of game contexts: it prepares scheduled contexts ahead of their
+// activation, activates them on time and pauses running contexts when their deactivation time is reached.
+type ContextLifecycleService struct {
+	contextService   *ContextService
+	contextRepo      domain.GameContextRepository
+	members          contextMembers
+	tleService       tleImporter
+	tleRepo          tleStore
+	satelliteService satelliteImporter
+	globalPropRepo   lifecycleSettings
+}
+
+// NewContextLifecycleService creates a new instance of ContextLifecycleService.
+func NewContextLifecycleService(
+	contextService *ContextService,
+	contextRepo domain.GameContextRepository,
+	members contextMembers,
+	tleService tleImporter,
+	tleRepo tleStore,
+	satelliteService satelliteImporter,
+	globalPropRepo lifecycleSettings,
+) ContextLifecycleService {
+	return ContextLifecycleService{
+		contextService:   contextService,
+		contextRepo:      contextRepo,
+		members:          members,
+		tleService:       tleService,
+		tleRepo:          tleRepo,
+		satelliteService: satelliteService,
+		globalPropRepo:   globalPropRepo,
+	}
+}
+
+// RunDue acts on every context of every tenant whose schedule is due at now. A failing context does not stop
+// the others; all failures are returned together.
+func (s *ContextLifecycleService) RunDue(ctx context.Context, now time.Time) (err error) {
+	ctx, span := tracing.NewSpan(ctx, "RunDue")
+	defer span.EndWithError(err)
+
+	leadTime, leadErr := s.globalPropRepo.GetContextPreparationLeadTime(ctx, repository.DefaultContextPreparationLeadTime)
+	if leadErr != nil {
+		log.Tracef("Using default context preparation lead time [%s]: %v", leadTime, leadErr)
+	}
+
+	var errs []error
+	upcoming, err := s.contextRepo.FindDueForActivation(ctx, now.Add(leadTime))
+	if err != nil {
+		return err
+	}
+	for _, gameContext := range upcoming {
+		tenantCtx := domain.WithTenant(ctx, gameContext.TenantID)
+		if err := s.Prepare(tenantCtx, gameContext); err != nil {
+			errs = append(errs, err)
+		}
+		if activateAt := gameContext.ScheduledActivationAt; activateAt.HasValue && !activateAt.Value.Inner().After(now) {
+			if err := s.activate(tenantCtx, gameContext); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	expired, err := s.contextRepo.FindDueForDeactivation(ctx, now)
+	if err != nil {
+		return errors.Join(append(errs, err)...)
+	}
+	for _, gameContext := range expired {
+		tenantCtx := domain.WithTenant(ctx, gameContext.TenantID)
+		if err := s.contextService.Transition(tenantCtx, gameContext.Name, domain.GameContextStatePaused, "scheduled deactivation"); err != nil {
+			errs = append(errs, fmt.Errorf("failed to deactivate context %s: %w", gameContext.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Prepare imports the catalogue entries and TLEs of the satellites of a context that have not been imported since
+// it was scheduled, stamping the matching trigger time after each import. The satellites are resolved from the
+// membership rules and manual assignments of the context; a context without satellites is prepared again on the
+// next run.
+func (s *ContextLifecycleService) Prepare(ctx context.Context, gameContext domain.GameContext) (err error) {
+	ctx, span := tracing.NewSpan(ctx, "Prepare")
+	defer span.EndWithError(err)
+
+	if gameContext.TriggerImportedSatelliteAt.HasValue && gameContext.TriggerImportedTLEAt.HasValue {
+		return nil
+	}
+
+	maxCount, countErr := s.globalPropRepo.GetContextImportMaxCount(ctx, repository.DefaultContextImportMaxCount)
+	if countErr != nil {
+		log.Tracef("Using default context import max count [%d]: %v", maxCount, countErr)
+	}
+
+	spaceIDs, err := s.members.ResolveMembers(ctx, gameContext, "scheduled preparation")
+	if err != nil {
+		return fmt.Errorf("failed to resolve satellites of context %s: %w", gameContext.Name, err)
+	}
+	if len(spaceIDs) == 0 {
+		log.Warnf("Context %s has no satellites to prepare yet", gameContext.Name)
+		return nil
+	}
+	if len(spaceIDs) > int(maxCount) {
+		log.Warnf("Context %s has %d satellites, preparing the first %d", gameContext.Name, len(spaceIDs), maxCount)
+		spaceIDs = spaceIDs[:maxCount]
+	}
+
+	if !gameContext.TriggerImportedSatelliteAt.HasValue {
+		log.Infof("🛰 Importing %d satellites for context %s", len(spaceIDs), gameContext.Name)
+		if _, err = s.satelliteService.FetchAndStoreSatellites(ctx, spaceIDs); err != nil {
+			return fmt.Errorf("failed to import satellites for context %s: %w", gameContext.Name, err)
+		}
+		if err = s.contextRepo.SetTriggerImportedSatelliteAt(ctx, gameContext.Name, time.Now().UTC()); err != nil {
+			return err
+		}
+	}
+
+	if !gameContext.TriggerImportedTLEAt.HasValue {
+		category, categoryErr := s.globalPropRepo.GetContextImportTleCategory(ctx, repository.DefaultContextImportTleCategory)
+		if categoryErr != nil {
+			log.Tracef("Using default context import TLE category [%s]: %v", category, categoryErr)
+		}
+
+		log.Infof("📡 Importing TLEs of category %s for context %s", category, gameContext.Name)
+		fetched, err := s.tleService.FetchTLEFromSatCatByCategory(ctx, category, gameContext.Name)
+		if err != nil {
+			return fmt.Errorf("failed to import TLEs for context %s: %w", gameContext.Name, err)
+		}
+		tles := contextTLEs(fetched, spaceIDs)
+		if missing := len(spaceIDs) - len(tles); missing > 0 {
+			log.Warnf("No TLE of category %s for %d satellites of context %s", category, missing, gameContext.Name)
+		}
+		if len(tles) > 0 {
+			if err = s.tleRepo.UpdateTleBatch(ctx, tles); err != nil {
+				return fmt.Errorf("failed to store TLEs for context %s: %w", gameContext.Name, err)
+			}
+		}
+		if err = s.contextRepo.SetTriggerImportedTLEAt(ctx, gameContext.Name, time.Now().UTC()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// contextTLEs keeps the TLEs of the given SPACE IDs, one per satellite.
+func contextTLEs(tles []domain.TLE, spaceIDs []string) []domain.TLE {
+	wanted := make(map[string]bool, len(spaceIDs))
+	for _, spaceID := range spaceIDs {
+		wanted[spaceID] = true
+	}
+	var kept []domain.TLE
+	for _, tle := range tles {
+		if wanted[tle.SpaceID] {
+			kept = append(kept, tle)
+			delete(wanted, tle.SpaceID)
+		}
+	}
+	return kept
+}
+
+// activate starts a scheduled context and requests the generation of its mappings, which rehydrates the
+// context so its satellites are propagated and mapped.
+func (s *ContextLifecycleService) activate(ctx context.Context, gameContext domain.GameContext) error {
+	if err := s.contextService.Transition(ctx, gameContext.Name, domain.GameContextStateActive, "scheduled activation"); err != nil {
+		return fmt.Errorf("failed to activate context %s: %w", gameContext.Name, err)
+	}
+
+	if err := s.contextService.Rehydrate(ctx, gameContext.Name); err != nil {
+		return fmt.Errorf("failed to request mapping generation for context %s: %w", gameContext.Name, err)
+	}
+	return s.contextRepo.SetTriggerGeneratedMappingAt(ctx, gameContext.Name, time.Now().UTC())
+}
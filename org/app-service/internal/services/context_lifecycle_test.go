@@ -0,0 +1,490 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/clients/broker"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	"github.com/org/2112-space-lab/org/app-service/internal/events"
+	fx "github.com/org/2112-space-lab/org/app-service/pkg/option"
+	xtime "github.com/org/2112-space-lab/org/app-service/pkg/time"
+)
+
+// memoryContextRepository keeps contexts in memory. Methods the lifecycle does not use are left to the embedded
+// interface and panic when called.
+type memoryContextRepository struct {
+	domain.GameContextRepository
+	mu       sync.Mutex
+	contexts map[domain.GameContextName]domain.GameContext
+}
+
+func newMemoryContextRepository(contexts ...domain.GameContext) *memoryContextRepository {
+	repo := &memoryContextRepository{contexts: make(map[domain.GameContextName]domain.GameContext)}
+	for _, gameContext := range contexts {
+		repo.contexts[gameContext.Name] = gameContext
+	}
+	return repo
+}
+
+func (r *memoryContextRepository) get(name domain.GameContextName) domain.GameContext {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.contexts[name]
+}
+
+func (r *memoryContextRepository) update(name domain.GameContextName, apply func(*domain.GameContext)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	gameContext, ok := r.contexts[name]
+	if !ok {
+		return fmt.Errorf("context %s not found", name)
+	}
+	apply(&gameContext)
+	r.contexts[name] = gameContext
+	return nil
+}
+
+func (r *memoryContextRepository) FindByUniqueName(ctx context.Context, name domain.GameContextName) (domain.GameContext, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	gameContext, ok := r.contexts[name]
+	if !ok {
+		return domain.GameContext{}, fmt.Errorf("context %s not found", name)
+	}
+	return gameContext, nil
+}
+
+func (r *memoryContextRepository) Transition(ctx context.Context, name domain.GameContextName, from, to domain.GameContextState, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	gameContext, ok := r.contexts[name]
+	if !ok || gameContext.State != from {
+		return fmt.Errorf("context %s is no longer %s: %w", name, from, domain.ErrInvalidContextTransition)
+	}
+	gameContext.State = to
+	switch {
+	case to == domain.GameContextStateActive:
+		gameContext.ActivatedAt = xtime.ToUtcTime(&at)
+		gameContext.ScheduledActivationAt = fx.NewEmptyOption[xtime.UtcTime]()
+	case from == domain.GameContextStateActive:
+		gameContext.DesactivatedAt = xtime.ToUtcTime(&at)
+		gameContext.ScheduledDeactivationAt = fx.NewEmptyOption[xtime.UtcTime]()
+	}
+	if to == domain.GameContextStateDraft || to == domain.GameContextStateArchived {
+		gameContext.ScheduledActivationAt = fx.NewEmptyOption[xtime.UtcTime]()
+		gameContext.ScheduledDeactivationAt = fx.NewEmptyOption[xtime.UtcTime]()
+	}
+	r.contexts[name] = gameContext
+	return nil
+}
+
+func (r *memoryContextRepository) SetSchedule(ctx context.Context, name domain.GameContextName, activateAt, deactivateAt *time.Time) error {
+	return r.update(name, func(gameContext *domain.GameContext) {
+		gameContext.ScheduledActivationAt = xtime.ToUtcTime(activateAt)
+		gameContext.ScheduledDeactivationAt = xtime.ToUtcTime(deactivateAt)
+	})
+}
+
+func (r *memoryContextRepository) FindDueForActivation(ctx context.Context, before time.Time) ([]domain.GameContext, error) {
+	return r.findDue(domain.GameContextStateScheduled, before, func(gameContext domain.GameContext) fx.Option[xtime.UtcTime] {
+		return gameContext.ScheduledActivationAt
+	}), nil
+}
+
+func (r *memoryContextRepository) FindDueForDeactivation(ctx context.Context, before time.Time) ([]domain.GameContext, error) {
+	return r.findDue(domain.GameContextStateActive, before, func(gameContext domain.GameContext) fx.Option[xtime.UtcTime] {
+		return gameContext.ScheduledDeactivationAt
+	}), nil
+}
+
+func (r *memoryContextRepository) findDue(state domain.GameContextState, before time.Time, at func(domain.GameContext) fx.Option[xtime.UtcTime]) []domain.GameContext {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var due []domain.GameContext
+	for _, gameContext := range r.contexts {
+		if scheduled := at(gameContext); gameContext.State == state && scheduled.HasValue && !scheduled.Value.Inner().After(before) {
+			due = append(due, gameContext)
+		}
+	}
+	return due
+}
+
+func (r *memoryContextRepository) setTrigger(name domain.GameContextName, field func(*domain.GameContext) *fx.Option[xtime.UtcTime], at *time.Time) error {
+	return r.update(name, func(gameContext *domain.GameContext) {
+		*field(gameContext) = xtime.ToUtcTime(at)
+	})
+}
+
+func generatedMapping(gameContext *domain.GameContext) *fx.Option[xtime.UtcTime] {
+	return &gameContext.TriggerGeneratedMappingAt
+}
+
+func importedTLE(gameContext *domain.GameContext) *fx.Option[xtime.UtcTime] {
+	return &gameContext.TriggerImportedTLEAt
+}
+
+func importedSatellite(gameContext *domain.GameContext) *fx.Option[xtime.UtcTime] {
+	return &gameContext.TriggerImportedSatelliteAt
+}
+
+func (r *memoryContextRepository) SetTriggerGeneratedMappingAt(ctx context.Context, name domain.GameContextName, timestamp time.Time) error {
+	return r.setTrigger(name, generatedMapping, &timestamp)
+}
+
+func (r *memoryContextRepository) UnsetTriggerGeneratedMappingAt(ctx context.Context, name domain.GameContextName) error {
+	return r.setTrigger(name, generatedMapping, nil)
+}
+
+func (r *memoryContextRepository) SetTriggerImportedTLEAt(ctx context.Context, name domain.GameContextName, timestamp time.Time) error {
+	return r.setTrigger(name, importedTLE, &timestamp)
+}
+
+func (r *memoryContextRepository) UnsetTriggerImportedTLEAt(ctx context.Context, name domain.GameContextName) error {
+	return r.setTrigger(name, importedTLE, nil)
+}
+
+func (r *memoryContextRepository) SetTriggerImportedSatelliteAt(ctx context.Context, name domain.GameContextName, timestamp time.Time) error {
+	return r.setTrigger(name, importedSatellite, &timestamp)
+}
+
+func (r *memoryContextRepository) UnsetTriggerImportedSatelliteAt(ctx context.Context, name domain.GameContextName) error {
+	return r.setTrigger(name, importedSatellite, nil)
+}
+
+// recordingEventStore records the events broadcast by an emitter.
+type recordingEventStore struct {
+	mu     sync.Mutex
+	events []domain.Event
+}
+
+func (s *recordingEventStore) Save(ctx context.Context, event domain.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *recordingEventStore) types() []domain.EventType {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	types := make([]domain.EventType, len(s.events))
+	for i, event := range s.events {
+		types[i] = event.EventType
+	}
+	return types
+}
+
+type discardHandlerLogs struct{}
+
+func (discardHandlerLogs) Save(ctx context.Context, handler domain.EventHandler) error { return nil }
+
+type discardBroker struct{}
+
+func (discardBroker) Publish(ctx context.Context, body []byte, headers *broker.Header) error {
+	return nil
+}
+
+func (discardBroker) Subscribe(ctx context.Context, filter *broker.Header) (<-chan broker.Message, error) {
+	return nil, errors.New("not supported")
+}
+
+func (discardBroker) Close() error { return nil }
+
+// newRecordingEmitter returns an emitter whose published events are recorded by the returned store.
+func newRecordingEmitter(t *testing.T) (*events.EventEmitter, *recordingEventStore) {
+	t.Helper()
+	store := &recordingEventStore{}
+	emitter, err := events.NewEventEmitter(context.Background(), discardBroker{}, events.NewEventProcessor(store, discardHandlerLogs{}), nil, events.DefaultEnvelope())
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	return emitter, store
+}
+
+type staticMembers map[domain.GameContextName][]string
+
+func (m staticMembers) ResolveMembers(ctx context.Context, gameContext domain.GameContext, reason string) ([]string, error) {
+	return m[gameContext.Name], nil
+}
+
+type recordingSatelliteImporter struct {
+	imported [][]string
+}
+
+func (i *recordingSatelliteImporter) FetchAndStoreSatellites(ctx context.Context, spaceIDs []string) ([]domain.Satellite, error) {
+	i.imported = append(i.imported, spaceIDs)
+	return nil, nil
+}
+
+type staticTLEImporter []string
+
+func (i staticTLEImporter) FetchTLEFromSatCatByCategory(ctx context.Context, category string, contextName domain.GameContextName) ([]domain.TLE, error) {
+	tles := make([]domain.TLE, len(i))
+	for idx, spaceID := range i {
+		tles[idx] = domain.TLE{SpaceID: spaceID}
+	}
+	return tles, nil
+}
+
+type recordingTLEStore struct {
+	stored [][]string
+}
+
+func (s *recordingTLEStore) UpdateTleBatch(ctx context.Context, tles []domain.TLE) error {
+	spaceIDs := make([]string, len(tles))
+	for i, tle := range tles {
+		spaceIDs[i] = tle.SpaceID
+	}
+	s.stored = append(s.stored, spaceIDs)
+	return nil
+}
+
+type defaultLifecycleSettings struct{}
+
+func (defaultLifecycleSettings) GetContextPreparationLeadTime(ctx context.Context, defaultValue time.Duration) (time.Duration, error) {
+	return defaultValue, nil
+}
+
+func (defaultLifecycleSettings) GetContextImportTleCategory(ctx context.Context, defaultValue string) (string, error) {
+	return defaultValue, nil
+}
+
+func (defaultLifecycleSettings) GetContextImportMaxCount(ctx context.Context, defaultValue int64) (int64, error) {
+	return defaultValue, nil
+}
+
+func utcOption(t time.Time) fx.Option[xtime.UtcTime] {
+	return xtime.ToUtcTime(&t)
+}
+
+func TestContextServiceTransition(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryContextRepository(
+		domain.GameContext{Name: "draft", State: domain.GameContextStateDraft},
+		domain.GameContext{Name: "archived", State: domain.GameContextStateArchived},
+	)
+	emitter, store := newRecordingEmitter(t)
+	service := NewContextService(repo, emitter)
+
+	if err := service.Transition(ctx, "draft", domain.GameContextStateActive, "test"); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if state := repo.get("draft").State; state != domain.GameContextStateActive {
+		t.Errorf("Expected %v, but got %v", domain.GameContextStateActive, state)
+	}
+	if !repo.get("draft").ActivatedAt.HasValue {
+		t.Errorf("Expected the activation time to be set")
+	}
+
+	err := service.Transition(ctx, "archived", domain.GameContextStateActive, "test")
+	if !errors.Is(err, domain.ErrInvalidContextTransition) {
+		t.Errorf("Expected ErrInvalidContextTransition, but got %v", err)
+	}
+
+	expected := []domain.EventType{"GAME_CONTEXT_STATE_CHANGED"}
+	if types := store.types(); !reflect.DeepEqual(types, expected) {
+		t.Errorf("Expected %v, but got %v", expected, types)
+	}
+}
+
+func TestContextServiceSchedule(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	activateAt := now.Add(24 * time.Hour)
+	deactivateAt := activateAt.Add(2 * time.Hour)
+
+	t.Run("Draft with activation time", func(t *testing.T) {
+		repo := newMemoryContextRepository(domain.GameContext{
+			Name:                       "session",
+			State:                      domain.GameContextStateDraft,
+			TriggerImportedSatelliteAt: utcOption(now),
+			TriggerImportedTLEAt:       utcOption(now),
+			TriggerGeneratedMappingAt:  utcOption(now),
+		})
+		emitter, store := newRecordingEmitter(t)
+		service := NewContextService(repo, emitter)
+
+		if err := service.Schedule(ctx, "session", &activateAt, &deactivateAt); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		gameContext := repo.get("session")
+		if gameContext.State != domain.GameContextStateScheduled {
+			t.Errorf("Expected %v, but got %v", domain.GameContextStateScheduled, gameContext.State)
+		}
+		if got := xtime.ToTimePointer(gameContext.ScheduledActivationAt); got == nil || !got.Equal(activateAt) {
+			t.Errorf("Expected %v, but got %v", activateAt, got)
+		}
+		if got := xtime.ToTimePointer(gameContext.ScheduledDeactivationAt); got == nil || !got.Equal(deactivateAt) {
+			t.Errorf("Expected %v, but got %v", deactivateAt, got)
+		}
+		if gameContext.TriggerImportedSatelliteAt.HasValue || gameContext.TriggerImportedTLEAt.HasValue || gameContext.TriggerGeneratedMappingAt.HasValue {
+			t.Errorf("Expected the import triggers to be reset, but got %+v", gameContext)
+		}
+		if len(store.types()) != 1 {
+			t.Errorf("Expected 1 event, but got %v", store.types())
+		}
+	})
+
+	t.Run("Deactivation before activation", func(t *testing.T) {
+		repo := newMemoryContextRepository(domain.GameContext{Name: "session", State: domain.GameContextStateDraft})
+		emitter, _ := newRecordingEmitter(t)
+		service := NewContextService(repo, emitter)
+
+		if err := service.Schedule(ctx, "session", &deactivateAt, &activateAt); !errors.Is(err, domain.ErrInvalidContextSchedule) {
+			t.Errorf("Expected ErrInvalidContextSchedule, but got %v", err)
+		}
+	})
+
+	t.Run("Deactivation alone on a draft", func(t *testing.T) {
+		repo := newMemoryContextRepository(domain.GameContext{Name: "session", State: domain.GameContextStateDraft})
+		emitter, _ := newRecordingEmitter(t)
+		service := NewContextService(repo, emitter)
+
+		if err := service.Schedule(ctx, "session", nil, &deactivateAt); !errors.Is(err, domain.ErrInvalidContextSchedule) {
+			t.Errorf("Expected ErrInvalidContextSchedule, but got %v", err)
+		}
+	})
+
+	t.Run("Deactivation alone on a running context", func(t *testing.T) {
+		repo := newMemoryContextRepository(domain.GameContext{Name: "session", State: domain.GameContextStateActive})
+		emitter, store := newRecordingEmitter(t)
+		service := NewContextService(repo, emitter)
+
+		if err := service.Schedule(ctx, "session", nil, &deactivateAt); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		gameContext := repo.get("session")
+		if gameContext.State != domain.GameContextStateActive {
+			t.Errorf("Expected %v, but got %v", domain.GameContextStateActive, gameContext.State)
+		}
+		if got := xtime.ToTimePointer(gameContext.ScheduledDeactivationAt); got == nil || !got.Equal(deactivateAt) {
+			t.Errorf("Expected %v, but got %v", deactivateAt, got)
+		}
+		if len(store.types()) != 0 {
+			t.Errorf("Expected no event, but got %v", store.types())
+		}
+	})
+
+	t.Run("Archived context", func(t *testing.T) {
+		repo := newMemoryContextRepository(domain.GameContext{Name: "session", State: domain.GameContextStateArchived})
+		emitter, _ := newRecordingEmitter(t)
+		service := NewContextService(repo, emitter)
+
+		if err := service.Schedule(ctx, "session", &activateAt, nil); !errors.Is(err, domain.ErrInvalidContextTransition) {
+			t.Errorf("Expected ErrInvalidContextTransition, but got %v", err)
+		}
+	})
+}
+
+func TestContextServiceUnschedule(t *testing.T) {
+	ctx := context.Background()
+	deactivateAt := time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+	activateAt := deactivateAt.Add(-time.Hour)
+	repo := newMemoryContextRepository(
+		domain.GameContext{Name: "scheduled", State: domain.GameContextStateScheduled, ScheduledActivationAt: utcOption(activateAt), ScheduledDeactivationAt: utcOption(deactivateAt)},
+		domain.GameContext{Name: "active", State: domain.GameContextStateActive, ScheduledDeactivationAt: utcOption(deactivateAt)},
+	)
+	emitter, _ := newRecordingEmitter(t)
+	service := NewContextService(repo, emitter)
+
+	for name, expected := range map[domain.GameContextName]domain.GameContextState{
+		"scheduled": domain.GameContextStateDraft,
+		"active":    domain.GameContextStateActive,
+	} {
+		if err := service.Unschedule(ctx, name); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		gameContext := repo.get(name)
+		if gameContext.State != expected {
+			t.Errorf("Expected %v, but got %v", expected, gameContext.State)
+		}
+		if gameContext.ScheduledActivationAt.HasValue || gameContext.ScheduledDeactivationAt.HasValue {
+			t.Errorf("Expected the schedule of %s to be cleared, but got %+v", name, gameContext)
+		}
+	}
+}
+
+func TestContextLifecycleRunDue(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	repo := newMemoryContextRepository(
+		domain.GameContext{Name: "upcoming", State: domain.GameContextStateScheduled, ScheduledActivationAt: utcOption(now.Add(10 * time.Minute))},
+		domain.GameContext{Name: "due", State: domain.GameContextStateScheduled, ScheduledActivationAt: utcOption(now.Add(-time.Minute))},
+		domain.GameContext{Name: "later", State: domain.GameContextStateScheduled, ScheduledActivationAt: utcOption(now.Add(2 * time.Hour))},
+		domain.GameContext{Name: "empty", State: domain.GameContextStateScheduled, ScheduledActivationAt: utcOption(now.Add(5 * time.Minute))},
+		domain.GameContext{Name: "expired", State: domain.GameContextStateActive, ScheduledDeactivationAt: utcOption(now.Add(-time.Minute))},
+	)
+	emitter, store := newRecordingEmitter(t)
+	contextService := NewContextService(repo, emitter)
+	satellites := &recordingSatelliteImporter{}
+	tles := &recordingTLEStore{}
+	service := NewContextLifecycleService(
+		&contextService,
+		repo,
+		staticMembers{"upcoming": {"1"}, "due": {"2", "3"}, "later": {"4"}},
+		staticTLEImporter{"1", "2", "3", "4", "5"},
+		tles,
+		satellites,
+		defaultLifecycleSettings{},
+	)
+
+	if err := service.RunDue(ctx, now); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	expectedStates := map[domain.GameContextName]domain.GameContextState{
+		"upcoming": domain.GameContextStateScheduled,
+		"due":      domain.GameContextStateActive,
+		"later":    domain.GameContextStateScheduled,
+		"empty":    domain.GameContextStateScheduled,
+		"expired":  domain.GameContextStatePaused,
+	}
+	for name, expected := range expectedStates {
+		if state := repo.get(name).State; state != expected {
+			t.Errorf("Expected %s to be %v, but got %v", name, expected, state)
+		}
+	}
+
+	for _, name := range []domain.GameContextName{"upcoming", "due"} {
+		gameContext := repo.get(name)
+		if !gameContext.TriggerImportedSatelliteAt.HasValue || !gameContext.TriggerImportedTLEAt.HasValue {
+			t.Errorf("Expected %s to be prepared, but got %+v", name, gameContext)
+		}
+	}
+	for _, name := range []domain.GameContextName{"later", "empty"} {
+		gameContext := repo.get(name)
+		if gameContext.TriggerImportedSatelliteAt.HasValue || gameContext.TriggerImportedTLEAt.HasValue {
+			t.Errorf("Expected %s not to be prepared, but got %+v", name, gameContext)
+		}
+	}
+	if !repo.get("due").TriggerGeneratedMappingAt.HasValue {
+		t.Errorf("Expected the mapping generation of due to be requested")
+	}
+
+	// Only the satellites of each prepared context are imported, in whichever order the contexts came.
+	imported := map[string]bool{}
+	for _, batch := range append(satellites.imported, tles.stored...) {
+		for _, spaceID := range batch {
+			imported[spaceID] = true
+		}
+	}
+	expectedImported := map[string]bool{"1": true, "2": true, "3": true}
+	if !reflect.DeepEqual(imported, expectedImported) {
+		t.Errorf("Expected %v, but got %v", expectedImported, imported)
+	}
+
+	expectedEvents := map[domain.EventType]int{"GAME_CONTEXT_STATE_CHANGED": 2, "REHYDRATE_GAME_CONTEXT_REQUESTED": 1}
+	events := map[domain.EventType]int{}
+	for _, eventType := range store.types() {
+		events[eventType]++
+	}
+	if !reflect.DeepEqual(events, expectedEvents) {
+		t.Errorf("Expected %v, but got %v", expectedEvents, events)
+	}
+}
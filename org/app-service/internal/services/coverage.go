@@ -0,0 +1,444 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	repository "github.com/org/2112-space-lab/org/app-service/internal/repositories"
+	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
+	fx "github.com/org/2112-space-lab/org/app-service/pkg/option"
+	"github.com/org/2112-space-lab/org/app-service/pkg/tracing"
+)
+
+const (
+	// MaxCoverageSamples caps the number of timeline samples of a single report.
+	MaxCoverageSamples = 10000
+	// DefaultCoverageWindow is the window computed when no bounds are requested and on refresh.
+	DefaultCoverageWindow = 24 * time.Hour
+	// DefaultCoverageStep is the timeline sampling step used by default.
+	DefaultCoverageStep = 10 * time.Minute
+)
+
+// CoverageService computes coverage analytics for game contexts from the stored tile mappings.
+type CoverageService struct {
+	tileRepo      domain.TileRepository
+	mappingRepo   domain.MappingRepository
+	satelliteRepo repository.SatelliteRepository
+	cacheRepo     domain.CoverageCacheRepository
+}
+
+// NewCoverageService creates a new instance of CoverageService.
+func NewCoverageService(
+	tileRepo domain.TileRepository,
+	mappingRepo domain.MappingRepository,
+	satelliteRepo repository.SatelliteRepository,
+	cacheRepo domain.CoverageCacheRepository,
+) CoverageService {
+	return CoverageService{
+		tileRepo:      tileRepo,
+		mappingRepo:   mappingRepo,
+		satelliteRepo: satelliteRepo,
+		cacheRepo:     cacheRepo,
+	}
+}
+
+// DefaultWindow returns the window used when a caller gives no bounds. The start is aligned
+// on the step so repeated requests hit the same cache entry.
+func (s *CoverageService) DefaultWindow(now time.Time, step time.Duration) (time.Time, time.Time) {
+	from := now.UTC().Truncate(step)
+	return from, from.Add(DefaultCoverageWindow)
+}
+
+// GetReport returns the coverage report of a context, served from cache when available.
+func (s *CoverageService) GetReport(ctx context.Context, contextID string, from, to time.Time, step time.Duration) (report domain.CoverageReport, err error) {
+	ctx, span := tracing.NewSpan(ctx, "GetCoverageReport")
+	defer span.EndWithError(err)
+
+	if err = validateCoverageWindow(from, to, step); err != nil {
+		return report, err
+	}
+
+	cached, ok, cacheErr := s.cacheRepo.Get(ctx, contextID, from, to, step)
+	if cacheErr != nil {
+		log.Warnf("⚠️ Failed to read coverage cache for context %s: %v", contextID, cacheErr)
+	}
+	if ok {
+		return cached, nil
+	}
+
+	report, err = s.ComputeReport(ctx, contextID, from, to, step)
+	if err != nil {
+		return report, err
+	}
+	if cacheErr := s.cacheRepo.Save(ctx, report); cacheErr != nil {
+		log.Warnf("⚠️ Failed to cache coverage report for context %s: %v", contextID, cacheErr)
+	}
+	return report, nil
+}
+
+// Refresh drops the cached reports of a context and recomputes the default window from every mapping.
+func (s *CoverageService) Refresh(ctx context.Context, contextID string) (err error) {
+	ctx, span := tracing.NewSpan(ctx, "RefreshCoverage")
+	defer span.EndWithError(err)
+
+	from, to := s.DefaultWindow(time.Now(), DefaultCoverageStep)
+	snapshot, err := s.computeSnapshot(ctx, contextID, from, to)
+	if err != nil {
+		return fmt.Errorf("failed to refresh coverage for context [%s]: %w", contextID, err)
+	}
+	return s.storeSnapshot(ctx, snapshot)
+}
+
+// RefreshSatellite updates the default window of a context with the current passes of a single satellite.
+// Only that satellite's mappings are read; the context is fully refreshed when no snapshot is cached.
+func (s *CoverageService) RefreshSatellite(ctx context.Context, contextID, spaceID string) (err error) {
+	ctx, span := tracing.NewSpan(ctx, "RefreshCoverageSatellite")
+	defer span.EndWithError(err)
+
+	from, to := s.DefaultWindow(time.Now(), DefaultCoverageStep)
+	snapshot, ok, cacheErr := s.cacheRepo.GetSnapshot(ctx, contextID, from, to)
+	if cacheErr != nil {
+		log.Warnf("⚠️ Failed to read coverage snapshot for context %s: %v", contextID, cacheErr)
+	}
+	if !ok {
+		return s.Refresh(ctx, contextID)
+	}
+
+	mappings, err := s.mappingRepo.FindMappingsInWindow(ctx, contextID, domain.MappingWindowFilter{
+		SpaceID: spaceID,
+		From:    fx.NewValueOption(from),
+		To:      fx.NewValueOption(to),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch mappings of SPACE ID [%s] for context [%s]: %w", spaceID, contextID, err)
+	}
+
+	snapshot.Passes = replaceSatellitePasses(snapshot.Passes, spaceID, clipPasses(mappings, from, to))
+	return s.storeSnapshot(ctx, snapshot)
+}
+
+// RefreshForMappings updates the cached coverage of a context once the mappings of a satellite changed, so reports
+// follow the passes computed after a propagation rather than those cached before. Failures are logged: the cached
+// coverage is dropped instead, to be recomputed on the next request.
+func (s *CoverageService) RefreshForMappings(ctx context.Context, contextID, spaceID string) {
+	if err := s.RefreshSatellite(ctx, contextID, spaceID); err != nil {
+		log.Warnf("⚠️ Failed to refresh coverage of context %s after mappings of %s changed: %v", contextID, spaceID, err)
+		if err := s.cacheRepo.Invalidate(ctx, contextID); err != nil {
+			log.Warnf("⚠️ Failed to invalidate coverage cache for context %s: %v", contextID, err)
+		}
+	}
+}
+
+// RefreshForSatellite refreshes the coverage of every running context the satellite belongs to, each within its tenant.
+// A non-empty contextName restricts the refresh to the context of that name in tenantID.
+func (s *CoverageService) RefreshForSatellite(ctx context.Context, spaceID string, tenantID domain.TenantID, contextName string) (err error) {
+	ctx, span := tracing.NewSpan(ctx, "RefreshCoverageForSatellite")
+	defer span.EndWithError(err)
+
+	satellite, err := s.satelliteRepo.FindBySpaceID(ctx, spaceID)
+	if err != nil {
+		return fmt.Errorf("failed to find satellite with SPACE ID [%s]: %w", spaceID, err)
+	}
+
+	contexts, err := s.satelliteRepo.FindContextsBySatellite(ctx, satellite.ID)
+	if err != nil {
+		return fmt.Errorf("failed to find contexts for SPACE ID [%s]: %w", spaceID, err)
+	}
+
+	for _, gameContext := range contexts {
+		if !gameContext.State.IsRunning() {
+			continue
+		}
+		if contextName != "" && (gameContext.TenantID != tenantID || string(gameContext.Name) != contextName) {
+			continue
+		}
+		if err = s.RefreshSatellite(domain.WithTenant(ctx, gameContext.TenantID), gameContext.ID, spaceID); err != nil {
+			return err
+		}
+		log.Debugf("🔄 Coverage refreshed for context %s after update of %s", gameContext.Name, spaceID)
+	}
+	return nil
+}
+
+// ComputeReport computes revisit statistics, the coverage timeline and per-satellite contributions
+// for the tiles of a context over [from, to].
+func (s *CoverageService) ComputeReport(ctx context.Context, contextID string, from, to time.Time, step time.Duration) (report domain.CoverageReport, err error) {
+	ctx, span := tracing.NewSpan(ctx, "ComputeCoverageReport")
+	defer span.EndWithError(err)
+
+	if err = validateCoverageWindow(from, to, step); err != nil {
+		return report, err
+	}
+
+	snapshot, err := s.computeSnapshot(ctx, contextID, from.UTC(), to.UTC())
+	if err != nil {
+		return report, err
+	}
+	return buildCoverageReport(snapshot, step), nil
+}
+
+// computeSnapshot reads the tiles of a context and the passes of every satellite over [from, to].
+func (s *CoverageService) computeSnapshot(ctx context.Context, contextID string, from, to time.Time) (snapshot domain.CoverageSnapshot, err error) {
+	tiles, err := s.tileRepo.GetTilesByContext(ctx, contextID)
+	if err != nil {
+		return snapshot, fmt.Errorf("failed to fetch tiles for context [%s]: %w", contextID, err)
+	}
+
+	mappings, err := s.mappingRepo.FindMappingsInWindow(ctx, contextID, domain.MappingWindowFilter{
+		From: fx.NewValueOption(from),
+		To:   fx.NewValueOption(to),
+	})
+	if err != nil {
+		return snapshot, fmt.Errorf("failed to fetch mappings for context [%s]: %w", contextID, err)
+	}
+
+	snapshot = domain.CoverageSnapshot{ContextID: contextID, From: from, To: to, Passes: clipPasses(mappings, from, to)}
+	for _, tile := range tiles {
+		snapshot.Tiles = append(snapshot.Tiles, domain.CoverageTile{ID: tile.ID, Quadkey: tile.Quadkey})
+	}
+	return snapshot, nil
+}
+
+// storeSnapshot drops the cached reports of the snapshot's context, then caches the snapshot and its default report.
+func (s *CoverageService) storeSnapshot(ctx context.Context, snapshot domain.CoverageSnapshot) error {
+	if err := s.cacheRepo.Invalidate(ctx, snapshot.ContextID); err != nil {
+		return fmt.Errorf("failed to invalidate coverage cache for context [%s]: %w", snapshot.ContextID, err)
+	}
+	if err := s.cacheRepo.SaveSnapshot(ctx, snapshot); err != nil {
+		log.Warnf("⚠️ Failed to cache coverage snapshot for context %s: %v", snapshot.ContextID, err)
+	}
+	if err := s.cacheRepo.Save(ctx, buildCoverageReport(snapshot, DefaultCoverageStep)); err != nil {
+		log.Warnf("⚠️ Failed to cache coverage report for context %s: %v", snapshot.ContextID, err)
+	}
+	return nil
+}
+
+// buildCoverageReport computes the report of a snapshot sampled every step.
+func buildCoverageReport(snapshot domain.CoverageSnapshot, step time.Duration) domain.CoverageReport {
+	from, to := snapshot.From, snapshot.To
+
+	passesByTile := make(map[string][]domain.CoveragePass)
+	for _, p := range snapshot.Passes {
+		passesByTile[p.TileID] = append(passesByTile[p.TileID], p)
+	}
+
+	report := domain.CoverageReport{
+		ContextID:   snapshot.ContextID,
+		From:        from,
+		To:          to,
+		StepSeconds: step.Seconds(),
+		TotalTiles:  len(snapshot.Tiles),
+		ComputedAt:  time.Now().UTC(),
+	}
+
+	merged := make(map[string][]domain.CoveragePass, len(passesByTile))
+	var revisitSum float64
+	var revisitCount int
+	for _, tile := range snapshot.Tiles {
+		passes := passesByTile[tile.ID]
+		merged[tile.ID] = mergePasses(passes)
+
+		stats := revisitStats(tile, merged[tile.ID], from, to)
+		report.Revisits = append(report.Revisits, stats)
+		if stats.PassCount > 0 {
+			report.VisitedTiles++
+		}
+		if stats.PassCount > 1 {
+			revisitSum += stats.MeanRevisitSeconds
+			revisitCount++
+		}
+		if stats.MaxGapSeconds > report.MaxGapSeconds {
+			report.MaxGapSeconds = stats.MaxGapSeconds
+		}
+
+		report.Contributions = append(report.Contributions, contribution(tile, passes))
+	}
+	if revisitCount > 0 {
+		report.MeanRevisitSeconds = revisitSum / float64(revisitCount)
+	}
+
+	report.Timeline = coverageTimeline(merged, len(snapshot.Tiles), from, to, step)
+	return report
+}
+
+// replaceSatellitePasses swaps the passes of spaceID for fresh ones.
+func replaceSatellitePasses(passes []domain.CoveragePass, spaceID string, fresh []domain.CoveragePass) []domain.CoveragePass {
+	kept := make([]domain.CoveragePass, 0, len(passes)+len(fresh))
+	for _, p := range passes {
+		if p.SpaceID != spaceID {
+			kept = append(kept, p)
+		}
+	}
+	return append(kept, fresh...)
+}
+
+func validateCoverageWindow(from, to time.Time, step time.Duration) error {
+	if !from.Before(to) {
+		return fmt.Errorf("from must be before to")
+	}
+	if step <= 0 {
+		return fmt.Errorf("step must be greater than zero")
+	}
+	if to.Sub(from)/step > MaxCoverageSamples {
+		return fmt.Errorf("window too large: more than %d samples requested", MaxCoverageSamples)
+	}
+	return nil
+}
+
+// clipPasses restricts the pass of each mapping to [from, to], dropping those outside of it.
+func clipPasses(mappings []domain.TileSatelliteInfo, from, to time.Time) []domain.CoveragePass {
+	passes := make([]domain.CoveragePass, 0, len(mappings))
+	for _, m := range mappings {
+		if pass, ok := clipPass(m, from, to); ok {
+			passes = append(passes, pass)
+		}
+	}
+	return passes
+}
+
+// clipPass restricts a mapping's pass to [from, to]. Mappings without a pass window fall back
+// to their intersection instant.
+func clipPass(m domain.TileSatelliteInfo, from, to time.Time) (domain.CoveragePass, bool) {
+	start, end := m.EnteredAt.UTC(), m.ExitedAt.UTC()
+	if start.IsZero() || end.IsZero() {
+		start, end = m.IntersectedAt.UTC(), m.IntersectedAt.UTC()
+	}
+	if start.Before(from) {
+		start = from
+	}
+	if end.After(to) {
+		end = to
+	}
+	if end.Before(start) {
+		return domain.CoveragePass{}, false
+	}
+	return domain.CoveragePass{TileID: m.TileID, SpaceID: m.SpaceID, Start: start, End: end}, true
+}
+
+// mergePasses sorts passes and fuses the overlapping ones, so simultaneous accesses by
+// several satellites count as a single visit.
+func mergePasses(passes []domain.CoveragePass) []domain.CoveragePass {
+	if len(passes) == 0 {
+		return nil
+	}
+	sorted := append([]domain.CoveragePass(nil), passes...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start.Before(sorted[j].Start) })
+
+	merged := []domain.CoveragePass{sorted[0]}
+	for _, p := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if !p.Start.After(last.End) {
+			if p.End.After(last.End) {
+				last.End = p.End
+			}
+			continue
+		}
+		merged = append(merged, p)
+	}
+	return merged
+}
+
+func revisitStats(tile domain.CoverageTile, merged []domain.CoveragePass, from, to time.Time) domain.TileRevisitStats {
+	stats := domain.TileRevisitStats{
+		TileID:    tile.ID,
+		Quadkey:   tile.Quadkey,
+		PassCount: len(merged),
+	}
+	if len(merged) == 0 {
+		stats.MaxGapSeconds = to.Sub(from).Seconds()
+		return stats
+	}
+
+	first, last := merged[0].Start, merged[len(merged)-1].End
+	stats.FirstPassAt, stats.LastPassAt = &first, &last
+
+	maxGap := maxDuration(first.Sub(from), to.Sub(last))
+	var gapSum time.Duration
+	for i, p := range merged {
+		stats.AccessSeconds += p.End.Sub(p.Start).Seconds()
+		if i == 0 {
+			continue
+		}
+		gap := p.Start.Sub(merged[i-1].End)
+		gapSum += gap
+		maxGap = maxDuration(maxGap, gap)
+	}
+	if len(merged) > 1 {
+		stats.MeanRevisitSeconds = gapSum.Seconds() / float64(len(merged)-1)
+	}
+	stats.MaxGapSeconds = maxGap.Seconds()
+	return stats
+}
+
+func contribution(tile domain.CoverageTile, passes []domain.CoveragePass) domain.TileContribution {
+	result := domain.TileContribution{TileID: tile.ID, Quadkey: tile.Quadkey}
+
+	bySatellite := make(map[string]*domain.SatelliteContribution)
+	var total float64
+	for _, p := range passes {
+		c, ok := bySatellite[p.SpaceID]
+		if !ok {
+			c = &domain.SatelliteContribution{SpaceID: p.SpaceID}
+			bySatellite[p.SpaceID] = c
+		}
+		seconds := p.End.Sub(p.Start).Seconds()
+		c.PassCount++
+		c.TotalSeconds += seconds
+		total += seconds
+	}
+
+	for _, c := range bySatellite {
+		if total > 0 {
+			c.Share = c.TotalSeconds / total
+		} else {
+			c.Share = float64(c.PassCount) / float64(len(passes))
+		}
+		result.Satellites = append(result.Satellites, *c)
+	}
+	sort.Slice(result.Satellites, func(i, j int) bool {
+		if result.Satellites[i].TotalSeconds != result.Satellites[j].TotalSeconds {
+			return result.Satellites[i].TotalSeconds > result.Satellites[j].TotalSeconds
+		}
+		return result.Satellites[i].SpaceID < result.Satellites[j].SpaceID
+	})
+	return result
+}
+
+// coverageTimeline samples the instantaneous and cumulative share of covered tiles every step.
+func coverageTimeline(merged map[string][]domain.CoveragePass, totalTiles int, from, to time.Time, step time.Duration) []domain.CoverageSample {
+	var samples []domain.CoverageSample
+	for at := from; !at.After(to); at = at.Add(step) {
+		sample := domain.CoverageSample{At: at}
+		for _, passes := range merged {
+			if len(passes) == 0 || passes[0].Start.After(at) {
+				continue
+			}
+			sample.CumulativeTiles++
+			for _, p := range passes {
+				if p.Start.After(at) {
+					break
+				}
+				if !p.End.Before(at) {
+					sample.CoveredTiles++
+					break
+				}
+			}
+		}
+		if totalTiles > 0 {
+			sample.Percent = 100 * float64(sample.CoveredTiles) / float64(totalTiles)
+			sample.CumulativePercent = 100 * float64(sample.CumulativeTiles) / float64(totalTiles)
+		}
+		samples = append(samples, sample)
+	}
+	return samples
+}
+
+func maxDuration(a, b time.Duration) time.Duration {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	repository "github.com/org/2112-space-lab/org/app-service/internal/repositories"
+)
+
+func TestReplaceSatellitePassesRebuildsReport(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	pass := func(tileID, spaceID string, start, end time.Duration) domain.CoveragePass {
+		return domain.CoveragePass{TileID: tileID, SpaceID: spaceID, Start: from.Add(start), End: from.Add(end)}
+	}
+	tiles := []domain.CoverageTile{{ID: "t1", Quadkey: "0"}, {ID: "t2", Quadkey: "1"}}
+
+	stale := domain.CoverageSnapshot{ContextID: "ctx", From: from, To: to, Tiles: tiles, Passes: []domain.CoveragePass{
+		pass("t1", "A", 0, 10*time.Minute),
+		pass("t1", "B", 20*time.Minute, 30*time.Minute),
+		pass("t2", "B", 40*time.Minute, 50*time.Minute),
+	}}
+	fresh := []domain.CoveragePass{pass("t2", "B", 5*time.Minute, 15*time.Minute)}
+
+	updated := stale
+	updated.Passes = replaceSatellitePasses(stale.Passes, "B", fresh)
+	expected := domain.CoverageSnapshot{ContextID: "ctx", From: from, To: to, Tiles: tiles, Passes: []domain.CoveragePass{
+		pass("t1", "A", 0, 10*time.Minute),
+		pass("t2", "B", 5*time.Minute, 15*time.Minute),
+	}}
+	if !reflect.DeepEqual(updated.Passes, expected.Passes) {
+		t.Fatalf("Expected passes %v, but got %v", expected.Passes, updated.Passes)
+	}
+
+	got := buildCoverageReport(updated, 10*time.Minute)
+	want := buildCoverageReport(expected, 10*time.Minute)
+	got.ComputedAt, want.ComputedAt = time.Time{}, time.Time{}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected report %+v, but got %+v", want, got)
+	}
+	if got.VisitedTiles != 2 {
+		t.Errorf("Expected 2 visited tiles, but got %d", got.VisitedTiles)
+	}
+	if len(got.Contributions[0].Satellites) != 1 || got.Contributions[0].Satellites[0].SpaceID != "A" {
+		t.Errorf("Expected tile t1 to be covered by A only, but got %+v", got.Contributions[0].Satellites)
+	}
+}
+
+// staticTiles serves the tiles of contexts. Methods the coverage does not use are left to the embedded interface
+// and panic when called.
+type staticTiles struct {
+	domain.TileRepository
+	tiles []domain.Tile
+}
+
+func (r staticTiles) GetTilesByContext(ctx context.Context, contextID string) ([]domain.Tile, error) {
+	return r.tiles, nil
+}
+
+// memoryMappings keeps the mappings of a context in memory. Methods the coverage does not use are left to the
+// embedded interface and panic when called.
+type memoryMappings struct {
+	domain.MappingRepository
+	mu       sync.Mutex
+	mappings []domain.TileSatelliteInfo
+}
+
+func (r *memoryMappings) append(mapping domain.TileSatelliteInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.mappings = append(r.mappings, mapping)
+}
+
+func (r *memoryMappings) FindMappingsInWindow(ctx context.Context, contextID string, filter domain.MappingWindowFilter) ([]domain.TileSatelliteInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var found []domain.TileSatelliteInfo
+	for _, mapping := range r.mappings {
+		if filter.SpaceID == "" || mapping.SpaceID == filter.SpaceID {
+			found = append(found, mapping)
+		}
+	}
+	return found, nil
+}
+
+// memoryCoverageCache keeps coverage reports and snapshots in memory.
+type memoryCoverageCache struct {
+	mu        sync.Mutex
+	reports   map[string]domain.CoverageReport
+	snapshots map[string]domain.CoverageSnapshot
+}
+
+func newMemoryCoverageCache() *memoryCoverageCache {
+	return &memoryCoverageCache{reports: map[string]domain.CoverageReport{}, snapshots: map[string]domain.CoverageSnapshot{}}
+}
+
+func coverageReportKey(contextID string, from, to time.Time, step time.Duration) string {
+	return fmt.Sprintf("%s:%d:%d:%d", contextID, from.Unix(), to.Unix(), int64(step.Seconds()))
+}
+
+func (c *memoryCoverageCache) Get(ctx context.Context, contextID string, from, to time.Time, step time.Duration) (domain.CoverageReport, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	report, ok := c.reports[coverageReportKey(contextID, from, to, step)]
+	return report, ok, nil
+}
+
+func (c *memoryCoverageCache) Save(ctx context.Context, report domain.CoverageReport) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	step := time.Duration(report.StepSeconds * float64(time.Second))
+	c.reports[coverageReportKey(report.ContextID, report.From, report.To, step)] = report
+	return nil
+}
+
+func (c *memoryCoverageCache) Invalidate(ctx context.Context, contextID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, report := range c.reports {
+		if report.ContextID == contextID {
+			delete(c.reports, key)
+		}
+	}
+	return nil
+}
+
+func (c *memoryCoverageCache) GetSnapshot(ctx context.Context, contextID string, from, to time.Time) (domain.CoverageSnapshot, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	snapshot, ok := c.snapshots[contextID]
+	if !ok || !snapshot.From.Equal(from) || !snapshot.To.Equal(to) {
+		return domain.CoverageSnapshot{}, false, nil
+	}
+	return snapshot, true, nil
+}
+
+func (c *memoryCoverageCache) SaveSnapshot(ctx context.Context, snapshot domain.CoverageSnapshot) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.snapshots[snapshot.ContextID] = snapshot
+	return nil
+}
+
+func TestCoverageReportFollowsAppendedMappings(t *testing.T) {
+	ctx := context.Background()
+	tiles := staticTiles{tiles: []domain.Tile{{Quadkey: "0"}, {Quadkey: "1"}}}
+	tiles.tiles[0].ID, tiles.tiles[1].ID = "t1", "t2"
+	mappings := &memoryMappings{}
+	service := NewCoverageService(tiles, mappings, repository.SatelliteRepository{}, newMemoryCoverageCache())
+
+	from, to := service.DefaultWindow(time.Now(), DefaultCoverageStep)
+	pass := func(tileID, spaceID string) domain.TileSatelliteInfo {
+		return domain.TileSatelliteInfo{TileID: tileID, SpaceID: spaceID, EnteredAt: from.Add(time.Hour), ExitedAt: from.Add(time.Hour + 10*time.Minute)}
+	}
+	mappings.append(pass("t1", "25544"))
+
+	// The propagation refreshes the coverage before the mappings of its passes are computed.
+	if err := service.Refresh(ctx, "ctx"); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	report, err := service.GetReport(ctx, "ctx", from, to, DefaultCoverageStep)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if report.VisitedTiles != 1 {
+		t.Fatalf("Expected 1 visited tile, but got %d", report.VisitedTiles)
+	}
+
+	mappings.append(pass("t2", "43013"))
+	service.RefreshForMappings(ctx, "ctx", "43013")
+
+	report, err = service.GetReport(ctx, "ctx", from, to, DefaultCoverageStep)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if report.VisitedTiles != 2 {
+		t.Errorf("Expected 2 visited tiles once the mappings are appended, but got %d", report.VisitedTiles)
+	}
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	"github.com/org/2112-space-lab/org/app-service/internal/events"
+	model "github.com/org/2112-space-lab/org/app-service/internal/graphql/models/generated"
+	"github.com/org/2112-space-lab/org/app-service/pkg/tracing"
+)
+
+// DeadLetterService inspects and re-drives the events handlers gave up on.
+type DeadLetterService struct {
+	repo    domain.DeadLetterRepository
+	emitter *events.EventEmitter
+}
+
+// NewDeadLetterService creates a new instance of DeadLetterService.
+func NewDeadLetterService(repo domain.DeadLetterRepository, emitter *events.EventEmitter) DeadLetterService {
+	return DeadLetterService{
+		repo:    repo,
+		emitter: emitter,
+	}
+}
+
+// GetPage retrieves dead-lettered events of the tenant of ctx, latest first, with their total count.
+func (s *DeadLetterService) GetPage(ctx context.Context, page, pageSize int, includeRedriven bool) (deadLetters []domain.DeadLetter, total int64, err error) {
+	ctx, span := tracing.NewSpan(ctx, "GetDeadLetters")
+	defer span.EndWithError(err)
+
+	return s.repo.FindPage(ctx, page, pageSize, includeRedriven)
+}
+
+// Get retrieves a dead-lettered event of the tenant of ctx.
+func (s *DeadLetterService) Get(ctx context.Context, id string) (deadLetter domain.DeadLetter, err error) {
+	ctx, span := tracing.NewSpan(ctx, "GetDeadLetter")
+	defer span.EndWithError(err)
+
+	return s.repo.FindByID(ctx, id)
+}
+
+// Redrive publishes a dead-lettered event again for the handler that gave up on it. A handler failing again
+// records a new dead letter.
+func (s *DeadLetterService) Redrive(ctx context.Context, id string) (deadLetter domain.DeadLetter, err error) {
+	ctx, span := tracing.NewSpan(ctx, "RedriveDeadLetter")
+	defer span.EndWithError(err)
+
+	deadLetter, err = s.repo.FindByID(ctx, id)
+	if err != nil {
+		return deadLetter, err
+	}
+
+	var event model.EventRoot
+	if err = json.Unmarshal([]byte(deadLetter.Event), &event); err != nil {
+		return deadLetter, fmt.Errorf("failed to parse dead-lettered event %s: %w", deadLetter.EventUID, err)
+	}
+	if err = s.emitter.Redrive(ctx, event, deadLetter.HandlerName); err != nil {
+		return deadLetter, err
+	}
+
+	now := time.Now().UTC()
+	if err = s.repo.MarkRedriven(ctx, id, now); err != nil {
+		return deadLetter, fmt.Errorf("failed to record re-drive of %s: %w", id, err)
+	}
+	deadLetter.RedrivenAt = &now
+	return deadLetter, nil
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	repository "github.com/org/2112-space-lab/org/app-service/internal/repositories"
+	"github.com/org/2112-space-lab/org/app-service/pkg/tracing"
+)
+
+// EventHistoryService browses the stored events and the executions of their handlers.
+type EventHistoryService struct {
+	eventRepo   repository.EventRepository
+	handlerRepo repository.EventHandlerRepository
+}
+
+// NewEventHistoryService creates a new instance of EventHistoryService.
+func NewEventHistoryService(eventRepo repository.EventRepository, handlerRepo repository.EventHandlerRepository) EventHistoryService {
+	return EventHistoryService{
+		eventRepo:   eventRepo,
+		handlerRepo: handlerRepo,
+	}
+}
+
+// GetPage retrieves a page of the events of the tenant of ctx matching filter, latest first, with the executions of
+// their handlers and the total count of matching events.
+func (s *EventHistoryService) GetPage(ctx context.Context, filter domain.EventReplayFilter, page, pageSize int) (histories []domain.EventHistory, total int64, err error) {
+	ctx, span := tracing.NewSpan(ctx, "GetEventHistories")
+	defer span.EndWithError(err)
+
+	stored, total, err := s.eventRepo.FindPage(ctx, filter, page, pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	eventUIDs := make([]string, len(stored))
+	for i, event := range stored {
+		eventUIDs[i] = event.EventUID
+	}
+	handlers, err := s.handlerRepo.FindByEventUIDs(ctx, eventUIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+	byEvent := map[string][]domain.EventHandler{}
+	for _, handler := range handlers {
+		byEvent[handler.EventID] = append(byEvent[handler.EventID], handler)
+	}
+
+	histories = make([]domain.EventHistory, len(stored))
+	for i, event := range stored {
+		histories[i] = domain.EventHistory{Event: event, Handlers: byEvent[event.EventUID]}
+	}
+	return histories, total, nil
+}
+
+// Get retrieves an event of the tenant of ctx with the executions of its handlers.
+func (s *EventHistoryService) Get(ctx context.Context, eventUID string) (history domain.EventHistory, err error) {
+	ctx, span := tracing.NewSpan(ctx, "GetEventHistory")
+	defer span.EndWithError(err)
+
+	event, err := s.eventRepo.FindByUID(ctx, eventUID)
+	if err != nil {
+		return history, err
+	}
+	handlers, err := s.handlerRepo.FindByEventUIDs(ctx, []string{event.EventUID})
+	if err != nil {
+		return history, err
+	}
+	return domain.EventHistory{Event: event, Handlers: handlers}, nil
+}
+
+// CountByStatus counts the handler executions of the events of the tenant of ctx matching filter, by handler and
+// status.
+func (s *EventHistoryService) CountByStatus(ctx context.Context, filter domain.EventReplayFilter) (counts []domain.EventHandlerStatusCount, err error) {
+	ctx, span := tracing.NewSpan(ctx, "CountEventHandlersByStatus")
+	defer span.EndWithError(err)
+
+	return s.handlerRepo.CountByStatus(ctx, filter)
+}
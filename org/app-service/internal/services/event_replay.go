@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	"github.com/org/2112-space-lab/org/app-service/internal/events"
+	repository "github.com/org/2112-space-lab/org/app-service/internal/repositories"
+	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
+	"github.com/org/2112-space-lab/org/app-service/pkg/tracing"
+)
+
+// DefaultEventReplayLimit bounds a replay that sets no limit.
+const DefaultEventReplayLimit = 10000
+
+// EventReplayService re-dispatches stored events to the handlers of the event monitors, e.g. after fixing a
+// handler. Replayed events go through the broker, so they reach the handlers registered by the running tasks.
+type EventReplayService struct {
+	eventRepo repository.EventRepository
+	emitter   *events.EventEmitter
+}
+
+// NewEventReplayService creates a new instance of EventReplayService.
+func NewEventReplayService(eventRepo repository.EventRepository, emitter *events.EventEmitter) EventReplayService {
+	return EventReplayService{
+		eventRepo: eventRepo,
+		emitter:   emitter,
+	}
+}
+
+// Replay publishes the stored events of the tenant of ctx selected by opts, oldest first, at most
+// opts.RatePerSecond per second. A dry run only reports the selected events.
+func (s *EventReplayService) Replay(ctx context.Context, opts domain.EventReplayOptions) (result domain.EventReplayResult, err error) {
+	ctx, span := tracing.NewSpan(ctx, "ReplayEvents")
+	defer span.EndWithError(err)
+
+	result = domain.EventReplayResult{DryRun: opts.DryRun, HandlerName: opts.HandlerName, EventUIDs: []string{}}
+	if err = opts.Filter.Validate(); err != nil {
+		return result, err
+	}
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultEventReplayLimit
+	}
+
+	stored, err := s.eventRepo.FindForReplay(ctx, opts.Filter, limit)
+	if err != nil {
+		return result, fmt.Errorf("failed to select events to replay: %w", err)
+	}
+	result.Matched = len(stored)
+
+	var tick <-chan time.Time
+	if opts.RatePerSecond > 0 && !opts.DryRun {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / opts.RatePerSecond))
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for i, event := range stored {
+		if !opts.DryRun {
+			if tick != nil && i > 0 {
+				select {
+				case <-ctx.Done():
+					return result, ctx.Err()
+				case <-tick:
+				}
+			}
+			if err = s.emitter.Replay(ctx, events.ConvertToEventRoot(event), opts.HandlerName); err != nil {
+				return result, err
+			}
+			result.Replayed++
+		}
+		result.EventUIDs = append(result.EventUIDs, event.EventUID)
+	}
+
+	log.Infof("🔁 Replayed %d of %d selected events (dry run: %t)", result.Replayed, result.Matched, opts.DryRun)
+	return result, nil
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	repository "github.com/org/2112-space-lab/org/app-service/internal/repositories"
+	"github.com/org/2112-space-lab/org/app-service/pkg/geoexport"
+	"github.com/org/2112-space-lab/org/app-service/pkg/tracing"
+	"github.com/org/2112-space-lab/org/go-utils/pkg/fx/xspace"
+)
+
+// MaxGroundTrackPoints caps the number of samples produced for a single ground track export.
+const MaxGroundTrackPoints = 10000
+
+// GeoExportService builds GeoJSON feature collections from tiles, mappings and propagated orbits.
+type GeoExportService struct {
+	tileRepo      domain.TileRepository
+	mappingRepo   repository.TileSatelliteMappingRepository
+	tleRepo       repository.TleRepository
+	satelliteRepo repository.SatelliteRepository
+}
+
+// NewGeoExportService creates a new instance of GeoExportService.
+func NewGeoExportService(
+	tileRepo domain.TileRepository,
+	mappingRepo repository.TileSatelliteMappingRepository,
+	tleRepo repository.TleRepository,
+	satelliteRepo repository.SatelliteRepository,
+) GeoExportService {
+	return GeoExportService{
+		tileRepo:      tileRepo,
+		mappingRepo:   mappingRepo,
+		tleRepo:       tleRepo,
+		satelliteRepo: satelliteRepo,
+	}
+}
+
+// ExportContextTiles returns every tile of a context as a polygon feature.
+func (s *GeoExportService) ExportContextTiles(ctx context.Context, contextID string) (fc geoexport.FeatureCollection, err error) {
+	ctx, span := tracing.NewSpan(ctx, "ExportContextTiles")
+	defer span.EndWithError(err)
+
+	tiles, err := s.tileRepo.GetTilesByContext(ctx, contextID)
+	if err != nil {
+		return fc, fmt.Errorf("failed to fetch tiles for context [%s]: %w", contextID, err)
+	}
+
+	fc = geoexport.NewFeatureCollection()
+	for _, tile := range tiles {
+		ring := make([]geoexport.Position, 0, len(tile.Vertices))
+		for _, v := range tile.Vertices {
+			ring = append(ring, geoexport.NewPosition(v.Latitude, v.Longitude))
+		}
+		fc.Add(geoexport.NewPolygonFeature(tile.ID, ring, map[string]interface{}{
+			"name":      tile.Quadkey,
+			"quadkey":   tile.Quadkey,
+			"zoomLevel": tile.ZoomLevel,
+			"centerLat": tile.CenterLat,
+			"centerLon": tile.CenterLon,
+			"nbFaces":   tile.NbFaces,
+			"radius":    tile.Radius,
+			"contextID": contextID,
+		}))
+	}
+	return fc, nil
+}
+
+// ExportSatelliteMappings returns the intersection points of a satellite's mappings in a context.
+func (s *GeoExportService) ExportSatelliteMappings(ctx context.Context, contextID, spaceID string) (fc geoexport.FeatureCollection, err error) {
+	ctx, span := tracing.NewSpan(ctx, "ExportSatelliteMappings")
+	defer span.EndWithError(err)
+
+	mappings, err := s.mappingRepo.GetSatelliteMappingsBySpaceID(ctx, contextID, spaceID)
+	if err != nil {
+		return fc, fmt.Errorf("failed to retrieve mappings for SPACE ID [%s] in context [%s]: %w", spaceID, contextID, err)
+	}
+
+	fc = geoexport.NewFeatureCollection()
+	for _, m := range mappings {
+		fc.Add(geoexport.NewPointFeature(m.MappingID,
+			geoexport.NewPosition(m.Intersection.Latitude, m.Intersection.Longitude),
+			map[string]interface{}{
+				"name":          fmt.Sprintf("%s/%s", spaceID, m.TileQuadkey),
+				"spaceID":       m.SpaceID,
+				"tileID":        m.TileID,
+				"tileQuadkey":   m.TileQuadkey,
+				"tileZoomLevel": m.TileZoomLevel,
+				"tileCenterLat": m.TileCenterLat,
+				"tileCenterLon": m.TileCenterLon,
+				"intersectedAt": m.IntersectedAt.UTC().Format(time.RFC3339),
+				"enteredAt":     m.EnteredAt.UTC().Format(time.RFC3339),
+				"exitedAt":      m.ExitedAt.UTC().Format(time.RFC3339),
+				"durationSec":   m.DurationSeconds,
+				"contextID":     contextID,
+			}))
+	}
+	return fc, nil
+}
+
+// ExportGroundTrack propagates the satellite's TLE over a window and returns its ground track.
+func (s *GeoExportService) ExportGroundTrack(ctx context.Context, spaceID string, start, end time.Time, interval time.Duration) (fc geoexport.FeatureCollection, err error) {
+	ctx, span := tracing.NewSpan(ctx, "ExportGroundTrack")
+	defer span.EndWithError(err)
+
+	if !start.Before(end) {
+		return fc, fmt.Errorf("start time must be before end time")
+	}
+	if interval <= 0 {
+		return fc, fmt.Errorf("interval must be greater than zero")
+	}
+	if end.Sub(start)/interval > MaxGroundTrackPoints {
+		return fc, fmt.Errorf("window too large: more than %d samples requested", MaxGroundTrackPoints)
+	}
+
+	tle, err := s.tleRepo.GetTle(ctx, spaceID)
+	if err != nil {
+		return fc, fmt.Errorf("failed to fetch TLE data for SPACE ID %s: %w", spaceID, err)
+	}
+
+	positions, err := xspace.PropagateRange(tle.Line1, tle.Line2, start.UTC(), end.UTC(), interval)
+	if err != nil {
+		return fc, fmt.Errorf("failed to propagate SPACE ID %s: %w", spaceID, err)
+	}
+
+	track := make([]geoexport.Position, 0, len(positions))
+	for _, p := range positions {
+		track = append(track, geoexport.NewPosition(p.Latitude, p.Longitude))
+	}
+
+	fc = geoexport.NewFeatureCollection()
+	fc.Add(geoexport.NewTrackFeature(spaceID, track, map[string]interface{}{
+		"name":            s.satelliteName(ctx, spaceID),
+		"spaceID":         spaceID,
+		"startTime":       start.UTC().Format(time.RFC3339),
+		"endTime":         end.UTC().Format(time.RFC3339),
+		"intervalSeconds": interval.Seconds(),
+		"tleEpoch":        tle.Epoch.UTC().Format(time.RFC3339),
+	}))
+	return fc, nil
+}
+
+// ExportFootprint returns the visibility footprint of a satellite at a given time.
+func (s *GeoExportService) ExportFootprint(ctx context.Context, spaceID string, at time.Time) (fc geoexport.FeatureCollection, err error) {
+	ctx, span := tracing.NewSpan(ctx, "ExportFootprint")
+	defer span.EndWithError(err)
+
+	tle, err := s.tleRepo.GetTle(ctx, spaceID)
+	if err != nil {
+		return fc, fmt.Errorf("failed to fetch TLE data for SPACE ID %s: %w", spaceID, err)
+	}
+
+	horizon, err := xspace.ComputeSatelliteHorizon(at.UTC(), tle.Line1, tle.Line2)
+	if err != nil {
+		return fc, fmt.Errorf("failed to compute footprint for SPACE ID %s: %w", spaceID, err)
+	}
+
+	ring := make([]geoexport.Position, 0, len(horizon))
+	for _, p := range horizon {
+		ring = append(ring, geoexport.NewPosition(p.Latitude, p.Longitude))
+	}
+
+	fc = geoexport.NewFeatureCollection()
+	fc.Add(geoexport.NewPolygonFeature(spaceID, ring, map[string]interface{}{
+		"name":     s.satelliteName(ctx, spaceID),
+		"spaceID":  spaceID,
+		"time":     at.UTC().Format(time.RFC3339),
+		"tleEpoch": tle.Epoch.UTC().Format(time.RFC3339),
+	}))
+	return fc, nil
+}
+
+// satelliteName resolves a display name for a satellite, falling back to its SPACE ID.
+func (s *GeoExportService) satelliteName(ctx context.Context, spaceID string) string {
+	sat, err := s.satelliteRepo.FindBySpaceID(ctx, spaceID)
+	if err != nil || sat.Name == "" {
+		return spaceID
+	}
+	return sat.Name
+}
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	"github.com/org/2112-space-lab/org/app-service/internal/events"
+	event_builder "github.com/org/2112-space-lab/org/app-service/internal/events/builder"
+	model "github.com/org/2112-space-lab/org/app-service/internal/graphql/models/generated"
+	repository "github.com/org/2112-space-lab/org/app-service/internal/repositories"
+	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
+	"github.com/org/2112-space-lab/org/app-service/pkg/tracing"
+)
+
+// MembershipService maintains the satellites of contexts from their membership rules. Satellites matching any
+// rule of a context are assigned to it; rule members that stop matching are removed. Satellites assigned by hand
+// are left alone.
+type MembershipService struct {
+	repo          repository.MembershipRuleRepository
+	contextRepo   repository.ContextRepository
+	satelliteRepo repository.SatelliteRepository
+	emitter       *events.EventEmitter
+}
+
+// NewMembershipService creates a new instance of MembershipService.
+func NewMembershipService(
+	repo repository.MembershipRuleRepository,
+	contextRepo repository.ContextRepository,
+	satelliteRepo repository.SatelliteRepository,
+	emitter *events.EventEmitter,
+) MembershipService {
+	return MembershipService{
+		repo:          repo,
+		contextRepo:   contextRepo,
+		satelliteRepo: satelliteRepo,
+		emitter:       emitter,
+	}
+}
+
+// GetRules retrieves the membership rules of a context.
+func (s *MembershipService) GetRules(ctx context.Context, name domain.GameContextName) (rules []domain.MembershipRule, err error) {
+	ctx, span := tracing.NewSpan(ctx, "GetMembershipRules")
+	defer span.EndWithError(err)
+
+	gameContext, err := s.contextRepo.FindByUniqueName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.FindRulesByContext(ctx, gameContext.ID)
+}
+
+// SaveRule creates or replaces a membership rule of a context and applies the rules of the context at once.
+func (s *MembershipService) SaveRule(ctx context.Context, name domain.GameContextName, rule domain.MembershipRule) (change domain.MembershipChange, err error) {
+	ctx, span := tracing.NewSpan(ctx, "SaveMembershipRule")
+	defer span.EndWithError(err)
+
+	if err = rule.Validate(); err != nil {
+		return change, err
+	}
+	gameContext, err := s.contextRepo.FindByUniqueName(ctx, name)
+	if err != nil {
+		return change, err
+	}
+	rule.ContextID = gameContext.ID
+	if err = s.repo.SaveRule(ctx, rule); err != nil {
+		return change, fmt.Errorf("failed to save rule %s: %w", rule.Name, err)
+	}
+	return s.evaluate(ctx, gameContext, fmt.Sprintf("rule %s saved", rule.Name))
+}
+
+// DeleteRule removes a membership rule of a context and applies the remaining rules.
+func (s *MembershipService) DeleteRule(ctx context.Context, name domain.GameContextName, ruleName string) (change domain.MembershipChange, err error) {
+	ctx, span := tracing.NewSpan(ctx, "DeleteMembershipRule")
+	defer span.EndWithError(err)
+
+	gameContext, err := s.contextRepo.FindByUniqueName(ctx, name)
+	if err != nil {
+		return change, err
+	}
+	if err = s.repo.DeleteRule(ctx, gameContext.ID, ruleName); err != nil {
+		return change, err
+	}
+	return s.evaluate(ctx, gameContext, fmt.Sprintf("rule %s deleted", ruleName))
+}
+
+// Evaluate applies the membership rules of a context to the current catalogue.
+func (s *MembershipService) Evaluate(ctx context.Context, name domain.GameContextName) (change domain.MembershipChange, err error) {
+	ctx, span := tracing.NewSpan(ctx, "EvaluateMembership")
+	defer span.EndWithError(err)
+
+	gameContext, err := s.contextRepo.FindByUniqueName(ctx, name)
+	if err != nil {
+		return change, err
+	}
+	return s.evaluate(ctx, gameContext, "manual evaluation")
+}
+
+// ResolveMembers applies the membership rules of a context and returns the SPACE IDs of its satellites, whether
+// assigned by a rule or by hand.
+func (s *MembershipService) ResolveMembers(ctx context.Context, gameContext domain.GameContext, reason string) (spaceIDs []string, err error) {
+	ctx, span := tracing.NewSpan(ctx, "ResolveMembers")
+	defer span.EndWithError(err)
+
+	if _, err = s.evaluate(ctx, gameContext, reason); err != nil {
+		return nil, err
+	}
+	members, err := s.repo.FindMembers(ctx, gameContext.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve satellites of context %s: %w", gameContext.Name, err)
+	}
+	for _, member := range members {
+		// Manual assignments may reference a satellite by its SPACE ID, without a catalogue entry to preload.
+		if member.Satellite.SpaceID != "" {
+			spaceIDs = append(spaceIDs, member.Satellite.SpaceID)
+		} else {
+			spaceIDs = append(spaceIDs, string(member.SatelliteID))
+		}
+	}
+	sort.Strings(spaceIDs)
+	return spaceIDs, nil
+}
+
+// EvaluateAll applies the membership rules of every context of every tenant, each within its tenant. It runs
+// after the catalogue changes. A failing context does not stop the others; all failures are returned together.
+func (s *MembershipService) EvaluateAll(ctx context.Context, reason string) (err error) {
+	ctx, span := tracing.NewSpan(ctx, "EvaluateAllMemberships")
+	defer span.EndWithError(err)
+
+	contexts, err := s.repo.FindContextsWithRules(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to find contexts with membership rules: %w", err)
+	}
+
+	var errs []error
+	for _, gameContext := range contexts {
+		if _, err := s.evaluate(domain.WithTenant(ctx, gameContext.TenantID), gameContext, reason); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// evaluate computes the rule members a context gains and loses, applies the change and publishes it.
+func (s *MembershipService) evaluate(ctx context.Context, gameContext domain.GameContext, reason string) (change domain.MembershipChange, err error) {
+	rules, err := s.repo.FindRulesByContext(ctx, gameContext.ID)
+	if err != nil {
+		return change, fmt.Errorf("failed to retrieve rules of context %s: %w", gameContext.Name, err)
+	}
+	members, err := s.repo.FindMembers(ctx, gameContext.ID)
+	if err != nil {
+		return change, fmt.Errorf("failed to retrieve satellites of context %s: %w", gameContext.Name, err)
+	}
+	var satellites []domain.Satellite
+	if len(rules) > 0 {
+		if satellites, err = s.satelliteRepo.FindAll(ctx); err != nil {
+			return change, fmt.Errorf("failed to retrieve satellite catalogue: %w", err)
+		}
+	}
+
+	// Manual assignments may reference a satellite by its SPACE ID.
+	assigned := make(map[string]bool, len(members))
+	for _, member := range members {
+		assigned[string(member.SatelliteID)] = true
+	}
+
+	matching := make(map[string]bool)
+	var add []domain.SatelliteID
+	for _, satellite := range satellites {
+		if !domain.MatchesAny(rules, satellite) {
+			continue
+		}
+		matching[satellite.ID] = true
+		if !assigned[satellite.ID] && !assigned[satellite.SpaceID] {
+			add = append(add, domain.SatelliteID(satellite.ID))
+			change.Added = append(change.Added, satellite.SpaceID)
+		}
+	}
+
+	var remove []domain.SatelliteID
+	for _, member := range members {
+		if member.RuleAssigned && !matching[string(member.SatelliteID)] {
+			remove = append(remove, member.SatelliteID)
+			change.Removed = append(change.Removed, member.Satellite.SpaceID)
+		}
+	}
+
+	if change.IsEmpty() {
+		return change, nil
+	}
+	if err = s.repo.ApplyMembership(ctx, gameContext.ID, add, remove); err != nil {
+		return domain.MembershipChange{}, fmt.Errorf("failed to apply membership of context %s: %w", gameContext.Name, err)
+	}
+	sort.Strings(change.Added)
+	sort.Strings(change.Removed)
+	log.Infof("🛰 Membership rules of context %s added %d and removed %d satellites: %s",
+		gameContext.Name, len(change.Added), len(change.Removed), reason)
+
+	// The membership is committed; a lost event must not undo it.
+	s.publish(ctx, model.EventTypeGameContextSatellitesAdded, gameContext.Name, change.Added, reason)
+	s.publish(ctx, model.EventTypeGameContextSatellitesRemoved, gameContext.Name, change.Removed, reason)
+	return change, nil
+}
+
+// publish emits a membership event, logging failures.
+func (s *MembershipService) publish(ctx context.Context, eventType model.EventType, name domain.GameContextName, spaceIDs []string, reason string) {
+	if len(spaceIDs) == 0 {
+		return
+	}
+	ev, err := event_builder.NewGameContextMembershipChangedEvent(eventType, string(name), spaceIDs, reason)
+	if err == nil {
+		err = s.emitter.PublishEvent(ctx, *ev)
+	}
+	if err != nil {
+		log.Errorf("❌ Failed to publish %s for context %s: %v", eventType, name, err)
+	}
+}
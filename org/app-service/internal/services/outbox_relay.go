@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	"github.com/org/2112-space-lab/org/app-service/internal/events"
+	model "github.com/org/2112-space-lab/org/app-service/internal/graphql/models/generated"
+	repository "github.com/org/2112-space-lab/org/app-service/internal/repositories"
+	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
+	"github.com/org/2112-space-lab/org/app-service/pkg/tracing"
+)
+
+// tleUpdatePublisher relays the TLE updates stored in the outbox.
+type tleUpdatePublisher interface {
+	PublishTLEUpdate(ctx context.Context, payload string) error
+}
+
+// OutboxRelayService publishes the messages stored in the transactional outbox. Delivery is at least once: a
+// message published just before its row is marked sent is published again by the next relay.
+type OutboxRelayService struct {
+	repo           domain.OutboxRepository
+	emitter        *events.EventEmitter
+	tleUpdates     tleUpdatePublisher
+	globalPropRepo repository.GlobalPropertyRepository
+}
+
+// NewOutboxRelayService creates a new instance of OutboxRelayService.
+func NewOutboxRelayService(
+	repo domain.OutboxRepository,
+	emitter *events.EventEmitter,
+	tleUpdates tleUpdatePublisher,
+	globalPropRepo repository.GlobalPropertyRepository,
+) OutboxRelayService {
+	return OutboxRelayService{
+		repo:           repo,
+		emitter:        emitter,
+		tleUpdates:     tleUpdates,
+		globalPropRepo: globalPropRepo,
+	}
+}
+
+// RelayPending publishes one batch of pending messages in order. A message that fails, or is waiting for its
+// retry, holds back the later messages of its aggregate until it is published. A message that cannot be read, or
+// failed the configured number of attempts, is parked so that its aggregate moves on. It returns the number of
+// published messages and whether the batch was full, i.e. more messages may be pending.
+func (s *OutboxRelayService) RelayPending(ctx context.Context) (sent int, full bool, err error) {
+	ctx, span := tracing.NewSpan(ctx, "RelayPending")
+	defer span.EndWithError(err)
+
+	batchSize, err := s.globalPropRepo.GetOutboxRelayBatchSize(ctx, repository.DefaultOutboxRelayBatchSize)
+	if err != nil {
+		log.Tracef("Using default outbox relay batch size [%d]: %v", batchSize, err)
+	}
+	if batchSize <= 0 {
+		batchSize = repository.DefaultOutboxRelayBatchSize
+	}
+	maxDelay, err := s.globalPropRepo.GetOutboxRetryMaxDelay(ctx, repository.DefaultOutboxRetryMaxDelay)
+	if err != nil {
+		log.Tracef("Using default outbox retry max delay [%s]: %v", maxDelay, err)
+	}
+	maxAttempts, err := s.globalPropRepo.GetOutboxMaxAttempts(ctx, repository.DefaultOutboxMaxAttempts)
+	if err != nil {
+		log.Tracef("Using default outbox max attempts [%d]: %v", maxAttempts, err)
+	}
+	if maxAttempts <= 0 {
+		maxAttempts = repository.DefaultOutboxMaxAttempts
+	}
+
+	acquired, err := s.repo.WithRelayLock(ctx, func(ctx context.Context) error {
+		messages, err := s.repo.FindPending(ctx, int(batchSize))
+		if err != nil {
+			return fmt.Errorf("failed to read outbox: %w", err)
+		}
+		full = len(messages) == int(batchSize)
+
+		now := time.Now().UTC()
+		blocked := make(map[string]bool)
+		for _, message := range messages {
+			if blocked[message.AggregateID] {
+				continue
+			}
+			if message.NextAttemptAt.After(now) {
+				blocked[message.AggregateID] = true
+				continue
+			}
+
+			if publishErr := s.publish(ctx, message); publishErr != nil {
+				attempts := message.Attempts + 1
+				if errors.Is(publishErr, domain.ErrOutboxMessageMalformed) || attempts >= int(maxAttempts) {
+					log.Errorf("❌ Parking outbox message %s of %s after %d attempts: %v", message.EventUID, message.AggregateID, attempts, publishErr)
+					if err := s.repo.MarkParked(ctx, message.Sequence, attempts, now, publishErr.Error()); err != nil {
+						return fmt.Errorf("failed to park outbox message %s: %w", message.EventUID, err)
+					}
+					continue
+				}
+
+				blocked[message.AggregateID] = true
+				retryAt := now.Add(domain.OutboxRetryDelay(attempts, repository.DefaultOutboxRetryBaseDelay, maxDelay))
+				log.Warnf("⚠️ Failed to publish outbox event %s (attempt %d), retrying at %s: %v", message.EventUID, attempts, retryAt.Format(time.RFC3339), publishErr)
+				if err := s.repo.MarkFailed(ctx, message.Sequence, attempts, retryAt, publishErr.Error()); err != nil {
+					return fmt.Errorf("failed to record outbox failure of %s: %w", message.EventUID, err)
+				}
+				continue
+			}
+
+			if err := s.repo.MarkSent(ctx, message.Sequence, time.Now().UTC()); err != nil {
+				return fmt.Errorf("failed to mark outbox event %s sent: %w", message.EventUID, err)
+			}
+			sent++
+		}
+		return nil
+	})
+	if err != nil {
+		return sent, false, err
+	}
+	if !acquired {
+		log.Tracef("Outbox relay lock held by another relay")
+	}
+	return sent, full, nil
+}
+
+// PurgeSentMessages deletes the messages published before the configured retention window. An event UID
+// enqueued again after its message was purged is published again.
+func (s *OutboxRelayService) PurgeSentMessages(ctx context.Context) (purged int64, err error) {
+	ctx, span := tracing.NewSpan(ctx, "PurgeSentOutboxMessages")
+	defer span.EndWithError(err)
+
+	retention, propErr := s.globalPropRepo.GetOutboxRetention(ctx, repository.DefaultOutboxRetention)
+	if propErr != nil {
+		log.Tracef("Using default outbox retention [%s]: %v", retention, propErr)
+	}
+	if retention <= 0 {
+		return 0, nil
+	}
+
+	cutoff := time.Now().UTC().Add(-retention)
+	purged, err = s.repo.PurgeSentBefore(ctx, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge outbox messages sent before %s: %w", cutoff.Format(time.RFC3339), err)
+	}
+
+	if purged > 0 {
+		log.Debugf("Purged %d outbox messages sent before %s", purged, cutoff.Format(time.RFC3339))
+	}
+	return purged, nil
+}
+
+// publish relays a stored message according to its kind: events go to the broker and the local event loop.
+func (s *OutboxRelayService) publish(ctx context.Context, message domain.OutboxMessage) error {
+	switch message.Kind {
+	case domain.OutboxKindEvent, "":
+		var event model.EventRoot
+		if err := json.Unmarshal([]byte(message.Event), &event); err != nil {
+			return fmt.Errorf("%w: failed to parse stored event: %v", domain.ErrOutboxMessageMalformed, err)
+		}
+		if err := s.emitter.PublishEvent(events.EventContext(ctx, event), event); err != nil {
+			if errors.Is(err, events.ErrInvalidPayload) {
+				return fmt.Errorf("%w: %v", domain.ErrOutboxMessageMalformed, err)
+			}
+			return err
+		}
+		return nil
+	case domain.OutboxKindTLEUpdate:
+		return s.tleUpdates.PublishTLEUpdate(ctx, message.Event)
+	default:
+		return fmt.Errorf("%w: unknown kind %q", domain.ErrOutboxMessageMalformed, message.Kind)
+	}
+}
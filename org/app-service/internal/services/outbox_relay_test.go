@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	repository "github.com/org/2112-space-lab/org/app-service/internal/repositories"
+)
+
+type memoryOutbox struct {
+	messages []domain.OutboxMessage
+}
+
+func (o *memoryOutbox) Enqueue(ctx context.Context, message domain.OutboxMessage) error {
+	message.Sequence = int64(len(o.messages) + 1)
+	o.messages = append(o.messages, message)
+	return nil
+}
+
+func (o *memoryOutbox) FindPending(ctx context.Context, limit int) ([]domain.OutboxMessage, error) {
+	var pending []domain.OutboxMessage
+	for _, message := range o.messages {
+		if message.SentAt == nil && message.ParkedAt == nil && len(pending) < limit {
+			pending = append(pending, message)
+		}
+	}
+	return pending, nil
+}
+
+func (o *memoryOutbox) update(sequence int64, fn func(*domain.OutboxMessage)) {
+	for i := range o.messages {
+		if o.messages[i].Sequence == sequence {
+			fn(&o.messages[i])
+		}
+	}
+}
+
+func (o *memoryOutbox) MarkSent(ctx context.Context, sequence int64, sentAt time.Time) error {
+	o.update(sequence, func(m *domain.OutboxMessage) { m.SentAt = &sentAt })
+	return nil
+}
+
+func (o *memoryOutbox) MarkFailed(ctx context.Context, sequence int64, attempts int, nextAttemptAt time.Time, lastError string) error {
+	o.update(sequence, func(m *domain.OutboxMessage) {
+		m.Attempts, m.NextAttemptAt, m.LastError = attempts, nextAttemptAt, lastError
+	})
+	return nil
+}
+
+func (o *memoryOutbox) MarkParked(ctx context.Context, sequence int64, attempts int, parkedAt time.Time, lastError string) error {
+	o.update(sequence, func(m *domain.OutboxMessage) {
+		m.Attempts, m.ParkedAt, m.LastError = attempts, &parkedAt, lastError
+	})
+	return nil
+}
+
+func (o *memoryOutbox) WithRelayLock(ctx context.Context, fn func(ctx context.Context) error) (bool, error) {
+	return true, fn(ctx)
+}
+
+func (o *memoryOutbox) PurgeSentBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	var kept []domain.OutboxMessage
+	for _, message := range o.messages {
+		if message.SentAt == nil || !message.SentAt.Before(cutoff) {
+			kept = append(kept, message)
+		}
+	}
+	purged := int64(len(o.messages) - len(kept))
+	o.messages = kept
+	return purged, nil
+}
+
+func (o *memoryOutbox) find(uid string) domain.OutboxMessage {
+	for _, message := range o.messages {
+		if message.EventUID == uid {
+			return message
+		}
+	}
+	return domain.OutboxMessage{}
+}
+
+// recordingTLEUpdates records the TLE updates relayed, failing for the payloads in failing.
+type recordingTLEUpdates struct {
+	published []string
+	failing   map[string]bool
+}
+
+func (p *recordingTLEUpdates) PublishTLEUpdate(ctx context.Context, payload string) error {
+	if p.failing[payload] {
+		return errors.New("redis unavailable")
+	}
+	p.published = append(p.published, payload)
+	return nil
+}
+
+func TestRelayPendingPublishesEachKind(t *testing.T) {
+	outbox := &memoryOutbox{}
+	emitter, store := newRecordingEmitter(t)
+	tleUpdates := &recordingTLEUpdates{}
+	relay := NewOutboxRelayService(outbox, emitter, tleUpdates, newDryRunGlobalProperties(t))
+
+	_ = outbox.Enqueue(context.Background(), domain.OutboxMessage{EventUID: "event", AggregateID: "tle_category:active", Kind: domain.OutboxKindEvent,
+		Event: `{"eventType":"TLE_BATCH_UPLOADED","eventUid":"event","payload":"{\"category\":\"active\",\"maxRequested\":10,\"processedTLEs\":10,\"timestamp\":\"2026-10-19T12:00:00Z\"}"}`})
+	_ = outbox.Enqueue(context.Background(), domain.OutboxMessage{EventUID: "tle", AggregateID: "tle:25544", Kind: domain.OutboxKindTLEUpdate, Event: `{"id":"25544"}`})
+
+	sent, _, err := relay.RelayPending(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if sent != 2 {
+		t.Errorf("Expected 2 messages sent, but got %d", sent)
+	}
+	if types := store.types(); !reflect.DeepEqual(types, []domain.EventType{"TLE_BATCH_UPLOADED"}) {
+		t.Errorf("Expected [TLE_BATCH_UPLOADED], but got %v", types)
+	}
+	if !reflect.DeepEqual(tleUpdates.published, []string{`{"id":"25544"}`}) {
+		t.Errorf("Expected the TLE update to be relayed, but got %v", tleUpdates.published)
+	}
+}
+
+func TestRelayPendingParksUndeliverableMessages(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name           string
+		message        domain.OutboxMessage
+		expectedParked bool
+		expectedTries  int
+	}{
+		{
+			name:           "Unparseable event is parked at once",
+			message:        domain.OutboxMessage{EventUID: "broken", AggregateID: "tle:25544", Event: "{not json"},
+			expectedParked: true,
+			expectedTries:  1,
+		},
+		{
+			name:           "Event with an invalid payload is parked at once",
+			message:        domain.OutboxMessage{EventUID: "broken", AggregateID: "tle:25544", Event: `{"eventType":"TLE_BATCH_UPLOADED","eventUid":"broken","payload":"{}"}`},
+			expectedParked: true,
+			expectedTries:  1,
+		},
+		{
+			name:           "Unknown kind is parked at once",
+			message:        domain.OutboxMessage{EventUID: "broken", AggregateID: "tle:25544", Kind: "carrier_pigeon", Event: "{}"},
+			expectedParked: true,
+			expectedTries:  1,
+		},
+		{
+			name:           "Failing message is retried",
+			message:        domain.OutboxMessage{EventUID: "broken", AggregateID: "tle:25544", Kind: domain.OutboxKindTLEUpdate, Event: "failing"},
+			expectedParked: false,
+			expectedTries:  1,
+		},
+		{
+			name: "Failing message is parked on its last attempt",
+			message: domain.OutboxMessage{EventUID: "broken", AggregateID: "tle:25544", Kind: domain.OutboxKindTLEUpdate, Event: "failing",
+				Attempts: repository.DefaultOutboxMaxAttempts - 1, NextAttemptAt: past},
+			expectedParked: true,
+			expectedTries:  repository.DefaultOutboxMaxAttempts,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outbox := &memoryOutbox{}
+			emitter, _ := newRecordingEmitter(t)
+			tleUpdates := &recordingTLEUpdates{failing: map[string]bool{"failing": true}}
+			relay := NewOutboxRelayService(outbox, emitter, tleUpdates, newDryRunGlobalProperties(t))
+
+			_ = outbox.Enqueue(context.Background(), tt.message)
+			_ = outbox.Enqueue(context.Background(), domain.OutboxMessage{EventUID: "next", AggregateID: "tle:25544", Kind: domain.OutboxKindTLEUpdate, Event: "next"})
+
+			if _, _, err := relay.RelayPending(context.Background()); err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+
+			broken := outbox.find("broken")
+			if parked := broken.ParkedAt != nil; parked != tt.expectedParked {
+				t.Errorf("Expected parked %v, but got %v", tt.expectedParked, parked)
+			}
+			if broken.Attempts != tt.expectedTries {
+				t.Errorf("Expected %d attempts, but got %d", tt.expectedTries, broken.Attempts)
+			}
+
+			// A parked message no longer holds back its aggregate; a retried one does.
+			next := outbox.find("next")
+			if sent := next.SentAt != nil; sent != tt.expectedParked {
+				t.Errorf("Expected the next message of the aggregate sent %v, but got %v", tt.expectedParked, sent)
+			}
+		})
+	}
+}
+
+func TestPurgeSentMessages(t *testing.T) {
+	now := time.Now().UTC()
+	expired, recent := now.Add(-repository.DefaultOutboxRetention-time.Hour), now.Add(-time.Hour)
+	outbox := &memoryOutbox{messages: []domain.OutboxMessage{
+		{Sequence: 1, EventUID: "expired", SentAt: &expired},
+		{Sequence: 2, EventUID: "recent", SentAt: &recent},
+		{Sequence: 3, EventUID: "pending", CreatedAt: expired},
+		{Sequence: 4, EventUID: "parked", CreatedAt: expired, ParkedAt: &expired},
+	}}
+	emitter, _ := newRecordingEmitter(t)
+	relay := NewOutboxRelayService(outbox, emitter, &recordingTLEUpdates{}, newDryRunGlobalProperties(t))
+
+	purged, err := relay.PurgeSentMessages(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if purged != 1 {
+		t.Errorf("Expected 1 message purged, but got %d", purged)
+	}
+	var kept []string
+	for _, message := range outbox.messages {
+		kept = append(kept, message.EventUID)
+	}
+	if expected := []string{"recent", "pending", "parked"}; !reflect.DeepEqual(kept, expected) {
+		t.Errorf("Expected %v kept, but got %v", expected, kept)
+	}
+}
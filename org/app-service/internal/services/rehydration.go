@@ -0,0 +1,282 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	"github.com/org/2112-space-lab/org/app-service/internal/events"
+	event_builder "github.com/org/2112-space-lab/org/app-service/internal/events/builder"
+	repository "github.com/org/2112-space-lab/org/app-service/internal/repositories"
+	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
+	"github.com/org/2112-space-lab/org/app-service/pkg/tracing"
+)
+
+// RehydrationService tracks the rehydration of contexts: each satellite is propagated, then its mappings are
+// computed. REHYDRATE_GAME_CONTEXT_SUCCESS or REHYDRATE_GAME_CONTEXT_FAILED is published once every satellite is
+// done or the rehydration timed out.
+type RehydrationService struct {
+	repo           domain.RehydrationRepository
+	contextRepo    repository.ContextRepository
+	tileService    *TileService
+	emitter        *events.EventEmitter
+	globalPropRepo repository.GlobalPropertyRepository
+}
+
+// NewRehydrationService creates a new instance of RehydrationService.
+func NewRehydrationService(
+	repo domain.RehydrationRepository,
+	contextRepo repository.ContextRepository,
+	tileService *TileService,
+	emitter *events.EventEmitter,
+	globalPropRepo repository.GlobalPropertyRepository,
+) RehydrationService {
+	return RehydrationService{
+		repo:           repo,
+		contextRepo:    contextRepo,
+		tileService:    tileService,
+		emitter:        emitter,
+		globalPropRepo: globalPropRepo,
+	}
+}
+
+// Start records a new rehydration of a context requested by an event, with a step per satellite whose propagation
+// is requested, replacing the previous rehydration of the context. A context without satellites is rehydrated at once.
+func (s *RehydrationService) Start(ctx context.Context, gameContext domain.GameContext, eventUID string, startTime time.Time, spaceIDs []string) (rehydration domain.Rehydration, err error) {
+	ctx, span := tracing.NewSpan(ctx, "StartRehydration")
+	defer span.EndWithError(err)
+
+	timeout, timeoutErr := s.globalPropRepo.GetRehydrationTimeout(ctx, repository.DefaultRehydrationTimeout)
+	if timeoutErr != nil {
+		log.Tracef("Using default rehydration timeout [%s]: %v", timeout, timeoutErr)
+	}
+	if timeout <= 0 {
+		timeout = repository.DefaultRehydrationTimeout
+	}
+
+	now := time.Now().UTC()
+	rehydration = domain.Rehydration{
+		ContextID:   gameContext.ID,
+		ContextName: gameContext.Name,
+		EventUID:    eventUID,
+		Status:      domain.RehydrationStatusRunning,
+		StartTime:   startTime.UTC(),
+		StartedAt:   now,
+		Deadline:    now.Add(timeout),
+		Steps:       make([]domain.RehydrationStep, len(spaceIDs)),
+	}
+	for i, spaceID := range spaceIDs {
+		rehydration.Steps[i] = domain.RehydrationStep{SpaceID: spaceID, Status: domain.RehydrationStepRequested, UpdatedAt: now}
+	}
+	if err = s.repo.Start(ctx, rehydration); err != nil {
+		return domain.Rehydration{}, fmt.Errorf("failed to start the rehydration of context %s: %w", gameContext.Name, err)
+	}
+
+	if len(spaceIDs) == 0 {
+		if err = s.complete(ctx, rehydration, domain.RehydrationStatusSucceeded, ""); err != nil {
+			return rehydration, err
+		}
+		rehydration.Status = domain.RehydrationStatusSucceeded
+	}
+	return rehydration, nil
+}
+
+// Fail ends the running rehydration of a context whose propagations could not all be requested. Its pending steps fail.
+func (s *RehydrationService) Fail(ctx context.Context, gameContext domain.GameContext, reason string) (err error) {
+	ctx, span := tracing.NewSpan(ctx, "FailRehydration")
+	defer span.EndWithError(err)
+
+	if err = s.repo.FailPendingSteps(ctx, gameContext.ID, reason); err != nil {
+		return err
+	}
+	rehydration, err := s.repo.FindByContext(ctx, gameContext.ID)
+	if err != nil {
+		return err
+	}
+	return s.complete(ctx, rehydration, domain.RehydrationStatusFailed, reason)
+}
+
+// RecordPropagated records the propagation of a satellite in the running rehydration of a context, or of every
+// context awaiting it when the propagation names no context. Propagations outside a running rehydration, and
+// duplicates, are ignored.
+func (s *RehydrationService) RecordPropagated(ctx context.Context, contextName domain.GameContextName, spaceID string) (err error) {
+	ctx, span := tracing.NewSpan(ctx, "RecordPropagated")
+	defer span.EndWithError(err)
+
+	if contextName != "" {
+		gameContext, err := s.contextRepo.FindByUniqueName(ctx, contextName)
+		if err != nil {
+			return fmt.Errorf("failed to find context %s: %w", contextName, err)
+		}
+		return s.recordPropagated(ctx, gameContext.ID, spaceID)
+	}
+
+	contextIDs, err := s.repo.FindAwaitingPropagation(ctx, spaceID)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, contextID := range contextIDs {
+		if err := s.recordPropagated(ctx, contextID, spaceID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// recordPropagated advances the step of a propagated satellite: its mappings are recomputed from the start time
+// of the rehydration, then the rehydration completes if it was the last step.
+func (s *RehydrationService) recordPropagated(ctx context.Context, contextID, spaceID string) error {
+	advanced, err := s.repo.AdvanceStep(ctx, contextID, spaceID,
+		[]domain.RehydrationStepStatus{domain.RehydrationStepRequested}, domain.RehydrationStepPropagated, "")
+	if err != nil || !advanced {
+		return err
+	}
+
+	rehydration, err := s.repo.FindByContext(ctx, contextID)
+	if err != nil {
+		return err
+	}
+
+	duration, durationErr := s.globalPropRepo.GetEventDetectorSimulationDuration(ctx, repository.DefdaultSimulationDuration)
+	if durationErr != nil {
+		log.Tracef("Using default simulation duration [%s]: %v", duration, durationErr)
+	}
+
+	status, stepErr := domain.RehydrationStepMapped, ""
+	if mappingErr := s.tileService.RecomputeMappings(ctx, contextID, spaceID, rehydration.StartTime, rehydration.StartTime.Add(duration)); mappingErr != nil {
+		log.Errorf("❌ Failed to compute the mappings of SPACE ID %s for context %s: %v", spaceID, rehydration.ContextName, mappingErr)
+		status, stepErr = domain.RehydrationStepFailed, mappingErr.Error()
+	}
+	if _, err = s.repo.AdvanceStep(ctx, contextID, spaceID,
+		[]domain.RehydrationStepStatus{domain.RehydrationStepPropagated}, status, stepErr); err != nil {
+		return err
+	}
+
+	return s.completeIfDone(ctx, contextID)
+}
+
+// Progress retrieves the rehydration of a context with the state of each of its satellites.
+func (s *RehydrationService) Progress(ctx context.Context, contextName domain.GameContextName) (rehydration domain.Rehydration, err error) {
+	ctx, span := tracing.NewSpan(ctx, "RehydrationProgress")
+	defer span.EndWithError(err)
+
+	gameContext, err := s.contextRepo.FindByUniqueName(ctx, contextName)
+	if err != nil {
+		return domain.Rehydration{}, err
+	}
+	return s.repo.FindByContext(ctx, gameContext.ID)
+}
+
+// ExpireOverdue times out the rehydrations of every tenant whose deadline passed at now: their pending steps fail.
+// A failing rehydration does not stop the others; all failures are returned together.
+func (s *RehydrationService) ExpireOverdue(ctx context.Context, now time.Time) (err error) {
+	ctx, span := tracing.NewSpan(ctx, "ExpireOverdueRehydrations")
+	defer span.EndWithError(err)
+
+	overdue, err := s.repo.FindOverdue(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, rehydration := range overdue {
+		tenantCtx := domain.WithTenant(ctx, rehydration.TenantID)
+		reason := fmt.Sprintf("rehydration timed out at %s", rehydration.Deadline.Format(time.RFC3339))
+		if err := s.repo.FailPendingSteps(tenantCtx, rehydration.ContextID, reason); err != nil {
+			errs = append(errs, fmt.Errorf("failed to time out the rehydration of context %s: %w", rehydration.ContextName, err))
+			continue
+		}
+		current, err := s.repo.FindByContext(tenantCtx, rehydration.ContextID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := s.complete(tenantCtx, current, domain.RehydrationStatusTimedOut, reason); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// FailStalledPropagations fails the steps of the running rehydrations of every tenant whose propagation was
+// requested longer than the propagation timeout before now and is still unreported. The propagator reports no
+// failure: a satellite it could not propagate would otherwise hold its rehydration until the rehydration times out.
+// The rehydrations whose steps are then all done complete. All failures are returned together.
+func (s *RehydrationService) FailStalledPropagations(ctx context.Context, now time.Time) (err error) {
+	ctx, span := tracing.NewSpan(ctx, "FailStalledPropagations")
+	defer span.EndWithError(err)
+
+	timeout, timeoutErr := s.globalPropRepo.GetRehydrationPropagationTimeout(ctx, repository.DefaultRehydrationPropagationTimeout)
+	if timeoutErr != nil {
+		log.Tracef("Using default rehydration propagation timeout [%s]: %v", timeout, timeoutErr)
+	}
+	if timeout <= 0 {
+		timeout = repository.DefaultRehydrationPropagationTimeout
+	}
+	before := now.Add(-timeout)
+
+	stalled, err := s.repo.FindStalled(ctx, before)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	reason := fmt.Sprintf("propagation not reported within %s", timeout)
+	for _, rehydration := range stalled {
+		tenantCtx := domain.WithTenant(ctx, rehydration.TenantID)
+		if err := s.repo.FailStalledSteps(tenantCtx, rehydration.ContextID, before, reason); err != nil {
+			errs = append(errs, fmt.Errorf("failed to fail the stalled propagations of context %s: %w", rehydration.ContextName, err))
+			continue
+		}
+		log.Warnf("⚠️ Propagations of context %s not reported within %s, their satellites failed", rehydration.ContextName, timeout)
+		if err := s.completeIfDone(tenantCtx, rehydration.ContextID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// completeIfDone completes the running rehydration of a context once all its steps are done.
+func (s *RehydrationService) completeIfDone(ctx context.Context, contextID string) error {
+	rehydration, err := s.repo.FindByContext(ctx, contextID)
+	if err != nil {
+		return err
+	}
+	if rehydration.Status != domain.RehydrationStatusRunning || !rehydration.Done() {
+		return nil
+	}
+
+	status, reason := rehydration.Outcome(), ""
+	if status == domain.RehydrationStatusFailed {
+		reason = fmt.Sprintf("%d of %d satellites failed", rehydration.CountSteps(domain.RehydrationStepFailed), len(rehydration.Steps))
+	}
+	return s.complete(ctx, rehydration, status, reason)
+}
+
+// complete ends a running rehydration and publishes its outcome. Only the caller ending it publishes.
+func (s *RehydrationService) complete(ctx context.Context, rehydration domain.Rehydration, status domain.RehydrationStatus, reason string) error {
+	completed, err := s.repo.Complete(ctx, rehydration.ContextID, status, reason, time.Now().UTC())
+	if err != nil || !completed {
+		return err
+	}
+
+	name := string(rehydration.ContextName)
+	if status == domain.RehydrationStatusSucceeded {
+		ev, err := event_builder.NewRehydrateGameContextSuccessEvent(name, int32(rehydration.CountSteps(domain.RehydrationStepMapped)))
+		if err != nil {
+			return err
+		}
+		log.Infof("✅ Rehydration completed successfully for context: %s", name)
+		return s.emitter.PublishEvent(ctx, *ev)
+	}
+
+	ev, err := event_builder.NewRehydrateGameContextFailedEvent(name, reason, int32(rehydration.CountSteps(domain.RehydrationStepFailed)))
+	if err != nil {
+		return err
+	}
+	log.Errorf("❌ Rehydration failed for context: %s | Reason: %s", name, reason)
+	return s.emitter.PublishEvent(ctx, *ev)
+}
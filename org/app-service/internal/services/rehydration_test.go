@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	repository "github.com/org/2112-space-lab/org/app-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+// memoryRehydrationRepository keeps rehydrations in memory. Methods the watchdog does not use are left to the
+// embedded interface and panic when called.
+type memoryRehydrationRepository struct {
+	domain.RehydrationRepository
+	mu           sync.Mutex
+	rehydrations map[string]*domain.Rehydration
+}
+
+func (r *memoryRehydrationRepository) FindByContext(ctx context.Context, contextID string) (domain.Rehydration, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rehydration, ok := r.rehydrations[contextID]
+	if !ok {
+		return domain.Rehydration{}, gorm.ErrRecordNotFound
+	}
+	copied := *rehydration
+	copied.Steps = append([]domain.RehydrationStep(nil), rehydration.Steps...)
+	return copied, nil
+}
+
+func (r *memoryRehydrationRepository) FindStalled(ctx context.Context, before time.Time) ([]domain.Rehydration, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var stalled []domain.Rehydration
+	for _, rehydration := range r.rehydrations {
+		if rehydration.Status != domain.RehydrationStatusRunning {
+			continue
+		}
+		for _, step := range rehydration.Steps {
+			if step.Status == domain.RehydrationStepRequested && step.UpdatedAt.Before(before) {
+				stalled = append(stalled, domain.Rehydration{ContextID: rehydration.ContextID, ContextName: rehydration.ContextName, TenantID: rehydration.TenantID})
+				break
+			}
+		}
+	}
+	return stalled, nil
+}
+
+func (r *memoryRehydrationRepository) FailStalledSteps(ctx context.Context, contextID string, before time.Time, stepErr string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, step := range r.rehydrations[contextID].Steps {
+		if step.Status == domain.RehydrationStepRequested && step.UpdatedAt.Before(before) {
+			r.rehydrations[contextID].Steps[i].Status = domain.RehydrationStepFailed
+			r.rehydrations[contextID].Steps[i].Error = stepErr
+		}
+	}
+	return nil
+}
+
+func (r *memoryRehydrationRepository) Complete(ctx context.Context, contextID string, status domain.RehydrationStatus, reason string, completedAt time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rehydration := r.rehydrations[contextID]
+	if rehydration.Status != domain.RehydrationStatusRunning {
+		return false, nil
+	}
+	rehydration.Status, rehydration.Reason, rehydration.CompletedAt = status, reason, &completedAt
+	return true, nil
+}
+
+func TestRehydrationFailStalledPropagations(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	stale, recent := now.Add(-time.Hour), now.Add(-time.Minute)
+
+	tests := []struct {
+		name           string
+		steps          []domain.RehydrationStep
+		expectedSteps  []domain.RehydrationStepStatus
+		expectedStatus domain.RehydrationStatus
+		expectedEvents []domain.EventType
+	}{
+		{
+			name: "Stalled propagation",
+			steps: []domain.RehydrationStep{
+				{SpaceID: "25544", Status: domain.RehydrationStepRequested, UpdatedAt: stale},
+				{SpaceID: "43013", Status: domain.RehydrationStepMapped, UpdatedAt: stale},
+			},
+			expectedSteps:  []domain.RehydrationStepStatus{domain.RehydrationStepFailed, domain.RehydrationStepMapped},
+			expectedStatus: domain.RehydrationStatusFailed,
+			expectedEvents: []domain.EventType{"REHYDRATE_GAME_CONTEXT_FAILED"},
+		},
+		{
+			name: "Propagation within the timeout",
+			steps: []domain.RehydrationStep{
+				{SpaceID: "25544", Status: domain.RehydrationStepRequested, UpdatedAt: recent},
+			},
+			expectedSteps:  []domain.RehydrationStepStatus{domain.RehydrationStepRequested},
+			expectedStatus: domain.RehydrationStatusRunning,
+		},
+		{
+			name: "Stalled propagation and propagation within the timeout",
+			steps: []domain.RehydrationStep{
+				{SpaceID: "25544", Status: domain.RehydrationStepRequested, UpdatedAt: stale},
+				{SpaceID: "43013", Status: domain.RehydrationStepRequested, UpdatedAt: recent},
+			},
+			expectedSteps:  []domain.RehydrationStepStatus{domain.RehydrationStepFailed, domain.RehydrationStepRequested},
+			expectedStatus: domain.RehydrationStatusRunning,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &memoryRehydrationRepository{rehydrations: map[string]*domain.Rehydration{
+				"context-1": {ContextID: "context-1", ContextName: "leo", TenantID: "tenant-a", Status: domain.RehydrationStatusRunning, Steps: tt.steps},
+			}}
+			emitter, store := newRecordingEmitter(t)
+			service := NewRehydrationService(repo, repository.ContextRepository{}, nil, emitter, newDryRunGlobalProperties(t))
+
+			if err := service.FailStalledPropagations(context.Background(), now); err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+
+			rehydration, _ := repo.FindByContext(context.Background(), "context-1")
+			steps := make([]domain.RehydrationStepStatus, len(rehydration.Steps))
+			for i, step := range rehydration.Steps {
+				steps[i] = step.Status
+			}
+			if !reflect.DeepEqual(steps, tt.expectedSteps) {
+				t.Errorf("Expected steps %v, but got %v", tt.expectedSteps, steps)
+			}
+			if rehydration.Status != tt.expectedStatus {
+				t.Errorf("Expected status %s, but got %s", tt.expectedStatus, rehydration.Status)
+			}
+			if types := store.types(); len(types) != len(tt.expectedEvents) || (len(types) > 0 && !reflect.DeepEqual(types, tt.expectedEvents)) {
+				t.Errorf("Expected events %v, but got %v", tt.expectedEvents, types)
+			}
+		})
+	}
+}
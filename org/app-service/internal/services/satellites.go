@@ -8,6 +8,7 @@ import (
 	propagator "github.com/org/2112-space-lab/org/app-service/internal/clients/propagate"
 	"github.com/org/2112-space-lab/org/app-service/internal/domain"
 	repository "github.com/org/2112-space-lab/org/app-service/internal/repositories"
+	api_mappers "github.com/org/2112-space-lab/org/app-service/pkg/api"
 	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
 	"github.com/org/2112-space-lab/org/app-service/pkg/tracing"
 	"github.com/org/2112-space-lab/org/go-utils/pkg/fx/xspace"
@@ -104,6 +105,16 @@ func (s *SatelliteService) GetSatelliteBySpaceID(ctx context.Context, spaceID st
 	return s.repo.FindBySpaceID(ctx, spaceID)
 }
 
+// SetSensorHalfAngle sets or clears the sensor cone half-angle used by swath mapping.
+func (s *SatelliteService) SetSensorHalfAngle(ctx context.Context, spaceID string, halfAngleDeg *float64) (err error) {
+	ctx, span := tracing.NewSpan(ctx, "SetSensorHalfAngle")
+	defer span.EndWithError(err)
+	if halfAngleDeg != nil && (*halfAngleDeg <= 0 || *halfAngleDeg >= 90) {
+		return fmt.Errorf("sensor half-angle must be within (0, 90) degrees")
+	}
+	return s.repo.SetSensorHalfAngle(ctx, spaceID, halfAngleDeg)
+}
+
 // ListAllSatellites retrieves all stored satellites.
 func (s *SatelliteService) ListAllSatellites(ctx context.Context) (satellite []domain.Satellite, err error) {
 	ctx, span := tracing.NewSpan(ctx, "ListAllSatellites")
@@ -122,8 +133,59 @@ func (s *SatelliteService) FetchAndStoreAllSatellites(ctx context.Context, maxCo
 		return nil, fmt.Errorf("no satellite metadata available")
 	}
 
-	var storedSatellites []domain.Satellite
+	storedSatellites, err := satellitesFromMetadata(rawSatellites, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(storedSatellites) > maxCount {
+		storedSatellites = storedSatellites[:maxCount] // Slice to keep only the first maxCount elements
+	}
+
+	if err := s.repo.SaveBatch(ctx, storedSatellites); err != nil {
+		return nil, fmt.Errorf("failed to save satellite to database: %w", err)
+	}
+
+	return storedSatellites, nil
+}
+
+// FetchAndStoreSatellites refreshes the catalogue entries of the given SPACE IDs only.
+func (s *SatelliteService) FetchAndStoreSatellites(ctx context.Context, spaceIDs []string) (satellite []domain.Satellite, err error) {
+	ctx, span := tracing.NewSpan(ctx, "FetchAndStoreSatellites")
+	defer span.EndWithError(err)
+	if len(spaceIDs) == 0 {
+		return nil, nil
+	}
+	rawSatellites, err := s.celestrackClient.FetchSatelliteMetadata(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch satellite metadata: %w", err)
+	}
+
+	wanted := make(map[string]bool, len(spaceIDs))
+	for _, spaceID := range spaceIDs {
+		wanted[spaceID] = true
+	}
+	storedSatellites, err := satellitesFromMetadata(rawSatellites, wanted)
+	if err != nil {
+		return nil, err
+	}
+	if len(storedSatellites) == 0 {
+		return nil, nil
+	}
+
+	if err := s.repo.SaveBatch(ctx, storedSatellites); err != nil {
+		return nil, fmt.Errorf("failed to save satellite to database: %w", err)
+	}
+	return storedSatellites, nil
+}
+
+// satellitesFromMetadata converts CelesTrak metadata to satellites, keeping the SPACE IDs of wanted when it is not nil.
+func satellitesFromMetadata(rawSatellites []*api_mappers.SatelliteMetadata, wanted map[string]bool) ([]domain.Satellite, error) {
+	var satellites []domain.Satellite
 	for _, rawSatellite := range rawSatellites {
+		if wanted != nil && !wanted[rawSatellite.SpaceID] {
+			continue
+		}
 
 		satellite, err := domain.NewSatelliteFromParameters(
 			rawSatellite.Name,
@@ -144,18 +206,9 @@ func (s *SatelliteService) FetchAndStoreAllSatellites(ctx context.Context, maxCo
 		if err != nil {
 			return nil, fmt.Errorf("failed to create satellite for SPACE ID %s: %w", rawSatellite.SpaceID, err)
 		}
-		storedSatellites = append(storedSatellites, satellite)
-	}
-
-	if len(storedSatellites) > maxCount {
-		storedSatellites = storedSatellites[:maxCount] // Slice to keep only the first maxCount elements
+		satellites = append(satellites, satellite)
 	}
-
-	if err := s.repo.SaveBatch(ctx, storedSatellites); err != nil {
-		return nil, fmt.Errorf("failed to save satellite to database: %w", err)
-	}
-
-	return storedSatellites, nil
+	return satellites, nil
 }
 
 // ListSatellitesWithPaginationAndTLE retrieves satellites with pagination and includes a flag indicating if a TLE is present.
@@ -194,8 +247,8 @@ func (s *SatelliteService) ListSatelliteInfoWithPagination(ctx context.Context,
 	return satelliteInfos, totalRecords, nil
 }
 
-// GetAndLockSatellites fetches and locks satellites for processing
-func (s *SatelliteService) GetAndLockSatellites(ctx context.Context, processorName string) (keys []string, err error) {
+// GetAndLockSatellites fetches and locks satellites for processing, restricted to one context when contextID is not empty
+func (s *SatelliteService) GetAndLockSatellites(ctx context.Context, processorName string, contextID string) (keys []string, err error) {
 	ctx, span := tracing.NewSpan(ctx, "GetAndLockSatellites")
 	defer span.EndWithError(err)
 
@@ -204,7 +257,7 @@ func (s *SatelliteService) GetAndLockSatellites(ctx context.Context, processorNa
 		return nil, fmt.Errorf("failed to fetch global property: %w", err)
 	}
 
-	lockedSatellites, err := s.repo.FetchAndLockSatellites(ctx, processorName, maxNbSatellites)
+	lockedSatellites, err := s.repo.FetchAndLockSatellites(ctx, processorName, maxNbSatellites, contextID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to lock satellites: %w", err)
 	}
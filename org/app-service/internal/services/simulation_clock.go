@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	repository "github.com/org/2112-space-lab/org/app-service/internal/repositories"
+	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
+	"github.com/org/2112-space-lab/org/app-service/pkg/tracing"
+)
+
+// SimulationClockService runs the simulation clocks of contexts. Position publishing, event detection and
+// visibility checks read the time of a context from it; contexts without a clock follow wall-clock time.
+type SimulationClockService struct {
+	repo           repository.SimulationClockRepository
+	contextRepo    repository.ContextRepository
+	globalPropRepo repository.GlobalPropertyRepository
+	cache          *simulationClockCache
+}
+
+// simulationClockCache keeps recently read clocks, shared by the copies of the service.
+type simulationClockCache struct {
+	mu     sync.Mutex
+	clocks map[string]cachedSimulationClock
+}
+
+type cachedSimulationClock struct {
+	clock     domain.SimulationClock
+	found     bool
+	fetchedAt time.Time
+}
+
+// NewSimulationClockService creates a new instance of SimulationClockService.
+func NewSimulationClockService(
+	repo repository.SimulationClockRepository,
+	contextRepo repository.ContextRepository,
+	globalPropRepo repository.GlobalPropertyRepository,
+) SimulationClockService {
+	return SimulationClockService{
+		repo:           repo,
+		contextRepo:    contextRepo,
+		globalPropRepo: globalPropRepo,
+		cache:          &simulationClockCache{clocks: make(map[string]cachedSimulationClock)},
+	}
+}
+
+// Get retrieves the clock of a context, a real-time clock when the context has none.
+func (s *SimulationClockService) Get(ctx context.Context, name domain.GameContextName) (clock domain.SimulationClock, err error) {
+	ctx, span := tracing.NewSpan(ctx, "GetSimulationClock")
+	defer span.EndWithError(err)
+
+	gameContext, err := s.contextRepo.FindByUniqueName(ctx, name)
+	if err != nil {
+		return clock, err
+	}
+	clock, _, err = s.load(ctx, gameContext.ID, time.Now().UTC())
+	return clock, err
+}
+
+// Start sets the clock of a context running from epoch at the given speed.
+func (s *SimulationClockService) Start(ctx context.Context, name domain.GameContextName, epoch time.Time, speed float64) (clock domain.SimulationClock, err error) {
+	ctx, span := tracing.NewSpan(ctx, "StartSimulationClock")
+	defer span.EndWithError(err)
+
+	if epoch.IsZero() {
+		return clock, fmt.Errorf("%w: missing epoch", domain.ErrInvalidSimulationClock)
+	}
+	return s.update(ctx, name, func(_ domain.SimulationClock, now time.Time) (domain.SimulationClock, error) {
+		return domain.NewSimulationClock("", epoch, speed, now)
+	})
+}
+
+// Pause freezes the clock of a context.
+func (s *SimulationClockService) Pause(ctx context.Context, name domain.GameContextName) (clock domain.SimulationClock, err error) {
+	ctx, span := tracing.NewSpan(ctx, "PauseSimulationClock")
+	defer span.EndWithError(err)
+
+	return s.update(ctx, name, func(c domain.SimulationClock, now time.Time) (domain.SimulationClock, error) {
+		return c.Pause(now), nil
+	})
+}
+
+// Resume restarts the paused clock of a context.
+func (s *SimulationClockService) Resume(ctx context.Context, name domain.GameContextName) (clock domain.SimulationClock, err error) {
+	ctx, span := tracing.NewSpan(ctx, "ResumeSimulationClock")
+	defer span.EndWithError(err)
+
+	return s.update(ctx, name, func(c domain.SimulationClock, now time.Time) (domain.SimulationClock, error) {
+		return c.Resume(now), nil
+	})
+}
+
+// Seek moves the clock of a context to the simulated time to.
+func (s *SimulationClockService) Seek(ctx context.Context, name domain.GameContextName, to time.Time) (clock domain.SimulationClock, err error) {
+	ctx, span := tracing.NewSpan(ctx, "SeekSimulationClock")
+	defer span.EndWithError(err)
+
+	if to.IsZero() {
+		return clock, fmt.Errorf("%w: missing time", domain.ErrInvalidSimulationClock)
+	}
+	return s.update(ctx, name, func(c domain.SimulationClock, now time.Time) (domain.SimulationClock, error) {
+		return c.Seek(to, now), nil
+	})
+}
+
+// SetSpeed changes the speed multiplier of the clock of a context.
+func (s *SimulationClockService) SetSpeed(ctx context.Context, name domain.GameContextName, speed float64) (clock domain.SimulationClock, err error) {
+	ctx, span := tracing.NewSpan(ctx, "SetSimulationClockSpeed")
+	defer span.EndWithError(err)
+
+	return s.update(ctx, name, func(c domain.SimulationClock, now time.Time) (domain.SimulationClock, error) {
+		return c.WithSpeed(speed, now)
+	})
+}
+
+// Reset removes the clock of a context, which follows wall-clock time again.
+func (s *SimulationClockService) Reset(ctx context.Context, name domain.GameContextName) (err error) {
+	ctx, span := tracing.NewSpan(ctx, "ResetSimulationClock")
+	defer span.EndWithError(err)
+
+	gameContext, err := s.contextRepo.FindByUniqueName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if err = s.repo.Delete(ctx, gameContext.ID); err != nil {
+		return fmt.Errorf("failed to reset clock of context %s: %w", name, err)
+	}
+	s.cache.forget(gameContext.ID)
+	return nil
+}
+
+// ClockFor returns the clock of a context of the tenant of ctx. Clocks are read again once the refresh period of
+// the cache has passed, so changes reach every reader within that period.
+func (s *SimulationClockService) ClockFor(ctx context.Context, contextID string) (clock domain.SimulationClock, err error) {
+	now := time.Now().UTC()
+	refresh, refreshErr := s.globalPropRepo.GetSimulationClockRefresh(ctx, repository.DefaultSimulationClockRefresh)
+	if refreshErr != nil {
+		log.Tracef("Using default simulation clock refresh [%s]: %v", refresh, refreshErr)
+	}
+	if cached, ok := s.cache.get(contextID, now, refresh); ok {
+		if !cached.found {
+			return domain.RealTimeClock(contextID, now), nil
+		}
+		return cached.clock, nil
+	}
+	clock, _, err = s.load(ctx, contextID, now)
+	return clock, err
+}
+
+// Now returns the simulated time of a context of the tenant of ctx.
+func (s *SimulationClockService) Now(ctx context.Context, contextID string) (time.Time, error) {
+	clock, err := s.ClockFor(ctx, contextID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return clock.Now(time.Now().UTC()), nil
+}
+
+// load reads the clock of a context and caches it.
+func (s *SimulationClockService) load(ctx context.Context, contextID string, now time.Time) (domain.SimulationClock, bool, error) {
+	clock, found, err := s.repo.FindByContext(ctx, contextID)
+	if err != nil {
+		return clock, false, fmt.Errorf("failed to read clock of context %s: %w", contextID, err)
+	}
+	s.cache.put(contextID, cachedSimulationClock{clock: clock, found: found, fetchedAt: now})
+	if !found {
+		return domain.RealTimeClock(contextID, now), false, nil
+	}
+	return clock, true, nil
+}
+
+// update applies a change to the clock of a context, starting from a real-time clock when it has none.
+func (s *SimulationClockService) update(ctx context.Context, name domain.GameContextName, change func(domain.SimulationClock, time.Time) (domain.SimulationClock, error)) (domain.SimulationClock, error) {
+	gameContext, err := s.contextRepo.FindByUniqueName(ctx, name)
+	if err != nil {
+		return domain.SimulationClock{}, err
+	}
+	now := time.Now().UTC()
+	current, _, err := s.load(ctx, gameContext.ID, now)
+	if err != nil {
+		return domain.SimulationClock{}, err
+	}
+
+	clock, err := change(current, now)
+	if err != nil {
+		return domain.SimulationClock{}, err
+	}
+	clock.ContextID = gameContext.ID
+	if err = s.repo.Save(ctx, clock); err != nil {
+		return domain.SimulationClock{}, fmt.Errorf("failed to save clock of context %s: %w", name, err)
+	}
+	s.cache.put(gameContext.ID, cachedSimulationClock{clock: clock, found: true, fetchedAt: now})
+	log.Infof("⏱ Clock of context %s at %s, speed x%g, paused %t", name, clock.Now(now).Format(time.RFC3339), clock.Speed, clock.Paused)
+	return clock, nil
+}
+
+func (c *simulationClockCache) get(contextID string, now time.Time, refresh time.Duration) (cachedSimulationClock, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached, ok := c.clocks[contextID]
+	if !ok || now.Sub(cached.fetchedAt) >= refresh {
+		return cachedSimulationClock{}, false
+	}
+	return cached, true
+}
+
+func (c *simulationClockCache) put(contextID string, cached cachedSimulationClock) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.clocks[contextID] = cached
+}
+
+func (c *simulationClockCache) forget(contextID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.clocks, contextID)
+}
@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	repository "github.com/org/2112-space-lab/org/app-service/internal/repositories"
+	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
+	"github.com/org/2112-space-lab/org/app-service/pkg/tracing"
+)
+
+// TaskScheduleService manages the cron schedules of tasks and hands their due runs to the schedulers. Schedulers
+// claim a run before starting it and renew its lease while it runs, so a run starts once whatever the number of
+// schedulers and never overlaps the previous run of its schedule.
+type TaskScheduleService struct {
+	repo           domain.TaskScheduleRepository
+	globalPropRepo repository.GlobalPropertyRepository
+}
+
+// NewTaskScheduleService creates a new instance of TaskScheduleService.
+func NewTaskScheduleService(repo domain.TaskScheduleRepository, globalPropRepo repository.GlobalPropertyRepository) TaskScheduleService {
+	return TaskScheduleService{repo: repo, globalPropRepo: globalPropRepo}
+}
+
+// GetAll retrieves every schedule.
+func (s *TaskScheduleService) GetAll(ctx context.Context) (schedules []domain.TaskSchedule, err error) {
+	ctx, span := tracing.NewSpan(ctx, "GetAllTaskSchedules")
+	defer span.EndWithError(err)
+	return s.repo.FindAll(ctx)
+}
+
+// Get retrieves a schedule by name.
+func (s *TaskScheduleService) Get(ctx context.Context, name string) (schedule domain.TaskSchedule, err error) {
+	ctx, span := tracing.NewSpan(ctx, "GetTaskSchedule")
+	defer span.EndWithError(err)
+	return s.repo.FindByName(ctx, name)
+}
+
+// Save creates or replaces a schedule. Its next run is computed from now on; a missing missed run policy skips
+// missed runs.
+func (s *TaskScheduleService) Save(ctx context.Context, schedule domain.TaskSchedule) (saved domain.TaskSchedule, err error) {
+	ctx, span := tracing.NewSpan(ctx, "SaveTaskSchedule")
+	defer span.EndWithError(err)
+
+	if schedule.MissedRunPolicy == "" {
+		schedule.MissedRunPolicy = domain.MissedRunSkip
+	}
+	cron, err := schedule.Validate()
+	if err != nil {
+		return domain.TaskSchedule{}, err
+	}
+	schedule.NextRunAt = nextRun(cron, schedule.Jitter, time.Now().UTC())
+
+	if err = s.repo.Save(ctx, schedule); err != nil {
+		return domain.TaskSchedule{}, err
+	}
+	return s.repo.FindByName(ctx, schedule.Name)
+}
+
+// Delete removes a schedule by name.
+func (s *TaskScheduleService) Delete(ctx context.Context, name string) (err error) {
+	ctx, span := tracing.NewSpan(ctx, "DeleteTaskSchedule")
+	defer span.EndWithError(err)
+	return s.repo.DeleteByName(ctx, name)
+}
+
+// Trigger makes an enabled schedule due at once. Its run still waits for the end of the previous one.
+func (s *TaskScheduleService) Trigger(ctx context.Context, name string) (schedule domain.TaskSchedule, err error) {
+	ctx, span := tracing.NewSpan(ctx, "TriggerTaskSchedule")
+	defer span.EndWithError(err)
+
+	schedule, err = s.repo.FindByName(ctx, name)
+	if err != nil {
+		return domain.TaskSchedule{}, err
+	}
+	if !schedule.Enabled {
+		return domain.TaskSchedule{}, fmt.Errorf("%w: %s", domain.ErrTaskScheduleDisabled, name)
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	if _, err = s.repo.Reschedule(ctx, name, schedule.NextRunAt, now); err != nil {
+		return domain.TaskSchedule{}, err
+	}
+	return s.repo.FindByName(ctx, name)
+}
+
+// ClaimDue claims for owner the due runs of the schedules at now. Runs missed by more than the grace period are
+// dropped by schedules skipping missed runs; the others run once. Schedules still running are left due until their
+// run finishes or its lease expires.
+func (s *TaskScheduleService) ClaimDue(ctx context.Context, now time.Time, owner string) (claimed []domain.TaskSchedule, err error) {
+	ctx, span := tracing.NewSpan(ctx, "ClaimDueTaskSchedules")
+	defer span.EndWithError(err)
+
+	lease := s.lease(ctx)
+	grace, graceErr := s.globalPropRepo.GetTaskScheduleMissedRunGrace(ctx, repository.DefaultTaskScheduleMissedRunGrace)
+	if graceErr != nil {
+		log.Tracef("Using default task schedule missed run grace [%s]: %v", grace, graceErr)
+	}
+
+	due, err := s.repo.FindDue(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	for _, schedule := range due {
+		cron, err := domain.ParseCronExpression(schedule.Cron)
+		if err != nil {
+			log.Errorf("❌ Schedule %s has an invalid cron expression: %v", schedule.Name, err)
+			continue
+		}
+		next := nextRun(cron, schedule.Jitter, now)
+
+		if schedule.MissedRunPolicy == domain.MissedRunSkip && schedule.Missed(now, grace) {
+			if _, err := s.repo.Reschedule(ctx, schedule.Name, schedule.NextRunAt, next); err != nil {
+				return claimed, fmt.Errorf("failed to skip the missed run of schedule %s: %w", schedule.Name, err)
+			}
+			log.Warnf("⏭️ Skipped the run of schedule %s missed at %s, next run at %s",
+				schedule.Name, schedule.NextRunAt.Format(time.RFC3339), next.Format(time.RFC3339))
+			continue
+		}
+
+		ok, err := s.repo.Claim(ctx, schedule.Name, schedule.NextRunAt, next, now, now.Add(lease), owner)
+		if err != nil {
+			return claimed, fmt.Errorf("failed to claim the run of schedule %s: %w", schedule.Name, err)
+		}
+		if !ok {
+			log.Tracef("Schedule %s is running or was claimed by another scheduler", schedule.Name)
+			continue
+		}
+		schedule.NextRunAt = next
+		claimed = append(claimed, schedule)
+	}
+	return claimed, nil
+}
+
+// Heartbeat renews the lease of the run of a schedule by owner every third of the lease until the context is
+// cancelled, so that a run lasting longer than the lease is not started again by another scheduler.
+func (s *TaskScheduleService) Heartbeat(ctx context.Context, schedule domain.TaskSchedule, owner string) {
+	for {
+		lease := s.lease(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(lease / 3):
+		}
+
+		ok, err := s.repo.Renew(ctx, schedule.Name, owner, time.Now().UTC().Add(lease))
+		switch {
+		case err != nil:
+			log.Errorf("❌ Failed to renew the lease of schedule %s: %v", schedule.Name, err)
+		case !ok:
+			log.Warnf("⚠️ Schedule %s is no longer run by %s, its lease is not renewed", schedule.Name, owner)
+			return
+		}
+	}
+}
+
+// Finish records the outcome of a run claimed by owner.
+func (s *TaskScheduleService) Finish(ctx context.Context, schedule domain.TaskSchedule, owner string, startedAt time.Time, runErr error) (err error) {
+	ctx, span := tracing.NewSpan(ctx, "FinishTaskSchedule")
+	defer span.EndWithError(err)
+
+	run := domain.TaskRun{StartedAt: startedAt, Duration: time.Since(startedAt), Status: domain.TaskRunSucceeded}
+	if runErr != nil {
+		run.Status, run.Error = domain.TaskRunFailed, runErr.Error()
+	}
+	return s.repo.Finish(ctx, schedule.Name, owner, run)
+}
+
+// lease returns how long a run may last without renewing its lease before another scheduler may start it again.
+func (s *TaskScheduleService) lease(ctx context.Context) time.Duration {
+	lease, err := s.globalPropRepo.GetTaskScheduleLease(ctx, repository.DefaultTaskScheduleLease)
+	if err != nil {
+		log.Tracef("Using default task schedule lease [%s]: %v", lease, err)
+	}
+	if lease <= 0 {
+		lease = repository.DefaultTaskScheduleLease
+	}
+	return lease
+}
+
+// nextRun returns the next run of a schedule after t with a random jitter, to the second.
+func nextRun(cron domain.CronSchedule, jitter time.Duration, t time.Time) time.Time {
+	return domain.NextRun(cron, jitter, t, rand.Float64()).Truncate(time.Second)
+}
@@ -0,0 +1,252 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	"gorm.io/gorm"
+)
+
+// memoryTaskScheduleRepository keeps schedules in memory with the claiming rules of the database. Methods the
+// schedulers do not use are left to the embedded interface and panic when called.
+type memoryTaskScheduleRepository struct {
+	domain.TaskScheduleRepository
+	mu        sync.Mutex
+	schedules map[string]domain.TaskSchedule
+}
+
+func newMemoryTaskScheduleRepository(schedules ...domain.TaskSchedule) *memoryTaskScheduleRepository {
+	repo := &memoryTaskScheduleRepository{schedules: map[string]domain.TaskSchedule{}}
+	for _, schedule := range schedules {
+		repo.schedules[schedule.Name] = schedule
+	}
+	return repo
+}
+
+func (r *memoryTaskScheduleRepository) FindByName(ctx context.Context, name string) (domain.TaskSchedule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	schedule, ok := r.schedules[name]
+	if !ok {
+		return domain.TaskSchedule{}, gorm.ErrRecordNotFound
+	}
+	return schedule, nil
+}
+
+func (r *memoryTaskScheduleRepository) FindDue(ctx context.Context, now time.Time) ([]domain.TaskSchedule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var due []domain.TaskSchedule
+	for _, schedule := range r.schedules {
+		if schedule.Enabled && !schedule.NextRunAt.After(now) {
+			due = append(due, schedule)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].NextRunAt.Before(due[j].NextRunAt) })
+	return due, nil
+}
+
+func (r *memoryTaskScheduleRepository) Claim(ctx context.Context, name string, dueAt, nextRunAt, now, leaseUntil time.Time, owner string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	schedule, ok := r.schedules[name]
+	if !ok || !schedule.Enabled || !schedule.NextRunAt.Equal(dueAt) {
+		return false, nil
+	}
+	if schedule.RunningSince != nil && !schedule.LeaseUntil.Before(now) {
+		return false, nil
+	}
+	schedule.NextRunAt, schedule.RunningSince, schedule.RunningOwner, schedule.LeaseUntil = nextRunAt, &now, owner, &leaseUntil
+	r.schedules[name] = schedule
+	return true, nil
+}
+
+func (r *memoryTaskScheduleRepository) Reschedule(ctx context.Context, name string, dueAt, nextRunAt time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	schedule, ok := r.schedules[name]
+	if !ok || !schedule.NextRunAt.Equal(dueAt) {
+		return false, nil
+	}
+	schedule.NextRunAt = nextRunAt
+	r.schedules[name] = schedule
+	return true, nil
+}
+
+func (r *memoryTaskScheduleRepository) Finish(ctx context.Context, name, owner string, run domain.TaskRun) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	schedule, ok := r.schedules[name]
+	if !ok || schedule.RunningSince == nil || schedule.RunningOwner != owner {
+		return nil
+	}
+	schedule.RunningSince, schedule.RunningOwner, schedule.LeaseUntil = nil, "", nil
+	schedule.LastRunAt, schedule.LastStatus, schedule.LastError, schedule.LastDuration = &run.StartedAt, run.Status, run.Error, run.Duration
+	r.schedules[name] = schedule
+	return nil
+}
+
+func newTestTaskScheduleService(t *testing.T, repo *memoryTaskScheduleRepository) TaskScheduleService {
+	t.Helper()
+	return NewTaskScheduleService(repo, newDryRunGlobalProperties(t))
+}
+
+func claimedNames(claimed []domain.TaskSchedule) []string {
+	names := make([]string, len(claimed))
+	for i, schedule := range claimed {
+		names[i] = schedule.Name
+	}
+	return names
+}
+
+func TestTaskScheduleClaimDue(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	schedule := func(name string, nextRunAt time.Time, enabled bool) domain.TaskSchedule {
+		return domain.TaskSchedule{
+			Name: name, TaskName: "celestrack_tle_upload", Cron: "0 * * * *", Enabled: enabled,
+			MissedRunPolicy: domain.MissedRunSkip, NextRunAt: nextRunAt,
+		}
+	}
+	repo := newMemoryTaskScheduleRepository(
+		schedule("due", now, true),
+		schedule("not-due", now.Add(time.Minute), true),
+		schedule("disabled", now, false),
+	)
+	service := newTestTaskScheduleService(t, repo)
+
+	claimed, err := service.ClaimDue(ctx, now, "scheduler-a")
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if names := claimedNames(claimed); len(names) != 1 || names[0] != "due" {
+		t.Fatalf("Expected [due] claimed, but got %v", names)
+	}
+	expectedNext := time.Date(2026, 1, 1, 13, 0, 0, 0, time.UTC)
+	if !claimed[0].NextRunAt.Equal(expectedNext) {
+		t.Errorf("Expected next run at %s, but got %s", expectedNext, claimed[0].NextRunAt)
+	}
+	stored, _ := repo.FindByName(ctx, "due")
+	if stored.RunningOwner != "scheduler-a" || stored.RunningSince == nil {
+		t.Errorf("Expected the run to be scheduler-a's, but got owner %q", stored.RunningOwner)
+	}
+
+	// The next activation is due while the first run is still within its lease: no other scheduler starts it.
+	if claimed, err := service.ClaimDue(ctx, expectedNext, "scheduler-b"); err != nil || len(claimed) != 0 {
+		t.Errorf("Expected nothing claimed during the run, but got %v, %v", claimedNames(claimed), err)
+	}
+
+	// Once the lease expired, another scheduler takes the run over.
+	claimed, err = service.ClaimDue(ctx, stored.LeaseUntil.Add(time.Second), "scheduler-b")
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if names := claimedNames(claimed); len(names) != 1 || names[0] != "due" {
+		t.Errorf("Expected [due] claimed after the lease, but got %v", names)
+	}
+}
+
+func TestTaskScheduleMissedRun(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 30, 0, 0, time.UTC)
+	expectedNext := time.Date(2026, 1, 1, 13, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		policy          domain.MissedRunPolicy
+		nextRunAt       time.Time
+		expectedClaimed bool
+	}{
+		{name: "Skipped", policy: domain.MissedRunSkip, nextRunAt: now.Add(-30 * time.Minute)},
+		{name: "Within the grace period", policy: domain.MissedRunSkip, nextRunAt: now.Add(-time.Minute), expectedClaimed: true},
+		{name: "Run once", policy: domain.MissedRunOnce, nextRunAt: now.Add(-30 * time.Minute), expectedClaimed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMemoryTaskScheduleRepository(domain.TaskSchedule{
+				Name: "tle-refresh", TaskName: "celestrack_tle_upload", Cron: "0 * * * *", Enabled: true,
+				MissedRunPolicy: tt.policy, NextRunAt: tt.nextRunAt,
+			})
+			service := newTestTaskScheduleService(t, repo)
+
+			claimed, err := service.ClaimDue(ctx, now, "scheduler-a")
+			if err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+			if got := len(claimed) == 1; got != tt.expectedClaimed {
+				t.Errorf("Expected claimed %v, but got %v", tt.expectedClaimed, claimedNames(claimed))
+			}
+			stored, _ := repo.FindByName(ctx, "tle-refresh")
+			if !stored.NextRunAt.Equal(expectedNext) {
+				t.Errorf("Expected next run at %s, but got %s", expectedNext, stored.NextRunAt)
+			}
+			if running := stored.RunningSince != nil; running != tt.expectedClaimed {
+				t.Errorf("Expected running %v, but got %v", tt.expectedClaimed, running)
+			}
+		})
+	}
+}
+
+func TestTaskScheduleFinish(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		owner           string
+		runErr          error
+		expectedStatus  domain.TaskRunStatus
+		expectedError   string
+		expectedRunning bool
+	}{
+		{name: "Succeeded", owner: "scheduler-a", expectedStatus: domain.TaskRunSucceeded},
+		{name: "Failed", owner: "scheduler-a", runErr: errors.New("boom"), expectedStatus: domain.TaskRunFailed, expectedError: "boom"},
+		{name: "Taken over", owner: "scheduler-b", expectedRunning: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMemoryTaskScheduleRepository(domain.TaskSchedule{
+				Name: "tle-refresh", TaskName: "celestrack_tle_upload", Cron: "0 * * * *", Enabled: true,
+				MissedRunPolicy: domain.MissedRunSkip, NextRunAt: now,
+			})
+			service := newTestTaskScheduleService(t, repo)
+			claimed, err := service.ClaimDue(ctx, now, "scheduler-a")
+			if err != nil || len(claimed) != 1 {
+				t.Fatalf("Expected the run claimed, but got %v, %v", claimedNames(claimed), err)
+			}
+
+			if err := service.Finish(ctx, claimed[0], tt.owner, now, tt.runErr); err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+
+			stored, _ := repo.FindByName(ctx, "tle-refresh")
+			if running := stored.RunningSince != nil; running != tt.expectedRunning {
+				t.Errorf("Expected running %v, but got %v", tt.expectedRunning, running)
+			}
+			if stored.LastStatus != tt.expectedStatus {
+				t.Errorf("Expected status %q, but got %q", tt.expectedStatus, stored.LastStatus)
+			}
+			if stored.LastError != tt.expectedError {
+				t.Errorf("Expected error %q, but got %q", tt.expectedError, stored.LastError)
+			}
+		})
+	}
+}
+
+func TestTaskScheduleTriggerDisabled(t *testing.T) {
+	repo := newMemoryTaskScheduleRepository(domain.TaskSchedule{
+		Name: "tle-refresh", TaskName: "celestrack_tle_upload", Cron: "0 * * * *", MissedRunPolicy: domain.MissedRunSkip,
+	})
+	service := newTestTaskScheduleService(t, repo)
+
+	if _, err := service.Trigger(context.Background(), "tle-refresh"); !errors.Is(err, domain.ErrTaskScheduleDisabled) {
+		t.Errorf("Expected ErrTaskScheduleDisabled, but got %v", err)
+	}
+}
@@ -9,27 +9,43 @@ import (
 	repository "github.com/org/2112-space-lab/org/app-service/internal/repositories"
 	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
 	"github.com/org/2112-space-lab/org/app-service/pkg/tracing"
+	"github.com/org/2112-space-lab/org/go-utils/pkg/fx/xspace"
 )
 
 type TileService struct {
-	repo          repository.TileRepository
-	tleRepo       repository.TleRepository
-	satelliteRepo repository.SatelliteRepository
-	mappingRepo   repository.TileSatelliteMappingRepository
+	repo           domain.TileRepository
+	tleRepo        repository.TleRepository
+	satelliteRepo  repository.SatelliteRepository
+	mappingRepo    repository.TileSatelliteMappingRepository
+	watermarkRepo  repository.MappingWatermarkRepository
+	globalPropRepo repository.GlobalPropertyRepository
+	transactor     *repository.Transactor
+	coverage       *CoverageService // Refreshed once mappings change; nil leaves coverage to its cache TTL
 }
 
+// mappingStitchTolerance absorbs the rounding of position timestamps when joining a new span to the previous one.
+const mappingStitchTolerance = time.Second
+
 // NewTileService creates a new instance of TileService.
 func NewTileService(
-	tileRepo repository.TileRepository,
+	tileRepo domain.TileRepository,
 	tleRepo repository.TleRepository,
 	satelliteRepo repository.SatelliteRepository,
 	mappingRepo repository.TileSatelliteMappingRepository,
+	watermarkRepo repository.MappingWatermarkRepository,
+	globalPropRepo repository.GlobalPropertyRepository,
+	transactor *repository.Transactor,
+	coverage *CoverageService,
 ) TileService {
 	return TileService{
-		repo:          tileRepo,
-		tleRepo:       tleRepo,
-		satelliteRepo: satelliteRepo,
-		mappingRepo:   mappingRepo,
+		repo:           tileRepo,
+		tleRepo:        tleRepo,
+		satelliteRepo:  satelliteRepo,
+		mappingRepo:    mappingRepo,
+		watermarkRepo:  watermarkRepo,
+		globalPropRepo: globalPropRepo,
+		transactor:     transactor,
+		coverage:       coverage,
 	}
 }
 
@@ -122,7 +138,31 @@ func (s *TileService) GetSatelliteMappingsBySpaceID(ctx context.Context, context
 	return mappings, nil
 }
 
-// RecomputeMappings deletes existing mappings for a SPACE ID in a specific context and computes new ones.
+// FindMappingsInWindow retrieves mappings whose pass overlaps the given time window.
+func (s *TileService) FindMappingsInWindow(ctx context.Context, contextID string, filter domain.MappingWindowFilter) (ts []domain.TileSatelliteInfo, err error) {
+	ctx, span := tracing.NewSpan(ctx, "FindMappingsInWindow")
+	defer span.EndWithError(err)
+
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	mappings, err := s.mappingRepo.FindMappingsInWindow(ctx, contextID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve mappings in window for context [%s]: %w", contextID, err)
+	}
+
+	return mappings, nil
+}
+
+// RecomputeMappings replaces the mappings of a SPACE ID in a specific context from startTime on with freshly computed ones.
+// Mappings that ended before startTime are kept until the retention purge removes them.
 func (s *TileService) RecomputeMappings(ctx context.Context, contextID, spaceID string, startTime, endTime time.Time) (err error) {
 	ctx, span := tracing.NewSpan(ctx, "RecomputeMappings")
 	defer span.EndWithError(err)
@@ -134,20 +174,18 @@ func (s *TileService) RecomputeMappings(ctx context.Context, contextID, spaceID
 	default:
 	}
 
-	// Step 1: Delete existing mappings
-	if err := s.mappingRepo.DeleteMappingsBySpaceID(ctx, contextID, spaceID); err != nil {
-		return fmt.Errorf("failed to delete existing mappings for SPACE ID [%s] in context [%s]: %w", spaceID, contextID, err)
-	}
-	log.Tracef("Deleted existing mappings for SPACE ID: %s in context: %s\n", spaceID, contextID)
-
-	// Step 2: Fetch satellite data
+	// Step 1: Fetch satellite data
 	satellite, err := s.satelliteRepo.FindBySpaceID(ctx, spaceID)
 	if err != nil {
 		return fmt.Errorf("failed to fetch satellite for SPACE ID [%s]: %w", spaceID, err)
 	}
 
-	// Step 3: Fetch satellite positions
-	positions, err := s.tleRepo.QuerySatellitePositions(ctx, satellite.SpaceID, startTime, endTime)
+	// Step 2: Fetch satellite positions, from the TLE the context is pinned to if any
+	pins, err := s.tleRepo.FindTLEPins(ctx, contextID, satellite.SpaceID)
+	if err != nil {
+		return fmt.Errorf("failed to resolve pinned TLEs of context [%s]: %w", contextID, err)
+	}
+	positions, err := s.satellitePositions(ctx, pins, satellite.SpaceID, startTime, endTime)
 	if err != nil {
 		return fmt.Errorf("failed to fetch satellite positions for SPACE ID [%s]: %w", spaceID, err)
 	}
@@ -157,17 +195,218 @@ func (s *TileService) RecomputeMappings(ctx context.Context, contextID, spaceID
 		return nil
 	}
 
-	// Step 4: Compute new mappings
-	mappings, err := s.repo.FindTilesVisibleFromLine(ctx, satellite, positions)
+	// Step 3: Compute new mappings
+	mappings, err := s.ComputeMappings(ctx, satellite, positions)
 	if err != nil {
 		return fmt.Errorf("failed to compute tile mappings for SPACE ID [%s]: %w", spaceID, err)
 	}
 
-	// Step 5: Save new mappings
-	if err := s.mappingRepo.SaveBatch(ctx, mappings); err != nil {
-		return fmt.Errorf("failed to save new mappings for SPACE ID [%s]: %w", spaceID, err)
+	// Step 4: Swap old and new mappings atomically; later increments continue from the end of the recomputed track
+	if err := s.replaceMappings(ctx, contextID, spaceID, startTime, mappings, positions[len(positions)-1].Timestamp); err != nil {
+		return err
 	}
 
 	log.Debugf("Recomputed and saved %d mappings for SPACE ID: %s in context: %s\n", len(mappings), spaceID, contextID)
 	return nil
 }
+
+// AppendMappings computes mappings only for the part of [startTime, endTime] past the satellite's watermark
+// and appends them. Passes cut at the previous watermark are extended rather than duplicated.
+func (s *TileService) AppendMappings(ctx context.Context, contextID string, satellite domain.Satellite, startTime, endTime time.Time) (err error) {
+	ctx, span := tracing.NewSpan(ctx, "AppendMappings")
+	defer span.EndWithError(err)
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	watermark, err := s.watermarkRepo.Get(ctx, contextID, satellite.SpaceID)
+	if err != nil {
+		return fmt.Errorf("failed to read mapping watermark for SPACE ID [%s]: %w", satellite.SpaceID, err)
+	}
+
+	from := startTime
+	if watermark.HasValue {
+		if !endTime.After(watermark.Value) {
+			log.Tracef("Mappings for SPACE ID: %s already computed up to %s\n", satellite.SpaceID, watermark.Value)
+			return nil
+		}
+		if watermark.Value.After(from) {
+			from = watermark.Value
+		}
+	}
+
+	pins, err := s.tleRepo.FindTLEPins(ctx, contextID, satellite.SpaceID)
+	if err != nil {
+		return fmt.Errorf("failed to resolve pinned TLEs of context [%s]: %w", contextID, err)
+	}
+	positions, err := s.satellitePositions(ctx, pins, satellite.SpaceID, from, endTime)
+	if err != nil {
+		return fmt.Errorf("failed to fetch satellite positions for SPACE ID [%s]: %w", satellite.SpaceID, err)
+	}
+
+	if len(positions) < 2 {
+		log.Warnf("Not enough positions to compute mappings for SPACE ID: %s\n", satellite.SpaceID)
+		return nil
+	}
+
+	mappings, err := s.ComputeMappings(ctx, satellite, positions)
+	if err != nil {
+		return fmt.Errorf("failed to compute tile mappings for SPACE ID [%s]: %w", satellite.SpaceID, err)
+	}
+
+	end := positions[len(positions)-1].Timestamp
+
+	// Without a watermark nothing tells which stored mappings the track overlaps, so they are replaced
+	// rather than appended to.
+	if !watermark.HasValue {
+		if err := s.replaceMappings(ctx, contextID, satellite.SpaceID, from, mappings, end); err != nil {
+			return err
+		}
+		log.Debugf("Replaced mappings with %d for SPACE ID: %s in context: %s\n", len(mappings), satellite.SpaceID, contextID)
+		return nil
+	}
+
+	extended, added := []domain.TileSatelliteMapping(nil), mappings
+	if !from.After(watermark.Value) {
+		open, err := s.mappingRepo.FindOpenMappings(ctx, contextID, satellite.SpaceID, watermark.Value.Add(-mappingStitchTolerance))
+		if err != nil {
+			return fmt.Errorf("failed to fetch open mappings for SPACE ID [%s]: %w", satellite.SpaceID, err)
+		}
+		extended, added = domain.StitchMappings(open, mappings, watermark.Value, mappingStitchTolerance)
+	}
+
+	err = s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.mappingRepo.ApplyMappingIncrement(ctx, contextID, satellite.SpaceID, extended, added); err != nil {
+			return fmt.Errorf("failed to save mappings for SPACE ID [%s]: %w", satellite.SpaceID, err)
+		}
+		if err := s.watermarkRepo.Set(ctx, contextID, satellite.SpaceID, end); err != nil {
+			return fmt.Errorf("failed to update mapping watermark for SPACE ID [%s]: %w", satellite.SpaceID, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	s.refreshCoverage(ctx, contextID, satellite.SpaceID)
+
+	log.Debugf("Appended %d and extended %d mappings for SPACE ID: %s in context: %s\n", len(added), len(extended), satellite.SpaceID, contextID)
+	return nil
+}
+
+// replaceMappings swaps the mappings of a satellite from from on and moves its watermark in one transaction, then
+// refreshes the coverage of the context.
+func (s *TileService) replaceMappings(ctx context.Context, contextID, spaceID string, from time.Time, mappings []domain.TileSatelliteMapping, watermark time.Time) error {
+	err := s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.mappingRepo.ReplaceMappings(ctx, contextID, spaceID, from, mappings); err != nil {
+			return fmt.Errorf("failed to replace mappings for SPACE ID [%s]: %w", spaceID, err)
+		}
+		if err := s.watermarkRepo.Set(ctx, contextID, spaceID, watermark); err != nil {
+			return fmt.Errorf("failed to update mapping watermark for SPACE ID [%s]: %w", spaceID, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	s.refreshCoverage(ctx, contextID, spaceID)
+	return nil
+}
+
+// refreshCoverage updates the cached coverage of a context after the mappings of a satellite were committed.
+func (s *TileService) refreshCoverage(ctx context.Context, contextID, spaceID string) {
+	if s.coverage != nil {
+		s.coverage.RefreshForMappings(ctx, contextID, spaceID)
+	}
+}
+
+// satellitePositions returns the track of a satellite for a context whose pinning was resolved by the caller. A context
+// with pinned TLEs propagates its pinned version locally, so its mappings do not move when newer TLEs are ingested;
+// other contexts read the shared track.
+func (s *TileService) satellitePositions(ctx context.Context, pins domain.TLEPins, spaceID string, from, to time.Time) ([]domain.SatellitePosition, error) {
+	tle, pinned, err := pins.Lookup(spaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !pinned {
+		return s.tleRepo.QuerySatellitePositions(ctx, spaceID, from, to)
+	}
+
+	step, propErr := s.globalPropRepo.GetPinnedPropagationStep(ctx, repository.DefaultPinnedPropagationStep)
+	if propErr != nil {
+		log.Tracef("Using default pinned propagation step [%s]: %v", step, propErr)
+	}
+	return propagateTLE(tle, from, to, step)
+}
+
+// propagateTLE computes the track of a TLE over [from, to], one position per step.
+func propagateTLE(tle domain.TLE, from, to time.Time, step time.Duration) ([]domain.SatellitePosition, error) {
+	track, err := xspace.PropagateRange(tle.Line1, tle.Line2, from.UTC(), to.UTC(), step)
+	if err != nil {
+		return nil, fmt.Errorf("failed to propagate pinned TLE of SPACE ID [%s] (epoch %s): %w", tle.SpaceID, tle.Epoch.Format(time.RFC3339), err)
+	}
+
+	positions := make([]domain.SatellitePosition, len(track))
+	for i, p := range track {
+		positions[i] = domain.SatellitePosition{
+			Latitude:  p.Latitude,
+			Longitude: p.Longitude,
+			Altitude:  p.Altitude,
+			Timestamp: p.Time,
+			CreatedAt: p.Time,
+		}
+	}
+	return positions, nil
+}
+
+// PurgeExpiredMappings deletes mappings that ended before the configured retention window.
+func (s *TileService) PurgeExpiredMappings(ctx context.Context) (purged int64, err error) {
+	ctx, span := tracing.NewSpan(ctx, "PurgeExpiredMappings")
+	defer span.EndWithError(err)
+
+	retention, propErr := s.globalPropRepo.GetMappingRetention(ctx, repository.DefaultMappingRetention)
+	if propErr != nil {
+		log.Tracef("Using default mapping retention [%s]: %v", retention, propErr)
+	}
+	if retention <= 0 {
+		return 0, nil
+	}
+
+	cutoff := time.Now().UTC().Add(-retention)
+	purged, err = s.mappingRepo.PurgeMappingsBefore(ctx, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge mappings ended before %s: %w", cutoff.Format(time.RFC3339), err)
+	}
+
+	if purged > 0 {
+		log.Debugf("Purged %d mappings ended before %s\n", purged, cutoff.Format(time.RFC3339))
+	}
+	return purged, nil
+}
+
+// ComputeMappings finds the tiles seen along a track using the configured mapping mode:
+// the nadir ground track (default) or the footprint swath.
+func (s *TileService) ComputeMappings(ctx context.Context, satellite domain.Satellite, positions []domain.SatellitePosition) (mappings []domain.TileSatelliteMapping, err error) {
+	ctx, span := tracing.NewSpan(ctx, "ComputeMappings")
+	defer span.EndWithError(err)
+
+	mode, propErr := s.globalPropRepo.GetMappingMode(ctx, repository.DefaultMappingMode)
+	if propErr != nil {
+		log.Tracef("Using default mapping mode [%s]: %v", mode, propErr)
+	}
+
+	switch domain.MappingMode(mode) {
+	case domain.MappingModeSwath:
+		minElevation, propErr := s.globalPropRepo.GetSwathMinElevation(ctx, repository.DefaultSwathMinElevationDeg)
+		if propErr != nil {
+			log.Tracef("Using default swath minimum elevation [%.1f]: %v", minElevation, propErr)
+		}
+		return s.repo.FindTilesVisibleFromSwath(ctx, satellite, positions, minElevation)
+	case domain.MappingModeNadir:
+		return s.repo.FindTilesVisibleFromLine(ctx, satellite, positions)
+	default:
+		return nil, fmt.Errorf("unknown mapping mode [%s]", mode)
+	}
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/data"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
+	repository "github.com/org/2112-space-lab/org/app-service/internal/repositories"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+var issTLE = domain.TLE{
+	SpaceID: "25544",
+	Line1:   "1 25544U 98067A   24296.51782528  .00016717  00000-0  30272-3 0  9994",
+	Line2:   "2 25544  51.6416 168.7165 0009024  84.3557 275.8483 15.49815291478456",
+	Epoch:   time.Date(2024, 10, 22, 12, 25, 40, 0, time.UTC),
+}
+
+// newDryRunGlobalProperties returns global properties read from a database that never returns rows, so every
+// setting takes its default.
+func newDryRunGlobalProperties(t *testing.T) repository.GlobalPropertyRepository {
+	t.Helper()
+	db, err := gorm.Open(
+		postgres.New(postgres.Config{DSN: "host=127.0.0.1 user=test dbname=test sslmode=disable"}),
+		&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true},
+	)
+	if err != nil {
+		t.Fatalf("Failed to open dry run database: %v", err)
+	}
+	return repository.NewGlobalPropertyRepository(&data.Database{DbHandler: db})
+}
+
+// newDryRunTileService returns a TileService with default settings. Its TLE repository must not be reached.
+func newDryRunTileService(t *testing.T) TileService {
+	t.Helper()
+	return TileService{globalPropRepo: newDryRunGlobalProperties(t)}
+}
+
+func TestSatellitePositionsPropagatesPinnedTLELocally(t *testing.T) {
+	service := newDryRunTileService(t)
+	from := issTLE.Epoch.Add(time.Hour)
+	to := from.Add(5 * time.Minute)
+
+	positions, err := service.satellitePositions(context.Background(), domain.NewTLEPins([]domain.TLE{issTLE}), issTLE.SpaceID, from, to)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	expected := int(to.Sub(from)/repository.DefaultPinnedPropagationStep) + 1
+	if len(positions) != expected {
+		t.Fatalf("Expected %d positions, but got %d", expected, len(positions))
+	}
+	for i, position := range positions {
+		at := from.Add(time.Duration(i) * repository.DefaultPinnedPropagationStep)
+		if !position.Timestamp.Equal(at) {
+			t.Errorf("Expected position %d at %s, but got %s", i, at, position.Timestamp)
+		}
+		if position.Latitude < -51.7 || position.Latitude > 51.7 {
+			t.Errorf("Expected latitude within the ISS inclination, but got %f", position.Latitude)
+		}
+		if position.Altitude < 350 || position.Altitude > 450 {
+			t.Errorf("Expected an ISS altitude, but got %f km", position.Altitude)
+		}
+	}
+}
+
+func TestSatellitePositionsRefusesUnpinnedSatelliteOfPinnedContext(t *testing.T) {
+	service := newDryRunTileService(t)
+
+	_, err := service.satellitePositions(context.Background(), domain.NewTLEPins([]domain.TLE{issTLE}), "48274", issTLE.Epoch, issTLE.Epoch.Add(time.Minute))
+	if !errors.Is(err, domain.ErrTLENotPinned) {
+		t.Errorf("Expected %v, but got %v", domain.ErrTLENotPinned, err)
+	}
+}
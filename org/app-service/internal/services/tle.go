@@ -8,6 +8,7 @@ import (
 	"github.com/org/2112-space-lab/org/app-service/internal/domain"
 	repository "github.com/org/2112-space-lab/org/app-service/internal/repositories"
 	api_mappers "github.com/org/2112-space-lab/org/app-service/pkg/api"
+	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
 	xtime "github.com/org/2112-space-lab/org/app-service/pkg/time"
 	"github.com/org/2112-space-lab/org/app-service/pkg/tracing"
 )
@@ -108,3 +109,50 @@ func (s *TleService) FetchSatelliteMetadata(ctx context.Context, contextName dom
 
 	return satellites, nil
 }
+
+// PinContextTLEs pins a context to the TLE versions in effect at epoch, so its rehydration, propagation and mappings
+// replay identically whatever is ingested later.
+func (s *TleService) PinContextTLEs(ctx context.Context, contextName domain.GameContextName, epoch time.Time) (count int, err error) {
+	ctx, span := tracing.NewSpan(ctx, "PinContextTLEs")
+	defer span.EndWithError(err)
+
+	if epoch.IsZero() {
+		return 0, fmt.Errorf("an epoch is required to pin the TLEs of context [%s]", contextName)
+	}
+	if epoch.After(time.Now().UTC()) {
+		return 0, fmt.Errorf("cannot pin the TLEs of context [%s] at a future epoch", contextName)
+	}
+
+	count, err = s.tleRepo.PinContextTLEs(ctx, contextName, epoch)
+	if err != nil {
+		return 0, err
+	}
+
+	log.Infof("📌 Pinned %d TLEs of context %s at %s", count, contextName, epoch.UTC().Format(time.RFC3339))
+	return count, nil
+}
+
+// UnpinContextTLEs makes a context follow the latest TLEs again.
+func (s *TleService) UnpinContextTLEs(ctx context.Context, contextName domain.GameContextName) (err error) {
+	ctx, span := tracing.NewSpan(ctx, "UnpinContextTLEs")
+	defer span.EndWithError(err)
+
+	if err = s.tleRepo.UnpinContextTLEs(ctx, contextName); err != nil {
+		return err
+	}
+
+	log.Infof("📌 Unpinned TLEs of context %s", contextName)
+	return nil
+}
+
+// GetPinnedTLEs retrieves the pinned TLEs of a context; pinned is false when the context follows the latest TLEs.
+func (s *TleService) GetPinnedTLEs(ctx context.Context, contextName domain.GameContextName) (tles []domain.TLE, pinned bool, err error) {
+	ctx, span := tracing.NewSpan(ctx, "GetPinnedTLEs")
+	defer span.EndWithError(err)
+
+	gameContext, err := s.contextRepo.FindByUniqueName(ctx, contextName)
+	if err != nil {
+		return nil, false, err
+	}
+	return s.tleRepo.GetPinnedTLEs(ctx, gameContext.ID)
+}
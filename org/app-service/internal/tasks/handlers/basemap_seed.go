@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/services"
+	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
+)
+
+type BasemapSeedHandler struct {
+	basemapService *services.BasemapService
+}
+
+// NewBasemapSeedHandler creates a new instance of BasemapSeedHandler.
+func NewBasemapSeedHandler(basemapService *services.BasemapService) BasemapSeedHandler {
+	return BasemapSeedHandler{
+		basemapService: basemapService,
+	}
+}
+
+// GetTask provides metadata about this handler's task.
+func (h *BasemapSeedHandler) GetTask() Task {
+	return Task{
+		Name:        "basemap_seed",
+		Description: "Pre-seeds the offline basemap cache for a zoom range (optional bbox: minLat, minLon, maxLat, maxLon)",
+		RequiredArgs: []string{
+			"minZoom",
+			"maxZoom",
+		},
+	}
+}
+
+// Run executes the handler's task with the provided arguments.
+func (h *BasemapSeedHandler) Run(ctx context.Context, args map[string]string) error {
+	minZoom, err := strconv.Atoi(args["minZoom"])
+	if err != nil {
+		return fmt.Errorf("invalid or missing argument minZoom: %w", err)
+	}
+	maxZoom, err := strconv.Atoi(args["maxZoom"])
+	if err != nil {
+		return fmt.Errorf("invalid or missing argument maxZoom: %w", err)
+	}
+
+	bounds := services.WorldBasemapBounds
+	for key, target := range map[string]*float64{
+		"minLat": &bounds.MinLat,
+		"minLon": &bounds.MinLon,
+		"maxLat": &bounds.MaxLat,
+		"maxLon": &bounds.MaxLon,
+	} {
+		v, ok := args[key]
+		if !ok || v == "" {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", key, err)
+		}
+		*target = parsed
+	}
+
+	report, err := h.basemapService.SeedZoomRange(ctx, minZoom, maxZoom, bounds)
+	if err != nil {
+		return fmt.Errorf("failed to seed basemap: %w", err)
+	}
+	if report.Failed > 0 {
+		log.Warnf("⚠️ %d basemap tiles failed to seed, rerun the task to retry", report.Failed)
+	}
+	return nil
+}
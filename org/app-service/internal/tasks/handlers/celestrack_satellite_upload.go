@@ -12,17 +12,25 @@ type SatelliteServiceClient interface {
 	FetchAndStoreAllSatellites(ctx context.Context, maxCount int) ([]domain.Satellite, error)
 }
 
+// MembershipEvaluator re-applies the membership rules of contexts after the catalogue changed
+type MembershipEvaluator interface {
+	EvaluateAll(ctx context.Context, reason string) error
+}
+
 type CelesTrackSatelliteUploadHandler struct {
-	satelliteRepo    domain.SatelliteRepository
-	satelliteService SatelliteServiceClient
+	satelliteRepo       domain.SatelliteRepository
+	satelliteService    SatelliteServiceClient
+	membershipEvaluator MembershipEvaluator
 }
 
 func NewCelesTrackSatelliteUploadHandler(
 	satelliteRepo domain.SatelliteRepository,
-	satelliteService SatelliteServiceClient) CelesTrackSatelliteUploadHandler {
+	satelliteService SatelliteServiceClient,
+	membershipEvaluator MembershipEvaluator) CelesTrackSatelliteUploadHandler {
 	return CelesTrackSatelliteUploadHandler{
-		satelliteRepo:    satelliteRepo,
-		satelliteService: satelliteService,
+		satelliteRepo:       satelliteRepo,
+		satelliteService:    satelliteService,
+		membershipEvaluator: membershipEvaluator,
 	}
 }
 
@@ -52,5 +60,8 @@ func (h *CelesTrackSatelliteUploadHandler) Run(ctx context.Context, args map[str
 		return err
 	}
 
+	if err = h.membershipEvaluator.EvaluateAll(ctx, "satcat ingestion"); err != nil {
+		return fmt.Errorf("failed to re-evaluate membership rules: %w", err)
+	}
 	return nil
 }
@@ -20,29 +20,40 @@ type TleServiceClient interface {
 	FetchTLEFromSatCatByCategory(ctx context.Context, category string, contextName domain.GameContextName) ([]domain.TLE, error)
 }
 
+// Transactor runs repository calls in one database transaction
+type Transactor interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 // CelestrackTleUploadHandler handles TLE uploads from CelesTrak
 type CelestrackTleUploadHandler struct {
-	satelliteRepo repository.SatelliteRepository
-	tleRepo       repository.TleRepository
-	tleService    TleServiceClient
-	eventEmitter  *events.EventEmitter
-	eventsMonitor *events.EventMonitor
+	satelliteRepo       repository.SatelliteRepository
+	tleRepo             repository.TleRepository
+	transactor          Transactor
+	tleService          TleServiceClient
+	membershipEvaluator MembershipEvaluator
+	eventEmitter        *events.EventEmitter
+	eventsMonitor       *events.EventMonitor
 }
 
 // NewCelestrackTleUploadHandler creates a new task
 func NewCelestrackTleUploadHandler(
 	satelliteRepo repository.SatelliteRepository,
 	tleRepo repository.TleRepository,
+	transactor Transactor,
 	tleService TleServiceClient,
+	membershipEvaluator MembershipEvaluator,
 	eventEmitter *events.EventEmitter,
 	eventsMonitor *events.EventMonitor,
 ) CelestrackTleUploadHandler {
 	return CelestrackTleUploadHandler{
-		satelliteRepo: satelliteRepo,
-		tleRepo:       tleRepo,
-		tleService:    tleService,
-		eventEmitter:  eventEmitter,
-		eventsMonitor: eventsMonitor,
+		satelliteRepo:       satelliteRepo,
+		tleRepo:             tleRepo,
+		transactor:          transactor,
+		tleService:          tleService,
+		membershipEvaluator: membershipEvaluator,
+		eventEmitter:        eventEmitter,
+		eventsMonitor:       eventsMonitor,
 	}
 }
 
@@ -88,38 +99,46 @@ func (h *CelestrackTleUploadHandler) Run(ctx context.Context, args map[string]st
 		tles = tles[:maxCount]
 	}
 
-	err = h.tleRepo.UpdateTleBatch(ctx, tles)
+	// The TLEs and their completion event are committed together, so stored TLEs always trigger propagation.
+	err = h.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := h.tleRepo.UpdateTleBatch(ctx, tles); err != nil {
+			return fmt.Errorf("failed to upsert TLE batch: %v", err)
+		}
+		return h.emitTleProcessedEvent(ctx, category, maxCount, len(tles))
+	})
 	if err != nil {
-		return fmt.Errorf("failed to upsert TLE batch: %v", err)
+		return err
 	}
 
 	log.Debugf("✅ Successfully processed %d TLEs for category %s", len(tles), category)
 
-	err = h.emitTleProcessedEvent(ctx, category, maxCount, len(tles))
-	return err
+	if err = h.membershipEvaluator.EvaluateAll(ctx, "tle ingestion"); err != nil {
+		return fmt.Errorf("failed to re-evaluate membership rules: %w", err)
+	}
+	return nil
 }
 
-// emitTleProcessedEvent sends a completion event
+// emitTleProcessedEvent stores a completion event in the outbox, within the transaction of ctx
 func (h *CelestrackTleUploadHandler) emitTleProcessedEvent(ctx context.Context, category string, maxRequested, processed int) (err error) {
 	ctx, span := tracing.NewSpan(ctx, "emitTleProcessedEvent")
 	defer span.EndWithError(err)
-	eventPayload := map[string]interface{}{
-		"category":      category,
-		"maxRequested":  maxRequested,
-		"processedTLEs": processed,
-		"timestamp":     time.Now().UTC(),
+	eventPayload := model.TleBatchUploaded{
+		Category:      category,
+		MaxRequested:  int32(maxRequested),
+		ProcessedTLEs: int32(processed),
+		Timestamp:     time.Now().UTC().Format(time.RFC3339),
 	}
 
 	eventData, _ := json.Marshal(eventPayload)
 	event := model.EventRoot{
-		EventType: model.EventTypeSatelliteTlePropagated.String(),
+		EventType: model.EventTypeTleBatchUploaded.String(),
 		Payload:   string(eventData),
 	}
 
-	if err := h.eventEmitter.PublishEvent(ctx, event); err != nil {
+	if err := h.eventEmitter.Enqueue(ctx, fmt.Sprintf("tle_category:%s", category), event); err != nil {
 		log.Errorf("❌ Failed to emit event: %v", err)
 		return err
 	}
-	log.Tracef("📡 Event emitted: TLE_UPLOAD_COMPLETED for category %s", category)
+	log.Tracef("📡 Event enqueued: TLE_BATCH_UPLOADED for category %s", category)
 	return nil
 }
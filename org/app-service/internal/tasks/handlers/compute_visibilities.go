@@ -13,11 +13,21 @@ import (
 	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
 )
 
+// defaultVisibilityWindow is the period a visibility request without end time covers, about one low orbit.
+const defaultVisibilityWindow = 90 * time.Minute
+
+// ContextClock reads the simulated time of contexts
+type ContextClock interface {
+	Now(ctx context.Context, contextID string) (time.Time, error)
+}
+
 // ComputeVisibilitiessHandler handles visibility computation for satellites based on user locations.
 type ComputeVisibilitiessHandler struct {
 	tileRepo       domain.TileRepository
 	mappingRepo    domain.MappingRepository
 	tleRepo        repository.TleRepository
+	contextRepo    domain.GameContextRepository
+	contextClock   ContextClock
 	redisClient    *redis.RedisClient
 	defaultHorizon int
 }
@@ -27,13 +37,17 @@ func NewComputeVisibilitiessHandler(
 	tileRepo domain.TileRepository,
 	mappingRepo domain.MappingRepository,
 	tleRepo repository.TleRepository,
+	contextRepo domain.GameContextRepository,
+	contextClock ContextClock,
 	redisClient *redis.RedisClient,
 ) ComputeVisibilitiessHandler {
 	return ComputeVisibilitiessHandler{
-		tileRepo:    tileRepo,
-		tleRepo:     tleRepo,
-		mappingRepo: mappingRepo,
-		redisClient: redisClient,
+		tileRepo:     tileRepo,
+		tleRepo:      tleRepo,
+		mappingRepo:  mappingRepo,
+		contextRepo:  contextRepo,
+		contextClock: contextClock,
+		redisClient:  redisClient,
 	}
 }
 
@@ -65,37 +79,76 @@ func (h *ComputeVisibilitiessHandler) Run(ctx context.Context, args map[string]s
 }
 
 // Subscribe listens for user location updates and computes visibilities.
+// A request naming a context is answered from that context of its tenant, otherwise from every running context of the tenant.
+// Without start time a request starts at the simulated time of each context, without end time it covers defaultVisibilityWindow.
 func (h *ComputeVisibilitiessHandler) Subscribe(ctx context.Context, channel string) error {
 	log.Debugf("Subscribing to Redis channel: %s\n", channel)
 
 	err := h.redisClient.Subscribe(ctx, channel, func(message string) error {
 		var request struct {
-			UID       string  `json:"uid"`
-			Latitude  float64 `json:"latitude"`
-			Longitude float64 `json:"longitude"`
-			Radius    float64 `json:"radius"`
-			Horizon   float64 `json:"horizon"`
-			StartTime string  `json:"startTime"`
-			EndTime   string  `json:"endTime"`
+			UID         string  `json:"uid"`
+			TenantID    string  `json:"tenant"`
+			ContextName string  `json:"contextName"`
+			Latitude    float64 `json:"latitude"`
+			Longitude   float64 `json:"longitude"`
+			Radius      float64 `json:"radius"`
+			Horizon     float64 `json:"horizon"`
+			StartTime   string  `json:"startTime"`
+			EndTime     string  `json:"endTime"`
 		}
 
 		if err := json.Unmarshal([]byte(message), &request); err != nil {
 			return fmt.Errorf("failed to parse update message: %w", err)
 		}
 
-		startTime, err := time.Parse(time.RFC3339, request.StartTime)
-		if err != nil {
-			return fmt.Errorf("failed to parse start time: %w", err)
+		var startTime, endTime time.Time
+		var err error
+		if request.StartTime != "" {
+			if startTime, err = time.Parse(time.RFC3339, request.StartTime); err != nil {
+				return fmt.Errorf("failed to parse start time: %w", err)
+			}
 		}
-		endTime, err := time.Parse(time.RFC3339, request.EndTime)
-		if err != nil {
-			return fmt.Errorf("failed to parse end time: %w", err)
+		if request.EndTime != "" {
+			if endTime, err = time.Parse(time.RFC3339, request.EndTime); err != nil {
+				return fmt.Errorf("failed to parse end time: %w", err)
+			}
 		}
 
 		log.Debugf("Received visibility request for UID: %s at location (%.6f, %.6f) with radius %.2f, horizon %.2f, from %s to %s\n",
-			request.UID, request.Latitude, request.Longitude, request.Radius, request.Horizon, startTime, endTime)
+			request.UID, request.Latitude, request.Longitude, request.Radius, request.Horizon, request.StartTime, request.EndTime)
+
+		requestCtx := domain.WithTenant(ctx, domain.TenantID(request.TenantID))
+		contexts, err := h.requestContexts(requestCtx, request.ContextName)
+		if err != nil {
+			return err
+		}
+
+		var visibilities []map[string]interface{}
+		for _, gameContext := range contexts {
+			contextStart, contextEnd, err := h.requestWindow(requestCtx, gameContext, startTime, endTime)
+			if err != nil {
+				return err
+			}
+			contextVisibilities, err := h.computeVisibility(requestCtx, request.UID, gameContext, request.Latitude, request.Longitude, request.Radius, contextStart, contextEnd)
+			if err != nil {
+				return err
+			}
+			visibilities = append(visibilities, contextVisibilities...)
+		}
+		if len(visibilities) == 0 {
+			return nil
+		}
 
-		return h.computeVisibility(ctx, request.UID, "toprovideincomputeVisibility", request.Latitude, request.Longitude, request.Radius, startTime, endTime)
+		cachedData, err := json.Marshal(visibilities)
+		if err != nil {
+			return fmt.Errorf("failed to serialize visibilities: %w", err)
+		}
+
+		key := fmt.Sprintf("user_visibilities_event:%s", request.UID)
+		if err := h.redisClient.Publish(ctx, key, cachedData); err != nil {
+			return fmt.Errorf("failed to publish visibility event: %w", err)
+		}
+		return nil
 	})
 
 	if err != nil {
@@ -106,25 +159,56 @@ func (h *ComputeVisibilitiessHandler) Subscribe(ctx context.Context, channel str
 	return nil
 }
 
-// computeVisibility computes visibilities for a given user location and time range.
-func (h *ComputeVisibilitiessHandler) computeVisibility(ctx context.Context, uid string, contextID string, latitude, longitude, radius float64, startTime, endTime time.Time) error {
+// requestWindow fills the unset bounds of a visibility request from the clock of a context.
+func (h *ComputeVisibilitiessHandler) requestWindow(ctx context.Context, gameContext domain.GameContext, startTime, endTime time.Time) (time.Time, time.Time, error) {
+	if startTime.IsZero() {
+		now, err := h.contextClock.Now(ctx, gameContext.ID)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("failed to read the clock of context %s: %w", gameContext.Name, err)
+		}
+		startTime = now
+	}
+	if endTime.IsZero() {
+		endTime = startTime.Add(defaultVisibilityWindow)
+	}
+	return startTime, endTime, nil
+}
+
+// requestContexts resolves the contexts a visibility request is answered from.
+func (h *ComputeVisibilitiessHandler) requestContexts(ctx context.Context, contextName string) ([]domain.GameContext, error) {
+	if contextName != "" {
+		gameContext, err := h.contextRepo.FindByUniqueName(ctx, domain.GameContextName(contextName))
+		if err != nil {
+			return nil, fmt.Errorf("failed to find context %s: %w", contextName, err)
+		}
+		return []domain.GameContext{gameContext}, nil
+	}
+	contexts, err := h.contextRepo.FindActiveContexts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find active contexts: %w", err)
+	}
+	return contexts, nil
+}
+
+// computeVisibility computes visibilities for a given user location and time range within one context.
+func (h *ComputeVisibilitiessHandler) computeVisibility(ctx context.Context, uid string, gameContext domain.GameContext, latitude, longitude, radius float64, startTime, endTime time.Time) ([]map[string]interface{}, error) {
 	if latitude < -90 || latitude > 90 {
-		return fmt.Errorf("latitude out of bounds: %f", latitude)
+		return nil, fmt.Errorf("latitude out of bounds: %f", latitude)
 	}
 	if longitude < -180 || longitude > 180 {
-		return fmt.Errorf("longitude out of bounds: %f", longitude)
+		return nil, fmt.Errorf("longitude out of bounds: %f", longitude)
 	}
 	if radius <= 0 {
-		return fmt.Errorf("radius must be greater than 0")
+		return nil, fmt.Errorf("radius must be greater than 0")
 	}
 
-	tiles, err := h.tileRepo.FindTilesIntersectingLocation(ctx, contextID, latitude, longitude, radius)
+	tiles, err := h.tileRepo.FindTilesIntersectingLocation(ctx, gameContext.ID, latitude, longitude, radius)
 	if err != nil {
-		return fmt.Errorf("failed to find tiles intersecting location: %w", err)
+		return nil, fmt.Errorf("failed to find tiles intersecting location: %w", err)
 	}
 	if len(tiles) == 0 {
-		log.Warnf("No tiles found for location: (%f, %f) with radius: %f\n", latitude, longitude, radius)
-		return nil
+		log.Warnf("No tiles found in context %s for location: (%f, %f) with radius: %f\n", gameContext.Name, latitude, longitude, radius)
+		return nil, nil
 	}
 
 	var tileIDs []string
@@ -132,26 +216,42 @@ func (h *ComputeVisibilitiessHandler) computeVisibility(ctx context.Context, uid
 		tileIDs = append(tileIDs, tile.ID)
 	}
 
-	satellites, err := h.mappingRepo.FindSatellitesForTiles(ctx, contextID, tileIDs)
+	satellites, err := h.mappingRepo.FindSatellitesForTiles(ctx, gameContext.ID, tileIDs)
 	if err != nil {
-		return fmt.Errorf("failed to find satellites for tiles: %w", err)
+		return nil, fmt.Errorf("failed to find satellites for tiles: %w", err)
 	}
 	if len(satellites) == 0 {
-		log.Warnf("No satellites found for the identified tiles.\n")
-		return nil
+		log.Warnf("No satellites found for the identified tiles in context %s.\n", gameContext.Name)
+		return nil, nil
+	}
+
+	// Resolve the TLE snapshot the context is pinned to once for all of its satellites
+	spaceIDs := make([]string, len(satellites))
+	for i, satellite := range satellites {
+		spaceIDs[i] = satellite.SpaceID
+	}
+	pins, err := h.tleRepo.FindTLEPins(ctx, gameContext.ID, spaceIDs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve pinned TLEs of context %s: %w", gameContext.Name, err)
 	}
 
-	key := fmt.Sprintf("user_visibilities_event:%s", uid)
 	var visibilities []map[string]interface{}
 	for _, satellite := range satellites {
 
-		// Get the TLE data for the satellite by SPACE ID
-		tle, err := h.tleRepo.GetTle(ctx, satellite.SpaceID)
+		// Get the TLE data for the satellite by SPACE ID, honouring the TLE snapshot the context is pinned to
+		tle, pinned, err := pins.Lookup(satellite.SpaceID)
 		if err != nil {
-			return fmt.Errorf("failed to fetch TLE data for SPACE ID %s: %w", satellite.SpaceID, err)
+			return nil, fmt.Errorf("failed to fetch pinned TLE data for SPACE ID %s: %w", satellite.SpaceID, err)
+		}
+		if !pinned {
+			tle, err = h.tleRepo.GetTle(ctx, satellite.SpaceID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch TLE data for SPACE ID %s: %w", satellite.SpaceID, err)
+			}
 		}
 
 		visibility := map[string]interface{}{
+			"contextName":   string(gameContext.Name),
 			"satelliteID":   satellite.SpaceID,
 			"satelliteName": satellite.Name,
 			"startTime":     startTime.Format(time.RFC3339), // start propgated period
@@ -170,14 +270,5 @@ func (h *ComputeVisibilitiessHandler) computeVisibility(ctx context.Context, uid
 		visibilities = append(visibilities, visibility)
 	}
 
-	cachedData, err := json.Marshal(visibilities)
-	if err != nil {
-		return fmt.Errorf("failed to serialize visibilities: %w", err)
-	}
-
-	if err := h.redisClient.Publish(ctx, key, cachedData); err != nil {
-		return fmt.Errorf("failed to publish visibility event: %w", err)
-	}
-
-	return nil
+	return visibilities, nil
 }
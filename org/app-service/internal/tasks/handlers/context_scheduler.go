@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	repository "github.com/org/2112-space-lab/org/app-service/internal/repositories"
+	"github.com/org/2112-space-lab/org/app-service/internal/services"
+	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
+)
+
+type ContextSchedulerHandler struct {
+	lifecycleService *services.ContextLifecycleService
+	globalPropRepo   *repository.GlobalPropertyRepository
+}
+
+// NewContextSchedulerHandler creates a new instance of ContextSchedulerHandler.
+func NewContextSchedulerHandler(lifecycleService *services.ContextLifecycleService, globalPropRepo *repository.GlobalPropertyRepository) ContextSchedulerHandler {
+	return ContextSchedulerHandler{
+		lifecycleService: lifecycleService,
+		globalPropRepo:   globalPropRepo,
+	}
+}
+
+// GetTask provides metadata about this handler's task.
+func (h *ContextSchedulerHandler) GetTask() Task {
+	return Task{
+		Name:         "context_scheduler",
+		Description:  "Prepares, activates and deactivates game contexts at their scheduled times",
+		RequiredArgs: []string{},
+		Daemon:       true,
+	}
+}
+
+// Run applies due context schedules until the context is cancelled. The interval is re-read after each run.
+func (h *ContextSchedulerHandler) Run(ctx context.Context, args map[string]string) error {
+	for {
+		if err := h.lifecycleService.RunDue(ctx, time.Now().UTC()); err != nil {
+			log.Errorf("❌ Failed to apply context schedules: %v", err)
+		}
+
+		interval, err := h.globalPropRepo.GetContextSchedulerInterval(ctx, repository.DefaultContextSchedulerInterval)
+		if err != nil {
+			log.Tracef("Using default context scheduler interval [%s]: %v", interval, err)
+		}
+		if interval <= 0 {
+			interval = repository.DefaultContextSchedulerInterval
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Warnf("Context scheduler stopped: %v", ctx.Err())
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/org/2112-space-lab/org/app-service/internal/clients/rabbitmq"
+	"github.com/org/2112-space-lab/org/app-service/internal/api/routers"
+	"github.com/org/2112-space-lab/org/app-service/internal/clients/broker"
+	"github.com/org/2112-space-lab/org/app-service/internal/clients/service"
 	"github.com/org/2112-space-lab/org/app-service/internal/dependencies"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
 	"github.com/org/2112-space-lab/org/app-service/internal/events"
 	event_handlers "github.com/org/2112-space-lab/org/app-service/internal/events/handlers"
 	model "github.com/org/2112-space-lab/org/app-service/internal/graphql/models/generated"
@@ -43,6 +46,7 @@ func (d *EventDetector) GetTask() Task {
 		Name:         "event_detector",
 		Description:  "Monitors events and processes TLE propagated events",
 		RequiredArgs: []string{"name"},
+		Continuous:   true,
 	}
 }
 
@@ -58,9 +62,27 @@ func (d *EventDetector) Run(ctx context.Context, args map[string]string) (err er
 		return fmt.Errorf("missing required argument: name")
 	}
 
-	positionsUpdatedHandler := event_handlers.NewSatellitePositionHandler(d.dependencies.Services.SatelliteService, d.eventEmitter, d.dependencies.Clients.RedisClient)
+	positionsUpdatedHandler := event_handlers.NewSatellitePositionHandler(
+		d.dependencies.Services.SatelliteService,
+		d.dependencies.Repositories.ContextRepo,
+		d.dependencies.Services.ClockService,
+		d.dependencies.Repositories.GlobalPropRepo,
+		d.eventEmitter,
+		d.dependencies.Clients.RedisClient,
+	)
+	coverageRefreshHandler := event_handlers.NewCoverageRefreshHandler(d.dependencies.Services.CoverageService)
+	rehydrationProgressHandler := event_handlers.NewRehydrationProgressHandler(&d.dependencies.Services.RehydrationService)
 
-	satelliteKeys, err := d.dependencies.Services.SatelliteService.GetAndLockSatellites(ctx, processorName)
+	var contextID string
+	if name := args[TaskArgContext]; name != "" {
+		gameContext, err := d.dependencies.Repositories.ContextRepo.FindByUniqueName(ctx, domain.GameContextName(name))
+		if err != nil {
+			return fmt.Errorf("failed to find context %s: %w", name, err)
+		}
+		contextID = gameContext.ID
+	}
+
+	satelliteKeys, err := d.dependencies.Services.SatelliteService.GetAndLockSatellites(ctx, processorName, contextID)
 	if err != nil {
 		return err
 	}
@@ -70,7 +92,31 @@ func (d *EventDetector) Run(ctx context.Context, args map[string]string) (err er
 		return err
 	}
 
-	header := rabbitmq.NewHeader()
+	err = d.eventMonitor.RegisterHandler(ctx, model.EventTypeSatelliteTlePropagated, coverageRefreshHandler)
+	if err != nil {
+		return err
+	}
+
+	err = d.eventMonitor.RegisterHandler(ctx, model.EventTypeSatelliteTlePropagated, rehydrationProgressHandler)
+	if err != nil {
+		return err
+	}
+
+	workers, err := d.dependencies.Repositories.GlobalPropRepo.GetEventHandlerWorkers(ctx, events.DefaultHandlerWorkers)
+	if err != nil {
+		log.Tracef("Using default event handler workers [%d]: %v", workers, err)
+	}
+	queueSize, err := d.dependencies.Repositories.GlobalPropRepo.GetEventHandlerQueueSize(ctx, events.DefaultHandlerQueueSize)
+	if err != nil {
+		log.Tracef("Using default event handler queue size [%d]: %v", queueSize, err)
+	}
+	d.eventMonitor.ConfigureWorkerPools(events.WorkerPoolConfig{Workers: int(workers), QueueSize: int(queueSize)})
+
+	// The detector serves no API: the gauges of its worker pools are exposed on the metrics port.
+	serviceConfig := service.GetClient().GetConfig()
+	go routers.InitMetricsRouter("event detector "+processorName).Serve(ctx, serviceConfig.Host, serviceConfig.MetricsPort)
+
+	header := broker.NewHeader()
 	for _, s := range satelliteKeys {
 		header.AddField("satellite_id", s)
 	}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	repository "github.com/org/2112-space-lab/org/app-service/internal/repositories"
+	"github.com/org/2112-space-lab/org/app-service/internal/services"
+	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
+)
+
+type MappingRetentionPurgeHandler struct {
+	tileService    *services.TileService
+	globalPropRepo *repository.GlobalPropertyRepository
+}
+
+// NewMappingRetentionPurgeHandler creates a new instance of MappingRetentionPurgeHandler.
+func NewMappingRetentionPurgeHandler(tileService *services.TileService, globalPropRepo *repository.GlobalPropertyRepository) MappingRetentionPurgeHandler {
+	return MappingRetentionPurgeHandler{
+		tileService:    tileService,
+		globalPropRepo: globalPropRepo,
+	}
+}
+
+// GetTask provides metadata about this handler's task.
+func (h *MappingRetentionPurgeHandler) GetTask() Task {
+	return Task{
+		Name:         "mapping_retention_purge",
+		Description:  "Periodically deletes tile mappings older than the mapping_retention_window global property",
+		RequiredArgs: []string{},
+		Daemon:       true,
+	}
+}
+
+// Run purges expired mappings until the context is cancelled. The interval is re-read after each purge.
+func (h *MappingRetentionPurgeHandler) Run(ctx context.Context, args map[string]string) error {
+	for {
+		if _, err := h.tileService.PurgeExpiredMappings(ctx); err != nil {
+			log.Errorf("❌ Failed to purge expired mappings: %v", err)
+		}
+
+		interval, err := h.globalPropRepo.GetMappingRetentionPurgeInterval(ctx, repository.DefaultMappingRetentionPurgeInterval)
+		if err != nil {
+			log.Tracef("Using default mapping purge interval [%s]: %v", interval, err)
+		}
+		if interval <= 0 {
+			interval = repository.DefaultMappingRetentionPurgeInterval
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Warnf("Mapping retention purge stopped: %v", ctx.Err())
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	repository "github.com/org/2112-space-lab/org/app-service/internal/repositories"
+	"github.com/org/2112-space-lab/org/app-service/internal/services"
+	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
+)
+
+type OutboxRelayHandler struct {
+	relayService   *services.OutboxRelayService
+	globalPropRepo *repository.GlobalPropertyRepository
+}
+
+// NewOutboxRelayHandler creates a new instance of OutboxRelayHandler.
+func NewOutboxRelayHandler(relayService *services.OutboxRelayService, globalPropRepo *repository.GlobalPropertyRepository) OutboxRelayHandler {
+	return OutboxRelayHandler{
+		relayService:   relayService,
+		globalPropRepo: globalPropRepo,
+	}
+}
+
+// GetTask provides metadata about this handler's task.
+func (h *OutboxRelayHandler) GetTask() Task {
+	return Task{
+		Name:         "outbox_relay",
+		Description:  "Publishes the events stored in the transactional outbox to the message broker",
+		RequiredArgs: []string{},
+		Daemon:       true,
+	}
+}
+
+// Run relays outbox events until the context is cancelled. Full batches are followed at once by the next one;
+// otherwise the relay waits for the poll interval, re-read after each batch.
+func (h *OutboxRelayHandler) Run(ctx context.Context, args map[string]string) error {
+	for {
+		sent, full, err := h.relayService.RelayPending(ctx)
+		if err != nil {
+			log.Errorf("❌ Failed to relay outbox events: %v", err)
+		} else if sent > 0 {
+			log.Debugf("📤 Relayed %d outbox events", sent)
+		}
+
+		interval, err := h.globalPropRepo.GetOutboxRelayInterval(ctx, repository.DefaultOutboxRelayInterval)
+		if err != nil {
+			log.Tracef("Using default outbox relay interval [%s]: %v", interval, err)
+		}
+		if interval <= 0 {
+			interval = repository.DefaultOutboxRelayInterval
+		}
+		if full && sent > 0 {
+			interval = 0
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Warnf("Outbox relay stopped: %v", ctx.Err())
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	repository "github.com/org/2112-space-lab/org/app-service/internal/repositories"
+	"github.com/org/2112-space-lab/org/app-service/internal/services"
+	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
+)
+
+type OutboxRetentionPurgeHandler struct {
+	relayService   *services.OutboxRelayService
+	globalPropRepo *repository.GlobalPropertyRepository
+}
+
+// NewOutboxRetentionPurgeHandler creates a new instance of OutboxRetentionPurgeHandler.
+func NewOutboxRetentionPurgeHandler(relayService *services.OutboxRelayService, globalPropRepo *repository.GlobalPropertyRepository) OutboxRetentionPurgeHandler {
+	return OutboxRetentionPurgeHandler{
+		relayService:   relayService,
+		globalPropRepo: globalPropRepo,
+	}
+}
+
+// GetTask provides metadata about this handler's task.
+func (h *OutboxRetentionPurgeHandler) GetTask() Task {
+	return Task{
+		Name:         "outbox_retention_purge",
+		Description:  "Periodically deletes published outbox messages older than the outbox_retention_window global property",
+		RequiredArgs: []string{},
+		Daemon:       true,
+	}
+}
+
+// Run purges published outbox messages until the context is cancelled. The interval is re-read after each purge.
+func (h *OutboxRetentionPurgeHandler) Run(ctx context.Context, args map[string]string) error {
+	for {
+		if _, err := h.relayService.PurgeSentMessages(ctx); err != nil {
+			log.Errorf("❌ Failed to purge outbox messages: %v", err)
+		}
+
+		interval, err := h.globalPropRepo.GetOutboxRetentionPurgeInterval(ctx, repository.DefaultOutboxRetentionPurgeInterval)
+		if err != nil {
+			log.Tracef("Using default outbox purge interval [%s]: %v", interval, err)
+		}
+		if interval <= 0 {
+			interval = repository.DefaultOutboxRetentionPurgeInterval
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Warnf("Outbox retention purge stopped: %v", ctx.Err())
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	repository "github.com/org/2112-space-lab/org/app-service/internal/repositories"
+	"github.com/org/2112-space-lab/org/app-service/internal/services"
+	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
+)
+
+type RehydrationWatchdogHandler struct {
+	rehydrationService *services.RehydrationService
+	globalPropRepo     *repository.GlobalPropertyRepository
+}
+
+// NewRehydrationWatchdogHandler creates a new instance of RehydrationWatchdogHandler.
+func NewRehydrationWatchdogHandler(rehydrationService *services.RehydrationService, globalPropRepo *repository.GlobalPropertyRepository) RehydrationWatchdogHandler {
+	return RehydrationWatchdogHandler{
+		rehydrationService: rehydrationService,
+		globalPropRepo:     globalPropRepo,
+	}
+}
+
+// GetTask provides metadata about this handler's task.
+func (h *RehydrationWatchdogHandler) GetTask() Task {
+	return Task{
+		Name:         "rehydration_watchdog",
+		Description:  "Fails the unreported satellite propagations of context rehydrations and times out the rehydrations not done by their deadline",
+		RequiredArgs: []string{},
+		Daemon:       true,
+	}
+}
+
+// Run fails stalled propagations and times out overdue rehydrations until the context is cancelled. The interval is
+// re-read after each run.
+func (h *RehydrationWatchdogHandler) Run(ctx context.Context, args map[string]string) error {
+	for {
+		if err := h.rehydrationService.FailStalledPropagations(ctx, time.Now().UTC()); err != nil {
+			log.Errorf("❌ Failed to fail stalled rehydration propagations: %v", err)
+		}
+		if err := h.rehydrationService.ExpireOverdue(ctx, time.Now().UTC()); err != nil {
+			log.Errorf("❌ Failed to time out overdue rehydrations: %v", err)
+		}
+
+		interval, err := h.globalPropRepo.GetRehydrationWatchdogInterval(ctx, repository.DefaultRehydrationWatchdogInterval)
+		if err != nil {
+			log.Tracef("Using default rehydration watchdog interval [%s]: %v", interval, err)
+		}
+		if interval <= 0 {
+			interval = repository.DefaultRehydrationWatchdogInterval
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Warnf("Rehydration watchdog stopped: %v", ctx.Err())
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
@@ -3,12 +3,14 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/org/2112-space-lab/org/app-service/internal/clients/redis"
 	"github.com/org/2112-space-lab/org/app-service/internal/domain"
 	repository "github.com/org/2112-space-lab/org/app-service/internal/repositories"
+	"github.com/org/2112-space-lab/org/app-service/internal/services"
 	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
 )
 
@@ -17,7 +19,10 @@ type SatellitesTilesMappingsHandler struct {
 	tleRepo       repository.TleRepository
 	satelliteRepo domain.SatelliteRepository
 	mappingRepo   domain.MappingRepository
+	contextRepo   domain.GameContextRepository
+	tileService   *services.TileService
 	redisClient   *redis.RedisClient
+	contextID     string
 }
 
 // NewSatellitesTilesMappingsHandler creates a new instance of the handler.
@@ -26,6 +31,8 @@ func NewSatellitesTilesMappingsHandler(
 	tleRepo repository.TleRepository,
 	satelliteRepo domain.SatelliteRepository,
 	mappingRepo domain.MappingRepository,
+	contextRepo domain.GameContextRepository,
+	tileService *services.TileService,
 	redisClient *redis.RedisClient,
 ) SatellitesTilesMappingsHandler {
 	return SatellitesTilesMappingsHandler{
@@ -33,6 +40,8 @@ func NewSatellitesTilesMappingsHandler(
 		tleRepo:       tleRepo,
 		satelliteRepo: satelliteRepo,
 		mappingRepo:   mappingRepo,
+		contextRepo:   contextRepo,
+		tileService:   tileService,
 		redisClient:   redisClient,
 	}
 }
@@ -46,71 +55,56 @@ func (h *SatellitesTilesMappingsHandler) GetTask() Task {
 }
 
 // Run executes the visibility computation process.
+// With a contextName argument only that context of the tenant is mapped, otherwise every running context of the satellite.
 func (h *SatellitesTilesMappingsHandler) Run(ctx context.Context, args map[string]string) error {
 	log.Debugf("Starting Run method")
+	if name := args[TaskArgContext]; name != "" {
+		gameContext, err := h.contextRepo.FindByUniqueName(ctx, domain.GameContextName(name))
+		if err != nil {
+			return fmt.Errorf("failed to find context %s: %w", name, err)
+		}
+		h.contextID = gameContext.ID
+	}
 	log.Debugf("Subscribing to event_satellite_positions_updated channel")
 	return h.Subscribe(ctx, "event_satellite_positions_updated")
 }
 
-// Exec executes the visibility computation process, considering satellite paths.
-func (h *SatellitesTilesMappingsHandler) Exec(ctx context.Context, id string, startTime time.Time, endTime time.Time) error {
+// Exec computes the mappings of the newly propagated part of a satellite path and appends them
+// to each running context the satellite belongs to. A non-empty contextID restricts the work to that context.
+func (h *SatellitesTilesMappingsHandler) Exec(ctx context.Context, id string, contextID string, startTime time.Time, endTime time.Time) error {
 	log.Debugf("Starting Exec method for satellite ID: %s, from %s to %s\n", id, startTime, endTime)
 	sat, err := h.satelliteRepo.FindBySpaceID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to fetch satellite: %w", err)
 	}
 
-	positions, err := h.tleRepo.QuerySatellitePositions(ctx, sat.SpaceID, startTime, endTime)
+	contexts, err := h.satelliteRepo.FindContextsBySatellite(ctx, sat.ID)
 	if err != nil {
-		return fmt.Errorf("error querying satellite positions for satellite %s: %w", sat.SpaceID, err)
+		return fmt.Errorf("failed to fetch contexts of satellite %s: %w", sat.SpaceID, err)
 	}
 
-	if len(positions) < 2 {
-		log.Warnf("Not enough positions to compute mappings for satellite %s\n", sat.SpaceID)
-		return nil
+	var errs []error
+	for _, gameContext := range contexts {
+		if !gameContext.State.IsRunning() {
+			continue
+		}
+		if (h.contextID != "" && gameContext.ID != h.contextID) || (contextID != "" && gameContext.ID != contextID) {
+			continue
+		}
+		log.Debugf("Computing mappings for satellite %s in context %s\n", sat.SpaceID, gameContext.Name)
+		contextCtx := domain.WithTenant(ctx, gameContext.TenantID)
+		if err := h.tileService.AppendMappings(contextCtx, gameContext.ID, sat, startTime, endTime); err != nil {
+			errs = append(errs, fmt.Errorf("error computing mappings for satellite %s in context %s: %w", sat.SpaceID, gameContext.Name, err))
+		}
 	}
-
-	log.Debugf("Computing mappings for satellite %s\n", sat.SpaceID)
-	if err := h.computeTileMappings(ctx, "todoSatellitesTilesMappingsHandler", sat, positions); err != nil {
-		return fmt.Errorf("error computing mappings for satellite %s: %w", sat.SpaceID, err)
+	if len(errs) > 0 {
+		return errors.Join(errs...)
 	}
 
 	log.Debugf("Completed Exec method for satellite ID: %s\n", id)
 	return nil
 }
 
-// computeTileMappings computes visibility for a list of satellite positions.
-func (h *SatellitesTilesMappingsHandler) computeTileMappings(
-	ctx context.Context,
-	contextID string,
-	sat domain.Satellite,
-	positions []domain.SatellitePosition,
-) error {
-	log.Debugf("Finding visible tiles for satellite %s along its path\n", sat.SpaceID)
-
-	err := h.mappingRepo.DeleteMappingsBySpaceID(ctx, contextID, sat.SpaceID)
-	if err != nil {
-		return fmt.Errorf("failed to delete visible tiles along the path: %w", err)
-	}
-
-	mappings, err := h.tileRepo.FindTilesVisibleFromLine(ctx, sat, positions)
-	if err != nil {
-		return fmt.Errorf("failed to find visible tiles along the path: %w", err)
-	}
-
-	if len(mappings) == 0 {
-		log.Warnf("No visible tiles found for satellite %s along its path\n", sat.SpaceID)
-		return nil
-	}
-
-	if err := h.mappingRepo.SaveBatch(ctx, mappings); err != nil {
-		return fmt.Errorf("failed to save mappings: %w", err)
-	}
-	log.Debugf("Saved %d mappings for satellite %s\n", len(mappings), sat.SpaceID)
-
-	return nil
-}
-
 // Subscribe listens for satellite position updates and computes visibility using a worker pool.
 func (h *SatellitesTilesMappingsHandler) Subscribe(ctx context.Context, channel string) error {
 	log.Debugf("Subscribing to Redis channel: %s\n", channel)
@@ -147,6 +141,7 @@ func (h *SatellitesTilesMappingsHandler) worker(ctx context.Context, messageChan
 		case message := <-messageChan:
 			var update struct {
 				SatelliteID string `json:"satellite_id"`
+				ContextID   string `json:"context_id"`
 				StartTime   string `json:"start_time"`
 				EndTime     string `json:"end_time"`
 			}
@@ -167,7 +162,7 @@ func (h *SatellitesTilesMappingsHandler) worker(ctx context.Context, messageChan
 			}
 
 			log.Tracef("Processing update for satellite ID: %s, from %s to %s\n", update.SatelliteID, startTime, endTime)
-			if err := h.Exec(ctx, update.SatelliteID, startTime, endTime); err != nil {
+			if err := h.Exec(ctx, update.SatelliteID, update.ContextID, startTime, endTime); err != nil {
 				log.Errorf("Failed to execute computation for satellite ID %s: %v\n", update.SatelliteID, err)
 			}
 
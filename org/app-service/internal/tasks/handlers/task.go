@@ -7,6 +7,12 @@ import (
 	"github.com/org/2112-space-lab/org/app-service/internal/config"
 )
 
+// TaskArgTenant is the optional argument naming the tenant a task runs for. Tasks run for the default tenant without it.
+const TaskArgTenant = "tenant"
+
+// TaskArgContext is the optional argument restricting a task to one context of its tenant. Tasks serve every active context without it.
+const TaskArgContext = "contextName"
+
 // TaskName alias definition
 type TaskName string
 
@@ -15,6 +21,13 @@ type Task struct {
 	Name         TaskName
 	Description  string
 	RequiredArgs []string
+	Daemon       bool // Runs until cancelled; started in the background by the start process
+	Continuous   bool // Runs until cancelled; started on demand
+}
+
+// RunsUntilCancelled reports whether the task never finishes on its own.
+func (t Task) RunsUntilCancelled() bool {
+	return t.Daemon || t.Continuous
 }
 
 // TaskEnv definition
@@ -3,8 +3,10 @@ package tasks
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/org/2112-space-lab/org/app-service/internal/dependencies"
+	"github.com/org/2112-space-lab/org/app-service/internal/domain"
 	"github.com/org/2112-space-lab/org/app-service/internal/events"
 	"github.com/org/2112-space-lab/org/app-service/internal/tasks/handlers"
 	"github.com/org/2112-space-lab/org/app-service/pkg/tracing"
@@ -27,9 +29,12 @@ func NewTaskMonitor(ctx context.Context, dependencies *dependencies.Dependencies
 	defer span.EndWithError(err)
 
 	eventMonitor, err := events.NewEventMonitor(ctx,
-		dependencies.Clients.RabbitMQClient,
-		dependencies.Repositories.EventRepo,
-		dependencies.Repositories.EventHandlerRepo)
+		dependencies.Clients.Broker,
+		&dependencies.Repositories.EventRepo,
+		&dependencies.Repositories.EventHandlerRepo,
+		&dependencies.Repositories.DeadLetterRepo,
+		&dependencies.Repositories.ProcessedEventRepo,
+		&dependencies.Repositories.Transactor)
 	if err != nil {
 		return t, err
 	}
@@ -37,35 +42,71 @@ func NewTaskMonitor(ctx context.Context, dependencies *dependencies.Dependencies
 	celestrackTleUpload := handlers.NewCelestrackTleUploadHandler(
 		dependencies.Repositories.SatelliteRepo,
 		dependencies.Repositories.TleRepo,
+		&dependencies.Repositories.Transactor,
 		&dependencies.Services.TleService,
+		&dependencies.Services.MembershipService,
 		dependencies.EventEmitter,
 		eventMonitor,
 	)
 
 	generateTilesHandler := handlers.NewGenerateTilesHandler(
-		&dependencies.Repositories.TileRepo,
+		dependencies.Repositories.TileRepo,
 	)
 
 	mappingHandler := handlers.NewSatellitesTilesMappingsHandler(
-		&dependencies.Repositories.TileRepo,
+		dependencies.Repositories.TileRepo,
 		dependencies.Repositories.TleRepo,
 		&dependencies.Repositories.SatelliteRepo,
 		&dependencies.Repositories.MappingRepo,
+		&dependencies.Repositories.ContextRepo,
+		&dependencies.Services.TileService,
 		dependencies.Clients.RedisClient,
 	)
 
 	celestrackSatelliteUpload := handlers.NewCelesTrackSatelliteUploadHandler(
 		&dependencies.Repositories.SatelliteRepo,
 		&dependencies.Services.SatelliteService,
+		&dependencies.Services.MembershipService,
 	)
 
 	satelliteVisibilities := handlers.NewComputeVisibilitiessHandler(
-		&dependencies.Repositories.TileRepo,
+		dependencies.Repositories.TileRepo,
 		&dependencies.Repositories.MappingRepo,
 		dependencies.Repositories.TleRepo,
+		&dependencies.Repositories.ContextRepo,
+		&dependencies.Services.ClockService,
 		dependencies.Clients.RedisClient,
 	)
 
+	basemapSeed := handlers.NewBasemapSeedHandler(
+		&dependencies.Services.BasemapService,
+	)
+
+	mappingRetentionPurge := handlers.NewMappingRetentionPurgeHandler(
+		&dependencies.Services.TileService,
+		&dependencies.Repositories.GlobalPropRepo,
+	)
+
+	contextScheduler := handlers.NewContextSchedulerHandler(
+		&dependencies.Services.LifecycleService,
+		&dependencies.Repositories.GlobalPropRepo,
+	)
+
+	outboxRelay := handlers.NewOutboxRelayHandler(
+		&dependencies.Services.OutboxRelayService,
+		&dependencies.Repositories.GlobalPropRepo,
+	)
+
+	outboxRetentionPurge := handlers.NewOutboxRetentionPurgeHandler(
+		&dependencies.Services.OutboxRelayService,
+		&dependencies.Repositories.GlobalPropRepo,
+	)
+
+	rehydrationWatchdog := handlers.NewRehydrationWatchdogHandler(
+		&dependencies.Services.RehydrationService,
+		&dependencies.Repositories.GlobalPropRepo,
+	)
+
 	eventDetector, err := handlers.NewEventDetector(
 		ctx, dependencies.EventEmitter, eventMonitor, dependencies)
 	if err != nil {
@@ -79,16 +120,48 @@ func NewTaskMonitor(ctx context.Context, dependencies *dependencies.Dependencies
 		celestrackSatelliteUpload.GetTask().Name: &celestrackSatelliteUpload,
 		satelliteVisibilities.GetTask().Name:     &satelliteVisibilities,
 		eventDetector.GetTask().Name:             &eventDetector,
+		basemapSeed.GetTask().Name:               &basemapSeed,
+		mappingRetentionPurge.GetTask().Name:     &mappingRetentionPurge,
+		contextScheduler.GetTask().Name:          &contextScheduler,
+		outboxRelay.GetTask().Name:               &outboxRelay,
+		outboxRetentionPurge.GetTask().Name:      &outboxRetentionPurge,
+		rehydrationWatchdog.GetTask().Name:       &rehydrationWatchdog,
 	}
 	return TaskMonitor{
 		Tasks: tasks,
 	}, err
 }
 
+// Daemons returns the names of the tasks run in the background by the start process, sorted.
+func (t *TaskMonitor) Daemons() []handlers.TaskName {
+	var names []handlers.TaskName
+	for name, handler := range t.Tasks {
+		if handler.GetTask().Daemon {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
+
+// CheckSchedulable returns an error wrapping domain.ErrInvalidTaskSchedule unless the task exists and finishes on
+// its own.
+func (t *TaskMonitor) CheckSchedulable(taskName string) error {
+	handler, ok := t.Tasks[handlers.TaskName(taskName)]
+	if !ok {
+		return fmt.Errorf("%w: unknown task %q", domain.ErrInvalidTaskSchedule, taskName)
+	}
+	if handler.GetTask().RunsUntilCancelled() {
+		return fmt.Errorf("%w: task %q runs until cancelled", domain.ErrInvalidTaskSchedule, taskName)
+	}
+	return nil
+}
+
 // Process execute processor
 func (t *TaskMonitor) Process(ctx context.Context, taskName handlers.TaskName, args map[string]string) (err error) {
 	ctx, span := tracing.NewSpan(ctx, "TaskMonitor.Process")
 	defer span.EndWithError(err)
+	ctx = domain.WithTenant(ctx, domain.TenantID(args[handlers.TaskArgTenant]))
 
 	handler, err := t.GetMatchingTask(ctx, taskName)
 	if err != nil {
@@ -113,6 +186,7 @@ func (t *TaskMonitor) GetMatchingTask(ctx context.Context, taskName handlers.Tas
 func (t *TaskMonitor) RunTaskAsGoroutine(ctx context.Context, taskName handlers.TaskName, args map[string]string) (err error) {
 	ctx, span := tracing.NewSpan(ctx, "TaskMonitor.RunTaskAsGoroutine")
 	defer span.EndWithError(err)
+	ctx = domain.WithTenant(ctx, domain.TenantID(args[handlers.TaskArgTenant]))
 
 	handler, err := t.GetMatchingTask(ctx, taskName)
 	if err != nil {
@@ -0,0 +1,94 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/org/2112-space-lab/org/app-service/internal/dependencies"
+	repository "github.com/org/2112-space-lab/org/app-service/internal/repositories"
+	"github.com/org/2112-space-lab/org/app-service/internal/services"
+	"github.com/org/2112-space-lab/org/app-service/internal/tasks/handlers"
+	log "github.com/org/2112-space-lab/org/app-service/pkg/log"
+)
+
+// TaskScheduler runs the tasks of the monitor on their stored cron schedules. Several schedulers may run at once,
+// in as many processes: each due run is claimed by a single one.
+type TaskScheduler struct {
+	monitor         *TaskMonitor
+	scheduleService *services.TaskScheduleService
+	globalPropRepo  *repository.GlobalPropertyRepository
+	owner           string
+}
+
+// NewTaskScheduler creates a new TaskScheduler running the tasks of monitor.
+func NewTaskScheduler(monitor *TaskMonitor, dependencies *dependencies.Dependencies) TaskScheduler {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return TaskScheduler{
+		monitor:         monitor,
+		scheduleService: &dependencies.Services.TaskScheduleService,
+		globalPropRepo:  &dependencies.Repositories.GlobalPropRepo,
+		owner:           fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+	}
+}
+
+// Run starts the due runs until the context is cancelled, then waits for the runs in progress. The interval is
+// re-read after each search for due runs.
+func (s *TaskScheduler) Run(ctx context.Context) error {
+	log.Infof("⏰ Task scheduler %s started", s.owner)
+
+	var wg sync.WaitGroup
+	for {
+		claimed, err := s.scheduleService.ClaimDue(ctx, time.Now().UTC(), s.owner)
+		if err != nil {
+			log.Errorf("❌ Failed to claim due task schedules: %v", err)
+		}
+		for _, schedule := range claimed {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				startedAt := time.Now().UTC()
+				log.Infof("▶️ Running task %s of schedule %s", schedule.TaskName, schedule.Name)
+
+				runErr := s.monitor.CheckSchedulable(schedule.TaskName)
+				if runErr == nil {
+					heartbeatCtx, stopHeartbeat := context.WithCancel(ctx)
+					go s.scheduleService.Heartbeat(heartbeatCtx, schedule, s.owner)
+					runErr = s.monitor.Process(ctx, handlers.TaskName(schedule.TaskName), schedule.Args)
+					stopHeartbeat()
+				}
+				if runErr != nil {
+					log.Errorf("❌ Task %s of schedule %s failed: %v", schedule.TaskName, schedule.Name, runErr)
+				} else {
+					log.Infof("✅ Task %s of schedule %s completed, next run at %s", schedule.TaskName, schedule.Name, schedule.NextRunAt.Format(time.RFC3339))
+				}
+
+				// The outcome of a run interrupted by the shutdown is recorded too.
+				if err := s.scheduleService.Finish(context.WithoutCancel(ctx), schedule, s.owner, startedAt, runErr); err != nil {
+					log.Errorf("❌ Failed to record the run of schedule %s: %v", schedule.Name, err)
+				}
+			}()
+		}
+
+		interval, err := s.globalPropRepo.GetTaskSchedulerInterval(ctx, repository.DefaultTaskSchedulerInterval)
+		if err != nil {
+			log.Tracef("Using default task scheduler interval [%s]: %v", interval, err)
+		}
+		if interval <= 0 {
+			interval = repository.DefaultTaskSchedulerInterval
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Warnf("Task scheduler stopped: %v", ctx.Err())
+			wg.Wait()
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
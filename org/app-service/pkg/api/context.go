@@ -0,0 +1,52 @@
+package api_mappers
+
+import "time"
+
+// ContextScheduleRequest sets when the scheduler activates and deactivates a context. Omitted times are left unset.
+type ContextScheduleRequest struct {
+	ActivateAt   *time.Time `json:"activateAt"`
+	DeactivateAt *time.Time `json:"deactivateAt"`
+}
+
+// ContextCloneRequest names the copy of a cloned context.
+type ContextCloneRequest struct {
+	Name            string `json:"name"`
+	IncludeMappings bool   `json:"includeMappings"`
+}
+
+// ContextTlePinRequest selects the epoch at which the TLEs of a context are pinned.
+type ContextTlePinRequest struct {
+	Epoch time.Time `json:"epoch"`
+}
+
+// ContextMembershipRuleRequest holds the filters of a membership rule. Omitted filters are not applied; list
+// filters match any of their values and names are matched against case-insensitive globs such as "STARLINK-*".
+type ContextMembershipRuleRequest struct {
+	OrbitTypes     []string   `json:"orbitTypes"`
+	Owners         []string   `json:"owners"`
+	ObjectTypes    []string   `json:"objectTypes"`
+	NamePatterns   []string   `json:"namePatterns"`
+	LaunchedAfter  *time.Time `json:"launchedAfter"`
+	LaunchedBefore *time.Time `json:"launchedBefore"`
+	MinInclination *float64   `json:"minInclination"`
+	MaxInclination *float64   `json:"maxInclination"`
+	MinAltitude    *float64   `json:"minAltitude"`
+	MaxAltitude    *float64   `json:"maxAltitude"`
+}
+
+// ContextClockRequest starts the simulation clock of a context at epoch, running speed times faster than wall-clock
+// time. A missing speed runs the clock in real time.
+type ContextClockRequest struct {
+	Epoch time.Time `json:"epoch"`
+	Speed *float64  `json:"speed"`
+}
+
+// ContextClockSeekRequest moves the simulation clock of a context to a simulated time.
+type ContextClockSeekRequest struct {
+	Time time.Time `json:"time"`
+}
+
+// ContextClockSpeedRequest changes the speed multiplier of the simulation clock of a context.
+type ContextClockSpeedRequest struct {
+	Speed float64 `json:"speed"`
+}
@@ -0,0 +1,18 @@
+package api_mappers
+
+import "time"
+
+// EventReplayRequest selects stored events of a tenant and replays them to every registered handler, or to
+// handlerName only. A dry run lists the selected events without publishing them.
+type EventReplayRequest struct {
+	Tenant        string     `json:"tenant"`
+	EventTypes    []string   `json:"eventTypes"`
+	EventUIDs     []string   `json:"eventUids"`
+	ContextName   string     `json:"contextName"`
+	From          *time.Time `json:"from"`
+	To            *time.Time `json:"to"`
+	HandlerName   string     `json:"handlerName"`
+	DryRun        bool       `json:"dryRun"`
+	RatePerSecond float64    `json:"ratePerSecond"`
+	Limit         int        `json:"limit"`
+}
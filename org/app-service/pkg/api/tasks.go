@@ -0,0 +1,13 @@
+package api_mappers
+
+// TaskScheduleRequest runs a task with its arguments on a cron expression, in UTC. A run is delayed by a random
+// jitter of up to jitterSeconds. Runs missed while no scheduler was running are skipped, or caught up with a single
+// run with the run_once missed run policy. A missing enabled enables the schedule.
+type TaskScheduleRequest struct {
+	Task            string            `json:"task"`
+	Cron            string            `json:"cron"`
+	Args            map[string]string `json:"args"`
+	Enabled         *bool             `json:"enabled"`
+	MissedRunPolicy string            `json:"missedRunPolicy"`
+	JitterSeconds   int64             `json:"jitterSeconds"`
+}
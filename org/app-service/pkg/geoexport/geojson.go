@@ -0,0 +1,285 @@
+package geoexport
+
+import (
+	"encoding/json"
+	"math"
+)
+
+// GeometryType enumerates the RFC 7946 geometry types produced by this package.
+type GeometryType string
+
+const (
+	GeometryPoint           GeometryType = "Point"
+	GeometryLineString      GeometryType = "LineString"
+	GeometryMultiLineString GeometryType = "MultiLineString"
+	GeometryPolygon         GeometryType = "Polygon"
+	GeometryMultiPolygon    GeometryType = "MultiPolygon"
+)
+
+// Position is a [longitude, latitude] pair as mandated by RFC 7946 section 3.1.1.
+type Position [2]float64
+
+// NewPosition builds a Position from latitude/longitude degrees.
+func NewPosition(lat, lon float64) Position {
+	return Position{lon, lat}
+}
+
+// Lat returns the latitude of the position.
+func (p Position) Lat() float64 { return p[1] }
+
+// Lon returns the longitude of the position.
+func (p Position) Lon() float64 { return p[0] }
+
+// Geometry is a GeoJSON geometry object.
+type Geometry struct {
+	Type        GeometryType `json:"type"`
+	Coordinates interface{}  `json:"coordinates"`
+}
+
+// Feature is a GeoJSON feature with free-form properties.
+type Feature struct {
+	Type       string                 `json:"type"`
+	ID         string                 `json:"id,omitempty"`
+	Geometry   Geometry               `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// FeatureCollection is the top-level GeoJSON document.
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+// NewFeatureCollection creates an empty FeatureCollection.
+func NewFeatureCollection() FeatureCollection {
+	return FeatureCollection{Type: "FeatureCollection", Features: []Feature{}}
+}
+
+// Add appends a feature to the collection.
+func (fc *FeatureCollection) Add(f Feature) {
+	fc.Features = append(fc.Features, f)
+}
+
+// Marshal encodes the collection as JSON.
+func (fc FeatureCollection) Marshal() ([]byte, error) {
+	return json.Marshal(fc)
+}
+
+// NewPointFeature creates a Point feature.
+func NewPointFeature(id string, p Position, props map[string]interface{}) Feature {
+	return newFeature(id, Geometry{Type: GeometryPoint, Coordinates: p}, props)
+}
+
+// NewPolygonFeature creates a Polygon feature from an outer ring. The ring is
+// closed and wound counterclockwise as required by RFC 7946 section 3.1.6, and
+// becomes a MultiPolygon when it crosses the antimeridian (section 3.1.9).
+func NewPolygonFeature(id string, ring []Position, props map[string]interface{}) Feature {
+	parts := SplitPolygon(ring)
+	if len(parts) == 1 {
+		return newFeature(id, Geometry{Type: GeometryPolygon, Coordinates: [][]Position{parts[0]}}, props)
+	}
+	polygons := make([][][]Position, len(parts))
+	for i, part := range parts {
+		polygons[i] = [][]Position{part}
+	}
+	return newFeature(id, Geometry{Type: GeometryMultiPolygon, Coordinates: polygons}, props)
+}
+
+// NewTrackFeature creates a LineString feature, or a MultiLineString when the
+// track crosses the antimeridian (RFC 7946 section 3.1.9).
+func NewTrackFeature(id string, track []Position, props map[string]interface{}) Feature {
+	segments := SplitAntimeridian(track)
+	if len(segments) == 1 {
+		return newFeature(id, Geometry{Type: GeometryLineString, Coordinates: segments[0]}, props)
+	}
+	return newFeature(id, Geometry{Type: GeometryMultiLineString, Coordinates: segments}, props)
+}
+
+func newFeature(id string, g Geometry, props map[string]interface{}) Feature {
+	if props == nil {
+		props = map[string]interface{}{}
+	}
+	return Feature{Type: "Feature", ID: id, Geometry: g, Properties: props}
+}
+
+// SplitAntimeridian cuts a track into segments wherever two consecutive
+// positions jump across the ±180° meridian, interpolating the crossing latitude.
+func SplitAntimeridian(track []Position) [][]Position {
+	if len(track) == 0 {
+		return [][]Position{{}}
+	}
+
+	var segments [][]Position
+	current := []Position{track[0]}
+	for i := 1; i < len(track); i++ {
+		prev, next := track[i-1], track[i]
+		if math.Abs(next.Lon()-prev.Lon()) <= 180 {
+			current = append(current, next)
+			continue
+		}
+
+		// Shift next into prev's hemisphere to interpolate the crossing latitude.
+		edge := 180.0
+		shifted := next.Lon() + 360
+		if prev.Lon() < 0 {
+			edge = -180.0
+			shifted = next.Lon() - 360
+		}
+		ratio := (edge - prev.Lon()) / (shifted - prev.Lon())
+		lat := prev.Lat() + ratio*(next.Lat()-prev.Lat())
+
+		current = append(current, NewPosition(lat, edge))
+		segments = append(segments, current)
+		current = []Position{NewPosition(lat, -edge), next}
+	}
+	return append(segments, current)
+}
+
+// SplitPolygon cuts a ring at the ±180° meridian and returns the closed,
+// counterclockwise rings that make up the polygon. A ring that winds around a
+// pole is closed along that pole's latitude before being cut.
+func SplitPolygon(ring []Position) [][]Position {
+	open := ring
+	if len(open) > 1 && open[0] == open[len(open)-1] {
+		open = open[:len(open)-1]
+	}
+	if len(open) < 3 {
+		return [][]Position{closeRing(ring)}
+	}
+
+	// Unwrap longitudes so that consecutive vertices are never more than 180° apart.
+	unwrapped := make([]Position, len(open))
+	unwrapped[0] = open[0]
+	var latSum float64
+	for i := range open {
+		latSum += open[i].Lat()
+		if i == 0 {
+			continue
+		}
+		unwrapped[i] = Position{unwrapLon(open[i].Lon(), unwrapped[i-1].Lon()), open[i].Lat()}
+	}
+
+	// A closing edge that does not return to the first longitude means the ring
+	// went all the way around the globe, i.e. it encloses a pole.
+	first, last := unwrapped[0], unwrapped[len(unwrapped)-1]
+	if end := unwrapLon(first.Lon(), last.Lon()); math.Abs(end-first.Lon()) > 180 {
+		poleLat := 90.0
+		if latSum < 0 {
+			poleLat = -90.0
+		}
+		unwrapped = append(unwrapped,
+			Position{end, first.Lat()},
+			Position{end, poleLat},
+			Position{first.Lon(), poleLat},
+		)
+	}
+
+	minLon, maxLon := unwrapped[0].Lon(), unwrapped[0].Lon()
+	for _, p := range unwrapped {
+		minLon = math.Min(minLon, p.Lon())
+		maxLon = math.Max(maxLon, p.Lon())
+	}
+
+	var parts [][]Position
+	kMin := int(math.Floor((minLon + 180) / 360))
+	kMax := int(math.Ceil((maxLon+180)/360)) - 1
+	if kMax < kMin {
+		kMax = kMin
+	}
+	for k := kMin; k <= kMax; k++ {
+		offset := float64(k) * 360
+		piece := clipLon(clipLon(unwrapped, offset-180, true), offset+180, false)
+		if len(piece) < 3 {
+			continue
+		}
+		shifted := make([]Position, len(piece))
+		for i, p := range piece {
+			shifted[i] = Position{p.Lon() - offset, p.Lat()}
+		}
+		closed := closeRing(shifted)
+		if math.Abs(signedArea(closed)) < 1e-12 {
+			continue
+		}
+		parts = append(parts, closed)
+	}
+	if len(parts) == 0 {
+		return [][]Position{closeRing(ring)}
+	}
+	return parts
+}
+
+// unwrapLon shifts lon by multiples of 360° so that it lies within 180° of ref.
+func unwrapLon(lon, ref float64) float64 {
+	for lon-ref > 180 {
+		lon -= 360
+	}
+	for lon-ref < -180 {
+		lon += 360
+	}
+	return lon
+}
+
+// clipLon clips an open ring against the half-plane lon >= bound (or lon <= bound)
+// using Sutherland-Hodgman.
+func clipLon(ring []Position, bound float64, keepEast bool) []Position {
+	inside := func(p Position) bool {
+		if keepEast {
+			return p.Lon() >= bound
+		}
+		return p.Lon() <= bound
+	}
+	crossing := func(a, b Position) Position {
+		ratio := (bound - a.Lon()) / (b.Lon() - a.Lon())
+		return Position{bound, a.Lat() + ratio*(b.Lat()-a.Lat())}
+	}
+
+	var out []Position
+	add := func(p Position) {
+		if len(out) == 0 || out[len(out)-1] != p {
+			out = append(out, p)
+		}
+	}
+	for i := range ring {
+		cur, prev := ring[i], ring[(i+len(ring)-1)%len(ring)]
+		switch {
+		case inside(cur) && !inside(prev):
+			add(crossing(prev, cur))
+			add(cur)
+		case inside(cur):
+			add(cur)
+		case inside(prev):
+			add(crossing(prev, cur))
+		}
+	}
+	if len(out) > 1 && out[0] == out[len(out)-1] {
+		out = out[:len(out)-1]
+	}
+	return out
+}
+
+// closeRing ensures the ring is closed and counterclockwise.
+func closeRing(ring []Position) []Position {
+	if len(ring) == 0 {
+		return ring
+	}
+	out := make([]Position, len(ring))
+	copy(out, ring)
+	if out[0] != out[len(out)-1] {
+		out = append(out, out[0])
+	}
+	if signedArea(out) < 0 {
+		for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+			out[i], out[j] = out[j], out[i]
+		}
+	}
+	return out
+}
+
+// signedArea returns the planar shoelace area; positive for counterclockwise rings.
+func signedArea(ring []Position) float64 {
+	var area float64
+	for i := 0; i < len(ring)-1; i++ {
+		area += ring[i].Lon()*ring[i+1].Lat() - ring[i+1].Lon()*ring[i].Lat()
+	}
+	return area / 2
+}
@@ -0,0 +1,153 @@
+package geoexport
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSplitPolygon(t *testing.T) {
+	tests := []struct {
+		name     string
+		ring     []Position
+		expected [][]Position
+	}{
+		{
+			name: "Regular ring",
+			ring: []Position{{10, 10}, {20, 10}, {20, 20}, {10, 20}},
+			expected: [][]Position{
+				{{10, 10}, {20, 10}, {20, 20}, {10, 20}, {10, 10}},
+			},
+		},
+		{
+			name: "Clockwise ring is rewound",
+			ring: []Position{{10, 10}, {10, 20}, {20, 20}, {20, 10}, {10, 10}},
+			expected: [][]Position{
+				{{10, 10}, {20, 10}, {20, 20}, {10, 20}, {10, 10}},
+			},
+		},
+		{
+			name: "Crosses the antimeridian",
+			ring: []Position{{170, -10}, {-170, -10}, {-170, 10}, {170, 10}},
+			expected: [][]Position{
+				{{170, -10}, {180, -10}, {180, 10}, {170, 10}, {170, -10}},
+				{{-180, -10}, {-170, -10}, {-170, 10}, {-180, 10}, {-180, -10}},
+			},
+		},
+		{
+			name: "Encloses the north pole",
+			ring: []Position{{-90, 80}, {0, 80}, {90, 80}, {180, 80}},
+			expected: [][]Position{
+				{{-90, 80}, {0, 80}, {90, 80}, {180, 80}, {180, 90}, {-90, 90}, {-90, 80}},
+				{{-180, 80}, {-90, 80}, {-90, 90}, {-180, 90}, {-180, 80}},
+			},
+		},
+		{
+			name: "Encloses the south pole",
+			ring: []Position{{0, -80}, {-90, -80}, {180, -80}, {90, -80}},
+			expected: [][]Position{
+				{{180, -80}, {90, -80}, {0, -80}, {0, -90}, {180, -90}, {180, -80}},
+				{{0, -80}, {-90, -80}, {-180, -80}, {-180, -90}, {0, -90}, {0, -80}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts := SplitPolygon(tt.ring)
+			for _, part := range parts {
+				if signedArea(part) <= 0 {
+					t.Errorf("Expected counterclockwise ring, but got %v", part)
+				}
+				for _, p := range part {
+					if p.Lon() < -180 || p.Lon() > 180 {
+						t.Errorf("Expected longitude within [-180, 180], but got %v", p)
+					}
+				}
+			}
+			if !sameRings(parts, tt.expected) {
+				t.Errorf("Expected %v, but got %v", tt.expected, parts)
+			}
+		})
+	}
+}
+
+func TestNewPolygonFeatureAcrossAntimeridian(t *testing.T) {
+	f := NewPolygonFeature("tile", []Position{{179, 0}, {-179, 0}, {-179, 1}, {179, 1}}, nil)
+	if f.Geometry.Type != GeometryMultiPolygon {
+		t.Fatalf("Expected %s, but got %s", GeometryMultiPolygon, f.Geometry.Type)
+	}
+	if polygons := f.Geometry.Coordinates.([][][]Position); len(polygons) != 2 {
+		t.Errorf("Expected 2 polygons, but got %d", len(polygons))
+	}
+
+	if _, err := MarshalKML("tiles", FeatureCollection{Type: "FeatureCollection", Features: []Feature{f}}); err != nil {
+		t.Errorf("Expected no error, but got %v", err)
+	}
+}
+
+func TestSplitAntimeridian(t *testing.T) {
+	segments := SplitAntimeridian([]Position{{170, 0}, {-170, 10}})
+	expected := [][]Position{
+		{{170, 0}, {180, 5}},
+		{{-180, 5}, {-170, 10}},
+	}
+	if !sameRings(segments, expected) {
+		t.Errorf("Expected %v, but got %v", expected, segments)
+	}
+}
+
+// sameRings compares rings vertex by vertex, ignoring the order of the rings
+// and the starting vertex of each closed ring.
+func sameRings(got, expected [][]Position) bool {
+	if len(got) != len(expected) {
+		return false
+	}
+	used := make([]bool, len(got))
+	for _, e := range expected {
+		found := false
+		for i, g := range got {
+			if !used[i] && sameRing(g, e) {
+				used[i], found = true, true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func sameRing(a, b []Position) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	closed := len(a) > 1 && a[0] == a[len(a)-1]
+	if !closed {
+		return samePositions(a, b)
+	}
+	n := len(a) - 1
+	for shift := 0; shift < n; shift++ {
+		rotated := make([]Position, 0, len(a))
+		for i := 0; i < n; i++ {
+			rotated = append(rotated, a[(i+shift)%n])
+		}
+		rotated = append(rotated, rotated[0])
+		if samePositions(rotated, b) {
+			return true
+		}
+	}
+	return false
+}
+
+func samePositions(a, b []Position) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i].Lon()-b[i].Lon()) > 1e-9 || math.Abs(a[i].Lat()-b[i].Lat()) > 1e-9 {
+			return false
+		}
+	}
+	return true
+}
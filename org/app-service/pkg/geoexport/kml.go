@@ -0,0 +1,166 @@
+package geoexport
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const kmlNamespace = "http://www.opengis.net/kml/2.2"
+
+// KML content type served by the export endpoints.
+const KMLContentType = "application/vnd.google-earth.kml+xml"
+
+type kmlRoot struct {
+	XMLName  xml.Name    `xml:"kml"`
+	Xmlns    string      `xml:"xmlns,attr"`
+	Document kmlDocument `xml:"Document"`
+}
+
+type kmlDocument struct {
+	Name       string         `xml:"name"`
+	Placemarks []kmlPlacemark `xml:"Placemark"`
+}
+
+type kmlPlacemark struct {
+	ID            string            `xml:"id,attr,omitempty"`
+	Name          string            `xml:"name,omitempty"`
+	ExtendedData  *kmlExtendedData  `xml:"ExtendedData,omitempty"`
+	Point         *kmlCoordinates   `xml:"Point,omitempty"`
+	LineString    *kmlCoordinates   `xml:"LineString,omitempty"`
+	Polygon       *kmlPolygon       `xml:"Polygon,omitempty"`
+	MultiGeometry *kmlMultiGeometry `xml:"MultiGeometry,omitempty"`
+}
+
+type kmlExtendedData struct {
+	Data []kmlData `xml:"Data"`
+}
+
+type kmlData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+type kmlCoordinates struct {
+	Tessellate  int    `xml:"tessellate,omitempty"`
+	Coordinates string `xml:"coordinates"`
+}
+
+type kmlPolygon struct {
+	OuterBoundary struct {
+		LinearRing kmlCoordinates `xml:"LinearRing"`
+	} `xml:"outerBoundaryIs"`
+}
+
+type kmlMultiGeometry struct {
+	LineStrings []kmlCoordinates `xml:"LineString"`
+	Polygons    []kmlPolygon     `xml:"Polygon"`
+}
+
+// MarshalKML renders the feature collection as a KML 2.2 document.
+func MarshalKML(name string, fc FeatureCollection) ([]byte, error) {
+	doc := kmlRoot{Xmlns: kmlNamespace, Document: kmlDocument{Name: name}}
+
+	for _, f := range fc.Features {
+		pm, err := toPlacemark(f)
+		if err != nil {
+			return nil, err
+		}
+		doc.Document.Placemarks = append(doc.Document.Placemarks, pm)
+	}
+
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode KML: %w", err)
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+func toPlacemark(f Feature) (kmlPlacemark, error) {
+	pm := kmlPlacemark{ID: f.ID, Name: placemarkName(f), ExtendedData: extendedData(f.Properties)}
+
+	switch f.Geometry.Type {
+	case GeometryPoint:
+		p, ok := f.Geometry.Coordinates.(Position)
+		if !ok {
+			return pm, fmt.Errorf("invalid coordinates for %s feature [%s]", f.Geometry.Type, f.ID)
+		}
+		pm.Point = &kmlCoordinates{Coordinates: formatCoordinates([]Position{p})}
+	case GeometryLineString:
+		line, ok := f.Geometry.Coordinates.([]Position)
+		if !ok {
+			return pm, fmt.Errorf("invalid coordinates for %s feature [%s]", f.Geometry.Type, f.ID)
+		}
+		pm.LineString = &kmlCoordinates{Tessellate: 1, Coordinates: formatCoordinates(line)}
+	case GeometryMultiLineString:
+		lines, ok := f.Geometry.Coordinates.([][]Position)
+		if !ok {
+			return pm, fmt.Errorf("invalid coordinates for %s feature [%s]", f.Geometry.Type, f.ID)
+		}
+		multi := &kmlMultiGeometry{}
+		for _, line := range lines {
+			multi.LineStrings = append(multi.LineStrings, kmlCoordinates{Tessellate: 1, Coordinates: formatCoordinates(line)})
+		}
+		pm.MultiGeometry = multi
+	case GeometryPolygon:
+		rings, ok := f.Geometry.Coordinates.([][]Position)
+		if !ok || len(rings) == 0 {
+			return pm, fmt.Errorf("invalid coordinates for %s feature [%s]", f.Geometry.Type, f.ID)
+		}
+		poly := &kmlPolygon{}
+		poly.OuterBoundary.LinearRing = kmlCoordinates{Tessellate: 1, Coordinates: formatCoordinates(rings[0])}
+		pm.Polygon = poly
+	case GeometryMultiPolygon:
+		polygons, ok := f.Geometry.Coordinates.([][][]Position)
+		if !ok {
+			return pm, fmt.Errorf("invalid coordinates for %s feature [%s]", f.Geometry.Type, f.ID)
+		}
+		multi := &kmlMultiGeometry{}
+		for _, rings := range polygons {
+			if len(rings) == 0 {
+				continue
+			}
+			poly := kmlPolygon{}
+			poly.OuterBoundary.LinearRing = kmlCoordinates{Tessellate: 1, Coordinates: formatCoordinates(rings[0])}
+			multi.Polygons = append(multi.Polygons, poly)
+		}
+		pm.MultiGeometry = multi
+	default:
+		return pm, fmt.Errorf("unsupported geometry type [%s]", f.Geometry.Type)
+	}
+	return pm, nil
+}
+
+func placemarkName(f Feature) string {
+	if name, ok := f.Properties["name"].(string); ok && name != "" {
+		return name
+	}
+	return f.ID
+}
+
+// extendedData converts feature properties into KML Data elements, sorted by key for stable output.
+func extendedData(props map[string]interface{}) *kmlExtendedData {
+	if len(props) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(props))
+	for k := range props {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	ed := &kmlExtendedData{}
+	for _, k := range keys {
+		ed.Data = append(ed.Data, kmlData{Name: k, Value: fmt.Sprint(props[k])})
+	}
+	return ed
+}
+
+func formatCoordinates(points []Position) string {
+	parts := make([]string, len(points))
+	for i, p := range points {
+		parts[i] = fmt.Sprintf("%.6f,%.6f,0", p.Lon(), p.Lat())
+	}
+	return strings.Join(parts, " ")
+}
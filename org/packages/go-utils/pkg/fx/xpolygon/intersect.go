@@ -0,0 +1,204 @@
+package xpolygon
+
+import (
+	"math"
+	"sort"
+
+	"github.com/org/2112-space-lab/org/go-utils/pkg/fx/xconstants"
+)
+
+// Interval is a [Start, End] range of the parameter t along a segment, with t in [0, 1].
+type Interval struct {
+	Start float64
+	End   float64
+}
+
+// BoundingBox returns the lat/lon extent of a set of points.
+func BoundingBox(points []Point) (minLat, minLon, maxLat, maxLon float64) {
+	if len(points) == 0 {
+		return 0, 0, 0, 0
+	}
+	minLat, maxLat = points[0].Latitude, points[0].Latitude
+	minLon, maxLon = points[0].Longitude, points[0].Longitude
+	for _, p := range points[1:] {
+		minLat = math.Min(minLat, p.Latitude)
+		maxLat = math.Max(maxLat, p.Latitude)
+		minLon = math.Min(minLon, p.Longitude)
+		maxLon = math.Max(maxLon, p.Longitude)
+	}
+	return minLat, minLon, maxLat, maxLon
+}
+
+// SegmentInsidePolygon returns the parts of segment a-b lying inside the polygon, as ordered, disjoint
+// intervals of the segment parameter. Coordinates are treated as planar lon/lat, like PostGIS geometries in EPSG:4326.
+func SegmentInsidePolygon(a, b Point, polygon []Point) []Interval {
+	if len(polygon) < 3 {
+		return nil
+	}
+
+	params := []float64{0, 1}
+	n := len(polygon)
+	for i := 0; i < n; i++ {
+		params = append(params, segmentCrossings(a, b, polygon[i], polygon[(i+1)%n])...)
+	}
+	sort.Float64s(params)
+
+	var intervals []Interval
+	for i := 0; i < len(params)-1; i++ {
+		t0, t1 := params[i], params[i+1]
+		if t1-t0 < xconstants.EPSILON {
+			continue
+		}
+		if !IsPointInPolygon(pointAt(a, b, (t0+t1)/2), polygon) {
+			continue
+		}
+		if last := len(intervals) - 1; last >= 0 && t0-intervals[last].End < xconstants.EPSILON {
+			intervals[last].End = t1
+			continue
+		}
+		intervals = append(intervals, Interval{Start: t0, End: t1})
+	}
+	return intervals
+}
+
+// PolygonsIntersect reports whether two polygons overlap or touch, in planar lon/lat.
+func PolygonsIntersect(a, b []Point) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return false
+	}
+	for i := range a {
+		for j := range b {
+			if segmentsIntersect(a[i], a[(i+1)%len(a)], b[j], b[(j+1)%len(b)]) {
+				return true
+			}
+		}
+	}
+	// No edge crossing: either one polygon contains the other or they are disjoint.
+	return IsPointInPolygon(a[0], b) || IsPointInPolygon(b[0], a)
+}
+
+// DistanceToPolygonKm returns the surface distance in km from a point to a polygon, 0 when the point is inside.
+// Distances use a local equirectangular projection around the point, accurate for tile-sized polygons.
+func DistanceToPolygonKm(p Point, polygon []Point) float64 {
+	if len(polygon) == 0 {
+		return math.Inf(1)
+	}
+	if IsPointInPolygon(p, polygon) {
+		return 0
+	}
+
+	proj := newLocalProjection(p)
+	origin := proj.project(p)
+	best := math.Inf(1)
+	n := len(polygon)
+	for i := 0; i < n; i++ {
+		d := pointSegmentDistance(origin, proj.project(polygon[i]), proj.project(polygon[(i+1)%n]))
+		best = math.Min(best, d)
+	}
+	return best
+}
+
+// DistanceSegmentToPolygonKm returns the surface distance in km between segment a-b and a polygon,
+// 0 when they intersect. The projection is centred on the segment midpoint.
+func DistanceSegmentToPolygonKm(a, b Point, polygon []Point) float64 {
+	if len(polygon) == 0 {
+		return math.Inf(1)
+	}
+	if IsPointInPolygon(a, polygon) || IsPointInPolygon(b, polygon) {
+		return 0
+	}
+	n := len(polygon)
+	for i := 0; i < n; i++ {
+		if segmentsIntersect(a, b, polygon[i], polygon[(i+1)%n]) {
+			return 0
+		}
+	}
+
+	proj := newLocalProjection(pointAt(a, b, 0.5))
+	pa, pb := proj.project(a), proj.project(b)
+	best := math.Inf(1)
+	for i := 0; i < n; i++ {
+		pc, pd := proj.project(polygon[i]), proj.project(polygon[(i+1)%n])
+		best = math.Min(best, pointSegmentDistance(pc, pa, pb))
+		best = math.Min(best, pointSegmentDistance(pa, pc, pd))
+		best = math.Min(best, pointSegmentDistance(pb, pc, pd))
+	}
+	return best
+}
+
+// segmentCrossings returns the parameters along a-b where it meets segment c-d.
+func segmentCrossings(a, b, c, d Point) []float64 {
+	rx, ry := b.Longitude-a.Longitude, b.Latitude-a.Latitude
+	sx, sy := d.Longitude-c.Longitude, d.Latitude-c.Latitude
+	qx, qy := c.Longitude-a.Longitude, c.Latitude-a.Latitude
+
+	denom := rx*sy - ry*sx
+	if math.Abs(denom) < 1e-12 {
+		// Parallel: only collinear overlaps matter, at the projections of c and d.
+		if math.Abs(qx*ry-qy*rx) > 1e-12 {
+			return nil
+		}
+		lengthSq := rx*rx + ry*ry
+		if lengthSq == 0 {
+			return nil
+		}
+		var out []float64
+		for _, p := range []Point{c, d} {
+			t := ((p.Longitude-a.Longitude)*rx + (p.Latitude-a.Latitude)*ry) / lengthSq
+			if t >= 0 && t <= 1 {
+				out = append(out, t)
+			}
+		}
+		return out
+	}
+
+	t := (qx*sy - qy*sx) / denom
+	u := (qx*ry - qy*rx) / denom
+	if t < 0 || t > 1 || u < 0 || u > 1 {
+		return nil
+	}
+	return []float64{t}
+}
+
+func segmentsIntersect(a, b, c, d Point) bool {
+	return len(segmentCrossings(a, b, c, d)) > 0
+}
+
+func pointAt(a, b Point, t float64) Point {
+	return Point{
+		Latitude:  a.Latitude + t*(b.Latitude-a.Latitude),
+		Longitude: a.Longitude + t*(b.Longitude-a.Longitude),
+	}
+}
+
+// localProjection maps lat/lon to km on a plane tangent at its origin.
+type localProjection struct {
+	origin Point
+	cosLat float64
+}
+
+type planarPoint struct {
+	x, y float64
+}
+
+func newLocalProjection(origin Point) localProjection {
+	return localProjection{origin: origin, cosLat: math.Cos(origin.Latitude * xconstants.PI_DIVIDE_BY_180)}
+}
+
+func (p localProjection) project(pt Point) planarPoint {
+	dLon := math.Remainder(pt.Longitude-p.origin.Longitude, 360)
+	return planarPoint{
+		x: dLon * xconstants.PI_DIVIDE_BY_180 * xconstants.EARTH_RADIUS_KM * p.cosLat,
+		y: (pt.Latitude - p.origin.Latitude) * xconstants.PI_DIVIDE_BY_180 * xconstants.EARTH_RADIUS_KM,
+	}
+}
+
+func pointSegmentDistance(p, a, b planarPoint) float64 {
+	dx, dy := b.x-a.x, b.y-a.y
+	lengthSq := dx*dx + dy*dy
+	t := 0.0
+	if lengthSq > 0 {
+		t = math.Max(0, math.Min(1, ((p.x-a.x)*dx+(p.y-a.y)*dy)/lengthSq))
+	}
+	return math.Hypot(p.x-(a.x+t*dx), p.y-(a.y+t*dy))
+}
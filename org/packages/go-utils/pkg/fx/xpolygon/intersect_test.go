@@ -0,0 +1,156 @@
+package xpolygon
+
+import (
+	"math"
+	"testing"
+)
+
+// unitSquare spans longitudes and latitudes 0..1.
+var unitSquare = []Point{
+	{Latitude: 0, Longitude: 0},
+	{Latitude: 0, Longitude: 1},
+	{Latitude: 1, Longitude: 1},
+	{Latitude: 1, Longitude: 0},
+}
+
+func TestSegmentInsidePolygon(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     Point
+		expected []Interval
+	}{
+		{
+			name:     "Crossing through",
+			a:        Point{Latitude: 0.5, Longitude: -1},
+			b:        Point{Latitude: 0.5, Longitude: 2},
+			expected: []Interval{{Start: 1.0 / 3, End: 2.0 / 3}},
+		},
+		{
+			name:     "Fully inside",
+			a:        Point{Latitude: 0.2, Longitude: 0.2},
+			b:        Point{Latitude: 0.8, Longitude: 0.8},
+			expected: []Interval{{Start: 0, End: 1}},
+		},
+		{
+			name:     "Starting inside",
+			a:        Point{Latitude: 0.5, Longitude: 0.5},
+			b:        Point{Latitude: 0.5, Longitude: 1.5},
+			expected: []Interval{{Start: 0, End: 0.5}},
+		},
+		{
+			name: "Missing",
+			a:    Point{Latitude: 2, Longitude: -1},
+			b:    Point{Latitude: 2, Longitude: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SegmentInsidePolygon(tt.a, tt.b, unitSquare)
+			if len(got) != len(tt.expected) {
+				t.Fatalf("Expected %d intervals, but got %d (%v)", len(tt.expected), len(got), got)
+			}
+			for i := range got {
+				if math.Abs(got[i].Start-tt.expected[i].Start) > 1e-9 || math.Abs(got[i].End-tt.expected[i].End) > 1e-9 {
+					t.Errorf("Expected interval %v, but got %v", tt.expected[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSegmentInsideConcavePolygon(t *testing.T) {
+	// U shape: the horizontal line crosses both arms.
+	u := []Point{
+		{Latitude: 0, Longitude: 0},
+		{Latitude: 0, Longitude: 3},
+		{Latitude: 3, Longitude: 3},
+		{Latitude: 3, Longitude: 2},
+		{Latitude: 1, Longitude: 2},
+		{Latitude: 1, Longitude: 1},
+		{Latitude: 3, Longitude: 1},
+		{Latitude: 3, Longitude: 0},
+	}
+
+	got := SegmentInsidePolygon(Point{Latitude: 2, Longitude: -1}, Point{Latitude: 2, Longitude: 4}, u)
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 intervals, but got %d (%v)", len(got), got)
+	}
+}
+
+func TestPolygonsIntersect(t *testing.T) {
+	shift := func(dLat, dLon float64) []Point {
+		out := make([]Point, len(unitSquare))
+		for i, p := range unitSquare {
+			out[i] = Point{Latitude: p.Latitude + dLat, Longitude: p.Longitude + dLon}
+		}
+		return out
+	}
+	inner := []Point{
+		{Latitude: 0.4, Longitude: 0.4},
+		{Latitude: 0.4, Longitude: 0.6},
+		{Latitude: 0.6, Longitude: 0.6},
+		{Latitude: 0.6, Longitude: 0.4},
+	}
+
+	tests := []struct {
+		name     string
+		other    []Point
+		expected bool
+	}{
+		{name: "Overlapping", other: shift(0.5, 0.5), expected: true},
+		{name: "Contained", other: inner, expected: true},
+		{name: "Disjoint", other: shift(2, 2), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PolygonsIntersect(unitSquare, tt.other); got != tt.expected {
+				t.Errorf("Expected %v, but got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestDistanceToPolygonKm(t *testing.T) {
+	const kmPerDegree = 111.19 // Along a meridian for a 6371 km sphere
+
+	tests := []struct {
+		name     string
+		point    Point
+		expected float64
+	}{
+		{name: "Inside", point: Point{Latitude: 0.5, Longitude: 0.5}, expected: 0},
+		{name: "One degree south", point: Point{Latitude: -1, Longitude: 0.5}, expected: kmPerDegree},
+		{name: "Half degree north", point: Point{Latitude: 1.5, Longitude: 0.5}, expected: kmPerDegree / 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DistanceToPolygonKm(tt.point, unitSquare)
+			if math.Abs(got-tt.expected) > 0.5 {
+				t.Errorf("Expected %.2f km, but got %.2f km", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestDistanceSegmentToPolygonKm(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     Point
+		expected float64
+	}{
+		{name: "Crossing", a: Point{Latitude: 0.5, Longitude: -1}, b: Point{Latitude: 0.5, Longitude: 2}, expected: 0},
+		{name: "Passing south", a: Point{Latitude: -1, Longitude: -1}, b: Point{Latitude: -1, Longitude: 2}, expected: 111.19},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DistanceSegmentToPolygonKm(tt.a, tt.b, unitSquare)
+			if math.Abs(got-tt.expected) > 0.5 {
+				t.Errorf("Expected %.2f km, but got %.2f km", tt.expected, got)
+			}
+		})
+	}
+}
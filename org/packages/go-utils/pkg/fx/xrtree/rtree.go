@@ -0,0 +1,362 @@
+package xrtree
+
+import "math"
+
+const (
+	// DefaultMaxEntries is the node capacity used by New.
+	DefaultMaxEntries = 16
+	// DefaultMinEntries is the minimum fill of a non-root node used by New.
+	DefaultMinEntries = 6
+)
+
+// Rect is an axis-aligned bounding box. X is the longitude axis, Y the latitude axis.
+type Rect struct {
+	MinX, MinY, MaxX, MaxY float64
+}
+
+// NewRect returns the rectangle spanning the two corners, whatever their order.
+func NewRect(x1, y1, x2, y2 float64) Rect {
+	return Rect{
+		MinX: math.Min(x1, x2),
+		MinY: math.Min(y1, y2),
+		MaxX: math.Max(x1, x2),
+		MaxY: math.Max(y1, y2),
+	}
+}
+
+// Intersects reports whether both rectangles share at least one point.
+func (r Rect) Intersects(o Rect) bool {
+	return r.MinX <= o.MaxX && o.MinX <= r.MaxX && r.MinY <= o.MaxY && o.MinY <= r.MaxY
+}
+
+// Contains reports whether o lies entirely within r.
+func (r Rect) Contains(o Rect) bool {
+	return r.MinX <= o.MinX && o.MaxX <= r.MaxX && r.MinY <= o.MinY && o.MaxY <= r.MaxY
+}
+
+// Union returns the smallest rectangle containing both rectangles.
+func (r Rect) Union(o Rect) Rect {
+	return Rect{
+		MinX: math.Min(r.MinX, o.MinX),
+		MinY: math.Min(r.MinY, o.MinY),
+		MaxX: math.Max(r.MaxX, o.MaxX),
+		MaxY: math.Max(r.MaxY, o.MaxY),
+	}
+}
+
+// Area returns the area of the rectangle.
+func (r Rect) Area() float64 {
+	return (r.MaxX - r.MinX) * (r.MaxY - r.MinY)
+}
+
+// Expand grows the rectangle by dx and dy on each side.
+func (r Rect) Expand(dx, dy float64) Rect {
+	return Rect{MinX: r.MinX - dx, MinY: r.MinY - dy, MaxX: r.MaxX + dx, MaxY: r.MaxY + dy}
+}
+
+type entry[T comparable] struct {
+	rect  Rect
+	child *node[T]
+	item  T
+}
+
+type node[T comparable] struct {
+	leaf    bool
+	entries []entry[T]
+}
+
+// RTree is a Guttman R-tree with quadratic split indexing items by bounding box.
+// It is not safe for concurrent use; callers must synchronise writes with reads.
+type RTree[T comparable] struct {
+	root       *node[T]
+	size       int
+	maxEntries int
+	minEntries int
+}
+
+// New creates an empty tree with the default node capacity.
+func New[T comparable]() *RTree[T] {
+	return NewWithCapacity[T](DefaultMaxEntries, DefaultMinEntries)
+}
+
+// NewWithCapacity creates an empty tree with the given node bounds. minEntries is clamped to [1, maxEntries/2].
+func NewWithCapacity[T comparable](maxEntries, minEntries int) *RTree[T] {
+	if maxEntries < 2 {
+		maxEntries = 2
+	}
+	if minEntries < 1 {
+		minEntries = 1
+	}
+	if minEntries > maxEntries/2 {
+		minEntries = maxEntries / 2
+	}
+	return &RTree[T]{
+		root:       &node[T]{leaf: true},
+		maxEntries: maxEntries,
+		minEntries: minEntries,
+	}
+}
+
+// Len returns the number of items stored in the tree.
+func (t *RTree[T]) Len() int {
+	return t.size
+}
+
+// Insert adds an item with its bounding box.
+func (t *RTree[T]) Insert(rect Rect, item T) {
+	t.insert(entry[T]{rect: rect, item: item}, 1)
+	t.size++
+}
+
+// Delete removes an item previously inserted with the same bounding box. It reports whether the item was found.
+func (t *RTree[T]) Delete(rect Rect, item T) bool {
+	var orphans []entry[T]
+	if !t.delete(t.root, rect, item, &orphans) {
+		return false
+	}
+	t.size--
+
+	// Shrink the root while it is an internal node with a single child.
+	for !t.root.leaf && len(t.root.entries) == 1 {
+		t.root = t.root.entries[0].child
+	}
+	if !t.root.leaf && len(t.root.entries) == 0 {
+		t.root = &node[T]{leaf: true}
+	}
+
+	// Reinsert the items of nodes dissolved for being underfull.
+	for _, o := range orphans {
+		t.insert(o, 1)
+	}
+	return true
+}
+
+// Search calls fn for every item whose bounding box intersects rect. Returning false from fn stops the search.
+func (t *RTree[T]) Search(rect Rect, fn func(Rect, T) bool) {
+	t.search(t.root, rect, fn)
+}
+
+// All calls fn for every item in the tree. Returning false from fn stops the iteration.
+func (t *RTree[T]) All(fn func(Rect, T) bool) {
+	t.all(t.root, fn)
+}
+
+func (t *RTree[T]) search(n *node[T], rect Rect, fn func(Rect, T) bool) bool {
+	for _, e := range n.entries {
+		if !e.rect.Intersects(rect) {
+			continue
+		}
+		if n.leaf {
+			if !fn(e.rect, e.item) {
+				return false
+			}
+			continue
+		}
+		if !t.search(e.child, rect, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *RTree[T]) all(n *node[T], fn func(Rect, T) bool) bool {
+	for _, e := range n.entries {
+		if n.leaf {
+			if !fn(e.rect, e.item) {
+				return false
+			}
+			continue
+		}
+		if !t.all(e.child, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// height returns the number of levels of the tree; a lone leaf root has height 1.
+func (t *RTree[T]) height() int {
+	h := 1
+	for n := t.root; !n.leaf; n = n.entries[0].child {
+		h++
+	}
+	return h
+}
+
+// insert places e in a node at the given level, where level 1 is the leaves.
+func (t *RTree[T]) insert(e entry[T], level int) {
+	split := t.insertAt(t.root, e, t.height(), level)
+	if split == nil {
+		return
+	}
+	old := t.root
+	t.root = &node[T]{entries: []entry[T]{
+		{rect: bounds(old), child: old},
+		{rect: bounds(split), child: split},
+	}}
+}
+
+// insertAt descends to the target level and returns the new sibling when n had to be split.
+func (t *RTree[T]) insertAt(n *node[T], e entry[T], nodeLevel, targetLevel int) *node[T] {
+	if nodeLevel == targetLevel {
+		n.entries = append(n.entries, e)
+	} else {
+		i := chooseSubtree(n, e.rect)
+		child := n.entries[i].child
+		split := t.insertAt(child, e, nodeLevel-1, targetLevel)
+		n.entries[i].rect = bounds(child)
+		if split != nil {
+			n.entries = append(n.entries, entry[T]{rect: bounds(split), child: split})
+		}
+	}
+
+	if len(n.entries) <= t.maxEntries {
+		return nil
+	}
+	return t.split(n)
+}
+
+// chooseSubtree picks the entry needing the least enlargement, breaking ties on the smallest area.
+func chooseSubtree[T comparable](n *node[T], rect Rect) int {
+	best := 0
+	bestEnlargement := math.Inf(1)
+	bestArea := math.Inf(1)
+	for i, e := range n.entries {
+		area := e.rect.Area()
+		enlargement := e.rect.Union(rect).Area() - area
+		if enlargement < bestEnlargement || (enlargement == bestEnlargement && area < bestArea) {
+			best, bestEnlargement, bestArea = i, enlargement, area
+		}
+	}
+	return best
+}
+
+// split distributes the entries of an overfull node between n and a new sibling using the quadratic algorithm.
+func (t *RTree[T]) split(n *node[T]) *node[T] {
+	entries := n.entries
+	seedA, seedB := pickSeeds(entries)
+
+	groupA := []entry[T]{entries[seedA]}
+	groupB := []entry[T]{entries[seedB]}
+	rectA, rectB := entries[seedA].rect, entries[seedB].rect
+
+	remaining := make([]entry[T], 0, len(entries)-2)
+	for i, e := range entries {
+		if i != seedA && i != seedB {
+			remaining = append(remaining, e)
+		}
+	}
+
+	for len(remaining) > 0 {
+		// Give every remaining entry to a group that would otherwise stay underfull.
+		if len(groupA)+len(remaining) == t.minEntries {
+			groupA = append(groupA, remaining...)
+			break
+		}
+		if len(groupB)+len(remaining) == t.minEntries {
+			groupB = append(groupB, remaining...)
+			break
+		}
+
+		next, preferA := pickNext(remaining, rectA, rectB, len(groupA), len(groupB))
+		e := remaining[next]
+		remaining = append(remaining[:next], remaining[next+1:]...)
+		if preferA {
+			groupA = append(groupA, e)
+			rectA = rectA.Union(e.rect)
+		} else {
+			groupB = append(groupB, e)
+			rectB = rectB.Union(e.rect)
+		}
+	}
+
+	n.entries = groupA
+	return &node[T]{leaf: n.leaf, entries: groupB}
+}
+
+// pickSeeds returns the pair of entries wasting the most area when grouped together.
+func pickSeeds[T comparable](entries []entry[T]) (int, int) {
+	seedA, seedB := 0, 1
+	worst := math.Inf(-1)
+	for i := 0; i < len(entries); i++ {
+		for j := i + 1; j < len(entries); j++ {
+			waste := entries[i].rect.Union(entries[j].rect).Area() - entries[i].rect.Area() - entries[j].rect.Area()
+			if waste > worst {
+				seedA, seedB, worst = i, j, waste
+			}
+		}
+	}
+	return seedA, seedB
+}
+
+// pickNext returns the entry with the strongest preference for one group, and whether that group is A.
+func pickNext[T comparable](remaining []entry[T], rectA, rectB Rect, sizeA, sizeB int) (int, bool) {
+	best := 0
+	bestDiff := math.Inf(-1)
+	preferA := true
+	for i, e := range remaining {
+		dA := rectA.Union(e.rect).Area() - rectA.Area()
+		dB := rectB.Union(e.rect).Area() - rectB.Area()
+		if diff := math.Abs(dA - dB); diff > bestDiff {
+			best, bestDiff = i, diff
+			switch {
+			case dA != dB:
+				preferA = dA < dB
+			case rectA.Area() != rectB.Area():
+				preferA = rectA.Area() < rectB.Area()
+			default:
+				preferA = sizeA <= sizeB
+			}
+		}
+	}
+	return best, preferA
+}
+
+// delete removes the item below n. Children left underfull are dissolved and their items appended to orphans.
+func (t *RTree[T]) delete(n *node[T], rect Rect, item T, orphans *[]entry[T]) bool {
+	if n.leaf {
+		for i, e := range n.entries {
+			if e.item == item && e.rect == rect {
+				n.entries = append(n.entries[:i], n.entries[i+1:]...)
+				return true
+			}
+		}
+		return false
+	}
+
+	for i := 0; i < len(n.entries); i++ {
+		e := n.entries[i]
+		if !e.rect.Contains(rect) {
+			continue
+		}
+		if !t.delete(e.child, rect, item, orphans) {
+			continue
+		}
+		if len(e.child.entries) < t.minEntries {
+			collectLeaves(e.child, orphans)
+			n.entries = append(n.entries[:i], n.entries[i+1:]...)
+		} else {
+			n.entries[i].rect = bounds(e.child)
+		}
+		return true
+	}
+	return false
+}
+
+func collectLeaves[T comparable](n *node[T], out *[]entry[T]) {
+	for _, e := range n.entries {
+		if n.leaf {
+			*out = append(*out, e)
+			continue
+		}
+		collectLeaves(e.child, out)
+	}
+}
+
+func bounds[T comparable](n *node[T]) Rect {
+	r := n.entries[0].rect
+	for _, e := range n.entries[1:] {
+		r = r.Union(e.rect)
+	}
+	return r
+}
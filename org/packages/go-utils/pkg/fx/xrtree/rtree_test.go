@@ -0,0 +1,156 @@
+package xrtree
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestRectIntersects(t *testing.T) {
+	base := NewRect(0, 0, 10, 10)
+	tests := []struct {
+		name     string
+		other    Rect
+		expected bool
+	}{
+		{name: "Overlapping", other: NewRect(5, 5, 15, 15), expected: true},
+		{name: "Contained", other: NewRect(2, 2, 3, 3), expected: true},
+		{name: "Touching edge", other: NewRect(10, 0, 20, 10), expected: true},
+		{name: "Disjoint", other: NewRect(11, 11, 20, 20), expected: false},
+		{name: "Swapped corners", other: NewRect(15, 15, 5, 5), expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := base.Intersects(tt.other); got != tt.expected {
+				t.Errorf("Expected %v, but got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRTreeSearch(t *testing.T) {
+	tests := []struct {
+		name  string
+		count int
+		query Rect
+	}{
+		{name: "Empty tree", count: 0, query: NewRect(-180, -90, 180, 90)},
+		{name: "Single leaf", count: 10, query: NewRect(-10, -10, 10, 10)},
+		{name: "Several levels", count: 2000, query: NewRect(-30, -20, 45, 35)},
+		{name: "Whole world", count: 2000, query: NewRect(-180, -90, 180, 90)},
+		{name: "Nothing matches", count: 500, query: NewRect(200, 100, 210, 110)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, rects := randomTree(tt.count, 1)
+
+			got := collect(tree, tt.query)
+			expected := bruteForce(rects, tt.query)
+			if !equalInts(got, expected) {
+				t.Errorf("Expected %d matches, but got %d", len(expected), len(got))
+			}
+			if tree.Len() != tt.count {
+				t.Errorf("Expected length %d, but got %d", tt.count, tree.Len())
+			}
+		})
+	}
+}
+
+func TestRTreeSearchStopsEarly(t *testing.T) {
+	tree, _ := randomTree(1000, 2)
+
+	calls := 0
+	tree.Search(NewRect(-180, -90, 180, 90), func(Rect, int) bool {
+		calls++
+		return calls < 3
+	})
+	if calls != 3 {
+		t.Errorf("Expected search to stop after 3 calls, but got %d", calls)
+	}
+}
+
+func TestRTreeDelete(t *testing.T) {
+	tests := []struct {
+		name     string
+		count    int
+		toDelete int
+	}{
+		{name: "Delete from leaf root", count: 5, toDelete: 3},
+		{name: "Delete half", count: 1000, toDelete: 500},
+		{name: "Delete everything", count: 300, toDelete: 300},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, rects := randomTree(tt.count, 3)
+
+			for i := 0; i < tt.toDelete; i++ {
+				if !tree.Delete(rects[i], i) {
+					t.Fatalf("Expected item %d to be deleted", i)
+				}
+			}
+			if tree.Delete(NewRect(500, 500, 501, 501), -1) {
+				t.Errorf("Expected deleting a missing item to fail")
+			}
+			if tree.Len() != tt.count-tt.toDelete {
+				t.Errorf("Expected length %d, but got %d", tt.count-tt.toDelete, tree.Len())
+			}
+
+			world := NewRect(-180, -90, 180, 90)
+			got := collect(tree, world)
+			expected := bruteForce(rects[tt.toDelete:], world)
+			for i := range expected {
+				expected[i] += tt.toDelete
+			}
+			if !equalInts(got, expected) {
+				t.Errorf("Expected %d remaining items, but got %d", len(expected), len(got))
+			}
+		})
+	}
+}
+
+func randomTree(count int, seed int64) (*RTree[int], []Rect) {
+	rng := rand.New(rand.NewSource(seed))
+	tree := New[int]()
+	rects := make([]Rect, count)
+	for i := range rects {
+		x, y := rng.Float64()*350-175, rng.Float64()*170-85
+		rects[i] = NewRect(x, y, x+rng.Float64()*5, y+rng.Float64()*5)
+		tree.Insert(rects[i], i)
+	}
+	return tree, rects
+}
+
+func collect(tree *RTree[int], query Rect) []int {
+	var out []int
+	tree.Search(query, func(_ Rect, item int) bool {
+		out = append(out, item)
+		return true
+	})
+	sort.Ints(out)
+	return out
+}
+
+func bruteForce(rects []Rect, query Rect) []int {
+	var out []int
+	for i, r := range rects {
+		if r.Intersects(query) {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
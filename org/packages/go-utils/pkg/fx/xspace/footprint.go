@@ -0,0 +1,40 @@
+package xspace
+
+import (
+	"math"
+
+	"github.com/org/2112-space-lab/org/go-utils/pkg/fx/xconstants"
+)
+
+// FootprintHalfAngleFromElevation returns the Earth central half-angle (degrees) of the
+// region from which a satellite at altitudeKm is seen at or above minElevationDeg.
+func FootprintHalfAngleFromElevation(altitudeKm, minElevationDeg float64) float64 {
+	if altitudeKm <= 0 {
+		return 0
+	}
+	eps := DegreesToRadians(math.Max(minElevationDeg, 0))
+	ratio := xconstants.EARTH_RADIUS_KM / (xconstants.EARTH_RADIUS_KM + altitudeKm)
+	lambda := math.Acos(ratio*math.Cos(eps)) - eps
+	return RadiansToDegrees(math.Max(lambda, 0))
+}
+
+// FootprintHalfAngleFromSensor returns the Earth central half-angle (degrees) covered by a
+// nadir-pointing sensor with the given cone half-angle. It never exceeds the geometric horizon.
+func FootprintHalfAngleFromSensor(altitudeKm, sensorHalfAngleDeg float64) float64 {
+	if altitudeKm <= 0 || sensorHalfAngleDeg <= 0 {
+		return 0
+	}
+	horizon := FootprintHalfAngleFromElevation(altitudeKm, 0)
+	eta := DegreesToRadians(sensorHalfAngleDeg)
+	sinRho := (xconstants.EARTH_RADIUS_KM + altitudeKm) / xconstants.EARTH_RADIUS_KM * math.Sin(eta)
+	if sinRho >= 1 {
+		return horizon
+	}
+	lambda := RadiansToDegrees(math.Asin(sinRho) - eta)
+	return math.Min(lambda, horizon)
+}
+
+// FootprintRadiusKm converts a central half-angle (degrees) to a ground radius in kilometers.
+func FootprintRadiusKm(halfAngleDeg float64) float64 {
+	return DegreesToRadians(halfAngleDeg) * xconstants.EARTH_RADIUS_KM
+}
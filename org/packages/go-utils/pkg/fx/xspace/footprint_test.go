@@ -0,0 +1,49 @@
+package xspace
+
+import "testing"
+
+func TestFootprintHalfAngleFromElevation(t *testing.T) {
+	tests := []struct {
+		name         string
+		altitudeKm   float64
+		minElevation float64
+		expected     float64 // degrees
+	}{
+		{name: "ISS horizon", altitudeKm: 420, minElevation: 0, expected: 20.26},
+		{name: "ISS 10 deg mask", altitudeKm: 420, minElevation: 10, expected: 12.50},
+		{name: "GEO horizon", altitudeKm: 35786, minElevation: 0, expected: 81.30},
+		{name: "Zero altitude", altitudeKm: 0, minElevation: 0, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FootprintHalfAngleFromElevation(tt.altitudeKm, tt.minElevation)
+			if !almostEqual(got, tt.expected, 0.05) {
+				t.Errorf("Expected %.2f, but got %.2f", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFootprintHalfAngleFromSensor(t *testing.T) {
+	tests := []struct {
+		name       string
+		altitudeKm float64
+		halfAngle  float64
+		expected   float64 // degrees
+	}{
+		{name: "Narrow imager", altitudeKm: 700, halfAngle: 1, expected: 0.11},
+		{name: "Wide sensor", altitudeKm: 700, halfAngle: 45, expected: 6.70},
+		{name: "Beyond horizon is clamped", altitudeKm: 700, halfAngle: 89, expected: 25.72},
+		{name: "No sensor", altitudeKm: 700, halfAngle: 0, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FootprintHalfAngleFromSensor(tt.altitudeKm, tt.halfAngle)
+			if !almostEqual(got, tt.expected, 0.05) {
+				t.Errorf("Expected %.2f, but got %.2f", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+// Command jsonschema generates a JSON Schema for each GraphQL type marked with the @event directive. Services
+// embed the schemas to validate event payloads on publish and on consume.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const (
+	eventDirective = "event"
+	schemaDialect  = "https://json-schema.org/draft/2020-12/schema"
+)
+
+// jsonSchema is the subset of JSON Schema the generated schemas use. Fields are declared in output order.
+type jsonSchema struct {
+	Schema     string                 `json:"$schema,omitempty"`
+	ID         string                 `json:"$id,omitempty"`
+	Title      string                 `json:"title,omitempty"`
+	Type       any                    `json:"type,omitempty"`
+	EventTypes []string               `json:"x-event-types,omitempty"`
+	Version    int                    `json:"x-schema-version,omitempty"`
+	Properties map[string]*jsonSchema `json:"properties,omitempty"`
+	Required   []string               `json:"required,omitempty"`
+	Items      *jsonSchema            `json:"items,omitempty"`
+	Enum       []any                  `json:"enum,omitempty"`
+}
+
+func main() {
+	schemas := flag.String("schemas", "../schemas/*.graphqls", "glob of the GraphQL schema files")
+	out := flag.String("out", "graph/jsonschema", "directory of the generated JSON Schemas")
+	flag.Parse()
+
+	schema, err := loadSchema(*schemas)
+	if err != nil {
+		log.Fatalf("failed to load GraphQL schema: %v", err)
+	}
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		log.Fatalf("failed to create %s: %v", *out, err)
+	}
+
+	names := make([]string, 0, len(schema.Types))
+	for name := range schema.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		def := schema.Types[name]
+		directive := def.Directives.ForName(eventDirective)
+		if def.Kind != ast.Object || directive == nil {
+			continue
+		}
+		generated, err := eventSchema(schema, def, directive)
+		if err != nil {
+			log.Fatalf("failed to generate schema of %s: %v", name, err)
+		}
+		body, err := json.MarshalIndent(generated, "", "  ")
+		if err != nil {
+			log.Fatalf("failed to encode schema of %s: %v", name, err)
+		}
+		path := filepath.Join(*out, name+".schema.json")
+		if err := os.WriteFile(path, append(body, '\n'), 0o644); err != nil {
+			log.Fatalf("failed to write %s: %v", path, err)
+		}
+		log.Printf("generated %s", path)
+	}
+}
+
+// loadSchema parses and validates the GraphQL schema files matching pattern.
+func loadSchema(pattern string) (*ast.Schema, error) {
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	sources := make([]*ast.Source, 0, len(paths))
+	for _, path := range paths {
+		input, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, &ast.Source{Name: path, Input: string(input)})
+	}
+	return gqlparser.LoadSchema(sources...)
+}
+
+// eventSchema builds the root schema of an event payload type from its @event directive.
+func eventSchema(schema *ast.Schema, def *ast.Definition, directive *ast.Directive) (*jsonSchema, error) {
+	types := directive.Arguments.ForName("types")
+	version := directive.Arguments.ForName("version")
+	if types == nil || version == nil {
+		return nil, fmt.Errorf("@%s needs types and version", eventDirective)
+	}
+	number, err := strconv.Atoi(version.Value.Raw)
+	if err != nil || number < 1 {
+		return nil, fmt.Errorf("invalid version %q", version.Value.Raw)
+	}
+
+	root := objectSchema(schema, def)
+	root.Schema = schemaDialect
+	root.ID = def.Name
+	root.Title = def.Name
+	root.Version = number
+	for _, child := range types.Value.Children {
+		root.EventTypes = append(root.EventTypes, child.Value.Raw)
+	}
+	return root, nil
+}
+
+// objectSchema maps the fields of an object type to properties, non-null fields being required.
+func objectSchema(schema *ast.Schema, def *ast.Definition) *jsonSchema {
+	object := &jsonSchema{Type: "object", Properties: map[string]*jsonSchema{}}
+	for _, field := range def.Fields {
+		object.Properties[field.Name] = typeSchema(schema, field.Type)
+		if field.Type.NonNull {
+			object.Required = append(object.Required, field.Name)
+		}
+	}
+	return object
+}
+
+// typeSchema maps a GraphQL field type; nullable types also accept null.
+func typeSchema(schema *ast.Schema, t *ast.Type) *jsonSchema {
+	var result *jsonSchema
+	if t.Elem != nil {
+		result = &jsonSchema{Type: "array", Items: typeSchema(schema, t.Elem)}
+	} else {
+		result = namedSchema(schema, t.NamedType)
+	}
+	if !t.NonNull {
+		result.Type = []string{result.Type.(string), "null"}
+		if result.Enum != nil {
+			result.Enum = append(result.Enum, nil)
+		}
+	}
+	return result
+}
+
+// namedSchema maps a scalar, enum or object type.
+func namedSchema(schema *ast.Schema, name string) *jsonSchema {
+	switch name {
+	case "String", "ID":
+		return &jsonSchema{Type: "string"}
+	case "Int":
+		return &jsonSchema{Type: "integer"}
+	case "Float":
+		return &jsonSchema{Type: "number"}
+	case "Boolean":
+		return &jsonSchema{Type: "boolean"}
+	}
+
+	def := schema.Types[name]
+	switch def.Kind {
+	case ast.Enum:
+		enum := &jsonSchema{Type: "string"}
+		for _, value := range def.EnumValues {
+			enum.Enum = append(enum.Enum, value.Name)
+		}
+		return enum
+	case ast.Object, ast.InputObject:
+		return objectSchema(schema, def)
+	}
+	// Custom scalars are serialized as strings.
+	return &jsonSchema{Type: "string"}
+}